@@ -0,0 +1,155 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Store URL prefixes used by AppStoreURL and PlayStoreURL.
+const (
+	appStoreBaseURL  = "https://apps.apple.com/app/id"
+	playStoreBaseURL = "https://play.google.com/store/apps/details"
+)
+
+// AppLinks describes the native app behind a group, used to compose deep links
+// and install links for its routes. It is inherited by child groups.
+type AppLinks struct {
+	// Scheme is the app's custom URL scheme (e.g. "myapp" for myapp://...).
+	Scheme string `json:"scheme" yaml:"scheme"`
+	// AppStoreID is the numeric Apple App Store id.
+	AppStoreID string `json:"app_store_id,omitempty" yaml:"app_store_id,omitempty"`
+	// PlayPackage is the Android package name on Google Play.
+	PlayPackage string `json:"play_package,omitempty" yaml:"play_package,omitempty"`
+}
+
+// InstallLinks holds the links composed for a route by Group.InstallLinks.
+type InstallLinks struct {
+	// Web is the regular URL of the route.
+	Web string `json:"web"`
+	// DeepLink opens the route in the app through its custom scheme.
+	DeepLink string `json:"deep_link"`
+	// AppStore and PlayStore are the store listings, empty when not configured.
+	AppStore  string `json:"app_store,omitempty"`
+	PlayStore string `json:"play_store,omitempty"`
+	// Android is an intent:// link that opens the app when installed and falls
+	// back to the Play Store listing (or the web URL) otherwise.
+	Android string `json:"android,omitempty"`
+}
+
+// AppStoreURL returns the Apple App Store URL for appID. Campaign query values
+// such as "pt" (provider token) and "ct" (campaign token) are appended.
+func AppStoreURL(appID string, campaign Query) string {
+	return JoinURL(appStoreBaseURL+appID, "", campaign)
+}
+
+// PlayStoreURL returns the Google Play URL for pkg. Referrer values (typically
+// utm_source, utm_medium and utm_campaign) are encoded into the single
+// "referrer" parameter that Play forwards to the installed app.
+func PlayStoreURL(pkg string, referrer Query) string {
+	query := Query{"id": pkg}
+	if len(referrer) > 0 {
+		values := url.Values{}
+		for key, value := range referrer {
+			values.Set(key, value)
+		}
+		query["referrer"] = values.Encode()
+	}
+	return JoinURL(playStoreBaseURL, "", query)
+}
+
+// SetAppLinks configures the native app for this group and its descendants.
+func (u *Group) SetAppLinks(app AppLinks) error {
+	if app.Scheme == "" {
+		return fmt.Errorf("set app links: scheme is required")
+	}
+	if strings.Contains(app.Scheme, ":") || strings.Contains(app.Scheme, "/") {
+		return fmt.Errorf("set app links: scheme %q must not contain ':' or '/'", app.Scheme)
+	}
+	if strings.Trim(app.AppStoreID, "0123456789") != "" {
+		return fmt.Errorf("set app links: app store id %q must be numeric", app.AppStoreID)
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set app links", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.appLinks = &app
+	u.mu.Unlock()
+	return nil
+}
+
+// AppLinks returns the effective app configuration, searching up the hierarchy.
+func (u *Group) AppLinks() (AppLinks, bool) {
+	for current := u; current != nil; {
+		current.mu.RLock()
+		app := current.appLinks
+		parent := current.parent
+		current.mu.RUnlock()
+
+		if app != nil {
+			return *app, true
+		}
+		current = parent
+	}
+	return AppLinks{}, false
+}
+
+// InstallLinks composes the web URL, deep link, store listings and an Android
+// intent link with store fallback for a route, so marketing pages can emit
+// correct "open in app" and install buttons. Referrer values are added to the
+// Play Store link and the App Store campaign tokens are taken from its "pt" and
+// "ct" keys.
+//
+// Example:
+//
+//	links, _ := frontend.InstallLinks("product", urlkit.Params{"id": 42}, urlkit.Query{"utm_source": "landing"})
+//	// links.DeepLink: myapp://products/42
+//	// links.Android:  intent://products/42#Intent;scheme=myapp;package=com.example.app;S.browser_fallback_url=...;end
+func (u *Group) InstallLinks(routeName string, params Params, referrer Query) (InstallLinks, error) {
+	app, ok := u.AppLinks()
+	if !ok {
+		return InstallLinks{}, fmt.Errorf("install links: no app configured for group %s", groupDisplayName(u))
+	}
+
+	web, err := u.Render(routeName, params)
+	if err != nil {
+		return InstallLinks{}, err
+	}
+	parsed, err := url.Parse(web)
+	if err != nil {
+		return InstallLinks{}, fmt.Errorf("install links: %w", err)
+	}
+	target := strings.TrimPrefix(parsed.EscapedPath(), "/")
+	if parsed.RawQuery != "" {
+		target += "?" + parsed.RawQuery
+	}
+
+	links := InstallLinks{Web: web, DeepLink: app.Scheme + "://" + target}
+	if app.AppStoreID != "" {
+		campaign := Query{}
+		for _, key := range []string{"pt", "ct"} {
+			if value, ok := referrer[key]; ok {
+				campaign[key] = value
+			}
+		}
+		links.AppStore = AppStoreURL(app.AppStoreID, campaign)
+	}
+
+	fallback := web
+	if app.PlayPackage != "" {
+		links.PlayStore = PlayStoreURL(app.PlayPackage, referrer)
+		fallback = links.PlayStore
+	}
+	intent := "intent://" + target + "#Intent;scheme=" + app.Scheme + ";"
+	if app.PlayPackage != "" {
+		intent += "package=" + app.PlayPackage + ";"
+	}
+	links.Android = intent + "S.browser_fallback_url=" + url.QueryEscape(fallback) + ";end"
+	return links, nil
+}
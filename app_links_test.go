@@ -0,0 +1,53 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestInstallLinks(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "frontend",
+			BaseURL: "https://shop.example.com",
+			Routes:  map[string]string{"product": "/products/:id"},
+			AppLinks: &urlkit.AppLinks{
+				Scheme:      "shop",
+				AppStoreID:  "123456789",
+				PlayPackage: "com.example.shop",
+			},
+		}},
+	})
+
+	links, err := manager.Group("frontend").InstallLinks("product", urlkit.Params{"id": 42}, urlkit.Query{"utm_source": "landing", "ct": "spring"})
+	if err != nil {
+		t.Fatalf("InstallLinks failed: %v", err)
+	}
+
+	want := urlkit.InstallLinks{
+		Web:       "https://shop.example.com/products/42",
+		DeepLink:  "shop://products/42",
+		AppStore:  "https://apps.apple.com/app/id123456789?ct=spring",
+		PlayStore: "https://play.google.com/store/apps/details?id=com.example.shop&referrer=ct%3Dspring%26utm_source%3Dlanding",
+	}
+	if links.Web != want.Web || links.DeepLink != want.DeepLink || links.AppStore != want.AppStore || links.PlayStore != want.PlayStore {
+		t.Fatalf("unexpected links: %+v", links)
+	}
+	if !strings.HasPrefix(links.Android, "intent://products/42#Intent;scheme=shop;package=com.example.shop;S.browser_fallback_url=https%3A%2F%2Fplay.google.com") || !strings.HasSuffix(links.Android, ";end") {
+		t.Fatalf("unexpected android link: %s", links.Android)
+	}
+
+	other := urlkit.NewRouteManager()
+	web, _, err := other.RegisterGroup("web", "https://example.com", map[string]string{"home": "/"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, err := web.InstallLinks("home", nil, nil); err == nil {
+		t.Fatal("expected error without app links")
+	}
+	if err := web.SetAppLinks(urlkit.AppLinks{Scheme: "x", AppStoreID: "id42"}); err == nil {
+		t.Fatal("expected error for non-numeric app store id")
+	}
+}
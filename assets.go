@@ -0,0 +1,148 @@
+package urlkit
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"strings"
+)
+
+// ErrAssetNotFound is returned when a group's asset manifest has no entry for
+// the requested asset name.
+var ErrAssetNotFound = errors.New("asset not found")
+
+// AssetEntry is a single asset manifest entry.
+type AssetEntry struct {
+	// File is the fingerprinted path (e.g. "assets/app.4f3a9c.js") or an
+	// absolute URL.
+	File string `json:"file"`
+	// Integrity is the Subresource Integrity value (e.g. "sha384-...").
+	Integrity string `json:"integrity,omitempty"`
+}
+
+// AssetManifest maps logical asset names (e.g. "app.js") to their entries.
+type AssetManifest map[string]AssetEntry
+
+// UnmarshalJSON accepts the common bundler manifest shapes: plain
+// {"app.js": "assets/app.4f3a9c.js"} maps and object entries using "file" or
+// "src" for the path plus an optional "integrity".
+func (m *AssetManifest) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	manifest := make(AssetManifest, len(raw))
+	for name, value := range raw {
+		var file string
+		if err := json.Unmarshal(value, &file); err == nil {
+			manifest[name] = AssetEntry{File: file}
+			continue
+		}
+
+		var entry struct {
+			File      string `json:"file"`
+			Src       string `json:"src"`
+			Integrity string `json:"integrity"`
+		}
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return fmt.Errorf("asset %q: %w", name, err)
+		}
+		if entry.File == "" {
+			entry.File = entry.Src
+		}
+		manifest[name] = AssetEntry{File: entry.File, Integrity: entry.Integrity}
+	}
+
+	*m = manifest
+	return nil
+}
+
+// LoadAssetManifest decodes a bundler asset manifest. See
+// AssetManifest.UnmarshalJSON for the accepted shapes.
+func LoadAssetManifest(r io.Reader) (AssetManifest, error) {
+	var manifest AssetManifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("load asset manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// ComputeIntegrity fills missing Integrity values with the sha384 digest of each
+// asset file read from fsys. Entries with absolute URLs are skipped.
+func (m AssetManifest) ComputeIntegrity(fsys fs.FS) error {
+	for name, entry := range m {
+		if entry.Integrity != "" || isAbsoluteAssetURL(entry.File) {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, strings.TrimPrefix(entry.File, "/"))
+		if err != nil {
+			return fmt.Errorf("asset %q: %w", name, err)
+		}
+		sum := sha512.Sum384(data)
+		entry.Integrity = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+		m[name] = entry
+	}
+	return nil
+}
+
+// SetAssetManifest attaches an asset manifest to the group. Asset URLs are
+// resolved against the root base URL and the group's full path.
+func (u *Group) SetAssetManifest(manifest AssetManifest) error {
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set asset manifest", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.assets = maps.Clone(manifest)
+	return nil
+}
+
+// Asset returns the URL of the named asset from the group's asset manifest.
+// Returns ErrAssetNotFound when the manifest has no such entry.
+func (u *Group) Asset(name string) (string, error) {
+	assetURL, _, err := u.AssetWithSRI(name)
+	return assetURL, err
+}
+
+// AssetWithSRI returns the URL of the named asset together with its
+// Subresource Integrity value, so templates can emit matching src/href and
+// integrity attributes. The integrity is empty when the manifest carries none.
+//
+// Example:
+//
+//	src, integrity, err := cdn.AssetWithSRI("app.js")
+//	// <script src="{src}" integrity="{integrity}" crossorigin="anonymous">
+func (u *Group) AssetWithSRI(name string) (string, string, error) {
+	u.mu.RLock()
+	entry, ok := u.assets[name]
+	u.mu.RUnlock()
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q in group %s", ErrAssetNotFound, name, groupDisplayName(u))
+	}
+
+	if isAbsoluteAssetURL(entry.File) {
+		return entry.File, entry.Integrity, nil
+	}
+
+	root := u.getRootGroup()
+	root.mu.RLock()
+	baseURL := root.baseURL
+	root.mu.RUnlock()
+
+	return JoinURL(baseURL, joinURLPath(u.getFullPath(), "/"+strings.TrimPrefix(entry.File, "/"))), entry.Integrity, nil
+}
+
+func isAbsoluteAssetURL(file string) bool {
+	return strings.HasPrefix(file, "https://") || strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "//")
+}
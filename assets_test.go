@@ -0,0 +1,71 @@
+package urlkit_test
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/flosch/pongo2/v6"
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestAssetWithSRI(t *testing.T) {
+	manifest, err := urlkit.LoadAssetManifest(strings.NewReader(`{
+		"app.js": {"file": "assets/app.4f3a9c.js", "integrity": "sha384-abc"},
+		"app.css": "assets/app.77d1e0.css",
+		"vendor.js": {"src": "https://cdn.example.net/vendor.js"}
+	}`))
+	if err != nil {
+		t.Fatalf("LoadAssetManifest failed: %v", err)
+	}
+
+	css := []byte("body{}")
+	files := fstest.MapFS{"assets/app.77d1e0.css": {Data: css}}
+	if err := manifest.ComputeIntegrity(files); err != nil {
+		t.Fatalf("ComputeIntegrity failed: %v", err)
+	}
+
+	manager := urlkit.NewRouteManager()
+	cdn, _, err := manager.RegisterGroup("cdn", "https://static.example.com", nil)
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := cdn.SetAssetManifest(manifest); err != nil {
+		t.Fatalf("SetAssetManifest failed: %v", err)
+	}
+
+	src, integrity, err := cdn.AssetWithSRI("app.js")
+	if err != nil {
+		t.Fatalf("AssetWithSRI failed: %v", err)
+	}
+	if src != "https://static.example.com/assets/app.4f3a9c.js" || integrity != "sha384-abc" {
+		t.Fatalf("unexpected app.js asset: %q %q", src, integrity)
+	}
+
+	sum := sha512.Sum384(css)
+	wantIntegrity := "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	if _, integrity, _ := cdn.AssetWithSRI("app.css"); integrity != wantIntegrity {
+		t.Fatalf("expected computed integrity %q, got %q", wantIntegrity, integrity)
+	}
+
+	if src, err := cdn.Asset("vendor.js"); err != nil || src != "https://cdn.example.net/vendor.js" {
+		t.Fatalf("expected absolute asset URL, got %q (%v)", src, err)
+	}
+	if _, err := cdn.Asset("missing.js"); !errors.Is(err, urlkit.ErrAssetNotFound) {
+		t.Fatalf("expected ErrAssetNotFound, got %v", err)
+	}
+
+	helpers := urlkit.TemplateHelpers(manager, nil)
+	assetSRI := helpers["asset_sri"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	value, perr := assetSRI(pongo2.AsValue("cdn"), pongo2.AsValue("app.js"))
+	if perr != nil {
+		t.Fatalf("asset_sri failed: %v", perr)
+	}
+	result, ok := value.Interface().(map[string]any)
+	if !ok || result["url"] != src || result["integrity"] != "sha384-abc" {
+		t.Fatalf("unexpected asset_sri result: %v", value.Interface())
+	}
+}
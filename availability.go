@@ -0,0 +1,80 @@
+package urlkit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRouteUnavailable is returned when building a route whose enforced
+// availability window does not include the current time.
+var ErrRouteUnavailable = errors.New("route is outside its availability window")
+
+// RouteAvailability restricts when a route is live, for scheduled launches and
+// seasonal pages. Zero bounds are open. Outside the window, builds report
+// WarningRouteUnavailable to the observer (or fail with ErrRouteUnavailable
+// when Enforce is set), and Sitemap and ManifestDocument leave the route out.
+type RouteAvailability struct {
+	// NotBefore is when the route becomes available (embargo).
+	NotBefore time.Time `json:"not_before,omitzero" yaml:"not_before,omitempty"`
+	// NotAfter is when the route stops being available (expiry).
+	NotAfter time.Time `json:"not_after,omitzero" yaml:"not_after,omitempty"`
+	// Enforce makes builds outside the window fail instead of warning.
+	Enforce bool `json:"enforce,omitempty" yaml:"enforce,omitempty"`
+}
+
+// AvailableAt reports whether t falls inside the window: at or after
+// NotBefore and before NotAfter.
+func (a RouteAvailability) AvailableAt(t time.Time) bool {
+	if !a.NotBefore.IsZero() && t.Before(a.NotBefore) {
+		return false
+	}
+	if !a.NotAfter.IsZero() && !t.Before(a.NotAfter) {
+		return false
+	}
+	return true
+}
+
+func (a RouteAvailability) validate() error {
+	if !a.NotBefore.IsZero() && !a.NotAfter.IsZero() && !a.NotAfter.After(a.NotBefore) {
+		return fmt.Errorf("availability not_after %s must be after not_before %s",
+			a.NotAfter.Format(time.RFC3339), a.NotBefore.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// RouteAvailable reports whether the route is available at t according to
+// its Availability metadata. Routes without a window are always available.
+func (u *Group) RouteAvailable(routeName string, t time.Time) bool {
+	meta, ok := u.RouteMetadata(routeName)
+	return !ok || meta.Availability == nil || meta.Availability.AvailableAt(t)
+}
+
+// checkAvailability warns about, or rejects when enforced, builds of a route
+// outside its availability window.
+func (u *Group) checkAvailability(routeName string, availability *RouteAvailability) error {
+	if availability == nil {
+		return nil
+	}
+	now := time.Now()
+	if availability.AvailableAt(now) {
+		return nil
+	}
+
+	window := availabilityWindow(*availability)
+	if availability.Enforce {
+		return fmt.Errorf("%w: route %q in group %s is available %s", ErrRouteUnavailable, routeName, groupDisplayName(u), window)
+	}
+	u.warn(WarningRouteUnavailable, routeName, "", "route %q is built outside its availability window (%s)", routeName, window)
+	return nil
+}
+
+func availabilityWindow(a RouteAvailability) string {
+	switch {
+	case a.NotBefore.IsZero():
+		return "until " + a.NotAfter.Format(time.RFC3339)
+	case a.NotAfter.IsZero():
+		return "from " + a.NotBefore.Format(time.RFC3339)
+	}
+	return "from " + a.NotBefore.Format(time.RFC3339) + " until " + a.NotAfter.Format(time.RFC3339)
+}
@@ -0,0 +1,91 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goliatone/go-urlkit"
+)
+
+func newAvailabilityManager(t *testing.T, opts ...urlkit.Option) *urlkit.RouteManager {
+	t.Helper()
+	cfg, err := urlkit.ParseConfig([]byte(`{"groups": [{
+		"name": "shop",
+		"base_url": "https://shop.example.com",
+		"routes": {"home": "/", "launch": "/launch", "summer": "/summer-sale", "preview": "/preview"},
+		"metadata": {
+			"launch": {"availability": {"not_before": "2999-01-01T00:00:00Z"}},
+			"summer": {"availability": {"not_before": "2000-06-01T00:00:00Z", "not_after": "2000-09-01T00:00:00Z", "enforce": true}},
+			"preview": {"availability": {"not_before": "2000-01-01T00:00:00Z", "not_after": "2999-01-01T00:00:00Z"}}
+		}
+	}]}`))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	return mustManagerFromConfig(t, cfg, opts...)
+}
+
+func TestRouteAvailabilityBuilds(t *testing.T) {
+	var warnings []urlkit.Warning
+	manager := newAvailabilityManager(t, urlkit.WithObserver(urlkit.Observer{
+		OnWarning: func(w urlkit.Warning) { warnings = append(warnings, w) },
+	}))
+
+	if built, err := manager.Resolve("shop", "launch", nil, nil); err != nil || built != "https://shop.example.com/launch" {
+		t.Fatalf("embargoed route should still build with a warning: %q, %v", built, err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != urlkit.WarningRouteUnavailable || warnings[0].Route != "launch" {
+		t.Errorf("unexpected warnings %+v", warnings)
+	}
+
+	if _, err := manager.Resolve("shop", "summer", nil, nil); !errors.Is(err, urlkit.ErrRouteUnavailable) {
+		t.Errorf("expected ErrRouteUnavailable for expired enforced route, got %v", err)
+	}
+	if _, err := manager.Resolve("shop", "preview", nil, nil); err != nil || len(warnings) != 1 {
+		t.Errorf("route inside its window should build silently: %v, %+v", err, warnings)
+	}
+
+	group := manager.Group("shop")
+	if group.RouteAvailable("launch", time.Now()) || !group.RouteAvailable("home", time.Now()) {
+		t.Error("unexpected RouteAvailable results")
+	}
+}
+
+func TestRouteAvailabilityExports(t *testing.T) {
+	manager := newAvailabilityManager(t)
+
+	sitemap, err := manager.Sitemap(urlkit.SitemapOptions{})
+	if err != nil {
+		t.Fatalf("Sitemap failed: %v", err)
+	}
+	body := string(sitemap)
+	if strings.Contains(body, "/launch") || strings.Contains(body, "/summer-sale") || !strings.Contains(body, "/preview") {
+		t.Errorf("sitemap should only list available routes:\n%s", body)
+	}
+
+	var routes []string
+	for _, entry := range manager.ManifestDocument().Routes {
+		routes = append(routes, entry.Route)
+	}
+	if strings.Join(routes, ",") != "home,preview" {
+		t.Errorf("unexpected manifest document routes %v", routes)
+	}
+	if len(manager.Manifest()) != 4 {
+		t.Errorf("Manifest should keep every route, got %d", len(manager.Manifest()))
+	}
+}
+
+func TestRouteAvailabilityValidation(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{Groups: []urlkit.GroupConfig{
+		{Name: "shop", Routes: map[string]string{"sale": "/sale"}},
+	}})
+	window := &urlkit.RouteAvailability{
+		NotBefore: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := manager.Group("shop").SetRouteMetadata("sale", urlkit.RouteMetadata{Availability: window}); err == nil {
+		t.Error("expected inverted availability window to be rejected")
+	}
+}
@@ -0,0 +1,98 @@
+package urlkit
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// urlkitPackage is this package's import path, used to skip internal frames
+// when locating the caller of a build.
+var urlkitPackage = reflect.TypeOf(BuildRequest{}).PkgPath()
+
+// BuildRequest describes a URL build reported to a build interceptor.
+type BuildRequest struct {
+	Group   string // FQN of the group that rendered the route
+	Route   string
+	Variant RouteVariant
+	Params  Params
+	Queries []Query
+	// File, Line and Function identify the first caller outside this package.
+	File     string
+	Line     int
+	Function string
+}
+
+// Caller returns the call site as "file:line".
+func (r BuildRequest) Caller() string {
+	if r.File == "" {
+		return ""
+	}
+	return r.File + ":" + strconv.Itoa(r.Line)
+}
+
+// SetBuildInterceptor installs fn to be called, synchronously, with every URL
+// successfully built through Render, Builder or Resolve, together with the call
+// site that requested it. It is meant for integration tests and local tooling
+// tracking down where a bad link came from; call sites are only captured while
+// an interceptor is set. Passing nil removes it.
+//
+// Params marked in RouteMetadata.Sensitive are redacted in both the request and
+// the reported URL; the caller of the build still gets the real URL.
+//
+// Example:
+//
+//	manager.SetBuildInterceptor(func(req urlkit.BuildRequest, url string) {
+//		log.Printf("%s %s.%s -> %s", req.Caller(), req.Group, req.Route, url)
+//	})
+func (m *RouteManager) SetBuildInterceptor(fn func(req BuildRequest, url string)) {
+	if m == nil || m.runtime == nil {
+		return
+	}
+	m.runtime.mu.Lock()
+	m.runtime.interceptor = fn
+	m.runtime.mu.Unlock()
+}
+
+func (r *runtimeState) currentInterceptor() func(BuildRequest, string) {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.interceptor
+}
+
+// intercept reports a built URL to the interceptor, if any.
+func (u *Group) intercept(routeName string, variant RouteVariant, params Params, queries []Query, built string) {
+	fn := u.runtime.currentInterceptor()
+	if fn == nil {
+		return
+	}
+
+	req := BuildRequest{
+		Group:   u.FQN(),
+		Route:   routeName,
+		Variant: variant,
+		Params:  cloneParamsMap(params),
+		Queries: queries,
+	}
+	req.File, req.Line, req.Function = externalCaller()
+	fn(req, u.redactBuild(&req, built))
+}
+
+// externalCaller returns the first stack frame outside this package.
+func externalCaller() (file string, line int, function string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, urlkitPackage+".") {
+			return frame.File, frame.Line, frame.Function
+		}
+		if !more {
+			return "", 0, ""
+		}
+	}
+}
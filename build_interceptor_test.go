@@ -0,0 +1,54 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestBuildInterceptor(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "frontend",
+			BaseURL: "https://example.com",
+			Routes:  map[string]string{"user": "/users/:id"},
+		}},
+	})
+	frontend := manager.Group("frontend")
+
+	var requests []urlkit.BuildRequest
+	var urls []string
+	manager.SetBuildInterceptor(func(req urlkit.BuildRequest, url string) {
+		requests = append(requests, req)
+		urls = append(urls, url)
+	})
+
+	if _, err := frontend.Render("user", urlkit.Params{"id": "1"}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if _, err := frontend.Builder("user").WithParam("id", "2").Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, err := frontend.Render("user", nil); err == nil {
+		t.Fatal("expected error for missing param")
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 intercepted builds, got %d", len(requests))
+	}
+	if urls[1] != "https://example.com/users/2" || requests[1].Group != "frontend" || requests[1].Route != "user" || requests[1].Params["id"] != "2" {
+		t.Fatalf("unexpected build %+v -> %s", requests[1], urls[1])
+	}
+	for _, req := range requests {
+		if !strings.HasSuffix(req.File, "build_interceptor_test.go") || !strings.HasSuffix(req.Function, "TestBuildInterceptor") {
+			t.Fatalf("expected call site in this test, got %s (%s)", req.Caller(), req.Function)
+		}
+	}
+
+	manager.SetBuildInterceptor(nil)
+	frontend.Render("user", urlkit.Params{"id": "3"})
+	if len(requests) != 2 {
+		t.Fatal("expected interceptor to be removed")
+	}
+}
@@ -8,6 +8,10 @@ type Builder struct {
 	params     Params
 	query      Query
 	multiQuery map[string][]string
+	variant    RouteVariant
+	signKey    []byte
+	compact    *compactQuery
+	subdomain  string
 	err        error
 }
 
@@ -16,7 +20,7 @@ func (b *Builder) WithParam(key string, value any) *Builder {
 		return b
 	}
 
-	b.params[key] = fmt.Sprint(value)
+	b.params[key] = normalizeParamValue(value)
 	return b
 }
 
@@ -69,6 +73,53 @@ func (b *Builder) WithQuery(key string, value any) *Builder {
 	return b
 }
 
+// WithQueryURL builds other and stores the resulting URL as the query value for
+// key. The nested URL is encoded exactly once when the final URL is assembled,
+// which avoids double-encoding redirect parameters such as redirect_uri.
+// Build errors from other are surfaced by Build.
+func (b *Builder) WithQueryURL(key string, other *Builder) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if other == nil {
+		b.err = fmt.Errorf("query url %q: nested builder is nil", key)
+		return b
+	}
+
+	nested, err := other.Build()
+	if err != nil {
+		b.err = fmt.Errorf("query url %q: %w", key, err)
+		return b
+	}
+
+	return b.WithQuery(key, nested)
+}
+
+// WithMultiQuery appends values to the query key, like url.Values.Add, so
+// repeated keys (?tag=a&tag=b) can be built up across calls. WithQuery
+// replaces the key instead; WithQueryValues sets several keys from a map.
+//
+// Example:
+//
+//	b.WithMultiQuery("tag", "go").WithMultiQuery("tag", "urls") // ?tag=go&tag=urls
+//	b.WithMultiQuery("tag", tags...)
+func (b *Builder) WithMultiQuery(key string, values ...string) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	var existing []string
+	if current, ok := b.multiQuery[key]; ok {
+		existing = current
+	} else if current, ok := b.query[key]; ok {
+		existing = []string{current}
+	}
+	existing = append(existing, values...)
+	b.setMultiQueryValues(key, existing)
+	return b
+}
+
 func (b *Builder) WithQueryValues(values map[string][]string) *Builder {
 	if b.err != nil {
 		return b
@@ -107,9 +158,24 @@ func (b *Builder) Build() (string, error) {
 
 	params := coerceParams(b.params)
 
-	queries := combineQueries(b.query, b.multiQuery)
+	queries := b.queries()
+
+	built, err := b.helper.render(b.routeName, b.variant, b.subdomain, params, nil, queries...)
+	if err != nil {
+		return "", err
+	}
+	return b.sign(built)
+}
 
-	return b.helper.Render(b.routeName, params, queries...)
+// BuildPath builds the URL like Build and returns only its path and query
+// (e.g. "/api/users/42?tab=posts"), including group and base URL path
+// prefixes, for relative links on the same host.
+func (b *Builder) BuildPath() (string, error) {
+	built, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+	return pathAndQuery(built)
 }
 
 func (b *Builder) MustBuild() string {
@@ -124,6 +190,8 @@ func (b *Builder) MustBuild() string {
 	return s
 }
 
+// Resolve implements Resolver: it looks up the dot-separated group path and
+// builds the URL for route in one call.
 func (m *RouteManager) Resolve(groupPath, route string, params Params, query Query) (string, error) {
 	group, err := m.GetGroup(groupPath)
 	if err != nil {
@@ -145,12 +213,7 @@ func (m *RouteManager) RoutePath(groupPath, route string) (string, error) {
 		return "", err
 	}
 
-	routeTemplate, err := group.Route(route)
-	if err != nil {
-		return "", err
-	}
-
-	return joinURLPath(group.getFullPath(), routeTemplate), nil
+	return group.RoutePath(route)
 }
 
 // RouteTemplate returns the raw route template for the group.
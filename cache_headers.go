@@ -0,0 +1,93 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CacheVisibility selects which caches may store a response.
+type CacheVisibility string
+
+const (
+	// CacheVisibilityPublic lets shared caches such as CDNs store the response.
+	CacheVisibilityPublic CacheVisibility = "public"
+	// CacheVisibilityPrivate restricts storage to the client's own cache.
+	CacheVisibilityPrivate CacheVisibility = "private"
+)
+
+// CachePolicy describes the cacheability of a route's responses.
+type CachePolicy struct {
+	// MaxAge is how long, in seconds, a response stays fresh.
+	MaxAge int `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+	// Visibility is public or private. Empty omits the directive.
+	Visibility CacheVisibility `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	// Immutable marks responses that never change while fresh, such as
+	// fingerprinted assets.
+	Immutable bool `json:"immutable,omitempty" yaml:"immutable,omitempty"`
+	// NoStore forbids caching entirely. It cannot be combined with the other
+	// fields.
+	NoStore bool `json:"no_store,omitempty" yaml:"no_store,omitempty"`
+}
+
+func (p CachePolicy) validate() error {
+	switch p.Visibility {
+	case "", CacheVisibilityPublic, CacheVisibilityPrivate:
+	default:
+		return fmt.Errorf("cache policy: unsupported visibility %q", p.Visibility)
+	}
+	if p.MaxAge < 0 {
+		return fmt.Errorf("cache policy: max_age must not be negative")
+	}
+	if p.NoStore && (p.MaxAge > 0 || p.Visibility != "" || p.Immutable) {
+		return fmt.Errorf("cache policy: no_store cannot be combined with other directives")
+	}
+	return nil
+}
+
+// CacheControl returns the Cache-Control header value for the policy.
+func (p CachePolicy) CacheControl() string {
+	if p.NoStore {
+		return "no-store"
+	}
+	var directives []string
+	if p.Visibility != "" {
+		directives = append(directives, string(p.Visibility))
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(p.MaxAge))
+	if p.Immutable {
+		directives = append(directives, "immutable")
+	}
+	return strings.Join(directives, ", ")
+}
+
+// CacheHeaders returns the HTTP cache headers declared by the Cache metadata
+// of the route identified by fqn ("group.route"), so handlers and CDN config
+// generators derive cache policy from the same registry as the URLs. It
+// returns nil when the route is unknown or declares no cache policy.
+//
+// Example:
+//
+//	// metadata: {"asset": {"cache": {"max_age": 31536000, "visibility": "public", "immutable": true}}}
+//	for key, values := range manager.CacheHeaders("cdn.asset") {
+//		w.Header()[key] = values
+//	}
+//	// Cache-Control: public, max-age=31536000, immutable
+func (m *RouteManager) CacheHeaders(fqn string) http.Header {
+	groupPath, route, err := splitRouteFQN(fqn)
+	if err != nil {
+		return nil
+	}
+	group, err := m.GetGroup(groupPath)
+	if err != nil {
+		return nil
+	}
+	meta, ok := group.RouteMetadata(route)
+	if !ok || meta.Cache == nil {
+		return nil
+	}
+	header := http.Header{}
+	header.Set("Cache-Control", meta.Cache.CacheControl())
+	return header
+}
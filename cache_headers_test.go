@@ -0,0 +1,49 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestCacheHeaders(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "cdn",
+				BaseURL: "https://cdn.example.com",
+				Routes:  map[string]string{"asset": "/assets/:file", "account": "/account", "login": "/login", "plain": "/plain"},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"asset":   {Cache: &urlkit.CachePolicy{MaxAge: 31536000, Visibility: urlkit.CacheVisibilityPublic, Immutable: true}},
+					"account": {Cache: &urlkit.CachePolicy{MaxAge: 60, Visibility: urlkit.CacheVisibilityPrivate}},
+					"login":   {Cache: &urlkit.CachePolicy{NoStore: true}},
+				},
+			},
+		},
+	})
+
+	cases := map[string]string{
+		"cdn.asset":   "public, max-age=31536000, immutable",
+		"cdn.account": "private, max-age=60",
+		"cdn.login":   "no-store",
+	}
+	for fqn, want := range cases {
+		if got := manager.CacheHeaders(fqn).Get("Cache-Control"); got != want {
+			t.Fatalf("%s: expected %q, got %q", fqn, want, got)
+		}
+	}
+	if header := manager.CacheHeaders("cdn.plain"); header != nil {
+		t.Fatalf("expected nil headers without a cache policy, got %v", header)
+	}
+	if header := manager.CacheHeaders("cdn.missing"); header != nil {
+		t.Fatalf("expected nil headers for an unknown route, got %v", header)
+	}
+
+	group := manager.Group("cdn")
+	if err := group.SetRouteMetadata("plain", urlkit.RouteMetadata{Cache: &urlkit.CachePolicy{NoStore: true, MaxAge: 10}}); err == nil {
+		t.Fatal("expected error combining no_store with max_age")
+	}
+	if err := group.SetRouteMetadata("plain", urlkit.RouteMetadata{Cache: &urlkit.CachePolicy{Visibility: "shared"}}); err == nil {
+		t.Fatal("expected error for unknown visibility")
+	}
+}
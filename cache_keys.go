@@ -0,0 +1,97 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// NormalizeForCache rewrites rawURL into a cache key for the route of this
+// group it matches: query params not listed in the route's CacheQuery metadata
+// are dropped, the rest are sorted by name (repeated values keep their order)
+// and the fragment is removed. Routes without CacheQuery keep every param.
+// rawURL may be a path or an absolute URL of this group; returns
+// ErrRouteNotFound when no route of the group matches its path.
+//
+// Example:
+//
+//	// metadata: {"search": {"cache_query": ["q", "page"]}}
+//	key, _ := group.NormalizeForCache("https://example.com/search?utm_source=x&page=2&q=go")
+//	// https://example.com/search?page=2&q=go
+func (u *Group) NormalizeForCache(rawURL string) (string, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("normalize for cache %q: %w", rawURL, err)
+	}
+
+	route, ok := u.matchOwnRoute(target)
+	if !ok {
+		return "", fmt.Errorf("normalize for cache %q: %w: no route of group %s matches", rawURL, ErrRouteNotFound, u.FQN())
+	}
+
+	query := target.Query()
+	if meta, ok := u.RouteMetadata(route); ok && meta.CacheQuery != nil {
+		kept := make(url.Values, len(meta.CacheQuery))
+		for _, name := range meta.CacheQuery {
+			if values, ok := query[name]; ok {
+				kept[name] = values
+			}
+		}
+		query = kept
+	}
+
+	target.RawQuery = query.Encode()
+	target.ForceQuery = false
+	target.Fragment, target.RawFragment = "", ""
+	target.Host = strings.ToLower(target.Host)
+	return target.String(), nil
+}
+
+// matchOwnRoute returns the most specific route of this group whose canonical
+// or vanity pattern matches target's path, after stripping the base URL path
+// (for absolute URLs) and the global prefix.
+func (u *Group) matchOwnRoute(target *url.URL) (string, bool) {
+	path := target.EscapedPath()
+	if target.Host != "" {
+		root := u.getRootGroup()
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+		if base, err := url.Parse(baseURL); err == nil {
+			if basePath := strings.TrimSuffix(base.EscapedPath(), "/"); basePath != "" {
+				if rest, ok := strings.CutPrefix(path, basePath); ok && (rest == "" || rest[0] == '/') {
+					path = rest
+				}
+			}
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+	path, ok := u.runtime.stripGlobalPrefix(path)
+	if !ok {
+		return "", false
+	}
+
+	u.mu.RLock()
+	routes := cloneRoutes(u.routes)
+	u.mu.RUnlock()
+
+	fullPath := u.getFullPath()
+	var matchers []routeMatcher
+	for _, name := range slices.Sorted(maps.Keys(routes)) {
+		if match, err := compileRouteMatcher(joinURLPath(fullPath, routes[name])); err == nil {
+			matchers = append(matchers, routeMatcher{route: name, match: match})
+		}
+		if vanity, ok := u.VanityRoute(name); ok {
+			if match, err := compileRouteMatcher(joinURLPath(fullPath, vanity)); err == nil {
+				matchers = append(matchers, routeMatcher{route: name, match: match})
+			}
+		}
+	}
+
+	best, _, ok := bestRouteMatch(matchers, path)
+	return best.route, ok
+}
@@ -0,0 +1,59 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestNormalizeForCache(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "shop",
+				BaseURL: "https://Example.com/store",
+				Routes: map[string]string{
+					"search":  "/search",
+					"product": "/products/:slug",
+					"cart":    "/cart",
+				},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"search":  {CacheQuery: []string{"q", "page", "tag"}},
+					"product": {CacheQuery: []string{}},
+				},
+			},
+		},
+	})
+	group, err := manager.GetGroup("shop")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://EXAMPLE.com/store/search?utm_source=news&tag=b&q=go&tag=a&page=2#top", "https://example.com/store/search?page=2&q=go&tag=b&tag=a"},
+		{"/search?fbclid=1", "/search"},
+		{"https://example.com/store/products/hat?ref=home&color=red", "https://example.com/store/products/hat"},
+		// Routes without CacheQuery keep every param, sorted
+		{"https://example.com/store/cart?z=1&a=2", "https://example.com/store/cart?a=2&z=1"},
+	}
+	for _, tt := range tests {
+		got, err := group.NormalizeForCache(tt.raw)
+		if err != nil {
+			t.Fatalf("NormalizeForCache(%q) failed: %v", tt.raw, err)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeForCache(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+
+	if _, err := group.NormalizeForCache("https://example.com/store/unknown?q=1"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Errorf("expected ErrRouteNotFound, got %v", err)
+	}
+	if err := group.SetRouteMetadata("cart", urlkit.RouteMetadata{CacheQuery: []string{""}}); err == nil {
+		t.Error("expected error for empty cache query param")
+	}
+}
@@ -0,0 +1,211 @@
+// Package calendar builds "add to calendar" links for Google Calendar and
+// Outlook, and iCalendar (ICS) documents and data URIs, from a single Event.
+//
+// Each target has its own date format and parameter names; the builders take
+// care of UTC conversion, all-day date ranges and percent-encoding:
+//
+//	event := calendar.Event{
+//		Title:    "Launch review",
+//		Start:    time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC),
+//		End:      time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC),
+//		Location: "Room 4 / Zoom",
+//	}
+//	calendar.GoogleURL(event)  // https://calendar.google.com/calendar/render?action=TEMPLATE&dates=...
+//	calendar.OutlookURL(event) // https://outlook.live.com/calendar/0/deeplink/compose?...
+//	calendar.ICSDataURI(event) // data:text/calendar;charset=utf-8,BEGIN%3AVCALENDAR...
+package calendar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	googleBaseURL    = "https://calendar.google.com/calendar/render"
+	outlookBaseURL   = "https://outlook.live.com/calendar/0/deeplink/compose"
+	office365BaseURL = "https://outlook.office.com/calendar/0/deeplink/compose"
+
+	googleTimeLayout = "20060102T150405Z"
+	googleDateLayout = "20060102"
+	icsTimeLayout    = "20060102T150405Z"
+	icsDateLayout    = "20060102"
+
+	// icsLineLimit is the maximum line length in octets before folding (RFC 5545).
+	icsLineLimit = 75
+)
+
+// Event describes a calendar entry.
+type Event struct {
+	Title       string
+	Description string
+	Location    string
+	// Start and End are converted to UTC. A zero End defaults to one hour after
+	// Start, or one day for all-day events.
+	Start time.Time
+	End   time.Time
+	// AllDay uses the dates of Start and End only; End is exclusive.
+	AllDay bool
+	// URL is included in ICS documents.
+	URL string
+	// UID identifies the event in ICS documents. Defaults to a hash of the
+	// title and start time, so repeated downloads update the same entry.
+	UID string
+	// Stamp is the ICS DTSTAMP. Defaults to the current time.
+	Stamp time.Time
+}
+
+func (e Event) end() time.Time {
+	if !e.End.IsZero() {
+		return e.End
+	}
+	if e.AllDay {
+		return e.Start.AddDate(0, 0, 1)
+	}
+	return e.Start.Add(time.Hour)
+}
+
+// GoogleURL returns a Google Calendar event template link.
+func GoogleURL(e Event) string {
+	var dates string
+	if e.AllDay {
+		dates = e.Start.Format(googleDateLayout) + "/" + e.end().Format(googleDateLayout)
+	} else {
+		dates = e.Start.UTC().Format(googleTimeLayout) + "/" + e.end().UTC().Format(googleTimeLayout)
+	}
+
+	values := url.Values{"action": {"TEMPLATE"}, "text": {e.Title}, "dates": {dates}}
+	setIfNotEmpty(values, "details", e.Description)
+	setIfNotEmpty(values, "location", e.Location)
+	return googleBaseURL + "?" + encode(values)
+}
+
+// OutlookURL returns an Outlook.com compose link.
+func OutlookURL(e Event) string {
+	return outlookURL(outlookBaseURL, e)
+}
+
+// Office365URL returns an Outlook on the web (Microsoft 365) compose link.
+func Office365URL(e Event) string {
+	return outlookURL(office365BaseURL, e)
+}
+
+func outlookURL(base string, e Event) string {
+	values := url.Values{
+		"path":    {"/calendar/action/compose"},
+		"rru":     {"addevent"},
+		"subject": {e.Title},
+	}
+	if e.AllDay {
+		values.Set("startdt", e.Start.Format(time.DateOnly))
+		values.Set("enddt", e.end().Format(time.DateOnly))
+		values.Set("allday", "true")
+	} else {
+		values.Set("startdt", e.Start.UTC().Format(time.RFC3339))
+		values.Set("enddt", e.end().UTC().Format(time.RFC3339))
+	}
+	setIfNotEmpty(values, "body", e.Description)
+	setIfNotEmpty(values, "location", e.Location)
+	return base + "?" + encode(values)
+}
+
+// ICS returns the event as an iCalendar document with CRLF line endings and
+// folded long lines.
+func ICS(e Event) string {
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	uid := e.UID
+	if uid == "" {
+		sum := sha256.Sum256([]byte(e.Title + "\x00" + e.Start.UTC().Format(time.RFC3339)))
+		uid = hex.EncodeToString(sum[:8]) + "@go-urlkit"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//goliatone//go-urlkit//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:" + escapeText(uid),
+		"DTSTAMP:" + stamp.UTC().Format(icsTimeLayout),
+	}
+	if e.AllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+e.Start.Format(icsDateLayout),
+			"DTEND;VALUE=DATE:"+e.end().Format(icsDateLayout),
+		)
+	} else {
+		lines = append(lines,
+			"DTSTART:"+e.Start.UTC().Format(icsTimeLayout),
+			"DTEND:"+e.end().UTC().Format(icsTimeLayout),
+		)
+	}
+	lines = append(lines, "SUMMARY:"+escapeText(e.Title))
+	if e.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeText(e.Location))
+	}
+	if e.URL != "" {
+		lines = append(lines, "URL:"+e.URL)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// ICSDataURI returns the ICS document as a percent-encoded data URI, usable as
+// the href of a download link.
+func ICSDataURI(e Event) string {
+	return "data:text/calendar;charset=utf-8," + url.PathEscape(ICS(e))
+}
+
+// escapeText escapes an ICS TEXT value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldLine splits lines longer than icsLineLimit octets, continuing them with a
+// leading space, without breaking UTF-8 sequences.
+func foldLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line
+	}
+
+	var b strings.Builder
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+func setIfNotEmpty(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+// encode encodes values with %20 for spaces, which both Google and Outlook
+// decode correctly in all fields.
+func encode(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
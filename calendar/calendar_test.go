@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarLinks(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	event := Event{
+		Title:       "Launch review + Q&A",
+		Description: "Agenda: demo, questions",
+		Location:    "Room 4 / Zoom",
+		Start:       time.Date(2024, 3, 1, 16, 0, 0, 0, berlin),
+	}
+
+	google := GoogleURL(event)
+	want := "https://calendar.google.com/calendar/render?action=TEMPLATE&dates=20240301T150000Z%2F20240301T160000Z&details=Agenda%3A%20demo%2C%20questions&location=Room%204%20%2F%20Zoom&text=Launch%20review%20%2B%20Q%26A"
+	if google != want {
+		t.Fatalf("expected %s, got %s", want, google)
+	}
+
+	outlook, err := url.Parse(OutlookURL(event))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	query := outlook.Query()
+	if query.Get("subject") != event.Title || query.Get("startdt") != "2024-03-01T15:00:00Z" || query.Get("enddt") != "2024-03-01T16:00:00Z" || query.Get("rru") != "addevent" {
+		t.Fatalf("unexpected outlook query: %v", query)
+	}
+
+	allDay := Event{Title: "Offsite", Start: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), AllDay: true}
+	if got := GoogleURL(allDay); !strings.Contains(got, "dates=20240506%2F20240507") {
+		t.Fatalf("unexpected all-day google url: %s", got)
+	}
+	if got := Office365URL(allDay); !strings.HasPrefix(got, office365BaseURL) || !strings.Contains(got, "allday=true") || !strings.Contains(got, "enddt=2024-05-07") {
+		t.Fatalf("unexpected all-day office365 url: %s", got)
+	}
+}
+
+func TestICS(t *testing.T) {
+	event := Event{
+		Title:       "Planning; Q2, draft",
+		Description: strings.Repeat("long description ", 8) + "\nsecond line",
+		Start:       time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC),
+		Stamp:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		UID:         "evt-1@example.com",
+	}
+
+	doc := ICS(event)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:evt-1@example.com\r\n",
+		"DTSTAMP:20240201T000000Z\r\n",
+		"DTSTART:20240301T150000Z\r\nDTEND:20240301T160000Z\r\n",
+		`SUMMARY:Planning\; Q2\, draft` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("ICS missing %q:\n%s", want, doc)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(doc, "\r\n"), "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Fatalf("line longer than %d octets: %q", icsLineLimit, line)
+		}
+	}
+	if unfolded := strings.ReplaceAll(doc, "\r\n ", ""); !strings.Contains(unfolded, `description \nsecond line`) {
+		t.Fatalf("expected escaped newline in description:\n%s", doc)
+	}
+
+	uri := ICSDataURI(event)
+	if !strings.HasPrefix(uri, "data:text/calendar;charset=utf-8,BEGIN:VCALENDAR%0D%0A") {
+		t.Fatalf("unexpected data URI prefix: %s", uri[:60])
+	}
+	decoded, err := url.PathUnescape(strings.TrimPrefix(uri, "data:text/calendar;charset=utf-8,"))
+	if err != nil || decoded != doc {
+		t.Fatalf("data URI does not round trip: %v", err)
+	}
+}
@@ -0,0 +1,516 @@
+package urlkit
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultCompactParam is the query parameter carrying compacted params when
+// no other name is configured.
+const DefaultCompactParam = "c"
+
+var (
+	// ErrCompactMalformed is returned when a compacted value is not valid
+	// base64 or its payload is truncated or corrupt.
+	ErrCompactMalformed = errors.New("malformed compact params")
+	// ErrCompactVersion is returned when a compacted value was encoded with a
+	// schema version the codec does not know.
+	ErrCompactVersion = errors.New("unknown compact params schema version")
+)
+
+// Value tags of the compact encoding.
+const (
+	compactNull byte = iota
+	compactString
+	compactInt
+	compactDecimal // a string holding a canonical base 10 integer
+	compactFloat
+	compactTrue
+	compactFalse
+	compactList
+)
+
+// CompactSchema lists the well-known keys of a compact encoding version.
+// Listed keys are encoded as a one-byte index instead of their name; keys not
+// listed are still encoded, inline. Append new keys in a new version rather
+// than reordering, so URLs already out in the wild keep decoding.
+type CompactSchema struct {
+	// Version identifies the schema in encoded values, 1 to 255. Version 0
+	// is the schemaless encoding used by CompactParams.
+	Version uint8 `json:"version" yaml:"version"`
+	// Fields are the well-known keys, in index order.
+	Fields []string `json:"fields" yaml:"fields"`
+}
+
+// CompactCodec encodes flat params into a short URL-safe string, for beacon
+// and pixel URLs where byte count matters. Values are packed in a versioned
+// binary format: integers (and strings holding them) as varints, booleans as
+// a single byte and schema keys as indexes. Encoding uses the highest schema
+// version; decoding accepts every registered version and the schemaless
+// format. A nil codec is the schemaless codec.
+type CompactCodec struct {
+	current *compactSchema
+	schemas map[uint8]*compactSchema
+}
+
+type compactSchema struct {
+	version uint8
+	fields  []string
+	index   map[string]int
+}
+
+// NewCompactCodec returns a codec for the given schema versions.
+//
+// Example:
+//
+//	codec, err := urlkit.NewCompactCodec(
+//		urlkit.CompactSchema{Version: 1, Fields: []string{"event", "page", "ts"}},
+//	)
+//	value := codec.Compact(map[string]any{"event": "view", "page": 42}) // "AQEBBHZpZXcCAlQ"
+func NewCompactCodec(schemas ...CompactSchema) (*CompactCodec, error) {
+	codec := &CompactCodec{schemas: make(map[uint8]*compactSchema, len(schemas))}
+	for _, schema := range schemas {
+		if schema.Version == 0 {
+			return nil, fmt.Errorf("compact schema version 0 is reserved for the schemaless encoding")
+		}
+		if _, exists := codec.schemas[schema.Version]; exists {
+			return nil, fmt.Errorf("compact schema version %d declared twice", schema.Version)
+		}
+		compiled := &compactSchema{
+			version: schema.Version,
+			fields:  slices.Clone(schema.Fields),
+			index:   make(map[string]int, len(schema.Fields)),
+		}
+		for i, field := range schema.Fields {
+			if field == "" {
+				return nil, fmt.Errorf("compact schema version %d: field %d is empty", schema.Version, i)
+			}
+			if _, exists := compiled.index[field]; exists {
+				return nil, fmt.Errorf("compact schema version %d: field %q listed twice", schema.Version, field)
+			}
+			compiled.index[field] = i + 1
+		}
+		codec.schemas[schema.Version] = compiled
+		if codec.current == nil || schema.Version > codec.current.version {
+			codec.current = compiled
+		}
+	}
+	return codec, nil
+}
+
+// CompactParams encodes values with the schemaless compact encoding. Decode
+// it with ExpandParams.
+//
+// Example:
+//
+//	urlkit.CompactParams(map[string]any{"e": "view", "n": 3}) // "AAABZQEEdmlldwABbgIG"
+func CompactParams(values map[string]any) string {
+	return (*CompactCodec)(nil).Compact(values)
+}
+
+// ExpandParams decodes a value produced by CompactParams. Integers decode as
+// int64 and floats as float64; strings that held integers decode as strings.
+func ExpandParams(value string) (map[string]any, error) {
+	return (*CompactCodec)(nil).Expand(value)
+}
+
+// Compact encodes values with the codec's newest schema. Supported values are
+// strings, booleans, integers, floats, nil and string slices; anything else
+// is encoded as its fmt.Sprint form.
+func (c *CompactCodec) Compact(values map[string]any) string {
+	var schema *compactSchema
+	if c != nil {
+		schema = c.current
+	}
+
+	buf := []byte{0}
+	if schema != nil {
+		buf[0] = schema.version
+	}
+
+	keys := slices.Collect(maps.Keys(values))
+	slices.SortFunc(keys, func(a, b string) int {
+		// Schema fields first, in index order, then inline keys by name
+		ia, ib := schema.fieldIndex(a), schema.fieldIndex(b)
+		switch {
+		case ia > 0 && ib > 0:
+			return ia - ib
+		case ia > 0:
+			return -1
+		case ib > 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	for _, key := range keys {
+		index := schema.fieldIndex(key)
+		buf = binary.AppendUvarint(buf, uint64(index))
+		if index == 0 {
+			buf = appendCompactString(buf, key)
+		}
+		buf = appendCompactValue(buf, values[key])
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Expand decodes a value produced by Compact with any of the codec's schema
+// versions, or by CompactParams.
+func (c *CompactCodec) Expand(value string) (map[string]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, ErrCompactMalformed
+	}
+
+	var schema *compactSchema
+	if version := data[0]; version != 0 {
+		if c != nil {
+			schema = c.schemas[version]
+		}
+		if schema == nil {
+			return nil, fmt.Errorf("%w: %d", ErrCompactVersion, version)
+		}
+	}
+
+	reader := compactReader{data: data[1:]}
+	values := make(map[string]any)
+	for !reader.done() {
+		index := reader.uvarint()
+		var key string
+		switch {
+		case index == 0:
+			key = reader.string()
+		case schema != nil && index <= uint64(len(schema.fields)):
+			key = schema.fields[index-1]
+		default:
+			return nil, fmt.Errorf("%w: field index %d out of range", ErrCompactMalformed, index)
+		}
+		value := reader.value()
+		if reader.err != nil {
+			return nil, reader.err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// fieldIndex returns the 1-based index of key in the schema, or 0 when the
+// key is encoded inline.
+func (s *compactSchema) fieldIndex(key string) int {
+	if s == nil {
+		return 0
+	}
+	return s.index[key]
+}
+
+func appendCompactString(buf []byte, value string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func appendCompactValue(buf []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, compactNull)
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && strconv.FormatInt(n, 10) == v {
+			return binary.AppendVarint(append(buf, compactDecimal), n)
+		}
+		return appendCompactString(append(buf, compactString), v)
+	case bool:
+		if v {
+			return append(buf, compactTrue)
+		}
+		return append(buf, compactFalse)
+	case int:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case int8:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case int16:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case int32:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case int64:
+		return binary.AppendVarint(append(buf, compactInt), v)
+	case uint8:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case uint16:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case uint32:
+		return binary.AppendVarint(append(buf, compactInt), int64(v))
+	case uint:
+		if uint64(v) <= math.MaxInt64 {
+			return binary.AppendVarint(append(buf, compactInt), int64(v))
+		}
+	case uint64:
+		if v <= math.MaxInt64 {
+			return binary.AppendVarint(append(buf, compactInt), int64(v))
+		}
+	case float32:
+		return appendCompactFloat(buf, float64(v))
+	case float64:
+		return appendCompactFloat(buf, v)
+	case []string:
+		buf = binary.AppendUvarint(append(buf, compactList), uint64(len(v)))
+		for _, item := range v {
+			buf = appendCompactString(buf, item)
+		}
+		return buf
+	case []any:
+		buf = binary.AppendUvarint(append(buf, compactList), uint64(len(v)))
+		for _, item := range v {
+			buf = appendCompactString(buf, fmt.Sprint(item))
+		}
+		return buf
+	}
+	return appendCompactString(append(buf, compactString), fmt.Sprint(value))
+}
+
+func appendCompactFloat(buf []byte, value float64) []byte {
+	if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+		// Whole floats are common (e.g. JSON numbers) and fit a varint
+		return binary.AppendVarint(append(buf, compactInt), int64(value))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, compactFloat), math.Float64bits(value))
+}
+
+// compactReader decodes the compact encoding, recording the first error.
+type compactReader struct {
+	data []byte
+	err  error
+}
+
+func (r *compactReader) done() bool {
+	return r.err != nil || len(r.data) == 0
+}
+
+func (r *compactReader) fail() {
+	if r.err == nil {
+		r.err = ErrCompactMalformed
+	}
+	r.data = nil
+}
+
+func (r *compactReader) byte() byte {
+	if len(r.data) == 0 {
+		r.fail()
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *compactReader) uvarint() uint64 {
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *compactReader) varint() int64 {
+	value, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *compactReader) string() string {
+	size := r.uvarint()
+	if r.err != nil || size > uint64(len(r.data)) {
+		r.fail()
+		return ""
+	}
+	value := string(r.data[:size])
+	r.data = r.data[size:]
+	return value
+}
+
+func (r *compactReader) value() any {
+	switch tag := r.byte(); tag {
+	case compactNull:
+		return nil
+	case compactString:
+		return r.string()
+	case compactInt:
+		return r.varint()
+	case compactDecimal:
+		return strconv.FormatInt(r.varint(), 10)
+	case compactFloat:
+		if len(r.data) < 8 {
+			r.fail()
+			return nil
+		}
+		value := math.Float64frombits(binary.BigEndian.Uint64(r.data))
+		r.data = r.data[8:]
+		return value
+	case compactTrue:
+		return true
+	case compactFalse:
+		return false
+	case compactList:
+		count := r.uvarint()
+		if count > uint64(len(r.data)) {
+			// Every item takes at least one byte
+			r.fail()
+			return nil
+		}
+		items := make([]string, 0, count)
+		for range count {
+			items = append(items, r.string())
+		}
+		return items
+	}
+	r.fail()
+	return nil
+}
+
+// CompactRoute designates a route whose builder query is compacted into a
+// single parameter. See Group.SetCompactRoute.
+type CompactRoute struct {
+	// Param is the query parameter carrying the compacted query. Defaults to
+	// DefaultCompactParam.
+	Param string `json:"param,omitempty" yaml:"param,omitempty"`
+	// Schemas are the schema versions of the route's codec. Without schemas
+	// the schemaless encoding is used.
+	Schemas []CompactSchema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+// compactQuery is a resolved CompactRoute.
+type compactQuery struct {
+	param string
+	codec *CompactCodec
+}
+
+// SetCompactRoute makes builders of routeName pack their whole query into a
+// single compact parameter, for beacon and pixel URLs. Path params are not
+// affected, and URLs built with Render or Resolve keep a plain query. A zero
+// CompactRoute uses the schemaless encoding under DefaultCompactParam.
+//
+// Example:
+//
+//	analytics.SetCompactRoute("pixel", urlkit.CompactRoute{
+//		Schemas: []urlkit.CompactSchema{{Version: 1, Fields: []string{"event", "page"}}},
+//	})
+//	analytics.Builder("pixel").WithQuery("event", "view").WithQuery("page", 42).Build()
+//	// https://t.example.com/p.gif?c=AQEBBHZpZXcCA1Q
+func (u *Group) SetCompactRoute(routeName string, route CompactRoute) error {
+	codec, err := NewCompactCodec(route.Schemas...)
+	if err != nil {
+		return fmt.Errorf("compact route %q: %w", routeName, err)
+	}
+	param := route.Param
+	if param == "" {
+		param = DefaultCompactParam
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set compact route", groupFQN)
+	if err != nil {
+		return err
+	}
+	var events []Event
+	defer func() { u.runtime.publish(events...) }()
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.routes[routeName]; !ok {
+		return fmt.Errorf("compact route: %w: route %q in group %s", ErrRouteNotFound, routeName, groupFQN)
+	}
+	if u.compactRoutes == nil {
+		u.compactRoutes = make(map[string]compactQuery)
+	}
+	u.compactRoutes[routeName] = compactQuery{param: param, codec: codec}
+	events = []Event{{Type: EventRoutesChanged, Group: groupFQN}}
+	return nil
+}
+
+// ClearCompactRoute restores the plain query for builders of routeName.
+func (u *Group) ClearCompactRoute(routeName string) error {
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("clear compact route", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.compactRoutes, routeName)
+	return nil
+}
+
+// compactRoute returns the compaction designated for routeName or the route
+// an alias refers to.
+func (u *Group) compactRoute(routeName string) (compactQuery, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if entry, ok := u.aliases[routeName]; ok {
+		if _, isRoute := u.routes[routeName]; !isRoute {
+			routeName = entry.route
+		}
+	}
+	compact, ok := u.compactRoutes[routeName]
+	return compact, ok
+}
+
+// WithCompactQuery packs the builder's whole query into the single param
+// using codec (nil selects the schemaless encoding), overriding any
+// compaction designated for the route with Group.SetCompactRoute. An empty
+// param defaults to DefaultCompactParam.
+//
+// Example:
+//
+//	link, err := group.Builder("beacon").
+//		WithQuery("event", "click").
+//		WithQuery("x", 120).
+//		WithCompactQuery("d", codec).
+//		Build()
+func (b *Builder) WithCompactQuery(param string, codec *CompactCodec) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if param == "" {
+		param = DefaultCompactParam
+	}
+	b.compact = &compactQuery{param: param, codec: codec}
+	return b
+}
+
+// queries returns the builder's query, compacted when requested by
+// WithCompactQuery or the route.
+func (b *Builder) queries() []Query {
+	compact := b.compact
+	if compact == nil {
+		if designated, ok := b.helper.compactRoute(b.routeName); ok {
+			compact = &designated
+		}
+	}
+	if compact == nil || len(b.query)+len(b.multiQuery) == 0 {
+		return combineQueries(b.query, b.multiQuery)
+	}
+
+	values := make(map[string]any, len(b.query)+len(b.multiQuery))
+	for key, value := range b.query {
+		values[key] = value
+	}
+	for key, items := range b.multiQuery {
+		values[key] = slices.Clone(items)
+	}
+	return []Query{{compact.param: compact.codec.Compact(values)}}
+}
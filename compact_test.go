@@ -0,0 +1,192 @@
+package urlkit_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-urlkit"
+)
+
+func TestCompactParamsRoundTrip(t *testing.T) {
+	values := map[string]any{
+		"event":  "view",
+		"page":   42,
+		"offset": -3,
+		"id":     "0042",
+		"count":  "17",
+		"ratio":  0.25,
+		"whole":  2.0,
+		"ok":     true,
+		"off":    false,
+		"empty":  nil,
+		"tags":   []string{"a", "b"},
+	}
+
+	encoded := urlkit.CompactParams(values)
+	if strings.ContainsAny(encoded, "+/=") {
+		t.Fatalf("encoding is not URL safe: %q", encoded)
+	}
+
+	decoded, err := urlkit.ExpandParams(encoded)
+	if err != nil {
+		t.Fatalf("ExpandParams failed: %v", err)
+	}
+	want := map[string]any{
+		"event":  "view",
+		"page":   int64(42),
+		"offset": int64(-3),
+		"id":     "0042",
+		"count":  "17",
+		"ratio":  0.25,
+		"whole":  int64(2),
+		"ok":     true,
+		"off":    false,
+		"empty":  nil,
+		"tags":   []string{"a", "b"},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("ExpandParams = %#v, want %#v", decoded, want)
+	}
+
+	if again := urlkit.CompactParams(values); again != encoded {
+		t.Fatalf("encoding is not deterministic: %q vs %q", again, encoded)
+	}
+}
+
+func TestCompactCodecSchemas(t *testing.T) {
+	v1 := urlkit.CompactSchema{Version: 1, Fields: []string{"event", "page"}}
+	v2 := urlkit.CompactSchema{Version: 2, Fields: []string{"event", "page", "ts"}}
+
+	old, err := urlkit.NewCompactCodec(v1)
+	if err != nil {
+		t.Fatalf("NewCompactCodec failed: %v", err)
+	}
+	codec, err := urlkit.NewCompactCodec(v1, v2)
+	if err != nil {
+		t.Fatalf("NewCompactCodec failed: %v", err)
+	}
+
+	values := map[string]any{"event": "view", "page": 42, "ts": 1767225600, "ref": "mail"}
+	encoded := codec.Compact(values)
+	if schemaless := urlkit.CompactParams(values); len(encoded) >= len(schemaless) {
+		t.Errorf("schema encoding %q should be shorter than %q", encoded, schemaless)
+	}
+
+	decoded, err := codec.Expand(encoded)
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if decoded["event"] != "view" || decoded["page"] != int64(42) || decoded["ts"] != int64(1767225600) || decoded["ref"] != "mail" {
+		t.Fatalf("unexpected decoded values: %#v", decoded)
+	}
+
+	// URLs encoded with an older schema keep decoding after a new version ships
+	legacy := old.Compact(map[string]any{"event": "click", "page": 7})
+	if decoded, err := codec.Expand(legacy); err != nil || decoded["event"] != "click" || decoded["page"] != int64(7) {
+		t.Fatalf("Expand(v1) = %#v, %v", decoded, err)
+	}
+	if decoded, err := codec.Expand(urlkit.CompactParams(map[string]any{"e": "x"})); err != nil || decoded["e"] != "x" {
+		t.Fatalf("Expand(schemaless) = %#v, %v", decoded, err)
+	}
+
+	if _, err := old.Expand(encoded); !errors.Is(err, urlkit.ErrCompactVersion) {
+		t.Errorf("expected ErrCompactVersion, got %v", err)
+	}
+	if _, err := urlkit.ExpandParams("not base64!"); !errors.Is(err, urlkit.ErrCompactMalformed) {
+		t.Errorf("expected ErrCompactMalformed, got %v", err)
+	}
+	truncated := encoded[:len(encoded)-2]
+	if _, err := codec.Expand(truncated); !errors.Is(err, urlkit.ErrCompactMalformed) {
+		t.Errorf("expected ErrCompactMalformed for truncated value, got %v", err)
+	}
+
+	invalid := [][]urlkit.CompactSchema{
+		{{Version: 0, Fields: []string{"a"}}},
+		{v1, v1},
+		{{Version: 3, Fields: []string{"a", "a"}}},
+		{{Version: 3, Fields: []string{""}}},
+	}
+	for _, schemas := range invalid {
+		if _, err := urlkit.NewCompactCodec(schemas...); err == nil {
+			t.Errorf("expected error for schemas %+v", schemas)
+		}
+	}
+}
+
+func TestBuilderCompactQuery(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "analytics",
+				BaseURL: "https://t.example.com",
+				Routes:  map[string]string{"pixel": "/p/:site.gif", "page": "/pages/:id"},
+				CompactRoutes: map[string]urlkit.CompactRoute{
+					"pixel": {Param: "d", Schemas: []urlkit.CompactSchema{{Version: 1, Fields: []string{"event", "page"}}}},
+				},
+			},
+		},
+	})
+	group := manager.Group("analytics")
+
+	built, err := group.Builder("pixel").
+		WithParam("site", "acme").
+		WithQuery("event", "view").
+		WithQuery("page", 42).
+		WithMultiQuery("tag", "a", "b").
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	u, err := url.Parse(built)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", built, err)
+	}
+	if u.Path != "/p/acme.gif" || len(u.Query()) != 1 {
+		t.Fatalf("expected path params kept and a single query param, got %q", built)
+	}
+
+	codec, _ := urlkit.NewCompactCodec(urlkit.CompactSchema{Version: 1, Fields: []string{"event", "page"}})
+	decoded, err := codec.Expand(u.Query().Get("d"))
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	want := map[string]any{"event": "view", "page": "42", "tag": []string{"a", "b"}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("decoded query = %#v, want %#v", decoded, want)
+	}
+
+	// Render and Resolve keep plain queries for designated routes
+	resolved, err := manager.Resolve("analytics", "pixel", urlkit.Params{"site": "acme"}, urlkit.Query{"event": "view"})
+	if err != nil || resolved != "https://t.example.com/p/acme.gif?event=view" {
+		t.Fatalf("Resolve = %q, %v", resolved, err)
+	}
+
+	// Other routes opt in per builder
+	built, err = group.Builder("page").WithParam("id", 1).WithQuery("ref", "mail").WithCompactQuery("", nil).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	u, _ = url.Parse(built)
+	if decoded, err := urlkit.ExpandParams(u.Query().Get(urlkit.DefaultCompactParam)); err != nil || decoded["ref"] != "mail" {
+		t.Fatalf("unexpected compact query in %q: %#v, %v", built, decoded, err)
+	}
+
+	// No query leaves the URL untouched
+	if built, err := group.Builder("pixel").WithParam("site", "acme").Build(); err != nil || built != "https://t.example.com/p/acme.gif" {
+		t.Fatalf("Build without query = %q, %v", built, err)
+	}
+
+	if err := group.ClearCompactRoute("pixel"); err != nil {
+		t.Fatalf("ClearCompactRoute failed: %v", err)
+	}
+	if built, err := group.Builder("pixel").WithParam("site", "acme").WithQuery("event", "view").Build(); err != nil || !strings.HasSuffix(built, "?event=view") {
+		t.Fatalf("Build after clear = %q, %v", built, err)
+	}
+
+	if err := group.SetCompactRoute("missing", urlkit.CompactRoute{}); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+}
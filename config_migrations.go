@@ -0,0 +1,188 @@
+package urlkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ConfigMigration upgrades a decoded config document in place from one schema
+// version to the next. doc is the generic form produced by json.Unmarshal or a
+// YAML decoder: nested map[string]any and []any values.
+type ConfigMigration func(doc map[string]any) error
+
+type configMigration struct {
+	to int
+	fn ConfigMigration
+}
+
+var configMigrations = struct {
+	sync.RWMutex
+	byFrom map[int]configMigration
+}{byFrom: map[int]configMigration{}}
+
+// RegisterConfigMigration registers fn to upgrade config documents declaring
+// version from to version to. Migrations chain on load: a version 1 document
+// runs the 1→2 migration, then the one registered from 2, and so on until no
+// migration is registered for the resulting version. Documents without a
+// version field are version 0. Each version can be migrated from only once.
+//
+// Example:
+//
+//	// v1 files used "paths"; v2 renamed it to "routes"
+//	urlkit.RegisterConfigMigration(1, 2, func(doc map[string]any) error {
+//		return urlkit.EachGroupDocument(doc, func(group map[string]any) error {
+//			if paths, ok := group["paths"]; ok {
+//				group["routes"] = paths
+//				delete(group, "paths")
+//			}
+//			return nil
+//		})
+//	})
+func RegisterConfigMigration(from, to int, fn ConfigMigration) error {
+	if fn == nil {
+		return fmt.Errorf("config migration %d→%d: migration function is required", from, to)
+	}
+	if from < 0 || to <= from {
+		return fmt.Errorf("config migration %d→%d: target version must be greater than source version", from, to)
+	}
+
+	configMigrations.Lock()
+	defer configMigrations.Unlock()
+	if existing, ok := configMigrations.byFrom[from]; ok {
+		return fmt.Errorf("config migration %d→%d: version %d already migrates to %d", from, to, from, existing.to)
+	}
+	configMigrations.byFrom[from] = configMigration{to: to, fn: fn}
+	return nil
+}
+
+// MigrateConfig applies the registered migrations to doc, starting from its
+// "version" field, and stores the resulting version back in doc.
+func MigrateConfig(doc map[string]any) (int, error) {
+	if doc == nil {
+		return 0, fmt.Errorf("config migration: document is required")
+	}
+	version, err := documentVersion(doc["version"])
+	if err != nil {
+		return 0, err
+	}
+
+	configMigrations.RLock()
+	defer configMigrations.RUnlock()
+	for {
+		migration, ok := configMigrations.byFrom[version]
+		if !ok {
+			break
+		}
+		if err := migration.fn(doc); err != nil {
+			return version, fmt.Errorf("config migration %d→%d: %w", version, migration.to, err)
+		}
+		version = migration.to
+		doc["version"] = version
+	}
+	return version, nil
+}
+
+// ConfigFromDocument migrates doc with MigrateConfig and decodes it into a
+// Config. Use it with YAML decoders that produce map[string]any.
+func ConfigFromDocument(doc map[string]any) (Config, error) {
+	if _, err := MigrateConfig(doc); err != nil {
+		return Config{}, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return Config{}, fmt.Errorf("config migration: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("configuration error: %w", err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig decodes a JSON config file, upgrading it with the registered
+// migrations first.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("routes.json")
+//	cfg, err := urlkit.ParseConfig(data)
+//	manager, err := urlkit.NewRouteManagerFromConfig(cfg)
+func ParseConfig(data []byte) (Config, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Config{}, fmt.Errorf("configuration error: %w", err)
+	}
+	return ConfigFromDocument(doc)
+}
+
+// EachGroupDocument calls fn for every group in a decoded config document,
+// parents before children, for migrations that rewrite group fields.
+func EachGroupDocument(doc map[string]any, fn func(group map[string]any) error) error {
+	groups, _ := doc["groups"].([]any)
+	for _, entry := range groups {
+		group, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		if err := fn(group); err != nil {
+			return err
+		}
+		if err := EachGroupDocument(group, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateConfigurator upgrades a typed Config whose Version has a registered
+// migration by round-tripping it through its document form. Other
+// Configurators are returned unchanged.
+func migrateConfigurator(config Configurator) (Configurator, error) {
+	var cfg Config
+	switch c := config.(type) {
+	case Config:
+		cfg = c
+	case *Config:
+		if c == nil {
+			return config, nil
+		}
+		cfg = *c
+	default:
+		return config, nil
+	}
+
+	configMigrations.RLock()
+	_, pending := configMigrations.byFrom[cfg.Version]
+	configMigrations.RUnlock()
+	if !pending {
+		return config, nil
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("config migration: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("config migration: %w", err)
+	}
+	return ConfigFromDocument(doc)
+}
+
+func documentVersion(value any) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		if v >= 0 {
+			return v, nil
+		}
+	case float64:
+		if v == math.Trunc(v) && v >= 0 {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("config migration: invalid version %v", value)
+}
@@ -0,0 +1,107 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// The migration registry is global, so these tests use versions no other test
+// registers.
+func TestConfigMigrations(t *testing.T) {
+	renamePaths := func(doc map[string]any) error {
+		return urlkit.EachGroupDocument(doc, func(group map[string]any) error {
+			if paths, ok := group["paths"]; ok {
+				group["routes"] = paths
+				delete(group, "paths")
+			}
+			return nil
+		})
+	}
+	renameHost := func(doc map[string]any) error {
+		return urlkit.EachGroupDocument(doc, func(group map[string]any) error {
+			if host, ok := group["host"]; ok {
+				group["base_url"] = host
+				delete(group, "host")
+			}
+			return nil
+		})
+	}
+	if err := urlkit.RegisterConfigMigration(100, 101, renamePaths); err != nil {
+		t.Fatalf("RegisterConfigMigration failed: %v", err)
+	}
+	if err := urlkit.RegisterConfigMigration(101, 103, renameHost); err != nil {
+		t.Fatalf("RegisterConfigMigration failed: %v", err)
+	}
+
+	cfg, err := urlkit.ParseConfig([]byte(`{
+		"version": 100,
+		"groups": [{
+			"name": "frontend",
+			"host": "https://example.com",
+			"paths": {"home": "/"},
+			"groups": [{"name": "en", "path": "/en", "paths": {"about": "/about"}}]
+		}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.Version != 103 {
+		t.Fatalf("expected version 103, got %d", cfg.Version)
+	}
+
+	manager := mustManagerFromConfig(t, cfg)
+	url, err := manager.Resolve("frontend.en", "about", nil, nil)
+	if err != nil || url != "https://example.com/en/about" {
+		t.Fatalf("unexpected url %q (%v)", url, err)
+	}
+
+	// Typed configs are migrated on load as well
+	typed := urlkit.Config{Version: 101, Groups: []urlkit.GroupConfig{{Name: "api", Routes: map[string]string{"status": "/status"}}}}
+	if _, err := urlkit.NewRouteManagerFromConfig(typed); err != nil {
+		t.Fatalf("NewRouteManagerFromConfig failed: %v", err)
+	}
+	if err := manager.Reload(&typed); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, err := manager.GetGroup("api"); err != nil {
+		t.Fatalf("expected reloaded group: %v", err)
+	}
+
+	// Versions without migrations load as is
+	cfg, err = urlkit.ParseConfig([]byte(`{"version": 103, "groups": [{"name": "api", "routes": {"status": "/status"}}]}`))
+	if err != nil || cfg.Version != 103 {
+		t.Fatalf("unexpected result %+v (%v)", cfg, err)
+	}
+}
+
+func TestConfigMigrationErrors(t *testing.T) {
+	noop := func(map[string]any) error { return nil }
+	if err := urlkit.RegisterConfigMigration(200, 200, noop); err == nil {
+		t.Fatal("expected error for non-increasing versions")
+	}
+	if err := urlkit.RegisterConfigMigration(200, 201, nil); err == nil {
+		t.Fatal("expected error for nil migration")
+	}
+	if err := urlkit.RegisterConfigMigration(200, 201, noop); err != nil {
+		t.Fatalf("RegisterConfigMigration failed: %v", err)
+	}
+	if err := urlkit.RegisterConfigMigration(200, 202, noop); err == nil || !strings.Contains(err.Error(), "already migrates to 201") {
+		t.Fatalf("expected duplicate migration error, got %v", err)
+	}
+
+	failure := errors.New("boom")
+	if err := urlkit.RegisterConfigMigration(300, 301, func(map[string]any) error { return failure }); err != nil {
+		t.Fatalf("RegisterConfigMigration failed: %v", err)
+	}
+	_, err := urlkit.ParseConfig([]byte(`{"version": 300, "groups": []}`))
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "300→301") {
+		t.Fatalf("expected wrapped migration error, got %v", err)
+	}
+
+	if _, err := urlkit.ParseConfig([]byte(`{"version": "two", "groups": []}`)); err == nil {
+		t.Fatal("expected error for invalid version")
+	}
+}
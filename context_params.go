@@ -0,0 +1,119 @@
+package urlkit
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// ContextParamExtractor reads a route parameter value from a context. It reports
+// false when the context does not carry the value.
+type ContextParamExtractor func(ctx context.Context) (any, bool)
+
+// WithContextParam registers an extractor used by Builder.BuildCtx to fill the
+// named param when the caller did not set it explicitly.
+//
+// Example:
+//
+//	manager := urlkit.NewRouteManager(
+//		urlkit.WithContextParam("tenantId", func(ctx context.Context) (any, bool) {
+//			tenant, ok := ctx.Value(tenantKey{}).(string)
+//			return tenant, ok
+//		}),
+//	)
+func WithContextParam(name string, extractor ContextParamExtractor) Option {
+	return func(m *RouteManager) {
+		if m == nil {
+			return
+		}
+		m.runtime.setContextParam(name, extractor)
+	}
+}
+
+// RegisterContextParam registers or replaces the extractor for the named param
+// after construction. Passing a nil extractor removes it.
+func (m *RouteManager) RegisterContextParam(name string, extractor ContextParamExtractor) error {
+	if name == "" {
+		return fmt.Errorf("register context param: name is required")
+	}
+
+	releaseMutation, err := m.runtime.beginMutation("register context param", "")
+	if err != nil {
+		return err
+	}
+	defer m.runtime.publish(Event{Type: EventRoutesChanged})
+	defer releaseMutation()
+
+	m.runtime.setContextParam(name, extractor)
+	return nil
+}
+
+func (r *runtimeState) setContextParam(name string, extractor ContextParamExtractor) {
+	if r == nil || name == "" {
+		return
+	}
+
+	r.ctxMu.Lock()
+	defer r.ctxMu.Unlock()
+	if extractor == nil {
+		delete(r.contextParams, name)
+		return
+	}
+	if r.contextParams == nil {
+		r.contextParams = make(map[string]ContextParamExtractor)
+	}
+	r.contextParams[name] = extractor
+}
+
+func (r *runtimeState) contextParamExtractors() map[string]ContextParamExtractor {
+	if r == nil {
+		return nil
+	}
+	r.ctxMu.RLock()
+	defer r.ctxMu.RUnlock()
+	return maps.Clone(r.contextParams)
+}
+
+// BuildCtx builds the URL like Build, first filling any param that was not set
+// explicitly from the extractors registered on the route manager. Explicit params
+// always win over context values. Root-relative URLs, produced by groups with a
+// relative base URL, are made absolute against the origin carried by ctx (see
+// WithRequestOrigin).
+func (b *Builder) BuildCtx(ctx context.Context) (string, error) {
+	built, err := b.buildCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+	return absolutizeURL(ctx, built), nil
+}
+
+func (b *Builder) buildCtx(ctx context.Context) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	extractors := b.helper.runtime.contextParamExtractors()
+	if ctx == nil || len(extractors) == 0 {
+		return b.Build()
+	}
+
+	params := cloneParamsMap(b.params)
+	if params == nil {
+		params = Params{}
+	}
+	for name, extract := range extractors {
+		if _, ok := params[name]; ok {
+			continue
+		}
+		if value, ok := extract(ctx); ok {
+			params[name] = value
+		}
+	}
+
+	queries := b.queries()
+	built, err := b.helper.render(b.routeName, b.variant, b.subdomain, coerceParams(params), nil, queries...)
+	if err != nil {
+		return "", err
+	}
+	return b.sign(built)
+}
@@ -0,0 +1,54 @@
+package urlkit_test
+
+import (
+	"context"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+type tenantKey struct{}
+
+func TestBuilderBuildCtxFillsParamsFromContext(t *testing.T) {
+	manager := urlkit.NewRouteManager(
+		urlkit.WithContextParam("tenantId", func(ctx context.Context) (any, bool) {
+			tenant, ok := ctx.Value(tenantKey{}).(string)
+			return tenant, ok
+		}),
+	)
+	group, _, err := manager.RegisterGroup("app", "https://app.example.com", map[string]string{
+		"invoice": "/tenants/:tenantId/invoices/:id",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	got, err := group.Builder("invoice").WithParam("id", 42).BuildCtx(ctx)
+	if err != nil {
+		t.Fatalf("BuildCtx failed: %v", err)
+	}
+	if got != "https://app.example.com/tenants/acme/invoices/42" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+
+	got, err = group.Builder("invoice").WithParam("id", 42).WithParam("tenantId", "globex").BuildCtx(ctx)
+	if err != nil {
+		t.Fatalf("BuildCtx failed: %v", err)
+	}
+	if got != "https://app.example.com/tenants/globex/invoices/42" {
+		t.Fatalf("explicit param should win, got %s", got)
+	}
+
+	if _, err := group.Builder("invoice").WithParam("id", 42).BuildCtx(context.Background()); err == nil {
+		t.Fatal("expected error when context lacks tenantId")
+	}
+
+	if err := manager.RegisterContextParam("tenantId", nil); err != nil {
+		t.Fatalf("RegisterContextParam failed: %v", err)
+	}
+	if _, err := group.Builder("invoice").WithParam("id", 42).BuildCtx(ctx); err == nil {
+		t.Fatal("expected error after removing extractor")
+	}
+}
@@ -0,0 +1,193 @@
+package urlkit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// accessLogRequest extracts the method and request target from the quoted
+// request line shared by the common and combined log formats.
+var accessLogRequest = regexp.MustCompile(`"([A-Z]+) (\S+)(?: [^"]*)?"`)
+
+// CoverageReport summarizes how often each registered route was hit in an
+// access log.
+type CoverageReport struct {
+	// Hits counts requests per route FQN ("group.route"). Every registered route
+	// is present, so routes without traffic have a zero count.
+	Hits map[string]int `json:"hits"`
+	// Unmatched counts request paths that no registered route matches.
+	Unmatched map[string]int `json:"unmatched"`
+	// Lines is the number of log lines read; Skipped counts the ones that could
+	// not be parsed.
+	Lines   int `json:"lines"`
+	Skipped int `json:"skipped"`
+}
+
+// Unused returns the FQNs of routes without hits, sorted.
+func (r CoverageReport) Unused() []string {
+	var unused []string
+	for fqn, hits := range r.Hits {
+		if hits == 0 {
+			unused = append(unused, fqn)
+		}
+	}
+	slices.Sort(unused)
+	return unused
+}
+
+// UnmatchedPaths returns the unmatched paths ordered by hit count, most
+// frequent first.
+func (r CoverageReport) UnmatchedPaths() []string {
+	paths := slices.Collect(maps.Keys(r.Unmatched))
+	slices.SortFunc(paths, func(a, b string) int {
+		if r.Unmatched[a] != r.Unmatched[b] {
+			return r.Unmatched[b] - r.Unmatched[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return paths
+}
+
+// Coverage reads an access log in common or combined format and matches every
+// request path against the registry's canonical and vanity patterns. Use the
+// report to prune routes that never see traffic and to discover endpoints
+// that are served but never registered. When several routes match a path, the
+// one with the fewest params (the most specific) wins.
+//
+// Example:
+//
+//	f, _ := os.Open("/var/log/nginx/access.log")
+//	report, err := manager.Coverage(f)
+//	for _, fqn := range report.Unused() { fmt.Println("unused:", fqn) }
+func (m *RouteManager) Coverage(log io.Reader) (CoverageReport, error) {
+	report := CoverageReport{Hits: map[string]int{}, Unmatched: map[string]int{}}
+	matchers := m.routeMatchers()
+	for _, matcher := range matchers {
+		report.Hits[matcher.fqn] = 0
+	}
+
+	resolved := map[string]string{}
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		report.Lines++
+		request := accessLogRequest.FindStringSubmatch(scanner.Text())
+		if request == nil {
+			report.Skipped++
+			continue
+		}
+		target, err := url.ParseRequestURI(request[2])
+		if err != nil {
+			report.Skipped++
+			continue
+		}
+
+		path := target.EscapedPath()
+		fqn, seen := resolved[path]
+		if !seen {
+			if stripped, ok := m.runtime.stripGlobalPrefix(path); ok {
+				fqn = matchRouteFQN(matchers, stripped)
+			}
+			resolved[path] = fqn
+		}
+		if fqn == "" {
+			report.Unmatched[path]++
+			continue
+		}
+		report.Hits[fqn]++
+	}
+	if err := scanner.Err(); err != nil {
+		return report, fmt.Errorf("read access log: %w", err)
+	}
+	return report, nil
+}
+
+// routeMatcher matches request paths against one full route pattern.
+type routeMatcher struct {
+	fqn     string
+	group   string
+	route   string
+	variant RouteVariant
+	match   func(string) (Params, bool)
+}
+
+// matcherCache holds the route matchers compiled at a registry generation.
+type matcherCache struct {
+	generation uint64
+	matchers   []routeMatcher
+}
+
+// routeMatchers returns matchers for every canonical and vanity pattern, in
+// manifest order. They are compiled once per registry generation; callers
+// must not modify the returned slice.
+func (m *RouteManager) routeMatchers() []routeMatcher {
+	// Read the generation first so a mutation racing with the compilation
+	// leaves the result stale rather than cached as current
+	generation := m.registryGeneration()
+	if cached := m.matchers.Load(); cached != nil && cached.generation == generation {
+		return cached.matchers
+	}
+	matchers := m.compileRouteMatchers()
+	m.matchers.Store(&matcherCache{generation: generation, matchers: matchers})
+	return matchers
+}
+
+// compileRouteMatchers compiles matchers for every canonical and vanity
+// pattern, in manifest order.
+func (m *RouteManager) compileRouteMatchers() []routeMatcher {
+	var matchers []routeMatcher
+	for _, entry := range m.Manifest() {
+		group, err := m.GetGroup(entry.GroupFQN)
+		if err != nil {
+			continue
+		}
+		matcher := routeMatcher{fqn: entry.GroupFQN + "." + entry.RouteKey, group: entry.GroupFQN, route: entry.RouteKey}
+		if match, err := compileRouteMatcher(entry.FullPathTemplate); err == nil {
+			matcher.variant, matcher.match = RouteVariantCanonical, match
+			matchers = append(matchers, matcher)
+		}
+		if vanity, ok := group.VanityRoute(entry.RouteKey); ok {
+			if match, err := compileRouteMatcher(joinURLPath(group.getFullPath(), vanity)); err == nil {
+				matcher.variant, matcher.match = RouteVariantVanity, match
+				matchers = append(matchers, matcher)
+			}
+		}
+	}
+	return matchers
+}
+
+// matchRouteFQN returns the FQN of the most specific route matching path, or
+// an empty string.
+func matchRouteFQN(matchers []routeMatcher, path string) string {
+	best, _, ok := bestRouteMatch(matchers, path)
+	if !ok {
+		return ""
+	}
+	return best.fqn
+}
+
+// bestRouteMatch returns the matcher with the fewest params that matches path,
+// together with the extracted params.
+func bestRouteMatch(matchers []routeMatcher, path string) (routeMatcher, Params, bool) {
+	var (
+		best       routeMatcher
+		bestParams Params
+		found      bool
+	)
+	for _, matcher := range matchers {
+		params, ok := matcher.match(path)
+		if !ok {
+			continue
+		}
+		if !found || len(params) < len(bestParams) {
+			best, bestParams, found = matcher, params, true
+		}
+	}
+	return best, bestParams, found
+}
@@ -0,0 +1,51 @@
+package urlkit_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestCoverage(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "frontend",
+			BaseURL: "https://example.com",
+			Routes: map[string]string{
+				"home":     "/",
+				"user":     "/users/:id",
+				"new_user": "/users/new",
+				"legacy":   "/old-page",
+			},
+		}},
+	})
+
+	log := strings.Join([]string{
+		`127.0.0.1 - - [10/Oct/2024:13:55:36 +0000] "GET / HTTP/1.1" 200 2326`,
+		`127.0.0.1 - - [10/Oct/2024:13:55:37 +0000] "GET /users/42?tab=posts HTTP/1.1" 200 512 "-" "curl/8.0"`,
+		`127.0.0.1 - - [10/Oct/2024:13:55:38 +0000] "GET /users/7 HTTP/1.1" 200 512 "https://example.com/" "Mozilla/5.0"`,
+		`127.0.0.1 - - [10/Oct/2024:13:55:39 +0000] "GET /users/new HTTP/1.1" 200 128`,
+		`127.0.0.1 - - [10/Oct/2024:13:55:40 +0000] "POST /api/hidden HTTP/1.1" 204 0`,
+		`127.0.0.1 - - [10/Oct/2024:13:55:41 +0000] "GET /api/hidden HTTP/1.1" 200 10`,
+		`not an access log line`,
+	}, "\n")
+
+	report, err := manager.Coverage(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("Coverage failed: %v", err)
+	}
+	if report.Lines != 7 || report.Skipped != 1 {
+		t.Fatalf("unexpected line counts: %+v", report)
+	}
+	if report.Hits["frontend.home"] != 1 || report.Hits["frontend.user"] != 2 || report.Hits["frontend.new_user"] != 1 {
+		t.Fatalf("unexpected hits: %v", report.Hits)
+	}
+	if unused := report.Unused(); !slices.Equal(unused, []string{"frontend.legacy"}) {
+		t.Fatalf("expected legacy to be unused, got %v", unused)
+	}
+	if paths := report.UnmatchedPaths(); !slices.Equal(paths, []string{"/api/hidden"}) || report.Unmatched["/api/hidden"] != 2 {
+		t.Fatalf("unexpected unmatched paths: %v", report.Unmatched)
+	}
+}
@@ -0,0 +1,113 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// cspDirectives lists the CSP directives that take source lists.
+var cspDirectives = []string{
+	"default-src", "script-src", "script-src-elem", "script-src-attr",
+	"style-src", "style-src-elem", "style-src-attr", "img-src", "font-src",
+	"connect-src", "media-src", "object-src", "frame-src", "child-src",
+	"worker-src", "manifest-src", "form-action", "frame-ancestors", "base-uri",
+}
+
+// CSPSources returns the sorted, de-duplicated origins (scheme://host[:port])
+// that the given groups and their descendants generate URLs for under
+// directive, so a Content-Security-Policy header stays in sync with the hosts
+// actually linked. With no group paths every root group is used. Origins come
+// from rendering every route with example params, which covers hosts produced
+// by URL templates, and from each root's base URL (wildcard hosts such as
+// https://*.example.com are kept as CSP wildcards).
+//
+// Routes declaring CSP directives in their metadata only contribute to those
+// directives and to default-src; routes without any contribute to all. A
+// root's base URL is included unless the group has routes and none of them
+// applies to directive.
+//
+// Example:
+//
+//	scripts, _ := manager.CSPSources("script-src", "cdn")
+//	images, _ := manager.CSPSources("img-src", "cdn", "media")
+//	w.Header().Set("Content-Security-Policy",
+//		"script-src 'self' "+strings.Join(scripts, " ")+"; img-src 'self' "+strings.Join(images, " "))
+func (m *RouteManager) CSPSources(directive string, groupPaths ...string) ([]string, error) {
+	directive = strings.ToLower(strings.TrimSpace(directive))
+	if !slices.Contains(cspDirectives, directive) {
+		return nil, fmt.Errorf("csp sources: unsupported directive %q", directive)
+	}
+
+	if len(groupPaths) == 0 {
+		m.mu.RLock()
+		groupPaths = slices.Sorted(maps.Keys(m.groups))
+		m.mu.RUnlock()
+	}
+
+	manifest := m.Manifest()
+	origins := map[string]bool{}
+	for _, path := range groupPaths {
+		group, err := m.GetGroup(path)
+		if err != nil {
+			return nil, fmt.Errorf("csp sources %s: %w", directive, err)
+		}
+
+		fqn := group.FQN()
+		routes, applicable := 0, 0
+		for _, entry := range manifest {
+			if entry.GroupFQN != fqn && !strings.HasPrefix(entry.GroupFQN, fqn+".") {
+				continue
+			}
+			owner, err := m.GetGroup(entry.GroupFQN)
+			if err != nil {
+				return nil, fmt.Errorf("csp sources %s: %w", directive, err)
+			}
+			routes++
+			if meta, _ := owner.RouteMetadata(entry.RouteKey); !cspApplies(meta.CSP, directive) {
+				continue
+			}
+			applicable++
+			params, err := owner.ExampleParams(entry.RouteKey)
+			if err != nil {
+				return nil, fmt.Errorf("csp sources %s: %w", directive, err)
+			}
+			built, err := owner.Render(entry.RouteKey, params)
+			if err != nil {
+				return nil, fmt.Errorf("csp sources %s: %s.%s: %w", directive, entry.GroupFQN, entry.RouteKey, err)
+			}
+			if origin, ok := urlOrigin(built); ok {
+				origins[origin] = true
+			}
+		}
+
+		if routes > 0 && applicable == 0 {
+			continue
+		}
+		root := group.getRootGroup()
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+		if origin, ok := urlOrigin(baseURL); ok {
+			origins[origin] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(origins)), nil
+}
+
+// cspApplies reports whether a route declaring directives is loaded under
+// directive. default-src is the fallback of every fetch directive.
+func cspApplies(directives []string, directive string) bool {
+	return len(directives) == 0 || directive == "default-src" || slices.Contains(directives, directive)
+}
+
+// urlOrigin returns the scheme://host[:port] of an absolute URL.
+func urlOrigin(raw string) (string, bool) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", false
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host), true
+}
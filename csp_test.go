@@ -0,0 +1,77 @@
+package urlkit_test
+
+import (
+	"slices"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestCSPSources(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:         "cdn",
+				BaseURL:      "https://cdn.example.com",
+				URLTemplate:  "https://{region}.cdn.example.com{route_path}",
+				TemplateVars: map[string]string{"region": "us"},
+				Routes:       map[string]string{"asset": "/assets/:file"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "eu", TemplateVars: map[string]string{"region": "eu"}, Routes: map[string]string{"asset": "/assets/:file"}},
+				},
+			},
+			{Name: "api", BaseURL: "https://API.example.com:8443", Routes: map[string]string{"user": "/users/:id"}},
+			{Name: "tenants", BaseURL: "https://*.example.com"},
+		},
+	})
+
+	sources, err := manager.CSPSources("script-src", "cdn")
+	if err != nil {
+		t.Fatalf("CSPSources failed: %v", err)
+	}
+	want := []string{"https://cdn.example.com", "https://eu.cdn.example.com", "https://us.cdn.example.com"}
+	if !slices.Equal(sources, want) {
+		t.Fatalf("expected %v, got %v", want, sources)
+	}
+
+	all, err := manager.CSPSources("connect-src")
+	if err != nil {
+		t.Fatalf("CSPSources failed: %v", err)
+	}
+	for _, origin := range []string{"https://api.example.com:8443", "https://*.example.com", "https://eu.cdn.example.com"} {
+		if !slices.Contains(all, origin) {
+			t.Fatalf("expected %s in %v", origin, all)
+		}
+	}
+
+	// Routes declaring directives only count for those
+	media := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "media",
+				BaseURL: "https://media.example.com",
+				Routes:  map[string]string{"image": "/images/:file", "video": "/videos/:file"},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"image": {CSP: []string{"img-src"}},
+					"video": {CSP: []string{"media-src"}},
+				},
+			},
+		},
+	})
+	for directive, want := range map[string]int{"img-src": 1, "media-src": 1, "default-src": 1, "script-src": 0} {
+		sources, err := media.CSPSources(directive)
+		if err != nil || len(sources) != want {
+			t.Errorf("CSPSources(%s) = %v, %v; want %d origins", directive, sources, err, want)
+		}
+	}
+	if err := media.Group("media").SetRouteMetadata("image", urlkit.RouteMetadata{CSP: []string{"img"}}); err == nil {
+		t.Error("expected error for an unknown csp directive")
+	}
+
+	if _, err := manager.CSPSources("script"); err == nil {
+		t.Fatal("expected error for unknown directive")
+	}
+	if _, err := manager.CSPSources("img-src", "missing"); err == nil {
+		t.Fatal("expected error for unknown group")
+	}
+}
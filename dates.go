@@ -0,0 +1,103 @@
+package urlkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDateLayout is the layout used to render time.Time params when no
+// group in the hierarchy sets one.
+const DefaultDateLayout = time.DateOnly
+
+// SetDateFormat sets the layout and timezone used to render time.Time params
+// for routes in this group and its descendants. An empty layout or nil location
+// inherits the parent's setting; the defaults are DefaultDateLayout and UTC.
+//
+// Example:
+//
+//	group.SetDateFormat("2006-01-02", time.UTC)
+//	group.Builder("report").WithParam("date", time.Now()).Build() // /reports/2024-01-05
+func (u *Group) SetDateFormat(layout string, loc *time.Location) error {
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set date format", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.dateLayout = layout
+	u.dateLocation = loc
+	return nil
+}
+
+// DateFormat returns the effective date layout and timezone for the group,
+// searching up the hierarchy.
+func (u *Group) DateFormat() (string, *time.Location) {
+	var (
+		layout string
+		loc    *time.Location
+	)
+	for current := u; current != nil && (layout == "" || loc == nil); {
+		current.mu.RLock()
+		if layout == "" {
+			layout = current.dateLayout
+		}
+		if loc == nil {
+			loc = current.dateLocation
+		}
+		parent := current.parent
+		current.mu.RUnlock()
+		current = parent
+	}
+
+	if layout == "" {
+		layout = DefaultDateLayout
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return layout, loc
+}
+
+// formatDateParams renders time.Time params with the group's date format. The
+// input map is returned unchanged when it holds no dates.
+func (u *Group) formatDateParams(params Params) Params {
+	var (
+		formatted Params
+		layout    string
+		loc       *time.Location
+	)
+	for key, value := range params {
+		date, ok := value.(time.Time)
+		if !ok {
+			continue
+		}
+		if formatted == nil {
+			formatted = cloneParamsMap(params)
+			layout, loc = u.DateFormat()
+		}
+		formatted[key] = date.In(loc).Format(layout)
+	}
+	if formatted == nil {
+		return params
+	}
+	return formatted
+}
+
+func applyDateConfig(group *Group, layout, timezone string) error {
+	if layout == "" && timezone == "" {
+		return nil
+	}
+
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("group %s date timezone: %w", groupDisplayName(group), err)
+		}
+	}
+	return group.SetDateFormat(layout, loc)
+}
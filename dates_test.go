@@ -0,0 +1,78 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestDateParams(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "reports",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"daily": "/reports/:date"},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"daily": {Params: map[string]urlkit.ParamType{"date": urlkit.ParamTypeDate}},
+				},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:         "madrid",
+						Path:         "/madrid",
+						Routes:       map[string]string{"daily": "/reports/:date"},
+						DateLayout:   "20060102",
+						DateTimezone: "Europe/Madrid",
+					},
+				},
+			},
+		},
+	})
+
+	reports, err := manager.GetGroup("reports")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	madrid := reports.Group("madrid")
+
+	// 23:30 UTC on Jan 4 is already Jan 5 in Madrid.
+	moment := time.Date(2024, 1, 4, 23, 30, 0, 0, time.UTC)
+
+	got, err := reports.Builder("daily").WithParam("date", moment).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://example.com/reports/2024-01-04"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	got, err = madrid.Builder("daily").WithParamsMap(map[string]any{"date": &moment}).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://example.com/madrid/reports/20240105"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	got, err = manager.Resolve("reports", "daily", urlkit.Params{"date": moment}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if want := "https://example.com/reports/2024-01-04"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	var paramErr urlkit.ParamError
+	_, err = reports.Builder("daily").WithParam("date", "05/01/2024").Build()
+	if !errors.As(err, &paramErr) || paramErr.Code != urlkit.ParamErrorInvalidDate {
+		t.Fatalf("expected invalid date error, got %v", err)
+	}
+
+	if _, err := urlkit.NewRouteManagerFromConfig(urlkit.Config{
+		Groups: []urlkit.GroupConfig{{Name: "bad", BaseURL: "https://example.com", DateTimezone: "Nowhere/Land"}},
+	}); err == nil {
+		t.Fatal("expected error for unknown timezone")
+	}
+}
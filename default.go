@@ -0,0 +1,54 @@
+package urlkit
+
+import "sync/atomic"
+
+var defaultManager atomic.Pointer[RouteManager]
+
+func init() {
+	defaultManager.Store(NewRouteManager())
+}
+
+// Default returns the package-level route manager used by URL and MustURL. It is
+// intended for small applications and scripts; larger programs should pass an
+// explicit RouteManager around instead.
+func Default() *RouteManager {
+	return defaultManager.Load()
+}
+
+// SetDefault replaces the package-level route manager. Passing nil installs a
+// fresh, empty manager.
+func SetDefault(m *RouteManager) {
+	if m == nil {
+		m = NewRouteManager()
+	}
+	defaultManager.Store(m)
+}
+
+// URL builds the fully qualified route (e.g. "api.v1.users") against the default
+// route manager. Optional queries are appended as with Group.Render.
+//
+// Example:
+//
+//	urlkit.Default().RegisterGroup("api", "https://api.example.com", routes)
+//	link, err := urlkit.URL("api.users", urlkit.Params{"id": 42})
+func URL(route string, params Params, queries ...Query) (string, error) {
+	groupPath, routeName, err := splitRouteFQN(route)
+	if err != nil {
+		return "", err
+	}
+
+	group, err := Default().GetGroup(groupPath)
+	if err != nil {
+		return "", err
+	}
+	return group.Render(routeName, coerceParams(params), queries...)
+}
+
+// MustURL is like URL but panics on error.
+func MustURL(route string, params Params, queries ...Query) string {
+	out, err := URL(route, params, queries...)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
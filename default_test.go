@@ -0,0 +1,44 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestDefaultManagerFacade(t *testing.T) {
+	previous := urlkit.Default()
+	t.Cleanup(func() { urlkit.SetDefault(previous) })
+
+	manager := urlkit.NewRouteManager()
+	urlkit.SetDefault(manager)
+	if urlkit.Default() != manager {
+		t.Fatal("expected SetDefault to install manager")
+	}
+
+	api, _, err := manager.RegisterGroup("api", "https://api.example.com", nil)
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, _, err := api.RegisterGroup("v1", "/v1", map[string]string{"users": "/users/:id"}); err != nil {
+		t.Fatalf("RegisterGroup child failed: %v", err)
+	}
+
+	got, err := urlkit.URL("api.v1.users", urlkit.Params{"id": 42}, urlkit.Query{"tab": "posts"})
+	if err != nil {
+		t.Fatalf("URL failed: %v", err)
+	}
+	if got != "https://api.example.com/v1/users/42?tab=posts" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+
+	if _, err := urlkit.URL("web.home", nil); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+
+	urlkit.SetDefault(nil)
+	if urlkit.Default() == nil || urlkit.Default() == manager {
+		t.Fatal("expected SetDefault(nil) to install a fresh manager")
+	}
+}
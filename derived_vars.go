@@ -0,0 +1,62 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TemplateVarCycleError reports derived template variables that reference each
+// other in a loop.
+type TemplateVarCycleError struct {
+	Group string
+	Chain []string
+}
+
+func (e TemplateVarCycleError) Error() string {
+	return fmt.Sprintf("template variable cycle in group %q: %s", e.Group, strings.Join(e.Chain, " -> "))
+}
+
+// expandTemplateVars resolves derived variables in place. A derived variable is
+// one whose value is itself a mini-template referencing other variables, such as
+// host = "{tenant}.{base_domain}". References are resolved recursively; cycles
+// fail with TemplateVarCycleError and references to undefined variables fail
+// with a descriptive error. Built-in variables (route_path, base_url) are never
+// expanded since they carry literal URL fragments.
+func expandTemplateVars(group string, vars map[string]string) error {
+	resolved := make(map[string]bool, len(vars))
+
+	var expand func(name string, chain []string) error
+	expand = func(name string, chain []string) error {
+		if resolved[name] || templateEscapeExempt[name] {
+			return nil
+		}
+		if slices.Contains(chain, name) {
+			return TemplateVarCycleError{Group: group, Chain: append(chain, name)}
+		}
+		chain = append(chain, name)
+
+		value := vars[name]
+		for _, match := range placeholderPattern.FindAllStringSubmatch(maskEscapedBraces(value), -1) {
+			ref := match[1]
+			if _, ok := vars[ref]; !ok {
+				return fmt.Errorf("template variable %q in group %q references undefined variable %q", name, group, ref)
+			}
+			if err := expand(ref, chain); err != nil {
+				return err
+			}
+		}
+
+		vars[name] = SubstituteTemplate(value, vars)
+		resolved[name] = true
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		if err := expand(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
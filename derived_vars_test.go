@@ -0,0 +1,71 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestDerivedTemplateVars(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "tenants",
+				BaseURL:     "https://example.com",
+				URLTemplate: "https://{host}{route_path}",
+				TemplateVars: map[string]string{
+					"base_domain": "example.com",
+					"region_host": "{region}.{base_domain}",
+					"host":        "{tenant}.{region_host}",
+					"region":      "eu",
+				},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:         "acme",
+						TemplateVars: map[string]string{"tenant": "acme"},
+						Routes:       map[string]string{"dashboard": "/dashboard"},
+					},
+				},
+			},
+		},
+	})
+
+	got, err := manager.Resolve("tenants.acme", "dashboard", nil, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got != "https://acme.eu.example.com/dashboard/" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+}
+
+func TestDerivedTemplateVarsCycle(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "app",
+				BaseURL:     "https://example.com",
+				URLTemplate: "https://{host}{route_path}",
+				TemplateVars: map[string]string{
+					"host":   "{tenant}.example.com",
+					"tenant": "{host}",
+				},
+				Routes: map[string]string{"home": "/"},
+			},
+		},
+	})
+
+	_, err := manager.Resolve("app", "home", nil, nil)
+	var cycle urlkit.TemplateVarCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("expected TemplateVarCycleError, got %v", err)
+	}
+	if len(cycle.Chain) != 3 || cycle.Chain[0] != cycle.Chain[2] {
+		t.Fatalf("unexpected cycle chain: %v", cycle.Chain)
+	}
+
+	if report := manager.HealthCheck(); report.Healthy {
+		t.Fatal("expected health check to report the cycle")
+	}
+}
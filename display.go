@@ -0,0 +1,292 @@
+package urlkit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultDisplayQueryLength is the query length DisplayURL truncates to when
+// DisplayOptions.MaxQueryLength is zero.
+const DefaultDisplayQueryLength = 64
+
+// DisplayOptions configures DisplayURL.
+type DisplayOptions struct {
+	// MaxQueryLength truncates longer query strings with an ellipsis. Zero uses
+	// DefaultDisplayQueryLength; a negative value never truncates.
+	MaxQueryLength int
+	// HideScheme omits the "https://" prefix.
+	HideScheme bool
+}
+
+// DisplayURL formats a URL for people to read, e.g. when a UI or an email
+// shows where a link leads. Internationalized hosts are converted from
+// punycode to Unicode unless a label could impersonate another domain (see
+// IsConfusableHost), in which case the ASCII form is kept. Percent-escapes in
+// the path, query and fragment are decoded when they stand for printable,
+// non-delimiting characters. User info is dropped and long queries are
+// truncated. The result is for display only; never use it as a link target.
+//
+// Example:
+//
+//	urlkit.DisplayURL("https://xn--mnchen-3ya.de/stra%C3%9Fe?q=caf%C3%A9", urlkit.DisplayOptions{})
+//	// https://münchen.de/straße?q=café
+func DisplayURL(raw string, opts DisplayOptions) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("display url: %w", err)
+	}
+
+	var out strings.Builder
+	if parsed.Scheme != "" && !opts.HideScheme {
+		out.WriteString(parsed.Scheme + ":")
+		if parsed.Host != "" {
+			out.WriteString("//")
+		}
+	}
+	if parsed.Host != "" {
+		host, port := parsed.Hostname(), parsed.Port()
+		if !IsConfusableHost(host) {
+			host = unicodeHost(host)
+		}
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		out.WriteString(host)
+		if port != "" {
+			out.WriteString(":" + port)
+		}
+	}
+	if parsed.Opaque != "" {
+		out.WriteString(parsed.Opaque)
+	}
+	out.WriteString(decodeDisplayEscapes(parsed.EscapedPath()))
+
+	if parsed.RawQuery != "" || parsed.ForceQuery {
+		query := decodeDisplayEscapes(parsed.RawQuery)
+		limit := opts.MaxQueryLength
+		if limit == 0 {
+			limit = DefaultDisplayQueryLength
+		}
+		if limit > 0 && utf8.RuneCountInString(query) > limit {
+			query = string([]rune(query)[:limit]) + "…"
+		}
+		out.WriteString("?" + query)
+	}
+	if parsed.Fragment != "" {
+		out.WriteString("#" + decodeDisplayEscapes(parsed.EscapedFragment()))
+	}
+	return out.String(), nil
+}
+
+// IsConfusableHost reports whether any label of host, after punycode
+// decoding, mixes Latin, Cyrillic and Greek letters or is written entirely in
+// Cyrillic or Greek letters that look like Latin ones (e.g. "аррӏе", which
+// displays like "apple"). Labels that fail to decode are reported as
+// confusable.
+func IsConfusableHost(host string) bool {
+	for _, label := range strings.Split(strings.ToLower(host), ".") {
+		encoded, ok := strings.CutPrefix(label, "xn--")
+		if !ok {
+			continue
+		}
+		decoded, err := decodePunycode(encoded)
+		if err != nil || confusableLabel(decoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// latinLookalikes are Cyrillic and Greek letters rendered like Latin ones.
+const latinLookalikes = "аеорсухіјѕԁӏһԛԝьвкмнтαικνορτυχ"
+
+func confusableLabel(label string) bool {
+	latin, cyrillic, greek, lookalikes, letters := false, false, false, 0, 0
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic = true
+		case unicode.Is(unicode.Greek, r):
+			greek = true
+		}
+		if strings.ContainsRune(latinLookalikes, r) {
+			lookalikes++
+		}
+	}
+	mixed := (latin && cyrillic) || (latin && greek) || (cyrillic && greek)
+	return mixed || (!latin && letters > 0 && lookalikes == letters)
+}
+
+// unicodeHost converts the punycode labels of host to Unicode, leaving IP
+// literals and labels that fail to decode unchanged.
+func unicodeHost(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	for i, label := range labels {
+		if encoded, ok := strings.CutPrefix(strings.ToLower(label), "xn--"); ok {
+			if decoded, err := decodePunycode(encoded); err == nil {
+				labels[i] = decoded
+			}
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// displayReserved lists the characters whose escapes DisplayURL keeps, because
+// decoding them would change how the URL reads.
+const displayReserved = "%/?#&=+;"
+
+// decodeDisplayEscapes decodes percent-escapes that encode printable UTF-8
+// characters, keeping reserved delimiters, spaces, control, format (such as
+// bidi overrides) and invalid sequences escaped.
+func decodeDisplayEscapes(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '%' {
+			out.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		// Collect the run of escaped bytes.
+		var raw []byte
+		j := i
+		for j+2 < len(s) && s[j] == '%' && isHexDigit(s[j+1]) && isHexDigit(s[j+2]) {
+			raw = append(raw, unhex(s[j+1])<<4|unhex(s[j+2]))
+			j += 3
+		}
+		if len(raw) == 0 {
+			out.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		for offset := 0; offset < len(raw); {
+			r, size := utf8.DecodeRune(raw[offset:])
+			if r == utf8.RuneError || !unicode.IsGraphic(r) || unicode.IsSpace(r) || unicode.Is(unicode.Cf, r) || strings.ContainsRune(displayReserved, r) {
+				out.WriteString(s[i+offset*3 : i+(offset+size)*3])
+			} else {
+				out.WriteRune(r)
+			}
+			offset += size
+		}
+		i = j
+	}
+	return out.String()
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	default:
+		return c - '0'
+	}
+}
+
+var errInvalidPunycode = errors.New("invalid punycode")
+
+// Punycode parameters from RFC 3492.
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// decodePunycode decodes the part of a label after "xn--" (RFC 3492).
+func decodePunycode(encoded string) (string, error) {
+	var output []rune
+	pos := 0
+	if b := strings.LastIndexByte(encoded, '-'); b >= 0 {
+		for _, c := range encoded[:b] {
+			if c >= utf8.RuneSelf {
+				return "", errInvalidPunycode
+			}
+			output = append(output, c)
+		}
+		pos = b + 1
+	}
+
+	n, i, bias := punycodeInitialN, 0, punycodeInitialBias
+	for pos < len(encoded) {
+		oldi, w := i, 1
+		for k := punycodeBase; ; k += punycodeBase {
+			if pos >= len(encoded) {
+				return "", errInvalidPunycode
+			}
+			digit := punycodeDigit(encoded[pos])
+			pos++
+			if digit < 0 || digit > (utf8.MaxRune-i)/w {
+				return "", errInvalidPunycode
+			}
+			i += digit * w
+			t := min(max(k-bias, punycodeTMin), punycodeTMax)
+			if digit < t {
+				break
+			}
+			w *= punycodeBase - t
+		}
+
+		points := len(output) + 1
+		bias = adaptPunycodeBias(i-oldi, points, oldi == 0)
+		n += i / points
+		i %= points
+		if n > utf8.MaxRune {
+			return "", errInvalidPunycode
+		}
+		output = append(output[:i], append([]rune{rune(n)}, output[i:]...)...)
+		i++
+	}
+	return string(output), nil
+}
+
+func punycodeDigit(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c-'0') + 26
+	case 'a' <= c && c <= 'z':
+		return int(c - 'a')
+	case 'A' <= c && c <= 'Z':
+		return int(c - 'A')
+	default:
+		return -1
+	}
+}
+
+func adaptPunycodeBias(delta, points int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
@@ -0,0 +1,156 @@
+package urlkit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DriftOptions configures RouteManager.CheckDrift.
+type DriftOptions struct {
+	// Client performs the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// SampleSize limits how many routes are probed. Routes are sampled evenly
+	// across the sorted manifest. Zero probes every route.
+	SampleSize int
+	// Params supplies example params keyed by route FQN ("group.route").
+	// Params that are not supplied are derived from the route's declared
+	// ParamType, falling back to "example".
+	Params map[string]Params
+}
+
+// DriftResult is the outcome of probing a single route.
+type DriftResult struct {
+	Route  string `json:"route"`
+	URL    string `json:"url"`
+	Method string `json:"method"`
+	Status int    `json:"status,omitempty"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+// DriftReport is the result of RouteManager.CheckDrift.
+type DriftReport struct {
+	BaseURL  string        `json:"base_url"`
+	Checked  int           `json:"checked"`
+	Failures int           `json:"failures"`
+	Results  []DriftResult `json:"results"`
+}
+
+// Err returns nil when every probed route responded, or an error listing the
+// routes that drifted.
+func (r DriftReport) Err() error {
+	if r.Failures == 0 {
+		return nil
+	}
+
+	parts := make([]string, 0, r.Failures)
+	for _, result := range r.Results {
+		if result.OK {
+			continue
+		}
+		reason := result.Error
+		if reason == "" {
+			reason = fmt.Sprintf("status %d", result.Status)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s: %s", result.Method, result.URL, reason))
+	}
+	return fmt.Errorf("route drift against %s: %s", r.BaseURL, strings.Join(parts, "; "))
+}
+
+// CheckDrift probes a sample of registered routes against a live service at
+// baseURL to detect drift between the registry and the deployed handlers. Each
+// route is requested with HEAD, falling back to OPTIONS when HEAD is not
+// allowed. A route is considered present unless the service answers 404, 410,
+// a 5xx status, or the request fails; 401, 403 and 405 count as present
+// because they show that the path is routed. The report is suitable for CI
+// smoke tests via DriftReport.Err.
+func (m *RouteManager) CheckDrift(ctx context.Context, baseURL string, opts DriftOptions) DriftReport {
+	report := DriftReport{BaseURL: baseURL}
+	if m == nil {
+		return report
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	for _, entry := range sampleManifest(m.Manifest(), opts.SampleSize) {
+		fqn := entry.GroupFQN + "." + entry.RouteKey
+		result := DriftResult{Route: fqn, Method: http.MethodHead}
+
+		path, err := m.examplePath(entry, opts.Params[fqn])
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.URL = baseURL + path
+			probeRoute(ctx, client, &result)
+		}
+
+		report.Checked++
+		if !result.OK {
+			report.Failures++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// sampleManifest returns up to size entries spread evenly across manifest.
+func sampleManifest(manifest []RouteManifestEntry, size int) []RouteManifestEntry {
+	if size <= 0 || size >= len(manifest) {
+		return manifest
+	}
+
+	sample := make([]RouteManifestEntry, 0, size)
+	for i := range size {
+		sample = append(sample, manifest[i*len(manifest)/size])
+	}
+	return sample
+}
+
+// examplePath renders the full path template of entry using the supplied
+// params, filling the rest from the route's declared param types.
+func (m *RouteManager) examplePath(entry RouteManifestEntry, supplied Params) (string, error) {
+	group, err := m.GetGroup(entry.GroupFQN)
+	if err != nil {
+		return "", err
+	}
+
+	compiled, err := compileRouteTemplate(entry.FullPathTemplate)
+	if err != nil {
+		return "", err
+	}
+	return compiled(group.exampleParams(entry.FullPathTemplate, entry.RouteKey, supplied))
+}
+
+func probeRoute(ctx context.Context, client *http.Client, result *DriftResult) {
+	status, err := probeStatus(ctx, client, http.MethodHead, result.URL)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		result.Method = http.MethodOptions
+		status, err = probeStatus(ctx, client, http.MethodOptions, result.URL)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+
+	result.Status = status
+	result.OK = status != http.StatusNotFound && status != http.StatusGone && status < http.StatusInternalServerError
+}
+
+func probeStatus(ctx context.Context, client *http.Client, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
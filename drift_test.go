@@ -0,0 +1,72 @@
+package urlkit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestCheckDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/42":
+			w.WriteHeader(http.StatusOK)
+		case "/orders/00000000-0000-0000-0000-000000000000":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"user":   "/users/:id",
+		"order":  "/orders/:id",
+		"legacy": "/legacy",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := group.SetRouteMetadata("order", urlkit.RouteMetadata{
+		Params: map[string]urlkit.ParamType{"id": urlkit.ParamTypeUUID},
+	}); err != nil {
+		t.Fatalf("SetRouteMetadata failed: %v", err)
+	}
+
+	report := manager.CheckDrift(context.Background(), server.URL+"/", urlkit.DriftOptions{
+		Params: map[string]urlkit.Params{"api.user": {"id": 42}},
+	})
+	if report.Checked != 3 || report.Failures != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	results := map[string]urlkit.DriftResult{}
+	for _, result := range report.Results {
+		results[result.Route] = result
+	}
+	if result := results["api.user"]; !result.OK || result.Method != http.MethodHead {
+		t.Fatalf("unexpected user result: %+v", result)
+	}
+	if result := results["api.order"]; !result.OK || result.Method != http.MethodOptions || result.Status != http.StatusNoContent {
+		t.Fatalf("unexpected order result: %+v", result)
+	}
+	if result := results["api.legacy"]; result.OK || result.Status != http.StatusNotFound {
+		t.Fatalf("unexpected legacy result: %+v", result)
+	}
+	if report.Err() == nil {
+		t.Fatal("expected drift error")
+	}
+
+	sampled := manager.CheckDrift(context.Background(), server.URL, urlkit.DriftOptions{SampleSize: 1})
+	if sampled.Checked != 1 {
+		t.Fatalf("expected 1 sampled route, got %d", sampled.Checked)
+	}
+}
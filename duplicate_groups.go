@@ -0,0 +1,74 @@
+package urlkit
+
+import "fmt"
+
+// DuplicateGroupPolicy controls what RegisterGroup does when a root group with
+// the same name is already registered.
+type DuplicateGroupPolicy string
+
+const (
+	// DuplicateGroupPolicyMerge merges the routes into the existing group. It is the
+	// default, kept for compatibility with configurations that split a group
+	// across several registrations.
+	DuplicateGroupPolicyMerge DuplicateGroupPolicy = "merge"
+	// DuplicateGroupPolicyError rejects the registration with a DuplicateGroupError,
+	// so a repeated or mistyped group name is caught instead of silently
+	// merged. Use MergeGroup to add routes to an existing group on purpose.
+	DuplicateGroupPolicyError DuplicateGroupPolicy = "error"
+)
+
+// DuplicateGroupError is returned by RegisterGroup under the
+// DuplicateGroupPolicyError policy when the root group already exists.
+type DuplicateGroupError struct {
+	GroupName string
+}
+
+func (e DuplicateGroupError) Error() string {
+	return fmt.Sprintf("register group: root group %q is already registered; use MergeGroup to add routes to it", e.GroupName)
+}
+
+// registerMode selects how registerRootGroup treats an existing group.
+type registerMode int
+
+const (
+	registerOrMerge registerMode = iota
+	registerOnly
+	mergeOnly
+)
+
+// WithDuplicateGroupPolicy sets how RegisterGroup, and configuration loading,
+// handle a root group registered twice.
+//
+// Example:
+//
+//	manager := urlkit.NewRouteManager(urlkit.WithDuplicateGroupPolicy(urlkit.DuplicateGroupPolicyError))
+func WithDuplicateGroupPolicy(policy DuplicateGroupPolicy) Option {
+	return func(m *RouteManager) {
+		if m == nil || m.runtime == nil {
+			return
+		}
+		m.runtime.mu.Lock()
+		m.runtime.duplicateGroup = policy
+		m.runtime.mu.Unlock()
+	}
+}
+
+func (r *runtimeState) duplicateGroupPolicy() DuplicateGroupPolicy {
+	if r == nil {
+		return DuplicateGroupPolicyMerge
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.duplicateGroup == DuplicateGroupPolicyError {
+		return DuplicateGroupPolicyError
+	}
+	return DuplicateGroupPolicyMerge
+}
+
+// MergeGroup adds routes to an existing root group regardless of the duplicate
+// group policy. The group must already be registered and baseURL must match
+// its base URL (a RootGroupConflictError is returned otherwise). Route
+// conflicts follow the conflict policy, as with RegisterGroup.
+func (m *RouteManager) MergeGroup(name, baseURL string, routes map[string]string) (*Group, RouteMutationResult, error) {
+	return m.registerRootGroup("merge group", name, baseURL, routes, mergeOnly)
+}
@@ -0,0 +1,51 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestDuplicateGroupPolicy(t *testing.T) {
+	merging := urlkit.NewRouteManager()
+	if _, _, err := merging.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, _, err := merging.RegisterGroup("api", "https://api.example.com", map[string]string{"health": "/health"}); err != nil {
+		t.Fatalf("expected default policy to merge, got %v", err)
+	}
+
+	strict := urlkit.NewRouteManager(urlkit.WithDuplicateGroupPolicy(urlkit.DuplicateGroupPolicyError))
+	if _, _, err := strict.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	_, _, err := strict.RegisterGroup("api", "https://api.example.com", map[string]string{"health": "/health"})
+	var duplicate urlkit.DuplicateGroupError
+	if !errors.As(err, &duplicate) || duplicate.GroupName != "api" {
+		t.Fatalf("expected DuplicateGroupError, got %v", err)
+	}
+
+	group, result, err := strict.MergeGroup("api", "https://api.example.com", map[string]string{"health": "/health"})
+	if err != nil {
+		t.Fatalf("MergeGroup failed: %v", err)
+	}
+	if len(result.Added) != 1 || result.Added[0] != "health" {
+		t.Fatalf("unexpected merge result: %+v", result)
+	}
+	if url, err := group.Render("status", nil); err != nil || url != "https://api.example.com/status" {
+		t.Fatalf("expected merged group to keep existing routes, got %q, %v", url, err)
+	}
+
+	if _, _, err := strict.MergeGroup("web", "https://example.com", nil); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for unknown group, got %v", err)
+	}
+
+	_, err = urlkit.NewRouteManagerFromConfig(urlkit.Config{Groups: []urlkit.GroupConfig{
+		{Name: "api", BaseURL: "https://api.example.com", Routes: map[string]string{"status": "/status"}},
+		{Name: "api", BaseURL: "https://api.example.com", Routes: map[string]string{"health": "/health"}},
+	}}, urlkit.WithDuplicateGroupPolicy(urlkit.DuplicateGroupPolicyError))
+	if !errors.As(err, &duplicate) {
+		t.Fatalf("expected duplicate group in config to fail, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// BindEnvVars copies environment variables named PREFIX_NAME into the template
+// variable "name" (lowercased) on the given groups, or on every root group when
+// none are given so the values are inherited by all children. With prefix
+// "URLKIT", URLKIT_REGION=eu-west sets {region} to "eu-west".
+//
+// The variables are applied immediately and re-read whenever the process
+// receives SIGHUP, until the returned stop function is called. Variables that
+// disappear from the environment keep their last value. Reload failures (for
+// example after Freeze) are reported to the observer as WarningEnvReloadFailed.
+//
+// Example:
+//
+//	stop, err := manager.BindEnvVars("URLKIT", "frontend", "api")
+//	if err != nil {
+//		return err
+//	}
+//	defer stop()
+func (m *RouteManager) BindEnvVars(prefix string, groups ...string) (func(), error) {
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "_")
+	if prefix == "" {
+		return nil, fmt.Errorf("bind env vars: prefix is required")
+	}
+
+	targets, err := m.envVarGroups(groups)
+	if err != nil {
+		return nil, fmt.Errorf("bind env vars: %w", err)
+	}
+	if err := applyEnvVars(prefix+"_", targets); err != nil {
+		return nil, fmt.Errorf("bind env vars: %w", err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				if err := applyEnvVars(prefix+"_", targets); err != nil {
+					targets[0].warn(WarningEnvReloadFailed, "", "", "reload env vars %s_*: %v", prefix, err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}, nil
+}
+
+func (m *RouteManager) envVarGroups(names []string) ([]*Group, error) {
+	if len(names) == 0 {
+		m.mu.RLock()
+		names = slices.Sorted(maps.Keys(m.groups))
+		m.mu.RUnlock()
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no groups registered", ErrGroupNotFound)
+	}
+
+	groups := make([]*Group, 0, len(names))
+	for _, name := range names {
+		group, err := m.GetGroup(name)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// applyEnvVars sets every environment variable starting with prefix as a
+// template variable on groups.
+func applyEnvVars(prefix string, groups []*Group) error {
+	vars := map[string]string{}
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok || name == "" {
+			continue
+		}
+		vars[strings.ToLower(name)] = value
+	}
+
+	for _, group := range groups {
+		for _, name := range slices.Sorted(maps.Keys(vars)) {
+			if err := group.SetTemplateVar(name, vars[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package urlkit_test
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestBindEnvVars(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not available on windows")
+	}
+
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "api",
+				BaseURL:     "https://api.example.com",
+				URLTemplate: "https://{region}.{domain}{route_path}",
+				Routes:      map[string]string{"status": "/status"},
+			},
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"home": "/"},
+			},
+		},
+	})
+
+	t.Setenv("URLKIT_REGION", "eu-west")
+	t.Setenv("URLKIT_DOMAIN", "example.com")
+
+	if _, err := manager.BindEnvVars("URLKIT", "missing"); err == nil {
+		t.Fatal("expected error for unknown group")
+	}
+
+	stop, err := manager.BindEnvVars("URLKIT_", "api")
+	if err != nil {
+		t.Fatalf("BindEnvVars failed: %v", err)
+	}
+	defer stop()
+
+	api := manager.Group("api")
+	if got, _ := api.Render("status", nil); got != "https://eu-west.example.com/status/" {
+		t.Fatalf("unexpected url %s", got)
+	}
+	if _, ok := manager.Group("frontend").GetTemplateVar("region"); ok {
+		t.Fatal("expected unselected group to be left alone")
+	}
+
+	t.Setenv("URLKIT_REGION", "us-east")
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("FindProcess failed: %v", err)
+	}
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Fatalf("Signal failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if got, _ := api.Render("status", nil); got == "https://us-east.example.com/status/" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected SIGHUP to reload env vars")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,92 @@
+package urlkit
+
+import "net/url"
+
+// TemplateEscapeMode controls how template variable values are escaped when they
+// are substituted into a URL template.
+type TemplateEscapeMode string
+
+const (
+	// TemplateEscapeRaw splices values verbatim. This is the default and matches
+	// the historical SubstituteTemplate behavior.
+	TemplateEscapeRaw TemplateEscapeMode = "raw"
+	// TemplateEscapePath escapes values as a single path segment (url.PathEscape).
+	TemplateEscapePath TemplateEscapeMode = "path"
+	// TemplateEscapeQuery escapes values as a query component (url.QueryEscape).
+	TemplateEscapeQuery TemplateEscapeMode = "query"
+)
+
+// templateEscapeExempt lists built-in variables that already carry encoded URL
+// fragments and must never be escaped again.
+var templateEscapeExempt = map[string]bool{
+	"route_path":        true,
+	"route_path_suffix": true,
+	"base_url":          true,
+}
+
+// WithTemplateEscaping sets how user supplied template variables are escaped when
+// rendering templated groups. The built-in {route_path} and {base_url} variables
+// are never escaped. Unknown modes fall back to TemplateEscapeRaw.
+//
+// Example:
+//
+//	manager := urlkit.NewRouteManager(urlkit.WithTemplateEscaping(urlkit.TemplateEscapePath))
+func WithTemplateEscaping(mode TemplateEscapeMode) Option {
+	return func(m *RouteManager) {
+		if m == nil {
+			return
+		}
+		m.runtime.setEscapeMode(mode)
+	}
+}
+
+func (r *runtimeState) setEscapeMode(mode TemplateEscapeMode) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch mode {
+	case TemplateEscapePath, TemplateEscapeQuery:
+		r.escapeMode = mode
+	default:
+		r.escapeMode = TemplateEscapeRaw
+	}
+}
+
+func (r *runtimeState) templateEscapeMode() TemplateEscapeMode {
+	if r == nil {
+		return TemplateEscapeRaw
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.escapeMode == "" {
+		return TemplateEscapeRaw
+	}
+	return r.escapeMode
+}
+
+// EscapeTemplateValue escapes a single template variable value for the given mode.
+func EscapeTemplateValue(value string, mode TemplateEscapeMode) string {
+	switch mode {
+	case TemplateEscapePath:
+		return url.PathEscape(value)
+	case TemplateEscapeQuery:
+		return url.QueryEscape(value)
+	default:
+		return value
+	}
+}
+
+// escapeTemplateVars escapes every non built-in variable in place.
+func escapeTemplateVars(vars map[string]string, mode TemplateEscapeMode) {
+	if mode == TemplateEscapeRaw || mode == "" {
+		return
+	}
+	for key, value := range vars {
+		if templateEscapeExempt[key] {
+			continue
+		}
+		vars[key] = EscapeTemplateValue(value, mode)
+	}
+}
@@ -0,0 +1,41 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestTemplateEscapingModes(t *testing.T) {
+	cfg := urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "docs",
+				BaseURL:     "https://docs.example.com",
+				URLTemplate: "{base_url}/{section}{route_path}?ref={source}",
+				TemplateVars: map[string]string{
+					"section": "user guide#intro",
+					"source":  "a&b c",
+				},
+				Routes: map[string]string{"page": "/page"},
+			},
+		},
+	}
+
+	cases := map[urlkit.TemplateEscapeMode]string{
+		urlkit.TemplateEscapeRaw:   "https://docs.example.com/user guide#intro/page/?ref=a&b c",
+		urlkit.TemplateEscapePath:  "https://docs.example.com/user%20guide%23intro/page/?ref=a&b%20c",
+		urlkit.TemplateEscapeQuery: "https://docs.example.com/user+guide%23intro/page/?ref=a%26b+c",
+	}
+
+	for mode, want := range cases {
+		manager := mustManagerFromConfig(t, cfg, urlkit.WithTemplateEscaping(mode))
+		got, err := manager.Resolve("docs", "page", nil, nil)
+		if err != nil {
+			t.Fatalf("mode %s: Resolve failed: %v", mode, err)
+		}
+		if got != want {
+			t.Fatalf("mode %s: expected %s, got %s", mode, want, got)
+		}
+	}
+}
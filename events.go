@@ -0,0 +1,125 @@
+package urlkit
+
+import (
+	"slices"
+	"sync"
+)
+
+// EventType identifies the kind of registry mutation carried by an Event.
+// Every successful mutation of the registry publishes one of them, so
+// subscribers can treat the event stream as complete.
+type EventType string
+
+const (
+	// EventGroupRegistered is published when a new group is created.
+	EventGroupRegistered EventType = "group_registered"
+	// EventRoutesAdded is published when routes are added to or replaced in a
+	// group, or a vanity route is set.
+	EventRoutesAdded EventType = "routes_added"
+	// EventTemplateChanged is published when a URL template, template variable,
+	// template list, date format, query name, profile variable set, the active
+	// profile, or the global prefix changes.
+	EventTemplateChanged EventType = "template_changed"
+	// EventConfigReloaded is published when the manager reloads its configuration.
+	EventConfigReloaded EventType = "config_reloaded"
+	// EventRoutesChanged is published when other state affecting built URLs or
+	// navigation changes: route metadata, aliases and compact routes, scheme,
+	// link, subdomain and region policies, locales, asset manifests and app
+	// links (with the group's FQN), host mappings and lazy groups (with the
+	// group name), mounts (with the mount prefix), and redirects and context
+	// params (manager-wide, with an empty Group).
+	EventRoutesChanged EventType = "routes_changed"
+)
+
+// Event describes a mutation of the route registry.
+type Event struct {
+	Type    EventType
+	Group   string   // FQN of the affected group, empty for manager-wide events
+	Routes  []string // Route names added or replaced, when applicable
+	Profile string   // Newly active profile for profile switches
+}
+
+// eventBus fans out events to subscribers. The zero value is ready to use.
+type eventBus struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers map[int]func(Event)
+}
+
+func (b *eventBus) subscribe(fn func(Event)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = make(map[int]func(Event))
+	}
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = fn
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			b.mu.Unlock()
+		})
+	}
+}
+
+func (b *eventBus) publish(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.mu.RLock()
+	ids := make([]int, 0, len(b.subscribers))
+	for id := range b.subscribers {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	subscribers := make([]func(Event), 0, len(ids))
+	for _, id := range ids {
+		subscribers = append(subscribers, b.subscribers[id])
+	}
+	b.mu.RUnlock()
+
+	for _, event := range events {
+		for _, fn := range subscribers {
+			fn(event)
+		}
+	}
+}
+
+func (r *runtimeState) publish(events ...Event) {
+	if r == nil || len(events) == 0 {
+		return
+	}
+	r.generation.Add(1)
+	r.bus.publish(events...)
+}
+
+func routesChangedEvents(groupFQN string, result RouteMutationResult) []Event {
+	changed := append(append([]string(nil), result.Added...), result.Replaced...)
+	if len(changed) == 0 {
+		return nil
+	}
+	slices.Sort(changed)
+	return []Event{{Type: EventRoutesAdded, Group: groupFQN, Routes: changed}}
+}
+
+// Subscribe registers fn to receive registry mutation events: every
+// successful mutating call on the manager or its groups publishes one, as
+// listed on the EventType constants, and so does loading a lazy group. Build
+// interceptors and Freeze do not change built URLs and publish nothing. Events
+// are delivered synchronously, in subscription order, after the mutation has
+// been applied and its locks released, so fn may safely read from the manager.
+// The returned function removes the subscription.
+//
+// Subscribers are typically caches (manifests, sitemaps, navigation) that need
+// to invalidate when the registry changes at runtime.
+func (m *RouteManager) Subscribe(fn func(Event)) (unsubscribe func()) {
+	if m == nil || fn == nil {
+		return func() {}
+	}
+	return m.runtime.bus.subscribe(fn)
+}
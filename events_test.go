@@ -0,0 +1,128 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSubscribeReceivesMutationEvents(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+
+	var events []urlkit.Event
+	unsubscribe := manager.Subscribe(func(event urlkit.Event) {
+		// Subscribers may read from the manager while handling events.
+		if event.Group != "" {
+			if _, err := manager.GetGroup(event.Group); err != nil {
+				t.Errorf("group %s not readable from subscriber: %v", event.Group, err)
+			}
+		}
+		events = append(events, event)
+	})
+
+	group, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, _, err := manager.AddRoutes("api", map[string]string{"users": "/users"}); err != nil {
+		t.Fatalf("AddRoutes failed: %v", err)
+	}
+	if _, _, err := group.RegisterGroup("v1", "/v1", nil); err != nil {
+		t.Fatalf("RegisterGroup child failed: %v", err)
+	}
+	if _, err := manager.EnsureGroup("api.v1.admin"); err != nil {
+		t.Fatalf("EnsureGroup failed: %v", err)
+	}
+	if err := group.SetURLTemplate("{base_url}{route_path}"); err != nil {
+		t.Fatalf("SetURLTemplate failed: %v", err)
+	}
+
+	expected := []struct {
+		kind  urlkit.EventType
+		group string
+	}{
+		{urlkit.EventGroupRegistered, "api"},
+		{urlkit.EventRoutesAdded, "api"},
+		{urlkit.EventGroupRegistered, "api.v1"},
+		{urlkit.EventGroupRegistered, "api.v1.admin"},
+		{urlkit.EventTemplateChanged, "api"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %+v", len(expected), events)
+	}
+	for i, want := range expected {
+		if events[i].Type != want.kind || events[i].Group != want.group {
+			t.Fatalf("event %d: expected %s/%s, got %+v", i, want.kind, want.group, events[i])
+		}
+	}
+	if len(events[1].Routes) != 1 || events[1].Routes[0] != "users" {
+		t.Fatalf("expected routes_added to list users, got %v", events[1].Routes)
+	}
+
+	unsubscribe()
+	if err := group.SetTemplateVar("env", "prod"); err != nil {
+		t.Fatalf("SetTemplateVar failed: %v", err)
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected no events after unsubscribe, got %+v", events[len(expected):])
+	}
+}
+
+func TestSubscribeSkipsFailedMutations(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	count := 0
+	manager.Subscribe(func(urlkit.Event) { count++ })
+
+	if _, _, err := manager.AddRoutes("api", map[string]string{"status": "/health"}); err == nil {
+		t.Fatal("expected conflict error")
+	}
+	if count != 0 {
+		t.Fatalf("expected no events for rejected mutation, got %d", count)
+	}
+}
+
+func TestSubscribeReceivesRoutesChangedEvents(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	var events []urlkit.Event
+	manager.Subscribe(func(event urlkit.Event) { events = append(events, event) })
+
+	if err := group.SetRouteMetadata("status", urlkit.RouteMetadata{Weight: 1}); err != nil {
+		t.Fatalf("SetRouteMetadata failed: %v", err)
+	}
+	if err := group.SetRouteAlias("health", "status"); err != nil {
+		t.Fatalf("SetRouteAlias failed: %v", err)
+	}
+	if err := manager.MapHost("api.example.com", "api"); err != nil {
+		t.Fatalf("MapHost failed: %v", err)
+	}
+	if err := manager.AddRedirects(urlkit.RedirectRule{From: "/ping", Route: "api.status"}); err != nil {
+		t.Fatalf("AddRedirects failed: %v", err)
+	}
+	if err := group.SetRouteMetadata("missing", urlkit.RouteMetadata{}); err == nil {
+		t.Fatal("expected ErrRouteNotFound")
+	}
+
+	expected := []urlkit.Event{
+		{Type: urlkit.EventRoutesChanged, Group: "api"},
+		{Type: urlkit.EventRoutesChanged, Group: "api"},
+		{Type: urlkit.EventRoutesChanged, Group: "api"},
+		{Type: urlkit.EventRoutesChanged},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %+v", len(expected), events)
+	}
+	for i, event := range events {
+		if event.Type != expected[i].Type || event.Group != expected[i].Group {
+			t.Errorf("event %d: expected %+v, got %+v", i, expected[i], event)
+		}
+	}
+}
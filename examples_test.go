@@ -0,0 +1,64 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestExampleCommand(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com",
+				Routes: map[string]string{
+					"user":   "/users/:id",
+					"create": "/users",
+				},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"user":   {Params: map[string]urlkit.ParamType{"id": urlkit.ParamTypeInt}},
+					"create": {Method: "post"},
+				},
+			},
+		},
+	})
+
+	cases := []struct {
+		fqn    string
+		format urlkit.CommandFormat
+		want   string
+	}{
+		{
+			fqn:    "api.user",
+			format: urlkit.CommandFormatCurl,
+			want:   `curl -X GET 'https://api.example.com/users/1' -H 'Authorization: Bearer $TOKEN'`,
+		},
+		{
+			fqn:    "api.create",
+			format: urlkit.CommandFormatCurl,
+			want:   `curl -X POST 'https://api.example.com/users' -H 'Authorization: Bearer $TOKEN' -H 'Content-Type: application/json' -d '{}'`,
+		},
+		{
+			fqn:    "api.user",
+			format: urlkit.CommandFormatHTTPie,
+			want:   `http GET 'https://api.example.com/users/1' 'Authorization:Bearer $TOKEN'`,
+		},
+	}
+	for _, tc := range cases {
+		got, err := manager.ExampleCommand(tc.fqn, tc.format)
+		if err != nil {
+			t.Fatalf("ExampleCommand(%s, %s) failed: %v", tc.fqn, tc.format, err)
+		}
+		if got != tc.want {
+			t.Fatalf("ExampleCommand(%s, %s):\nexpected %s\ngot      %s", tc.fqn, tc.format, tc.want, got)
+		}
+	}
+
+	if _, err := manager.ExampleCommand("api.user", "wget"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+	if _, err := manager.ExampleCommand("api.missing", urlkit.CommandFormatCurl); err == nil {
+		t.Fatal("expected error for unknown route")
+	}
+}
@@ -0,0 +1,77 @@
+package urlkit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Fingerprint returns a stable content hash of the registry, as "sha256:<hex>".
+// It covers base URLs, group paths, URL templates and template variables, routes,
+// vanity patterns, route aliases and mounted managers, and does not depend on registration
+// order. Exports embed it so consumers can cache artifacts and CI can detect
+// when they need regenerating.
+func (m *RouteManager) Fingerprint() string {
+	hash := sha256.New()
+	m.writeFingerprint(hash)
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+func (m *RouteManager) writeFingerprint(w io.Writer) {
+	if m == nil {
+		return
+	}
+
+	m.mu.RLock()
+	roots := make([]*Group, 0, len(m.groups))
+	for _, name := range slices.Sorted(maps.Keys(m.groups)) {
+		roots = append(roots, m.groups[name])
+	}
+	prefixes := slices.Sorted(maps.Keys(m.mounts))
+	mounts := make([]*RouteManager, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		mounts = append(mounts, m.mounts[prefix])
+	}
+	m.mu.RUnlock()
+
+	fmt.Fprintf(w, "prefix\x00%s\n", m.runtime.prefix())
+	for _, root := range roots {
+		writeGroupFingerprint(w, root)
+	}
+	for i, prefix := range prefixes {
+		fmt.Fprintf(w, "mount\x00%s\n", prefix)
+		mounts[i].writeFingerprint(w)
+		fmt.Fprintf(w, "end mount\x00%s\n", prefix)
+	}
+}
+
+func writeGroupFingerprint(w io.Writer, group *Group) {
+	fqn := group.FQN()
+
+	group.mu.RLock()
+	fmt.Fprintf(w, "group\x00%s\x00%s\x00%s\x00%s\n", fqn, group.baseURL, group.path, group.urlTemplate)
+	for _, key := range slices.Sorted(maps.Keys(group.templateVars)) {
+		fmt.Fprintf(w, "var\x00%s\x00%s\n", key, group.templateVars[key])
+	}
+	for _, route := range slices.Sorted(maps.Keys(group.routes)) {
+		fmt.Fprintf(w, "route\x00%s\x00%s\n", route, group.routes[route])
+	}
+	for _, route := range slices.Sorted(maps.Keys(group.vanityRoutes)) {
+		fmt.Fprintf(w, "vanity\x00%s\x00%s\n", route, group.vanityRoutes[route].template)
+	}
+	for _, alias := range slices.Sorted(maps.Keys(group.aliases)) {
+		fmt.Fprintf(w, "alias\x00%s\x00%s\n", alias, group.aliases[alias].route)
+	}
+	children := make([]*Group, 0, len(group.children))
+	for _, name := range slices.Sorted(maps.Keys(group.children)) {
+		children = append(children, group.children[name])
+	}
+	group.mu.RUnlock()
+
+	for _, child := range children {
+		writeGroupFingerprint(w, child)
+	}
+}
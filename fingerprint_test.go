@@ -0,0 +1,48 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestFingerprint(t *testing.T) {
+	build := func(routes map[string]string) *urlkit.RouteManager {
+		manager := urlkit.NewRouteManager()
+		if _, _, err := manager.RegisterGroup("api", "https://api.example.com", routes); err != nil {
+			t.Fatalf("RegisterGroup failed: %v", err)
+		}
+		if _, _, err := manager.RegisterGroup("web", "https://example.com", map[string]string{"home": "/"}); err != nil {
+			t.Fatalf("RegisterGroup failed: %v", err)
+		}
+		return manager
+	}
+
+	first := build(map[string]string{"users": "/users", "user": "/users/:id"})
+	second := build(map[string]string{"user": "/users/:id", "users": "/users"})
+
+	fingerprint := first.Fingerprint()
+	if !strings.HasPrefix(fingerprint, "sha256:") || len(fingerprint) != len("sha256:")+64 {
+		t.Fatalf("unexpected fingerprint format: %q", fingerprint)
+	}
+	if fingerprint != second.Fingerprint() {
+		t.Fatal("expected identical registries to share a fingerprint")
+	}
+	if doc := first.ManifestDocument(); doc.Fingerprint != fingerprint {
+		t.Fatalf("expected manifest document to carry the fingerprint, got %q", doc.Fingerprint)
+	}
+
+	if err := first.Group("api").SetVanityRoute("user", "/u/:id"); err != nil {
+		t.Fatalf("SetVanityRoute failed: %v", err)
+	}
+	if first.Fingerprint() == fingerprint {
+		t.Fatal("expected fingerprint to change after adding a vanity route")
+	}
+	if _, err := second.Group("api").AddRoutes(map[string]string{"health": "/health"}); err != nil {
+		t.Fatalf("AddRoutes failed: %v", err)
+	}
+	if second.Fingerprint() == fingerprint {
+		t.Fatal("expected fingerprint to change after adding a route")
+	}
+}
@@ -0,0 +1,170 @@
+// Package geo builds geo: URIs (RFC 5870) and Google Maps and Apple Maps links
+// for coordinates, place searches and directions.
+//
+//	office := geo.Location{Lat: 52.520008, Lng: 13.404954, Query: "Office"}
+//	geo.GeoURI(office, 15)        // geo:52.520008,13.404954?z=15&q=52.520008,13.404954(Office)
+//	geo.GoogleMapsURL(office)     // https://www.google.com/maps/search/?api=1&query=52.520008%2C13.404954
+//	geo.AppleDirectionsURL(nil, office, geo.Walking)
+package geo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Mode is a directions travel mode.
+type Mode string
+
+const (
+	Driving   Mode = "driving"
+	Walking   Mode = "walking"
+	Bicycling Mode = "bicycling"
+	Transit   Mode = "transit"
+)
+
+// appleModes maps travel modes to Apple Maps dirflg values. Apple Maps has no
+// cycling flag, so Bicycling falls back to the user's default mode.
+var appleModes = map[Mode]string{Driving: "d", Walking: "w", Transit: "r"}
+
+// Location is a point, a free-text place search, or a point with a label. When
+// Lat and Lng are both zero, Query is treated as a search.
+type Location struct {
+	Lat   float64
+	Lng   float64
+	Query string
+}
+
+func (l Location) hasPoint() bool {
+	return l.Lat != 0 || l.Lng != 0
+}
+
+func (l Location) validate() error {
+	if !l.hasPoint() {
+		if strings.TrimSpace(l.Query) == "" {
+			return fmt.Errorf("geo: location needs coordinates or a query")
+		}
+		return nil
+	}
+	if l.Lat < -90 || l.Lat > 90 {
+		return fmt.Errorf("geo: latitude %v out of range [-90, 90]", l.Lat)
+	}
+	if l.Lng < -180 || l.Lng > 180 {
+		return fmt.Errorf("geo: longitude %v out of range [-180, 180]", l.Lng)
+	}
+	return nil
+}
+
+// coordinates formats the point as "lat,lng" with no trailing zeros.
+func (l Location) coordinates() string {
+	return strconv.FormatFloat(l.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lng, 'f', -1, 64)
+}
+
+// target is the value used where maps accept either coordinates or a search.
+func (l Location) target() string {
+	if l.hasPoint() {
+		return l.coordinates()
+	}
+	return l.Query
+}
+
+// GeoURI returns a geo: URI. Labels and searches use the "q" parameter
+// understood by Android and most map apps; zoom is added when positive.
+func GeoURI(loc Location, zoom int) (string, error) {
+	if err := loc.validate(); err != nil {
+		return "", err
+	}
+
+	uri := "geo:0,0"
+	if loc.hasPoint() {
+		uri = "geo:" + loc.coordinates()
+	}
+
+	var params []string
+	if zoom > 0 {
+		params = append(params, "z="+strconv.Itoa(zoom))
+	}
+	switch {
+	case loc.hasPoint() && loc.Query != "":
+		params = append(params, "q="+loc.coordinates()+"("+escape(loc.Query)+")")
+	case !loc.hasPoint():
+		params = append(params, "q="+escape(loc.Query))
+	}
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri, nil
+}
+
+// GoogleMapsURL returns a Google Maps search link for loc.
+func GoogleMapsURL(loc Location) (string, error) {
+	if err := loc.validate(); err != nil {
+		return "", err
+	}
+	values := url.Values{"api": {"1"}, "query": {loc.target()}}
+	return "https://www.google.com/maps/search/?" + encode(values), nil
+}
+
+// GoogleDirectionsURL returns a Google Maps directions link to destination. A
+// nil origin starts from the user's current location; an empty mode lets Maps
+// choose.
+func GoogleDirectionsURL(origin *Location, destination Location, mode Mode) (string, error) {
+	if err := destination.validate(); err != nil {
+		return "", err
+	}
+	values := url.Values{"api": {"1"}, "destination": {destination.target()}}
+	if origin != nil {
+		if err := origin.validate(); err != nil {
+			return "", err
+		}
+		values.Set("origin", origin.target())
+	}
+	if mode != "" {
+		values.Set("travelmode", string(mode))
+	}
+	return "https://www.google.com/maps/dir/?" + encode(values), nil
+}
+
+// AppleMapsURL returns an Apple Maps link for loc.
+func AppleMapsURL(loc Location) (string, error) {
+	if err := loc.validate(); err != nil {
+		return "", err
+	}
+	values := url.Values{}
+	if loc.hasPoint() {
+		values.Set("ll", loc.coordinates())
+	}
+	if loc.Query != "" {
+		values.Set("q", loc.Query)
+	}
+	return "https://maps.apple.com/?" + encode(values), nil
+}
+
+// AppleDirectionsURL returns an Apple Maps directions link to destination. A
+// nil origin starts from the user's current location.
+func AppleDirectionsURL(origin *Location, destination Location, mode Mode) (string, error) {
+	if err := destination.validate(); err != nil {
+		return "", err
+	}
+	values := url.Values{"daddr": {destination.target()}}
+	if origin != nil {
+		if err := origin.validate(); err != nil {
+			return "", err
+		}
+		values.Set("saddr", origin.target())
+	}
+	if flag, ok := appleModes[mode]; ok {
+		values.Set("dirflg", flag)
+	}
+	return "https://maps.apple.com/?" + encode(values), nil
+}
+
+// escape percent-encodes a geo: URI label or query, keeping spaces as %20.
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func encode(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
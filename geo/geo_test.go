@@ -0,0 +1,38 @@
+package geo
+
+import "testing"
+
+func TestGeoLinks(t *testing.T) {
+	office := Location{Lat: 52.520008, Lng: 13.404954, Query: "Office & Co"}
+	station := Location{Query: "Berlin Hbf"}
+
+	cases := []struct {
+		name  string
+		build func() (string, error)
+		want  string
+	}{
+		{"geo point", func() (string, error) { return GeoURI(Location{Lat: 48.2, Lng: 16.37}, 0) }, "geo:48.2,16.37"},
+		{"geo label", func() (string, error) { return GeoURI(office, 15) }, "geo:52.520008,13.404954?z=15&q=52.520008,13.404954(Office%20%26%20Co)"},
+		{"geo search", func() (string, error) { return GeoURI(station, 0) }, "geo:0,0?q=Berlin%20Hbf"},
+		{"google search", func() (string, error) { return GoogleMapsURL(station) }, "https://www.google.com/maps/search/?api=1&query=Berlin%20Hbf"},
+		{"google point", func() (string, error) { return GoogleMapsURL(office) }, "https://www.google.com/maps/search/?api=1&query=52.520008%2C13.404954"},
+		{"google directions", func() (string, error) { return GoogleDirectionsURL(&station, office, Transit) }, "https://www.google.com/maps/dir/?api=1&destination=52.520008%2C13.404954&origin=Berlin%20Hbf&travelmode=transit"},
+		{"apple place", func() (string, error) { return AppleMapsURL(office) }, "https://maps.apple.com/?ll=52.520008%2C13.404954&q=Office%20%26%20Co"},
+		{"apple directions", func() (string, error) { return AppleDirectionsURL(nil, office, Walking) }, "https://maps.apple.com/?daddr=52.520008%2C13.404954&dirflg=w"},
+	}
+	for _, tc := range cases {
+		got, err := tc.build()
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+
+	for _, invalid := range []Location{{}, {Lat: 91, Lng: 0}, {Lat: 0, Lng: -181}} {
+		if _, err := GoogleMapsURL(invalid); err == nil {
+			t.Fatalf("expected error for %+v", invalid)
+		}
+	}
+}
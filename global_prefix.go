@@ -0,0 +1,84 @@
+package urlkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetGlobalPrefix mounts every generated URL under prefix (e.g. "/app" when the
+// application is served behind an ingress at that path) without editing any
+// group's base URL. The prefix is inserted before group and route paths in both
+// path-concatenation and template modes ({route_path} includes it), and
+// MatchRoute and Coverage strip it from inbound paths. An empty prefix or "/"
+// removes it. Router registration helpers such as RoutePath are unaffected.
+func (m *RouteManager) SetGlobalPrefix(prefix string) error {
+	prefix = strings.TrimRight(strings.TrimSpace(prefix), "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	if strings.ContainsAny(prefix, "?#:") {
+		return fmt.Errorf("set global prefix: invalid prefix %q", prefix)
+	}
+
+	if m.runtime.isFrozen() {
+		return FrozenRouteManagerError{Operation: "set global prefix"}
+	}
+	if previous := m.runtime.setGlobalPrefix(prefix); previous != prefix {
+		m.runtime.publish(Event{Type: EventTemplateChanged})
+	}
+	return nil
+}
+
+// GlobalPrefix returns the prefix set with SetGlobalPrefix.
+func (m *RouteManager) GlobalPrefix() string {
+	return m.runtime.prefix()
+}
+
+func (r *runtimeState) prefix() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.globalPrefix
+}
+
+func (r *runtimeState) setGlobalPrefix(prefix string) string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous := r.globalPrefix
+	r.globalPrefix = prefix
+	return previous
+}
+
+// applyGlobalPrefix prepends the global prefix to a path.
+func (r *runtimeState) applyGlobalPrefix(path string) string {
+	prefix := r.prefix()
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix + "/"
+	}
+	return prefix + ensureLeadingSlash(path)
+}
+
+// stripGlobalPrefix removes the global prefix from an inbound path. It reports
+// false when a prefix is set and path is outside it.
+func (r *runtimeState) stripGlobalPrefix(path string) (string, bool) {
+	prefix := r.prefix()
+	if prefix == "" {
+		return path, true
+	}
+	rest, ok := strings.CutPrefix(path, prefix)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return path, false
+	}
+	if rest == "" {
+		rest = "/"
+	}
+	return rest, true
+}
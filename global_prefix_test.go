@@ -0,0 +1,78 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestGlobalPrefix(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Path:    "/web",
+				Routes:  map[string]string{"home": "/", "user": "/users/:id"},
+			},
+			{
+				Name:        "tenant",
+				BaseURL:     "https://example.com",
+				URLTemplate: "{protocol}://{host}{route_path}",
+				TemplateVars: map[string]string{
+					"protocol": "https",
+					"host":     "acme.example.com",
+				},
+				Routes: map[string]string{"dashboard": "/dashboard"},
+			},
+		},
+	})
+
+	if err := manager.SetGlobalPrefix("app/"); err != nil {
+		t.Fatalf("SetGlobalPrefix failed: %v", err)
+	}
+	if got := manager.GlobalPrefix(); got != "/app" {
+		t.Fatalf("expected normalized prefix /app, got %q", got)
+	}
+
+	frontend := manager.Group("frontend")
+	cases := map[string]struct {
+		build func() (string, error)
+		want  string
+	}{
+		"path mode":     {func() (string, error) { return frontend.Render("user", urlkit.Params{"id": "7"}) }, "https://example.com/app/web/users/7"},
+		"path root":     {func() (string, error) { return frontend.Render("home", nil) }, "https://example.com/app/web/"},
+		"template mode": {func() (string, error) { return manager.Group("tenant").Render("dashboard", nil) }, "https://acme.example.com/app/dashboard/"},
+	}
+	for name, tc := range cases {
+		got, err := tc.build()
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", name, tc.want, got)
+		}
+	}
+
+	params, _, ok := frontend.MatchRoute("user", "/app/web/users/7")
+	if !ok || params["id"] != "7" {
+		t.Fatalf("expected prefixed path to match, got %v %v", params, ok)
+	}
+	if _, _, ok := frontend.MatchRoute("user", "/web/users/7"); ok {
+		t.Fatal("expected path outside the prefix not to match")
+	}
+	if _, _, ok := frontend.MatchRoute("user", "/application/web/users/7"); ok {
+		t.Fatal("expected partial segment prefix not to match")
+	}
+
+	if err := manager.SetGlobalPrefix("/app?x"); err == nil {
+		t.Fatal("expected error for prefix with query")
+	}
+
+	if err := manager.SetGlobalPrefix("/"); err != nil {
+		t.Fatalf("clearing prefix failed: %v", err)
+	}
+	if got, _ := frontend.Render("user", urlkit.Params{"id": "7"}); got != "https://example.com/web/users/7" {
+		t.Fatalf("expected prefix to be cleared, got %s", got)
+	}
+}
@@ -6,8 +6,10 @@ toolchain go1.24.7
 
 require (
 	github.com/flosch/pongo2 v0.0.0-20200913210552-0d938eb266f3
+	github.com/flosch/pongo2/v6 v6.0.0
 	github.com/golang-jwt/jwt/v5 v5.3.0
 	github.com/google/uuid v1.6.0
+	github.com/gorilla/mux v1.8.1
 	github.com/soongo/path-to-regexp v1.6.4
 	golang.org/x/oauth2 v0.31.0
 )
@@ -15,5 +17,4 @@ require (
 require (
 	cloud.google.com/go/compute/metadata v0.3.0 // indirect
 	github.com/dlclark/regexp2 v1.11.5 // indirect
-	github.com/flosch/pongo2/v6 v6.0.0 // indirect
 )
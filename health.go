@@ -0,0 +1,244 @@
+package urlkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Health check identifiers reported in HealthIssue.Check.
+const (
+	HealthCheckRouteCompile     = "route_compile"
+	HealthCheckTemplateResolve  = "template_resolve"
+	HealthCheckBaseURL          = "base_url"
+	HealthCheckOrphanedChildren = "orphaned_child"
+)
+
+// HealthIssue describes a single failed invariant found by HealthCheck.
+type HealthIssue struct {
+	Check   string `json:"check"`
+	Group   string `json:"group"`
+	Route   string `json:"route,omitempty"`
+	Message string `json:"message"`
+}
+
+// HealthReport is the result of RouteManager.HealthCheck.
+type HealthReport struct {
+	Healthy bool          `json:"healthy"`
+	Groups  int           `json:"groups"`
+	Routes  int           `json:"routes"`
+	Issues  []HealthIssue `json:"issues,omitempty"`
+}
+
+// Err returns nil for a healthy report, or an error summarizing every issue.
+func (r HealthReport) Err() error {
+	if r.Healthy {
+		return nil
+	}
+
+	parts := make([]string, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		target := issue.Group
+		if issue.Route != "" {
+			target += "." + issue.Route
+		}
+		parts = append(parts, fmt.Sprintf("%s %s: %s", issue.Check, target, issue.Message))
+	}
+	return fmt.Errorf("route manager unhealthy: %s", strings.Join(parts, "; "))
+}
+
+// HealthCheck runs quick consistency invariants over the registry:
+//   - every route template compiles
+//   - URL templates only reference variables available to each group
+//   - root base URLs are parseable
+//   - child groups point back to their parent (no orphaned children)
+//
+// It is cheap enough to run from readiness probes; see HealthHandler.
+func (m *RouteManager) HealthCheck() HealthReport {
+	report := HealthReport{}
+	if m == nil {
+		report.Issues = append(report.Issues, HealthIssue{Check: HealthCheckOrphanedChildren, Message: "route manager is nil"})
+		return report
+	}
+
+	m.mu.RLock()
+	rootNames := slices.Sorted(maps.Keys(m.groups))
+	roots := make([]*Group, 0, len(rootNames))
+	for _, name := range rootNames {
+		roots = append(roots, m.groups[name])
+	}
+	m.mu.RUnlock()
+
+	for _, root := range roots {
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+
+		if issue, ok := checkBaseURL(baseURL); !ok {
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckBaseURL,
+				Group:   root.FQN(),
+				Message: issue,
+			})
+		}
+
+		m.healthCheckGroup(root, &report)
+	}
+
+	report.Healthy = len(report.Issues) == 0
+	return report
+}
+
+func checkBaseURL(baseURL string) (string, bool) {
+	if baseURL == "" {
+		return "", true
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Sprintf("base URL %q is not parseable: %v", baseURL, err), false
+	}
+	if parsed.Scheme != "" && parsed.Host == "" {
+		return fmt.Sprintf("base URL %q has a scheme but no host", baseURL), false
+	}
+	return "", true
+}
+
+func (m *RouteManager) healthCheckGroup(group *Group, report *HealthReport) {
+	group.mu.RLock()
+	groupName := group.fqnLocked()
+	routes := maps.Clone(group.routes)
+	compiled := make(map[string]bool, len(group.compiledRoutes))
+	for name := range group.compiledRoutes {
+		compiled[name] = true
+	}
+	children := maps.Clone(group.children)
+	runtime := group.runtime
+	group.mu.RUnlock()
+
+	report.Groups++
+	report.Routes += len(routes)
+
+	for _, routeName := range slices.Sorted(maps.Keys(routes)) {
+		if _, err := compileRouteTemplate(routes[routeName]); err != nil {
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckRouteCompile,
+				Group:   groupName,
+				Route:   routeName,
+				Message: err.Error(),
+			})
+			continue
+		}
+		if !compiled[routeName] {
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckRouteCompile,
+				Group:   groupName,
+				Route:   routeName,
+				Message: "route has no compiled template",
+			})
+		}
+	}
+
+	for _, message := range templateHealthIssues(group) {
+		report.Issues = append(report.Issues, HealthIssue{
+			Check:   HealthCheckTemplateResolve,
+			Group:   groupName,
+			Message: message,
+		})
+	}
+
+	for _, childName := range slices.Sorted(maps.Keys(children)) {
+		child := children[childName]
+		child.mu.RLock()
+		parent := child.parent
+		name := child.name
+		childRuntime := child.runtime
+		child.mu.RUnlock()
+
+		switch {
+		case parent != group:
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckOrphanedChildren,
+				Group:   groupName,
+				Message: fmt.Sprintf("child %q does not reference its parent", childName),
+			})
+		case name != childName:
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckOrphanedChildren,
+				Group:   groupName,
+				Message: fmt.Sprintf("child registered as %q is named %q", childName, name),
+			})
+		case childRuntime != runtime:
+			report.Issues = append(report.Issues, HealthIssue{
+				Check:   HealthCheckOrphanedChildren,
+				Group:   groupName,
+				Message: fmt.Sprintf("child %q belongs to a different route manager", childName),
+			})
+		}
+
+		m.healthCheckGroup(child, report)
+	}
+}
+
+// templateHealthIssues reports template variables and references that cannot be
+// resolved for the group's effective template.
+func templateHealthIssues(group *Group) []string {
+	owner := group.FindTemplateOwner()
+	if owner == nil {
+		return nil
+	}
+
+	owner.mu.RLock()
+	template := owner.urlTemplate
+	owner.mu.RUnlock()
+
+	vars := group.CollectTemplateVars()
+	vars["route_path"] = ""
+	vars["base_url"] = ""
+
+	var issues []string
+	if missing := detectMissingTemplateVars(template, vars); len(missing) > 0 {
+		issues = append(issues, fmt.Sprintf("template %q missing variables %v", template, missing))
+	}
+	if err := expandTemplateVars(groupDisplayName(group), vars); err != nil {
+		issues = append(issues, err.Error())
+	}
+
+	for _, match := range refPlaceholderPattern.FindAllStringSubmatch(maskEscapedBraces(template), -1) {
+		groupPath, route, err := splitRouteFQN(match[1])
+		if err == nil && group.runtime != nil && group.runtime.lookup != nil {
+			var target *Group
+			if target, err = group.runtime.lookup(groupPath); err == nil {
+				_, err = target.Route(route)
+			}
+		}
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("template reference %q unresolvable: %v", match[1], err))
+		}
+	}
+
+	return issues
+}
+
+// HealthHandler returns an http.Handler that serves the HealthCheck report as
+// JSON, responding 200 when healthy and 503 otherwise. It is intended for
+// readiness probes.
+func HealthHandler(m *RouteManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := m.HealthCheck()
+
+		status := http.StatusOK
+		if !report.Healthy {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,87 @@
+package urlkit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestHealthCheckHealthyManager(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"home": "/"},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:         "en",
+						Path:         "/en",
+						URLTemplate:  "{base_url}/{locale}{route_path}",
+						TemplateVars: map[string]string{"locale": "en"},
+						Routes:       map[string]string{"about": "/about"},
+					},
+				},
+			},
+		},
+	})
+
+	report := manager.HealthCheck()
+	if !report.Healthy || report.Err() != nil {
+		t.Fatalf("expected healthy report, got %+v", report)
+	}
+	if report.Groups != 2 || report.Routes != 2 {
+		t.Fatalf("unexpected counts: groups=%d routes=%d", report.Groups, report.Routes)
+	}
+}
+
+func TestHealthCheckReportsBrokenInvariants(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "broken",
+				BaseURL: "https://",
+				Routes:  map[string]string{"home": "/"},
+			},
+			{
+				Name:        "templated",
+				BaseURL:     "https://example.com",
+				URLTemplate: "{base_url}/{locale}{route_path}?cb={ref:missing.route}",
+				Routes:      map[string]string{"home": "/"},
+			},
+		},
+	})
+
+	report := manager.HealthCheck()
+	if report.Healthy {
+		t.Fatal("expected unhealthy report")
+	}
+
+	checks := map[string]int{}
+	for _, issue := range report.Issues {
+		checks[issue.Check]++
+	}
+	if checks[urlkit.HealthCheckBaseURL] != 1 {
+		t.Fatalf("expected one base URL issue, got %+v", report.Issues)
+	}
+	if checks[urlkit.HealthCheckTemplateResolve] != 2 {
+		t.Fatalf("expected missing var and reference issues, got %+v", report.Issues)
+	}
+
+	recorder := httptest.NewRecorder()
+	urlkit.HealthHandler(manager).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", recorder.Code)
+	}
+
+	var decoded urlkit.HealthReport
+	if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if len(decoded.Issues) != len(report.Issues) {
+		t.Fatalf("expected %d issues in response, got %d", len(report.Issues), len(decoded.Issues))
+	}
+}
@@ -0,0 +1,158 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// hostPattern is a host (optionally "*."-prefixed) with an optional port.
+type hostPattern struct {
+	host  string
+	port  string
+	group string
+}
+
+func parseHostPattern(pattern string) (hostPattern, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return hostPattern{}, fmt.Errorf("empty host pattern")
+	}
+
+	host, port := splitHostPort(pattern)
+	if host == "" {
+		return hostPattern{}, fmt.Errorf("invalid host pattern %q", pattern)
+	}
+	if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+		return hostPattern{}, fmt.Errorf("host pattern %q only supports a leading \"*.\" wildcard", pattern)
+	}
+	return hostPattern{host: host, port: port}, nil
+}
+
+func splitHostPort(hostport string) (string, string) {
+	if host, port, err := net.SplitHostPort(hostport); err == nil {
+		return strings.Trim(host, "[]"), port
+	}
+	return strings.Trim(hostport, "[]"), ""
+}
+
+// matches reports whether host/port match the pattern. Wildcards match exactly
+// one or more subdomain labels, never the apex itself. The port is only compared
+// when both sides specify one.
+func (p hostPattern) matches(host, port string) bool {
+	if p.port != "" && port != "" && p.port != port {
+		return false
+	}
+	if suffix, ok := strings.CutPrefix(p.host, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return p.host == host
+}
+
+// MapHost maps an additional host pattern to a root group for GroupForHost.
+// Patterns may carry a port and a leading wildcard label ("*.example.com") to
+// match any subdomain.
+func (m *RouteManager) MapHost(pattern, groupName string) error {
+	if _, err := parseHostPattern(pattern); err != nil {
+		return fmt.Errorf("map host: %w", err)
+	}
+
+	releaseMutation, err := m.runtime.beginMutation("map host", groupName)
+	if err != nil {
+		return err
+	}
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+	defer releaseMutation()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.groups[groupName]; !ok {
+		return fmt.Errorf("%w: %s", ErrGroupNotFound, groupName)
+	}
+	if m.hosts == nil {
+		m.hosts = make(map[string]string)
+	}
+	m.hosts[strings.ToLower(strings.TrimSpace(pattern))] = groupName
+	events = []Event{{Type: EventRoutesChanged, Group: groupName}}
+	return nil
+}
+
+// GroupForHost returns the root group serving the given Host header value
+// (which may include a port). Root groups are matched by the host of their base
+// URL, including "*." wildcard base URLs, and by patterns registered with
+// MapHost. Exact hosts win over wildcards, and longer wildcard suffixes win over
+// shorter ones. Returns ErrGroupNotFound when no group matches.
+func (m *RouteManager) GroupForHost(host string) (*Group, error) {
+	requestHost, requestPort := splitHostPort(strings.ToLower(strings.TrimSpace(host)))
+	if requestHost == "" {
+		return nil, fmt.Errorf("%w: empty host", ErrGroupNotFound)
+	}
+
+	var best *Group
+	bestScore := -1
+	for _, candidate := range m.hostPatterns() {
+		if !candidate.matches(requestHost, requestPort) {
+			continue
+		}
+
+		score := len(candidate.host)
+		if !strings.HasPrefix(candidate.host, "*.") {
+			score += 1 << 16 // exact hosts always win
+		}
+		if score > bestScore {
+			group, err := m.GetGroup(candidate.group)
+			if err != nil {
+				continue
+			}
+			best, bestScore = group, score
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("%w: no group for host %s", ErrGroupNotFound, host)
+	}
+	return best, nil
+}
+
+// hostPatterns collects base URL hosts and mapped hosts in a stable order.
+func (m *RouteManager) hostPatterns() []hostPattern {
+	m.mu.RLock()
+	roots := maps.Clone(m.groups)
+	mapped := maps.Clone(m.hosts)
+	m.mu.RUnlock()
+
+	var patterns []hostPattern
+	for _, name := range slices.Sorted(maps.Keys(roots)) {
+		root := roots[name]
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+
+		parsed, err := url.Parse(baseURL)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		pattern, err := parseHostPattern(parsed.Host)
+		if err != nil {
+			continue
+		}
+		pattern.group = name
+		patterns = append(patterns, pattern)
+	}
+
+	for _, raw := range slices.Sorted(maps.Keys(mapped)) {
+		pattern, err := parseHostPattern(raw)
+		if err != nil {
+			continue
+		}
+		pattern.group = mapped[raw]
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
@@ -0,0 +1,56 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestGroupForHost(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	for name, baseURL := range map[string]string{
+		"frontend": "https://example.com",
+		"api":      "https://api.example.com:8443",
+		"tenants":  "https://*.example.com",
+		"eu":       "https://*.eu.example.com",
+	} {
+		if _, _, err := manager.RegisterGroup(name, baseURL, map[string]string{"home": "/"}); err != nil {
+			t.Fatalf("RegisterGroup(%s) failed: %v", name, err)
+		}
+	}
+	if err := manager.MapHost("www.example.com", "frontend"); err != nil {
+		t.Fatalf("MapHost failed: %v", err)
+	}
+
+	cases := map[string]string{
+		"example.com":          "frontend",
+		"EXAMPLE.com:443":      "frontend",
+		"www.example.com":      "frontend",
+		"api.example.com":      "api",
+		"api.example.com:8443": "api",
+		"acme.example.com":     "tenants",
+		"acme.eu.example.com":  "eu",
+		"a.b.example.com":      "tenants",
+		"api.example.com:9000": "tenants",
+	}
+	for host, want := range cases {
+		group, err := manager.GroupForHost(host)
+		if err != nil {
+			t.Fatalf("GroupForHost(%q) failed: %v", host, err)
+		}
+		if group.FQN() != want {
+			t.Fatalf("GroupForHost(%q) = %s, want %s", host, group.FQN(), want)
+		}
+	}
+
+	if _, err := manager.GroupForHost("example.org"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+	if err := manager.MapHost("cdn.example.org", "missing"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for unknown group, got %v", err)
+	}
+	if err := manager.MapHost("a.*.example.org", "frontend"); err == nil {
+		t.Fatal("expected error for inner wildcard")
+	}
+}
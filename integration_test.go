@@ -1,4 +1,4 @@
-package urlkit
+package urlkit_test
 
 import (
 	"testing"
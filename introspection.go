@@ -0,0 +1,100 @@
+package urlkit
+
+import (
+	"maps"
+	"slices"
+)
+
+// Routes returns a copy of the group's routes, mapping route names to their
+// path templates. Child group routes are not included.
+func (u *Group) Routes() map[string]string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return maps.Clone(u.routes)
+}
+
+// RouteNames returns the sorted names of the group's routes.
+func (u *Group) RouteNames() []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return slices.Sorted(maps.Keys(u.routes))
+}
+
+// Children returns the sorted names of the group's direct child groups.
+func (u *Group) Children() []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return slices.Sorted(maps.Keys(u.children))
+}
+
+// Walk calls fn for every group of the registry, depth first in name order,
+// with the fully qualified name that resolves the group on this manager.
+// Groups of mounted managers follow the root groups, named under their mount
+// prefix ("billing.invoices"). Lazy groups that were not materialized yet are
+// skipped. Returning false from fn stops the walk. fn may read and mutate
+// the registry, but groups added during the walk may not be visited.
+//
+// Example:
+//
+//	manager.Walk(func(fqn string, group *urlkit.Group) bool {
+//		for _, route := range group.RouteNames() {
+//			fmt.Println(fqn + "." + route)
+//		}
+//		return true
+//	})
+func (m *RouteManager) Walk(fn func(fqn string, g *Group) bool) {
+	if m == nil || fn == nil {
+		return
+	}
+	m.walk("", fn)
+}
+
+// walk visits the manager's groups with prefix prepended to their names and
+// reports whether the walk should continue.
+func (m *RouteManager) walk(prefix string, fn func(string, *Group) bool) bool {
+	m.mu.RLock()
+	rootNames := slices.Sorted(maps.Keys(m.groups))
+	roots := make([]*Group, len(rootNames))
+	for i, name := range rootNames {
+		roots[i] = m.groups[name]
+	}
+	mountPrefixes := slices.Sorted(maps.Keys(m.mounts))
+	mounts := make([]*RouteManager, len(mountPrefixes))
+	for i, mountPrefix := range mountPrefixes {
+		mounts[i] = m.mounts[mountPrefix]
+	}
+	m.mu.RUnlock()
+
+	for i, root := range roots {
+		if !walkGroup(prefix+rootNames[i], root, fn) {
+			return false
+		}
+	}
+	for i, mounted := range mounts {
+		if !mounted.walk(prefix+mountPrefixes[i]+".", fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func walkGroup(fqn string, group *Group, fn func(string, *Group) bool) bool {
+	if !fn(fqn, group) {
+		return false
+	}
+
+	group.mu.RLock()
+	names := slices.Sorted(maps.Keys(group.children))
+	children := make([]*Group, len(names))
+	for i, name := range names {
+		children[i] = group.children[name]
+	}
+	group.mu.RUnlock()
+
+	for i, child := range children {
+		if !walkGroup(fqn+"."+names[i], child, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package urlkit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goliatone/go-urlkit"
+)
+
+func TestGroupIntrospection(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"home": "/", "about": "/about"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "es", Path: "/es", Routes: map[string]string{"home": "/"}},
+					{Name: "en", Path: "/en"},
+				},
+			},
+		},
+	})
+	frontend := manager.Group("frontend")
+
+	routes := frontend.Routes()
+	if !reflect.DeepEqual(routes, map[string]string{"home": "/", "about": "/about"}) {
+		t.Fatalf("Routes = %v", routes)
+	}
+	routes["home"] = "/changed"
+	if template, _ := frontend.Route("home"); template != "/" {
+		t.Fatalf("Routes must return a copy, route is now %q", template)
+	}
+
+	if names := frontend.RouteNames(); !reflect.DeepEqual(names, []string{"about", "home"}) {
+		t.Errorf("RouteNames = %v", names)
+	}
+	if children := frontend.Children(); !reflect.DeepEqual(children, []string{"en", "es"}) {
+		t.Errorf("Children = %v", children)
+	}
+	if children := frontend.Group("en").Children(); len(children) != 0 {
+		t.Errorf("expected no children, got %v", children)
+	}
+}
+
+func TestRouteManagerWalk(t *testing.T) {
+	billing := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "invoices", BaseURL: "https://billing.example.com", Routes: map[string]string{"show": "/invoices/:id"}},
+		},
+	})
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"home": "/"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "es", Path: "/es", Groups: []urlkit.GroupConfig{{Name: "account", Path: "/cuenta"}}},
+					{Name: "en", Path: "/en"},
+				},
+			},
+			{Name: "api", BaseURL: "https://api.example.com"},
+		},
+	})
+	if err := manager.Mount("billing", billing); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	if err := manager.RegisterLazyGroup("catalog", func() (urlkit.GroupConfig, error) {
+		return urlkit.GroupConfig{BaseURL: "https://shop.example.com"}, nil
+	}); err != nil {
+		t.Fatalf("RegisterLazyGroup failed: %v", err)
+	}
+
+	var visited []string
+	manager.Walk(func(fqn string, group *urlkit.Group) bool {
+		visited = append(visited, fqn)
+		if resolved, err := manager.GetGroup(fqn); err != nil || resolved != group {
+			t.Errorf("GetGroup(%q) does not resolve the walked group: %v", fqn, err)
+		}
+		return true
+	})
+	want := []string{"api", "frontend", "frontend.en", "frontend.es", "frontend.es.account", "billing.invoices"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Fatalf("Walk visited %v, want %v", visited, want)
+	}
+
+	visited = nil
+	manager.Walk(func(fqn string, _ *urlkit.Group) bool {
+		visited = append(visited, fqn)
+		return fqn != "frontend.en"
+	})
+	if !reflect.DeepEqual(visited, []string{"api", "frontend", "frontend.en"}) {
+		t.Fatalf("Walk did not stop: %v", visited)
+	}
+}
@@ -0,0 +1,91 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// NormalizeURLHost rewrites the host of an absolute URL so IPv6 literals survive
+// url.Parse: bare literals ("http://::1/x") are bracketed and zone identifiers
+// are percent-encoded as RFC 6874 requires ("[fe80::1%eth0]" becomes
+// "[fe80::1%25eth0]"). Other hosts, and URLs without a scheme, are returned
+// unchanged. Malformed IPv6 literals are reported as errors.
+//
+// A bare literal is always read as a whole address, so ports must be written
+// with brackets: "http://[::1]:8080".
+func NormalizeURLHost(raw string) (string, error) {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok || scheme == "" {
+		return raw, nil
+	}
+
+	authority, tail := rest, ""
+	if idx := strings.IndexAny(rest, "/?#"); idx >= 0 {
+		authority, tail = rest[:idx], rest[idx:]
+	}
+	userinfo := ""
+	if idx := strings.LastIndex(authority, "@"); idx >= 0 {
+		userinfo, authority = authority[:idx+1], authority[idx+1:]
+	}
+
+	host, err := normalizeIPv6Authority(authority)
+	if err != nil {
+		return "", fmt.Errorf("url %q: %w", raw, err)
+	}
+	return scheme + "://" + userinfo + host + tail, nil
+}
+
+// normalizeIPv6Authority brackets and validates an IPv6 host[:port] authority.
+func normalizeIPv6Authority(authority string) (string, error) {
+	if !strings.HasPrefix(authority, "[") {
+		if strings.Count(authority, ":") < 2 {
+			return authority, nil
+		}
+		literal, err := formatIPv6Literal(authority)
+		if err != nil {
+			return "", fmt.Errorf("%w; write IPv6 hosts as [address]:port", err)
+		}
+		return literal, nil
+	}
+
+	end := strings.Index(authority, "]")
+	if end < 0 {
+		return "", fmt.Errorf("unterminated IPv6 literal %q", authority)
+	}
+	literal, err := formatIPv6Literal(authority[1:end])
+	if err != nil {
+		return "", err
+	}
+
+	port := authority[end+1:]
+	if port != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(port, ":"))
+		if !strings.HasPrefix(port, ":") || err != nil || number < 0 || number > 65535 {
+			return "", fmt.Errorf("invalid port %q after IPv6 literal", port)
+		}
+	}
+	return literal + port, nil
+}
+
+// formatIPv6Literal validates address (with an optional raw or %25-encoded zone)
+// and returns it bracketed with the zone percent-encoded.
+func formatIPv6Literal(address string) (string, error) {
+	host, zone, hasZone := strings.Cut(address, "%")
+	if hasZone {
+		zone = strings.TrimPrefix(zone, "25")
+		if zone == "" || strings.ContainsAny(zone, ":%[]") {
+			return "", fmt.Errorf("invalid IPv6 zone in %q", address)
+		}
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !addr.Is6() {
+		return "", fmt.Errorf("invalid IPv6 literal %q", address)
+	}
+	if hasZone {
+		return "[" + host + "%25" + zone + "]", nil
+	}
+	return "[" + host + "]", nil
+}
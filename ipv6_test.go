@@ -0,0 +1,54 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestIPv6Hosts(t *testing.T) {
+	cases := map[string]string{
+		"http://[::1]:8080":            "http://[::1]:8080/foo?a=1",
+		"http://::1":                   "http://[::1]/foo?a=1",
+		"http://[fe80::1%eth0]:8080":   "http://[fe80::1%25eth0]:8080/foo?a=1",
+		"http://[fe80::1%25eth0]:8080": "http://[fe80::1%25eth0]:8080/foo?a=1",
+		"http://user@[::1]/api":        "http://user@[::1]/api/foo?a=1",
+		"https://example.com:8443":     "https://example.com:8443/foo?a=1",
+	}
+	for base, want := range cases {
+		if got := urlkit.JoinURL(base, "/foo", urlkit.Query{"a": "1"}); got != want {
+			t.Fatalf("JoinURL(%q): expected %q, got %q", base, want, got)
+		}
+	}
+
+	for _, invalid := range []string{"http://[::1", "http://[zz::1]", "http://[::1]:http", "http://fe80::1%eth0:8080", "http://[127.0.0.1]"} {
+		if _, err := urlkit.NormalizeURLHost(invalid); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "plain", BaseURL: "http://[fe80::1%eth0]:8080", Routes: map[string]string{"health": "/health"}},
+			{Name: "templated", BaseURL: "http://[::1]:9000", URLTemplate: "{base_url}{route_path}", Routes: map[string]string{"health": "/health"}},
+		},
+	})
+	for group, want := range map[string]string{
+		"plain":     "http://[fe80::1%25eth0]:8080/health",
+		"templated": "http://[::1]:9000/health/",
+	} {
+		got, err := manager.Group(group).Render("health", nil)
+		if err != nil {
+			t.Fatalf("Render(%s) failed: %v", group, err)
+		}
+		if got != want {
+			t.Fatalf("Render(%s): expected %q, got %q", group, want, got)
+		}
+	}
+
+	if _, err := urlkit.NewRouteManagerFromConfig(urlkit.Config{
+		Groups: []urlkit.GroupConfig{{Name: "bad", BaseURL: "http://[::1", Routes: map[string]string{"x": "/x"}}},
+	}); err == nil {
+		t.Fatal("expected config error for malformed IPv6 base URL")
+	}
+}
@@ -0,0 +1,121 @@
+package urlkit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// lazyGroup is a root group whose configuration is produced on first access.
+type lazyGroup struct {
+	mu      sync.Mutex
+	factory func() (GroupConfig, error)
+	done    bool
+}
+
+// RegisterLazyGroup registers a root group whose configuration is produced by
+// factory the first time the group, or one of its descendants, is looked up
+// (GetGroup, Group, Resolve, Builder references and so on). Use it when route
+// sets come from a database or a remote service and should not block startup.
+//
+// Concurrent first accesses share a single factory call; the others wait for
+// it. A factory error is returned to the callers waiting on it and is not
+// cached, so the next access tries again. The config name may be empty and
+// otherwise must match name. Until it is materialized the group does not
+// appear in enumerations such as Manifest, and a frozen manager cannot
+// materialize it.
+//
+// Example:
+//
+//	manager.RegisterLazyGroup("catalog", func() (urlkit.GroupConfig, error) {
+//		return loadCatalogRoutes(db)
+//	})
+//	url, err := manager.Resolve("catalog", "product", urlkit.Params{"id": 7}, nil)
+func (m *RouteManager) RegisterLazyGroup(name string, factory func() (GroupConfig, error)) error {
+	if name == "" {
+		return fmt.Errorf("register lazy group: group name is required")
+	}
+	if strings.Contains(name, ".") {
+		return fmt.Errorf("register lazy group: root group name %q cannot contain '.'", name)
+	}
+	if factory == nil {
+		return fmt.Errorf("register lazy group %s: factory is required", name)
+	}
+
+	releaseMutation, err := m.runtime.beginMutation("register lazy group", name)
+	if err != nil {
+		return err
+	}
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+	defer releaseMutation()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.groups[name]; exists {
+		return fmt.Errorf("register lazy group: group %q is already registered", name)
+	}
+	if _, exists := m.lazy[name]; exists {
+		return fmt.Errorf("register lazy group: group %q is already registered", name)
+	}
+	if _, mounted := m.mounts[name]; mounted {
+		return fmt.Errorf("register lazy group: %q is used as a mount prefix", name)
+	}
+	if m.lazy == nil {
+		m.lazy = make(map[string]*lazyGroup)
+	}
+	m.lazy[name] = &lazyGroup{factory: factory}
+	events = []Event{{Type: EventRoutesChanged, Group: name}}
+	return nil
+}
+
+// materializeLazyGroup loads the lazy root group of path, if there is one. It
+// reports whether a group was loaded (by this call or a concurrent one).
+func (m *RouteManager) materializeLazyGroup(path string) (bool, error) {
+	name, _, _ := strings.Cut(path, ".")
+
+	m.mu.RLock()
+	lazy, ok := m.lazy[name]
+	m.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	// Published once the group is fully loaded and lazy.mu released, since
+	// the groups and routes registered while loading can change after their
+	// own events (e.g. the group path).
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+
+	lazy.mu.Lock()
+	defer lazy.mu.Unlock()
+	if lazy.done {
+		return true, nil
+	}
+
+	cfg, err := lazy.factory()
+	if err != nil {
+		return false, fmt.Errorf("lazy group %s: %w", name, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = name
+	}
+	if cfg.Name != name {
+		return false, fmt.Errorf("lazy group %s: factory returned group %q", name, cfg.Name)
+	}
+	if _, err := m.loadGroupFromConfig(cfg, nil); err != nil {
+		// Drop the partially loaded group so the next access retries cleanly.
+		m.mu.Lock()
+		delete(m.groups, name)
+		m.mu.Unlock()
+		return false, fmt.Errorf("lazy group %s: %w", name, err)
+	}
+
+	lazy.done = true
+	m.mu.Lock()
+	delete(m.lazy, name)
+	m.mu.Unlock()
+	events = []Event{{Type: EventRoutesChanged, Group: name}}
+	return true, nil
+}
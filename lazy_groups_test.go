@@ -0,0 +1,78 @@
+package urlkit_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRegisterLazyGroup(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	err := manager.RegisterLazyGroup("catalog", func() (urlkit.GroupConfig, error) {
+		calls.Add(1)
+		<-release
+		return urlkit.GroupConfig{
+			BaseURL: "https://shop.example.com",
+			Routes:  map[string]string{"product": "/products/:id"},
+			Groups:  []urlkit.GroupConfig{{Name: "admin", Path: "/admin", Routes: map[string]string{"edit": "/products/:id/edit"}}},
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterLazyGroup failed: %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("expected the factory not to run before first access")
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = manager.Resolve("catalog.admin", "edit", urlkit.Params{"id": i}, nil)
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single factory call, got %d", calls.Load())
+	}
+	if results[3] != "https://shop.example.com/admin/products/3/edit" {
+		t.Fatalf("unexpected URL %q", results[3])
+	}
+	if err := manager.RegisterLazyGroup("catalog", func() (urlkit.GroupConfig, error) { return urlkit.GroupConfig{}, nil }); err == nil {
+		t.Fatal("expected error when registering an existing group")
+	}
+}
+
+func TestRegisterLazyGroupRetriesAfterError(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+
+	failing := errors.New("database unavailable")
+	attempts := 0
+	_ = manager.RegisterLazyGroup("catalog", func() (urlkit.GroupConfig, error) {
+		attempts++
+		if attempts == 1 {
+			return urlkit.GroupConfig{}, failing
+		}
+		return urlkit.GroupConfig{BaseURL: "https://shop.example.com", Routes: map[string]string{"home": "/"}}, nil
+	})
+
+	if _, err := manager.GetGroup("catalog"); !errors.Is(err, failing) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+	if _, err := manager.GetGroup("catalog"); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if _, err := manager.GetGroup("catalog.missing"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for a missing child, got %v", err)
+	}
+}
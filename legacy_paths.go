@@ -0,0 +1,151 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	ptre "github.com/soongo/path-to-regexp"
+)
+
+// RewriteRule maps a legacy inbound path pattern to the route it is now an
+// alias of. Params captured by From are used to render the route's current
+// path, so the rewrite follows the route when its pattern changes.
+type RewriteRule struct {
+	// From is the legacy path pattern, e.g. "/old/users/:id".
+	From string `json:"from" yaml:"from"`
+	// Route is the fully qualified target route, e.g. "api.users.show".
+	Route string `json:"route" yaml:"route"`
+}
+
+type compiledRewriteRule struct {
+	match func(string) (Params, bool)
+	group *Group
+	route string
+}
+
+// RewriteMiddleware returns net/http middleware that rewrites requests whose
+// path matches a legacy pattern to the current path of the aliased route,
+// before the next handler (usually the router) sees them. Rules are tried in
+// order and the query string is preserved. With a global prefix, legacy
+// patterns match the path after the prefix, as in Match, and requests outside
+// the prefix are passed through. Unlike a redirect, the client never
+// observes the rewrite, which suits internal-only path changes.
+//
+// Every rule is validated up front: the target route must exist and From must
+// capture each required param of the route.
+//
+// Example:
+//
+//	rewrite, err := manager.RewriteMiddleware([]urlkit.RewriteRule{
+//		{From: "/old/users/:id", Route: "api.users.show"},
+//	})
+//	http.ListenAndServe(":8080", rewrite(router))
+func (m *RouteManager) RewriteMiddleware(rules []RewriteRule) (func(http.Handler) http.Handler, error) {
+	compiled := make([]compiledRewriteRule, 0, len(rules))
+	for _, rule := range rules {
+		entry, err := m.compileRewriteRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, entry)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if target, ok := m.rewritePath(compiled, r.URL.Path); ok {
+				r2 := new(http.Request)
+				*r2 = *r
+				r2.URL = new(url.URL)
+				*r2.URL = *r.URL
+				r2.URL.Path = target.Path
+				r2.URL.RawPath = target.RawPath
+				r = r2
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func (m *RouteManager) compileRewriteRule(rule RewriteRule) (compiledRewriteRule, error) {
+	groupPath, route, err := splitRouteFQN(rule.Route)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+	group, err := m.GetGroup(groupPath)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+	template, err := group.Route(route)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+
+	match, err := compileRouteMatcher(rule.From)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+
+	captured, err := routeParamNames(rule.From)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+	required, err := requiredRouteParams(template)
+	if err != nil {
+		return compiledRewriteRule{}, fmt.Errorf("rewrite %q: %w", rule.From, err)
+	}
+	for _, name := range required {
+		if !slices.Contains(captured, name) {
+			return compiledRewriteRule{}, fmt.Errorf("rewrite %q: route %s requires param %q not captured by the legacy pattern", rule.From, rule.Route, name)
+		}
+	}
+
+	return compiledRewriteRule{match: match, group: group, route: route}, nil
+}
+
+// rewritePath returns the current path for the first rule matching path once
+// the global prefix is stripped.
+func (m *RouteManager) rewritePath(rules []compiledRewriteRule, path string) (*url.URL, bool) {
+	path, ok := m.runtime.stripGlobalPrefix(path)
+	if !ok {
+		return nil, false
+	}
+	for _, rule := range rules {
+		params, ok := rule.match(path)
+		if !ok {
+			continue
+		}
+		rendered, err := rule.group.Render(rule.route, coerceParams(params))
+		if err != nil {
+			return nil, false
+		}
+		target, err := url.Parse(rendered)
+		if err != nil {
+			return nil, false
+		}
+		return target, true
+	}
+	return nil, false
+}
+
+// requiredRouteParams returns the names of params in template that are not
+// optional.
+func requiredRouteParams(template string) ([]string, error) {
+	tokens, err := ptre.Parse(template, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, token := range tokens {
+		t, ok := token.(ptre.Token)
+		if !ok || t.Modifier == "?" || t.Modifier == "*" {
+			continue
+		}
+		if name, ok := t.Name.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
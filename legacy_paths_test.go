@@ -0,0 +1,80 @@
+package urlkit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRewriteMiddleware(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	api, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"home": "/"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	mustRegisterGroup(t, api, "users", "/v2/users", map[string]string{"show": "/:id"})
+
+	rewrite, err := manager.RewriteMiddleware([]urlkit.RewriteRule{
+		{From: "/old/users/:id", Route: "api.users.show"},
+		{From: "/members/:id/profile", Route: "api.users.show"},
+	})
+	if err != nil {
+		t.Fatalf("RewriteMiddleware failed: %v", err)
+	}
+
+	var seen string
+	handler := rewrite(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.URL.RequestURI()
+	}))
+
+	cases := map[string]string{
+		"/old/users/42?tab=posts": "/v2/users/42?tab=posts",
+		"/members/7/profile":      "/v2/users/7",
+		"/v2/users/9":             "/v2/users/9",
+	}
+	for in, want := range cases {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, in, nil))
+		if seen != want {
+			t.Fatalf("rewrite %q: expected %q, got %q", in, want, seen)
+		}
+	}
+
+	if _, err := manager.RewriteMiddleware([]urlkit.RewriteRule{{From: "/old/users", Route: "api.users.show"}}); err == nil {
+		t.Fatal("expected error when the legacy pattern misses a required param")
+	}
+	if _, err := manager.RewriteMiddleware([]urlkit.RewriteRule{{From: "/old", Route: "api.missing"}}); err == nil {
+		t.Fatal("expected error for unknown route")
+	}
+}
+
+func TestRewriteMiddlewareGlobalPrefix(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"user": "/users/:id"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := manager.SetGlobalPrefix("/app"); err != nil {
+		t.Fatalf("SetGlobalPrefix failed: %v", err)
+	}
+
+	rewrite, err := manager.RewriteMiddleware([]urlkit.RewriteRule{{From: "/old/:id", Route: "api.user"}})
+	if err != nil {
+		t.Fatalf("RewriteMiddleware failed: %v", err)
+	}
+	var seen string
+	handler := rewrite(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.URL.RequestURI()
+	}))
+
+	cases := map[string]string{
+		"/app/old/1": "/app/users/1",
+		"/old/1":     "/old/1",
+	}
+	for in, want := range cases {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, in, nil))
+		if seen != want {
+			t.Fatalf("rewrite %q: expected %q, got %q", in, want, seen)
+		}
+	}
+}
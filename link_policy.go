@@ -0,0 +1,145 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// LinkPolicy constrains the host and first path segment of URLs built by a
+// group, protecting against open-redirect style links when user-provided values
+// flow into those positions (e.g. a "{tenant}.example.com" host or a "/:target"
+// route). Deny lists win over allow lists, and an empty allow list allows
+// everything. Policies are inherited: the nearest group in the chain that sets
+// one wins.
+type LinkPolicy struct {
+	// AllowHosts and DenyHosts hold host patterns; a leading "*." matches any
+	// subdomain and a port is only compared when both sides have one.
+	AllowHosts []string `json:"allow_hosts,omitempty" yaml:"allow_hosts,omitempty"`
+	DenyHosts  []string `json:"deny_hosts,omitempty" yaml:"deny_hosts,omitempty"`
+	// AllowSegments and DenySegments hold first path segment values, compared
+	// case-insensitively after unescaping.
+	AllowSegments []string `json:"allow_segments,omitempty" yaml:"allow_segments,omitempty"`
+	DenySegments  []string `json:"deny_segments,omitempty" yaml:"deny_segments,omitempty"`
+}
+
+// LinkPolicyError is returned when a built URL violates the group's LinkPolicy.
+type LinkPolicyError struct {
+	Group  string
+	Route  string
+	URL    string
+	Reason string
+}
+
+func (e LinkPolicyError) Error() string {
+	return fmt.Sprintf("group %q route %q produced disallowed URL %q: %s", e.Group, e.Route, e.URL, e.Reason)
+}
+
+type compiledLinkPolicy struct {
+	policy     LinkPolicy
+	allowHosts []hostPattern
+	denyHosts  []hostPattern
+}
+
+// SetLinkPolicy declares the host and path constraints for URLs built by this
+// group and its descendants. Pass a zero LinkPolicy to clear the group's policy
+// and inherit the parent's again.
+func (u *Group) SetLinkPolicy(policy LinkPolicy) error {
+	compiled := &compiledLinkPolicy{policy: policy}
+	for _, pattern := range policy.AllowHosts {
+		parsed, err := parseHostPattern(pattern)
+		if err != nil {
+			return fmt.Errorf("set link policy: %w", err)
+		}
+		compiled.allowHosts = append(compiled.allowHosts, parsed)
+	}
+	for _, pattern := range policy.DenyHosts {
+		parsed, err := parseHostPattern(pattern)
+		if err != nil {
+			return fmt.Errorf("set link policy: %w", err)
+		}
+		compiled.denyHosts = append(compiled.denyHosts, parsed)
+	}
+	if policy.isZero() {
+		compiled = nil
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set link policy", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.linkPolicy = compiled
+	u.mu.Unlock()
+	return nil
+}
+
+// LinkPolicy returns the effective link policy, searching up the hierarchy.
+func (u *Group) LinkPolicy() (LinkPolicy, bool) {
+	if compiled := u.effectiveLinkPolicy(); compiled != nil {
+		return compiled.policy, true
+	}
+	return LinkPolicy{}, false
+}
+
+func (u *Group) effectiveLinkPolicy() *compiledLinkPolicy {
+	for current := u; current != nil; {
+		current.mu.RLock()
+		policy := current.linkPolicy
+		parent := current.parent
+		current.mu.RUnlock()
+
+		if policy != nil {
+			return policy
+		}
+		current = parent
+	}
+	return nil
+}
+
+// enforceLinkPolicy checks a built URL against the effective link policy.
+func (u *Group) enforceLinkPolicy(routeName, built string) error {
+	policy := u.effectiveLinkPolicy()
+	if policy == nil {
+		return nil
+	}
+
+	fail := func(reason string) error {
+		return LinkPolicyError{Group: groupDisplayName(u), Route: routeName, URL: built, Reason: reason}
+	}
+
+	parsed, err := url.Parse(built)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	if parsed.Host != "" {
+		host, port := splitHostPort(strings.ToLower(parsed.Host))
+		matches := func(pattern hostPattern) bool { return pattern.matches(host, port) }
+		if slices.ContainsFunc(policy.denyHosts, matches) {
+			return fail(fmt.Sprintf("host %q is denied", host))
+		}
+		if len(policy.allowHosts) > 0 && !slices.ContainsFunc(policy.allowHosts, matches) {
+			return fail(fmt.Sprintf("host %q is not allowed", host))
+		}
+	}
+
+	segment, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	matches := func(value string) bool { return strings.EqualFold(value, segment) }
+	if slices.ContainsFunc(policy.policy.DenySegments, matches) {
+		return fail(fmt.Sprintf("path segment %q is denied", segment))
+	}
+	if len(policy.policy.AllowSegments) > 0 && !slices.ContainsFunc(policy.policy.AllowSegments, matches) {
+		return fail(fmt.Sprintf("path segment %q is not allowed", segment))
+	}
+	return nil
+}
+
+func (p LinkPolicy) isZero() bool {
+	return len(p.AllowHosts) == 0 && len(p.DenyHosts) == 0 && len(p.AllowSegments) == 0 && len(p.DenySegments) == 0
+}
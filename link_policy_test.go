@@ -0,0 +1,77 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestLinkPolicy(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "tenants",
+				URLTemplate: "https://{tenant}.example.com{route_path}",
+				Routes:      map[string]string{"home": "/"},
+				LinkPolicy: &urlkit.LinkPolicy{
+					AllowHosts: []string{"*.example.com"},
+					DenyHosts:  []string{"admin.example.com"},
+				},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:   "pages",
+						Path:   "/",
+						Routes: map[string]string{"page": "/:section"},
+					},
+				},
+			},
+		},
+	})
+	tenants, err := manager.GetGroup("tenants")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+
+	buildWithTenant := func(group *urlkit.Group, tenant, route string, params urlkit.Params) error {
+		t.Helper()
+		if err := tenants.SetTemplateVar("tenant", tenant); err != nil {
+			t.Fatalf("SetTemplateVar failed: %v", err)
+		}
+		_, err := group.Render(route, params)
+		return err
+	}
+
+	if err := buildWithTenant(tenants, "acme", "home", nil); err != nil {
+		t.Fatalf("expected allowed host, got %v", err)
+	}
+
+	var policyErr urlkit.LinkPolicyError
+	if err := buildWithTenant(tenants, "admin", "home", nil); !errors.As(err, &policyErr) {
+		t.Fatalf("expected LinkPolicyError for denied host, got %v", err)
+	}
+	if err := buildWithTenant(tenants, "evil.com/", "home", nil); !errors.As(err, &policyErr) {
+		t.Fatalf("expected LinkPolicyError for foreign host, got %v", err)
+	}
+
+	pages := tenants.Group("pages")
+	if err := pages.SetLinkPolicy(urlkit.LinkPolicy{AllowSegments: []string{"docs", "blog"}}); err != nil {
+		t.Fatalf("SetLinkPolicy failed: %v", err)
+	}
+	if err := buildWithTenant(pages, "acme", "page", urlkit.Params{"section": "docs"}); err != nil {
+		t.Fatalf("expected allowed segment, got %v", err)
+	}
+	if err := buildWithTenant(pages, "acme", "page", urlkit.Params{"section": "login"}); !errors.As(err, &policyErr) {
+		t.Fatalf("expected LinkPolicyError for segment, got %v", err)
+	}
+
+	if err := pages.SetLinkPolicy(urlkit.LinkPolicy{}); err != nil {
+		t.Fatalf("SetLinkPolicy failed: %v", err)
+	}
+	if policy, ok := pages.LinkPolicy(); !ok || len(policy.AllowHosts) != 1 {
+		t.Fatalf("expected inherited policy after clearing, got %+v %v", policy, ok)
+	}
+	if err := pages.SetLinkPolicy(urlkit.LinkPolicy{AllowHosts: []string{"bad*host"}}); err == nil {
+		t.Fatal("expected error for invalid host pattern")
+	}
+}
@@ -0,0 +1,149 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// LintSeverity classifies how serious a LintIssue is.
+type LintSeverity string
+
+const (
+	LintWarning LintSeverity = "warning"
+	LintError   LintSeverity = "error"
+)
+
+// Lint rule identifiers reported in LintIssue.Rule.
+const (
+	LintRuleUnreachableGroup = "unreachable_group"
+	LintRuleInsecureBaseURL  = "insecure_base_url"
+	LintRuleRouteAlias       = "route_alias"
+)
+
+// LintIssue describes a configuration smell found by Lint, with an explanation
+// and a suggested fix.
+type LintIssue struct {
+	Rule       string       `json:"rule"`
+	Severity   LintSeverity `json:"severity"`
+	Group      string       `json:"group"`
+	Message    string       `json:"message"`
+	Suggestion string       `json:"suggestion,omitempty"`
+}
+
+func (i LintIssue) String() string {
+	out := fmt.Sprintf("[%s] %s: %s (%s)", i.Severity, i.Group, i.Message, i.Rule)
+	if i.Suggestion != "" {
+		out += "; suggestion: " + i.Suggestion
+	}
+	return out
+}
+
+// lintRule inspects a single group and reports issues for it.
+type lintRule func(group *Group) []LintIssue
+
+var lintRules = []lintRule{
+	lintUnreachableGroup,
+	lintInsecureBaseURL,
+	lintRouteAlias,
+}
+
+// Lint walks every group and reports configuration issues that do not prevent
+// the manager from loading but will produce wrong or unusable URLs. Issues are
+// sorted by group and rule.
+func (m *RouteManager) Lint() []LintIssue {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	roots := make([]*Group, 0, len(m.groups))
+	for _, name := range slices.Sorted(maps.Keys(m.groups)) {
+		roots = append(roots, m.groups[name])
+	}
+	m.mu.RUnlock()
+
+	var issues []LintIssue
+	for _, root := range roots {
+		lintGroup(root, &issues)
+	}
+
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		if a.Group != b.Group {
+			return strings.Compare(a.Group, b.Group)
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+	return issues
+}
+
+func lintGroup(group *Group, issues *[]LintIssue) {
+	for _, rule := range lintRules {
+		*issues = append(*issues, rule(group)...)
+	}
+
+	group.mu.RLock()
+	children := maps.Clone(group.children)
+	group.mu.RUnlock()
+
+	for _, name := range slices.Sorted(maps.Keys(children)) {
+		lintGroup(children[name], issues)
+	}
+}
+
+// lintUnreachableGroup flags groups whose routes can never produce valid,
+// distinct URLs: the effective template omits {route_path} (every route renders
+// the same URL) or references variables that no group in the chain sets.
+func lintUnreachableGroup(group *Group) []LintIssue {
+	group.mu.RLock()
+	routeCount := len(group.routes)
+	group.mu.RUnlock()
+	if routeCount == 0 {
+		return nil
+	}
+
+	owner := group.FindTemplateOwner()
+	if owner == nil {
+		return nil
+	}
+
+	owner.mu.RLock()
+	template := owner.urlTemplate
+	owner.mu.RUnlock()
+
+	groupName := groupDisplayName(group)
+	ownerName := groupDisplayName(owner)
+
+	var issues []LintIssue
+	if !strings.Contains(template, "{route_path}") && routeCount > 1 {
+		issues = append(issues, LintIssue{
+			Rule:     LintRuleUnreachableGroup,
+			Severity: LintError,
+			Group:    groupName,
+			Message: fmt.Sprintf(
+				"template %q owned by %s omits {route_path}, so all %d routes render the same URL",
+				template, ownerName, routeCount,
+			),
+			Suggestion: fmt.Sprintf("add {route_path} to the url_template of %s", ownerName),
+		})
+	}
+
+	vars := group.CollectTemplateVars()
+	vars["route_path"] = ""
+	vars["base_url"] = ""
+	if missing := detectMissingTemplateVars(template, vars); len(missing) > 0 {
+		issues = append(issues, LintIssue{
+			Rule:     LintRuleUnreachableGroup,
+			Severity: LintError,
+			Group:    groupName,
+			Message: fmt.Sprintf(
+				"template %q owned by %s needs %v, which neither %s nor its ancestors set; every build fails",
+				template, ownerName, missing, groupName,
+			),
+			Suggestion: fmt.Sprintf("set template_vars %v on %s or one of its ancestors", missing, groupName),
+		})
+	}
+
+	return issues
+}
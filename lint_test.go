@@ -0,0 +1,49 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestLintFindsUnreachableGroups(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "frontend",
+				BaseURL:     "https://example.com",
+				URLTemplate: "{base_url}/{locale}{route_path}",
+				Groups: []urlkit.GroupConfig{
+					{Name: "en", TemplateVars: map[string]string{"locale": "en"}, Routes: map[string]string{"home": "/"}},
+					{Name: "orphan", Routes: map[string]string{"home": "/"}},
+				},
+			},
+			{
+				Name:        "landing",
+				BaseURL:     "https://landing.example.com",
+				URLTemplate: "{base_url}/promo",
+				Routes:      map[string]string{"a": "/a", "b": "/b"},
+			},
+		},
+	})
+
+	issues := manager.Lint()
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+
+	if issues[0].Group != "frontend.orphan" || !strings.Contains(issues[0].Message, "[locale]") {
+		t.Fatalf("unexpected missing var issue: %+v", issues[0])
+	}
+	if !strings.Contains(issues[0].Suggestion, "frontend.orphan") {
+		t.Fatalf("expected suggestion to name the group, got %q", issues[0].Suggestion)
+	}
+
+	if issues[1].Group != "landing" || !strings.Contains(issues[1].Message, "{route_path}") {
+		t.Fatalf("unexpected route_path issue: %+v", issues[1])
+	}
+	if issues[1].Rule != urlkit.LintRuleUnreachableGroup || issues[1].Severity != urlkit.LintError {
+		t.Fatalf("unexpected rule metadata: %+v", issues[1])
+	}
+}
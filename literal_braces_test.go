@@ -0,0 +1,84 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestEscapedBracesInRoutePatterns(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"braces": `/files/\{name\}/:id`,
+		"parens": `/v\(1\)/:id`,
+		"dotted": `/reports/:id\.json`,
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	cases := map[string]string{
+		"braces": "https://api.example.com/files/%7Bname%7D/7",
+		"parens": "https://api.example.com/v%281%29/7",
+		"dotted": "https://api.example.com/reports/7.json",
+	}
+	for route, want := range cases {
+		got, err := group.Builder(route).WithParam("id", "7").Build()
+		if err != nil {
+			t.Fatalf("Build(%s) failed: %v", route, err)
+		}
+		if got != want {
+			t.Fatalf("Build(%s): expected %q, got %q", route, want, got)
+		}
+	}
+
+	for _, pattern := range []string{"/v(1)", "/files/{name}"} {
+		_, _, err := manager.RegisterGroup("bad", "https://example.com", map[string]string{"route": pattern})
+		if err == nil || !strings.Contains(err.Error(), "unnamed group") {
+			t.Fatalf("expected unnamed group error for %q, got %v", pattern, err)
+		}
+	}
+}
+
+func TestEscapedBracesInURLTemplates(t *testing.T) {
+	got := urlkit.SubstituteTemplate(`{host}/\{host\}/{missing}`, map[string]string{"host": "example.com"})
+	if want := "example.com/{host}/{missing}"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	got = urlkit.SubstituteTemplate(`https://{api-host}/{tenant.id}/\{api-host\}`, map[string]string{"api-host": "api.example.com", "tenant.id": "acme"})
+	if want := "https://api.example.com/acme/{api-host}"; got != want {
+		t.Fatalf("expected keys outside [a-zA-Z0-9_] to be substituted, got %q", got)
+	}
+
+	got = urlkit.SubstituteTemplate("{a}{b}", map[string]string{"a": "{b}", "b": "x"})
+	if want := "{b}x"; got != want {
+		t.Fatalf("expected substituted values not to be rescanned, got %q", got)
+	}
+
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("frontend", "https://example.com", map[string]string{
+		"search": "/search",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := group.SetURLTemplate(`{protocol}://{host}{route_path}#\{q\}`); err != nil {
+		t.Fatalf("SetURLTemplate failed: %v", err)
+	}
+	group.SetTemplateVar("protocol", "https")
+	group.SetTemplateVar("host", "example.com")
+
+	got, err = group.Builder("search").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://example.com/search/#{q}"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	if report := manager.HealthCheck(); !report.Healthy {
+		t.Fatalf("expected escaped braces to be ignored by health check, got %v", report.Err())
+	}
+}
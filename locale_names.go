@@ -0,0 +1,80 @@
+package urlkit
+
+// localeName holds the English and native display names of a locale, taken
+// from CLDR.
+type localeName struct {
+	name   string
+	native string
+	rtl    bool
+}
+
+// localeNames covers the languages and regional variants most sites ship.
+// Locales missing here can be described in config (see GroupLocale); regional
+// tags of listed languages fall back to "<language> (<REGION>)".
+var localeNames = map[string]localeName{
+	"af":      {name: "Afrikaans", native: "Afrikaans"},
+	"ar":      {name: "Arabic", native: "العربية", rtl: true},
+	"bg":      {name: "Bulgarian", native: "български"},
+	"bn":      {name: "Bangla", native: "বাংলা"},
+	"ca":      {name: "Catalan", native: "català"},
+	"cs":      {name: "Czech", native: "čeština"},
+	"da":      {name: "Danish", native: "dansk"},
+	"de":      {name: "German", native: "Deutsch"},
+	"de-AT":   {name: "Austrian German", native: "Österreichisches Deutsch"},
+	"de-CH":   {name: "Swiss High German", native: "Schweizer Hochdeutsch"},
+	"el":      {name: "Greek", native: "Ελληνικά"},
+	"en":      {name: "English", native: "English"},
+	"en-AU":   {name: "Australian English", native: "Australian English"},
+	"en-CA":   {name: "Canadian English", native: "Canadian English"},
+	"en-GB":   {name: "British English", native: "British English"},
+	"en-US":   {name: "American English", native: "American English"},
+	"es":      {name: "Spanish", native: "español"},
+	"es-419":  {name: "Latin American Spanish", native: "español latinoamericano"},
+	"es-ES":   {name: "European Spanish", native: "español de España"},
+	"es-MX":   {name: "Mexican Spanish", native: "español de México"},
+	"et":      {name: "Estonian", native: "eesti"},
+	"eu":      {name: "Basque", native: "euskara"},
+	"fa":      {name: "Persian", native: "فارسی", rtl: true},
+	"fi":      {name: "Finnish", native: "suomi"},
+	"fil":     {name: "Filipino", native: "Filipino"},
+	"fr":      {name: "French", native: "français"},
+	"fr-CA":   {name: "Canadian French", native: "français canadien"},
+	"fr-CH":   {name: "Swiss French", native: "français suisse"},
+	"ga":      {name: "Irish", native: "Gaeilge"},
+	"gl":      {name: "Galician", native: "galego"},
+	"he":      {name: "Hebrew", native: "עברית", rtl: true},
+	"hi":      {name: "Hindi", native: "हिन्दी"},
+	"hr":      {name: "Croatian", native: "hrvatski"},
+	"hu":      {name: "Hungarian", native: "magyar"},
+	"id":      {name: "Indonesian", native: "Indonesia"},
+	"is":      {name: "Icelandic", native: "íslenska"},
+	"it":      {name: "Italian", native: "italiano"},
+	"ja":      {name: "Japanese", native: "日本語"},
+	"ko":      {name: "Korean", native: "한국어"},
+	"lt":      {name: "Lithuanian", native: "lietuvių"},
+	"lv":      {name: "Latvian", native: "latviešu"},
+	"ms":      {name: "Malay", native: "Melayu"},
+	"nb":      {name: "Norwegian Bokmål", native: "norsk bokmål"},
+	"nl":      {name: "Dutch", native: "Nederlands"},
+	"nl-BE":   {name: "Flemish", native: "Vlaams"},
+	"no":      {name: "Norwegian", native: "norsk"},
+	"pl":      {name: "Polish", native: "polski"},
+	"pt":      {name: "Portuguese", native: "português"},
+	"pt-BR":   {name: "Brazilian Portuguese", native: "português (Brasil)"},
+	"pt-PT":   {name: "European Portuguese", native: "português europeu"},
+	"ro":      {name: "Romanian", native: "română"},
+	"ru":      {name: "Russian", native: "русский"},
+	"sk":      {name: "Slovak", native: "slovenčina"},
+	"sl":      {name: "Slovenian", native: "slovenščina"},
+	"sr":      {name: "Serbian", native: "српски"},
+	"sv":      {name: "Swedish", native: "svenska"},
+	"sw":      {name: "Swahili", native: "Kiswahili"},
+	"th":      {name: "Thai", native: "ไทย"},
+	"tr":      {name: "Turkish", native: "Türkçe"},
+	"uk":      {name: "Ukrainian", native: "українська"},
+	"ur":      {name: "Urdu", native: "اردو", rtl: true},
+	"vi":      {name: "Vietnamese", native: "Tiếng Việt"},
+	"zh":      {name: "Chinese", native: "中文"},
+	"zh-Hans": {name: "Simplified Chinese", native: "简体中文"},
+	"zh-Hant": {name: "Traditional Chinese", native: "繁體中文"},
+}
@@ -0,0 +1,252 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LocaleSort selects the order of RouteManager.Locales.
+type LocaleSort string
+
+const (
+	// LocaleSortCode orders locales by code. This is the default.
+	LocaleSortCode LocaleSort = "code"
+	// LocaleSortName orders locales by English display name.
+	LocaleSortName LocaleSort = "name"
+	// LocaleSortNativeName orders locales by native name, the usual order of
+	// locale pickers.
+	LocaleSortNativeName LocaleSort = "native_name"
+)
+
+func (s LocaleSort) valid() bool {
+	switch s {
+	case "", LocaleSortCode, LocaleSortName, LocaleSortNativeName:
+		return true
+	}
+	return false
+}
+
+// GroupLocale describes the locale served by a group. Empty fields are filled
+// from the built-in CLDR names; Code defaults to the group's "locale" template
+// var or its name.
+type GroupLocale struct {
+	Code       string `json:"code,omitempty" yaml:"code,omitempty"`
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	NativeName string `json:"native_name,omitempty" yaml:"native_name,omitempty"`
+	// Direction is the script direction, "ltr" or "rtl".
+	Direction string `json:"dir,omitempty" yaml:"dir,omitempty"`
+}
+
+// LocaleDescriptor is a locale child group listed by RouteManager.Locales.
+type LocaleDescriptor struct {
+	// Code is the BCP 47 tag of the locale (e.g. "pt-BR").
+	Code string `json:"code"`
+	// Group is the fully qualified name of the locale group.
+	Group      string `json:"group"`
+	Name       string `json:"name"`
+	NativeName string `json:"native_name"`
+	Direction  string `json:"dir"`
+}
+
+var localeTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// SetLocale describes the locale this group serves, overriding the
+// built-in names. See RouteManager.Locales.
+func (u *Group) SetLocale(info GroupLocale) error {
+	if info.Code != "" && !localeTagPattern.MatchString(info.Code) {
+		return fmt.Errorf("invalid locale code %q", info.Code)
+	}
+	if info.Direction != "" && info.Direction != "ltr" && info.Direction != "rtl" {
+		return fmt.Errorf("invalid locale direction %q: use ltr or rtl", info.Direction)
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set locale", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.locale = &info
+	u.mu.Unlock()
+	return nil
+}
+
+// SetLocaleSort sets the order in which RouteManager.Locales lists the locale
+// children of this group.
+func (u *Group) SetLocaleSort(sort LocaleSort) error {
+	if !sort.valid() {
+		return fmt.Errorf("unsupported locale sort %q", sort)
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set locale sort", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.localeSort = sort
+	u.mu.Unlock()
+	return nil
+}
+
+// Locales lists the locale child groups of groupBase (e.g. "frontend.en",
+// "frontend.pt-br") with their display and native names, for locale pickers.
+// A child is a locale when its GroupLocale is configured, it sets the "locale"
+// template var, or its name is a language tag with built-in names. Locales
+// are sorted by the base group's LocaleSort; names compare case and accent
+// insensitively. Returns nil when groupBase does not exist.
+//
+// Example:
+//
+//	for _, locale := range manager.Locales("frontend") {
+//		fmt.Printf("%s (%s)\n", locale.NativeName, locale.Code) // español (es)
+//	}
+func (m *RouteManager) Locales(groupBase string) []LocaleDescriptor {
+	base, err := m.GetGroup(groupBase)
+	if err != nil {
+		return nil
+	}
+
+	base.mu.RLock()
+	children := maps.Clone(base.children)
+	sort := base.localeSort
+	base.mu.RUnlock()
+
+	var locales []LocaleDescriptor
+	for _, name := range slices.Sorted(maps.Keys(children)) {
+		if locale, ok := children[name].localeDescriptor(); ok {
+			locales = append(locales, locale)
+		}
+	}
+
+	slices.SortStableFunc(locales, func(a, b LocaleDescriptor) int {
+		var cmp int
+		switch sort {
+		case LocaleSortName:
+			cmp = strings.Compare(localeSortKey(a.Name), localeSortKey(b.Name))
+		case LocaleSortNativeName:
+			cmp = strings.Compare(localeSortKey(a.NativeName), localeSortKey(b.NativeName))
+		}
+		if cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.Code, b.Code)
+	})
+	return locales
+}
+
+// localeDescriptor describes the group as a locale, if it is one.
+func (u *Group) localeDescriptor() (LocaleDescriptor, bool) {
+	u.mu.RLock()
+	var info GroupLocale
+	configured := u.locale != nil
+	if configured {
+		info = *u.locale
+	}
+	localeVar, hasVar := u.templateVars["locale"]
+	name := u.name
+	u.mu.RUnlock()
+
+	code := info.Code
+	if code == "" && hasVar && localeTagPattern.MatchString(localeVar) {
+		code = localeVar
+	}
+	if code == "" {
+		code = name
+	}
+	if !localeTagPattern.MatchString(code) {
+		return LocaleDescriptor{}, false
+	}
+	code = normalizeLocaleCode(code)
+
+	builtin, known := lookupLocaleName(code)
+	if !known && !configured && !hasVar {
+		return LocaleDescriptor{}, false
+	}
+
+	locale := LocaleDescriptor{
+		Code:       code,
+		Group:      u.FQN(),
+		Name:       firstNonEmpty(info.Name, builtin.name, code),
+		NativeName: firstNonEmpty(info.NativeName, builtin.native, code),
+		Direction:  info.Direction,
+	}
+	if locale.Direction == "" {
+		locale.Direction = "ltr"
+		if builtin.rtl {
+			locale.Direction = "rtl"
+		}
+	}
+	return locale, true
+}
+
+// lookupLocaleName finds the built-in names of code, falling back from
+// regional tags to their language.
+func lookupLocaleName(code string) (localeName, bool) {
+	if name, ok := localeNames[code]; ok {
+		return name, true
+	}
+	language, rest, found := strings.Cut(code, "-")
+	name, ok := localeNames[language]
+	if !ok || !found {
+		return name, ok
+	}
+	name.name += " (" + rest + ")"
+	name.native += " (" + rest + ")"
+	return name, true
+}
+
+// normalizeLocaleCode applies BCP 47 casing: lowercase language, title case
+// script and uppercase region (e.g. "zh_hant_tw" -> "zh-Hant-TW").
+func normalizeLocaleCode(code string) string {
+	parts := strings.FieldsFunc(code, func(r rune) bool { return r == '-' || r == '_' })
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part)
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+var localeAccentFolder = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a", "ă", "a",
+	"ç", "c", "č", "c", "ć", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e", "ě", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ī", "i",
+	"ñ", "n", "ń", "n", "ň", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o",
+	"ř", "r", "š", "s", "ś", "s", "ș", "s", "ş", "s", "ț", "t", "ţ", "t",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ū", "u", "ů", "u",
+	"ý", "y", "ÿ", "y", "ž", "z", "ź", "z", "ż", "z",
+)
+
+// localeSortKey folds case and common Latin diacritics so that, for example,
+// "Čeština" sorts next to "Català" instead of after "Zulu".
+func localeSortKey(name string) string {
+	return localeAccentFolder.Replace(strings.ToLower(name))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	"github.com/goliatone/go-urlkit"
+)
+
+func newLocalesManager(t *testing.T, sort urlkit.LocaleSort) *urlkit.RouteManager {
+	t.Helper()
+	home := map[string]string{"home": "/"}
+	return mustManagerFromConfig(t, urlkit.Config{Groups: []urlkit.GroupConfig{
+		{
+			Name:       "frontend",
+			BaseURL:    "https://example.com",
+			LocaleSort: sort,
+			Groups: []urlkit.GroupConfig{
+				{Name: "en", Path: "/en", Routes: home},
+				{Name: "es", Path: "/es", Routes: home},
+				{Name: "pt_br", Path: "/pt-br", Routes: home},
+				{Name: "cs", Path: "/cs", Routes: home},
+				{Name: "arabic", Path: "/ar", Routes: home, TemplateVars: map[string]string{"locale": "ar"}},
+				{Name: "eo", Path: "/eo", Routes: home, Locale: &urlkit.GroupLocale{NativeName: "Esperanto"}},
+				{Name: "api", Path: "/api", Routes: home},
+			},
+		},
+	}})
+}
+
+func localeCodes(locales []urlkit.LocaleDescriptor) []string {
+	codes := make([]string, len(locales))
+	for i, locale := range locales {
+		codes[i] = locale.Code
+	}
+	return codes
+}
+
+func TestLocales(t *testing.T) {
+	manager := newLocalesManager(t, "")
+	locales := manager.Locales("frontend")
+
+	got := localeCodes(locales)
+	want := []string{"ar", "cs", "en", "eo", "es", "pt-BR"}
+	if len(got) != len(want) {
+		t.Fatalf("Locales() codes = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Locales() codes = %v, want %v", got, want)
+		}
+	}
+
+	byCode := map[string]urlkit.LocaleDescriptor{}
+	for _, locale := range locales {
+		byCode[locale.Code] = locale
+	}
+	if ar := byCode["ar"]; ar.Group != "frontend.arabic" || ar.Direction != "rtl" || ar.NativeName != "العربية" {
+		t.Errorf("unexpected Arabic locale %+v", ar)
+	}
+	if pt := byCode["pt-BR"]; pt.Name != "Brazilian Portuguese" || pt.Direction != "ltr" {
+		t.Errorf("unexpected Portuguese locale %+v", pt)
+	}
+	if eo := byCode["eo"]; eo.Name != "eo" || eo.NativeName != "Esperanto" {
+		t.Errorf("configured names should override the fallback: %+v", eo)
+	}
+
+	if manager.Locales("missing") != nil {
+		t.Error("expected nil for a missing group")
+	}
+}
+
+func TestLocalesSortByNativeName(t *testing.T) {
+	manager := newLocalesManager(t, urlkit.LocaleSortNativeName)
+
+	// čeština sorts with c despite its diacritic; Arabic script sorts last
+	got := localeCodes(manager.Locales("frontend"))
+	want := []string{"cs", "en", "es", "eo", "pt-BR", "ar"}
+	for i := range want {
+		if i >= len(got) || got[i] != want[i] {
+			t.Fatalf("Locales() codes = %v, want %v", got, want)
+		}
+	}
+
+	if err := manager.Group("frontend").SetLocaleSort("alphabetical"); err == nil {
+		t.Error("expected unsupported locale sort to be rejected")
+	}
+}
@@ -0,0 +1,118 @@
+package urlkit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ManifestDocument is the JSON document served by ManifestHandler. Other
+// services can decode it to build links to this registry without duplicating
+// its configuration.
+type ManifestDocument struct {
+	// Fingerprint is the registry Fingerprint the document was built from.
+	Fingerprint string            `json:"fingerprint"`
+	BaseURLs    map[string]string `json:"base_urls"`
+	Routes      []ManifestRoute   `json:"routes"`
+}
+
+// ManifestRoute is a route of a ManifestDocument. It mirrors
+// RouteManifestEntry with stable JSON field names.
+type ManifestRoute struct {
+	Group         string `json:"group"`
+	Route         string `json:"route"`
+	RouteTemplate string `json:"route_template"`
+	PathTemplate  string `json:"path_template"`
+}
+
+// ManifestDocument returns the route manifest together with the base URL of
+// every root group and the registry fingerprint. Routes outside their
+// availability window (see RouteAvailability) are left out.
+func (m *RouteManager) ManifestDocument() ManifestDocument {
+	doc := ManifestDocument{Fingerprint: m.Fingerprint(), BaseURLs: map[string]string{}, Routes: []ManifestRoute{}}
+	if m == nil {
+		return doc
+	}
+
+	now := time.Now()
+	for _, entry := range m.Manifest() {
+		if group, err := m.GetGroup(entry.GroupFQN); err == nil && !group.RouteAvailable(entry.RouteKey, now) {
+			continue
+		}
+		doc.Routes = append(doc.Routes, ManifestRoute{
+			Group:         entry.GroupFQN,
+			Route:         entry.RouteKey,
+			RouteTemplate: entry.RouteTemplate,
+			PathTemplate:  entry.FullPathTemplate,
+		})
+	}
+
+	m.mu.RLock()
+	roots := make([]*Group, 0, len(m.groups))
+	for _, group := range m.groups {
+		roots = append(roots, group)
+	}
+	m.mu.RUnlock()
+
+	for _, root := range roots {
+		root.mu.RLock()
+		doc.BaseURLs[root.name] = root.baseURL
+		root.mu.RUnlock()
+	}
+	return doc
+}
+
+// ManifestHandler returns an http.Handler that serves the ManifestDocument as
+// JSON. Responses carry a strong ETag derived from the body, and requests whose
+// If-None-Match header matches it receive 304 Not Modified. The document is
+// rebuilt per request, so runtime registry changes are visible immediately.
+func ManifestHandler(m *RouteManager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body bytes.Buffer
+		if err := json.NewEncoder(&body).Encode(m.ManifestDocument()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		sum := sha256.Sum256(body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body.Bytes())
+		}
+	})
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison required for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
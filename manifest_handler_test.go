@@ -0,0 +1,86 @@
+package urlkit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestManifestHandlerETag(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"users": "/users/:id",
+	}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	handler := urlkit.ManifestHandler(manager)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/routes.json", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", recorder.Code)
+	}
+	etag := recorder.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+
+	var doc urlkit.ManifestDocument
+	if err := json.Unmarshal(recorder.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to decode manifest: %v", err)
+	}
+	if doc.BaseURLs["api"] != "https://api.example.com" {
+		t.Fatalf("unexpected base URLs: %v", doc.BaseURLs)
+	}
+	if len(doc.Routes) != 1 || doc.Routes[0].Group != "api" || doc.Routes[0].PathTemplate != "/users/:id" {
+		t.Fatalf("unexpected routes: %+v", doc.Routes)
+	}
+	if !strings.Contains(recorder.Body.String(), `"path_template":"/users/:id"`) {
+		t.Fatalf("unexpected manifest JSON: %s", recorder.Body.String())
+	}
+
+	// RouteManifestEntry keeps its Go field names in JSON
+	if data, _ := json.Marshal(urlkit.RouteManifestEntry{GroupFQN: "api"}); !strings.Contains(string(data), `"GroupFQN":"api"`) {
+		t.Fatalf("unexpected RouteManifestEntry JSON: %s", data)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/routes.json", nil)
+	request.Header.Set("If-None-Match", `"stale", W/`+etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", recorder.Code)
+	}
+	if recorder.Body.Len() != 0 {
+		t.Fatalf("expected empty body for 304, got %q", recorder.Body.String())
+	}
+
+	group, err := manager.GetGroup("api")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if _, err := group.AddRoutes(map[string]string{"posts": "/posts"}); err != nil {
+		t.Fatalf("AddRoutes failed: %v", err)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/routes.json", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200 after registry change, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("ETag") == etag {
+		t.Fatal("expected ETag to change after registry change")
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/routes.json", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,92 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MatchResult describes the route an incoming URL maps to.
+type MatchResult struct {
+	// Group is the fully qualified group name (e.g. "api.v1").
+	Group string
+	// Route is the route name within Group.
+	Route string
+	// Variant reports whether the canonical or the vanity pattern matched.
+	Variant RouteVariant
+	// Params holds the decoded path params.
+	Params Params
+	// Query holds the query values of the URL.
+	Query url.Values
+}
+
+// FQN returns the fully qualified route name ("group.route").
+func (r *MatchResult) FQN() string {
+	return r.Group + "." + r.Route
+}
+
+// Match recognizes an incoming URL: it returns the group and route whose
+// pattern matches the path, the extracted path params and the query values.
+// rawURL may be a path ("/users/42?tab=posts") or an absolute URL; with a host,
+// only the root group serving that host (see GroupForHost) is considered and
+// its base URL path is stripped. The global prefix, if set, is stripped as
+// well. When several routes match, the one with the fewest params (the most
+// specific) wins, as in Coverage. Returns ErrRouteNotFound when nothing
+// matches.
+//
+// Example:
+//
+//	match, err := manager.Match(r.URL.String())
+//	if err == nil {
+//		log.Printf("route=%s id=%v", match.FQN(), match.Params["id"])
+//	}
+func (m *RouteManager) Match(rawURL string) (*MatchResult, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("match %q: %w", rawURL, err)
+	}
+
+	path := target.EscapedPath()
+	matchers := m.routeMatchers()
+	if target.Host != "" {
+		root, err := m.GroupForHost(target.Host)
+		if err != nil {
+			return nil, fmt.Errorf("match %q: %w", rawURL, err)
+		}
+		rootName := root.FQN()
+		var filtered []routeMatcher
+		for _, matcher := range matchers {
+			if matcher.group == rootName || strings.HasPrefix(matcher.group, rootName+".") {
+				filtered = append(filtered, matcher)
+			}
+		}
+		matchers = filtered
+
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+		if base, err := url.Parse(baseURL); err == nil {
+			if basePath := strings.TrimSuffix(base.EscapedPath(), "/"); basePath != "" {
+				if rest, ok := strings.CutPrefix(path, basePath); ok && (rest == "" || rest[0] == '/') {
+					path = rest
+				}
+			}
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	if stripped, ok := m.runtime.stripGlobalPrefix(path); ok {
+		if best, params, ok := bestRouteMatch(matchers, stripped); ok {
+			return &MatchResult{
+				Group:   best.group,
+				Route:   best.route,
+				Variant: best.variant,
+				Params:  params,
+				Query:   target.Query(),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("match %q: %w", rawURL, ErrRouteNotFound)
+}
@@ -0,0 +1,103 @@
+package urlkit_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestMatch(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com/base",
+				Routes:  map[string]string{"user": "/users/:id", "me": "/users/me"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "v1", Path: "/v1", Routes: map[string]string{"post": "/posts/:slug"}},
+				},
+			},
+			{Name: "web", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "post": "/posts/:slug"}},
+		},
+	})
+
+	match, err := manager.Match("https://api.example.com/base/users/42?tab=posts")
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if match.FQN() != "api.user" || match.Params["id"] != "42" || match.Query.Get("tab") != "posts" {
+		t.Fatalf("unexpected match: %+v", match)
+	}
+	if match.Variant != urlkit.RouteVariantCanonical {
+		t.Fatalf("expected canonical variant, got %q", match.Variant)
+	}
+
+	match, err = manager.Match("https://api.example.com/base/users/me")
+	if err != nil || match.Route != "me" {
+		t.Fatalf("expected the most specific route, got %+v, %v", match, err)
+	}
+
+	match, err = manager.Match("https://example.com/posts/hello%20world")
+	if err != nil || match.FQN() != "web.post" || match.Params["slug"] != "hello world" {
+		t.Fatalf("expected web.post with decoded slug, got %+v, %v", match, err)
+	}
+
+	match, err = manager.Match("/v1/posts/intro")
+	if err != nil || match.FQN() != "api.v1.post" {
+		t.Fatalf("expected api.v1.post for a bare path, got %+v, %v", match, err)
+	}
+
+	if _, err := manager.Match("https://example.com/users/42"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound for a route of another host, got %v", err)
+	}
+	if _, err := manager.Match("https://unknown.test/"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for an unknown host, got %v", err)
+	}
+}
+
+func TestMatchSeesRegistryChanges(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "web", BaseURL: "https://example.com", Routes: map[string]string{"home": "/"}},
+		},
+	})
+
+	if _, err := manager.Match("/about"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+	if _, err := manager.Group("web").AddRoutes(map[string]string{"about": "/about"}); err != nil {
+		t.Fatalf("AddRoutes failed: %v", err)
+	}
+	if match, err := manager.Match("/about"); err != nil || match.FQN() != "web.about" {
+		t.Fatalf("expected the added route to match, got %+v, %v", match, err)
+	}
+
+	if err := manager.Group("web").SetVanityRoute("about", "/a"); err != nil {
+		t.Fatalf("SetVanityRoute failed: %v", err)
+	}
+	if match, err := manager.Match("/a"); err != nil || match.Variant != urlkit.RouteVariantVanity {
+		t.Fatalf("expected the vanity route to match, got %+v, %v", match, err)
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	routes := make(map[string]string, 100)
+	for i := range 100 {
+		routes[fmt.Sprintf("route%d", i)] = fmt.Sprintf("/section%d/items/:id", i)
+	}
+	manager, err := urlkit.NewRouteManagerFromConfig(urlkit.Config{
+		Groups: []urlkit.GroupConfig{{Name: "web", BaseURL: "https://example.com", Routes: routes}},
+	})
+	if err != nil {
+		b.Fatalf("NewRouteManagerFromConfig failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := manager.Match("/section99/items/42"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RouteMetadata carries optional information about a route that does not affect
+// how its URL is built, such as the HTTP method used to serve it.
+type RouteMetadata struct {
+	// Method is the HTTP method the route is served with (e.g. "GET").
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Params declares typed constraints for path params keyed by param name
+	// (e.g. {"id": "int"}). See ParamType for the supported types.
+	Params map[string]ParamType `json:"params,omitempty" yaml:"params,omitempty"`
+
+	// Weight orders the route in navigation menus; lower weights come first.
+	// Routes with equal weight keep their requested (or alphabetical) order.
+	Weight int `json:"weight,omitempty" yaml:"weight,omitempty"`
+
+	// Hidden excludes the route from Navigation and NavigationTree.
+	Hidden bool `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+
+	// Sensitive marks params (path or query) whose values must not reach
+	// build interceptors, logs or metrics, keyed by param name with the
+	// redaction to apply (e.g. {"email": "hash", "token": "mask"}).
+	Sensitive map[string]Redaction `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+
+	// Cache declares how responses of the route may be cached. See
+	// RouteManager.CacheHeaders.
+	Cache *CachePolicy `json:"cache,omitempty" yaml:"cache,omitempty"`
+
+	// CacheQuery lists the query params significant for caching the route;
+	// Group.NormalizeForCache drops all others. Nil keeps every param, an
+	// empty list drops them all.
+	CacheQuery []string `json:"cache_query,omitempty" yaml:"cache_query,omitempty"`
+
+	// Sitemap sets the changefreq and priority of the route's sitemap
+	// entries, or excludes it. See RouteManager.Sitemap.
+	Sitemap *SitemapPolicy `json:"sitemap,omitempty" yaml:"sitemap,omitempty"`
+
+	// Availability limits when the route is live (not before / not after).
+	// See RouteAvailability.
+	Availability *RouteAvailability `json:"availability,omitempty" yaml:"availability,omitempty"`
+
+	// CSP lists the Content-Security-Policy directives the route's URLs are
+	// loaded under (e.g. ["img-src"]). Empty means every directive. See
+	// RouteManager.CSPSources.
+	CSP []string `json:"csp,omitempty" yaml:"csp,omitempty"`
+}
+
+// SetRouteMetadata attaches metadata to an existing route in this group.
+// Returns ErrRouteNotFound when the route is not registered.
+func (u *Group) SetRouteMetadata(routeName string, meta RouteMetadata) error {
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set route metadata", groupFQN)
+	if err != nil {
+		return err
+	}
+	var events []Event
+	defer func() { u.runtime.publish(events...) }()
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.routes[routeName]; !ok {
+		return fmt.Errorf("%w: route %q in group %s", ErrRouteNotFound, routeName, u.fqnLocked())
+	}
+
+	meta.Method = strings.ToUpper(strings.TrimSpace(meta.Method))
+	for param, paramType := range meta.Params {
+		if !paramType.valid() {
+			return fmt.Errorf("route %q param %q: unsupported param type %q", routeName, param, paramType)
+		}
+	}
+	for param, redaction := range meta.Sensitive {
+		if !redaction.valid() {
+			return fmt.Errorf("route %q param %q: unsupported redaction %q", routeName, param, redaction)
+		}
+	}
+	if meta.Cache != nil {
+		if err := meta.Cache.validate(); err != nil {
+			return fmt.Errorf("route %q: %w", routeName, err)
+		}
+		cache := *meta.Cache
+		meta.Cache = &cache
+	}
+	if meta.Sitemap != nil {
+		if err := meta.Sitemap.validate(); err != nil {
+			return fmt.Errorf("route %q: %w", routeName, err)
+		}
+		sitemap := *meta.Sitemap
+		meta.Sitemap = &sitemap
+	}
+	if meta.Availability != nil {
+		if err := meta.Availability.validate(); err != nil {
+			return fmt.Errorf("route %q: %w", routeName, err)
+		}
+		availability := *meta.Availability
+		meta.Availability = &availability
+	}
+	if slices.Contains(meta.CacheQuery, "") {
+		return fmt.Errorf("route %q: cache query param names must not be empty", routeName)
+	}
+	meta.CacheQuery = slices.Clone(meta.CacheQuery)
+	meta.CSP = slices.Clone(meta.CSP)
+	for i, directive := range meta.CSP {
+		meta.CSP[i] = strings.ToLower(strings.TrimSpace(directive))
+		if !slices.Contains(cspDirectives, meta.CSP[i]) {
+			return fmt.Errorf("route %q: unsupported csp directive %q", routeName, directive)
+		}
+	}
+	meta.Params = maps.Clone(meta.Params)
+	meta.Sensitive = maps.Clone(meta.Sensitive)
+	if u.metadata == nil {
+		u.metadata = make(map[string]RouteMetadata)
+	}
+	u.metadata[routeName] = meta
+	events = []Event{{Type: EventRoutesChanged, Group: groupFQN}}
+	return nil
+}
+
+// RouteMetadata returns the metadata attached to the route, if any.
+func (u *Group) RouteMetadata(routeName string) (RouteMetadata, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	meta, ok := u.metadata[u.canonicalRouteNameLocked(routeName)]
+	return meta, ok
+}
@@ -0,0 +1,39 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRouteMetadataFromConfig(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:     "api",
+				BaseURL:  "https://api.example.com",
+				Routes:   map[string]string{"users": "/users"},
+				Metadata: map[string]urlkit.RouteMetadata{"users": {Method: "get"}},
+			},
+		},
+	})
+
+	meta, ok := manager.Group("api").RouteMetadata("users")
+	if !ok || meta.Method != "GET" {
+		t.Fatalf("expected normalized GET metadata, got %+v", meta)
+	}
+
+	_, err := urlkit.NewRouteManagerFromConfig(urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:     "api",
+				BaseURL:  "https://api.example.com",
+				Metadata: map[string]urlkit.RouteMetadata{"missing": {Method: "GET"}},
+			},
+		},
+	})
+	if !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound for metadata on unknown route, got %v", err)
+	}
+}
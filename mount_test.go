@@ -0,0 +1,84 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestMountResolvesExternalGroups(t *testing.T) {
+	billing := urlkit.NewRouteManager()
+	invoices, _, err := billing.RegisterGroup("invoices", "https://billing.example.com", map[string]string{
+		"show": "/invoices/:id",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	mustRegisterGroup(t, invoices, "admin", "/admin", map[string]string{"list": "/list"})
+
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("frontend", "https://example.com", map[string]string{"home": "/"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := manager.Mount("billing", billing); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+
+	got, err := manager.Resolve("billing.invoices", "show", urlkit.Params{"id": "42"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if want := "https://billing.example.com/invoices/42"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	got, err = manager.Resolve("billing.invoices.admin", "list", nil, nil)
+	if err != nil {
+		t.Fatalf("Resolve nested failed: %v", err)
+	}
+	if want := "https://billing.example.com/admin/list"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	if _, err := manager.GetGroup("billing"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for bare prefix, got %v", err)
+	}
+	if _, err := manager.GetGroup("billing.missing"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound for missing mounted group, got %v", err)
+	}
+	if mounts := manager.Mounts(); len(mounts) != 1 || mounts[0] != "billing" {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+}
+
+func TestMountConflicts(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("frontend", "https://example.com", map[string]string{"home": "/"}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	other := urlkit.NewRouteManager()
+
+	if err := manager.Mount("frontend", other); !errors.Is(err, urlkit.ErrMountConflict) {
+		t.Fatalf("expected ErrMountConflict for root group clash, got %v", err)
+	}
+	if err := manager.Mount("a.b", other); err == nil {
+		t.Fatal("expected error for dotted prefix")
+	}
+	if err := manager.Mount("self", manager); !errors.Is(err, urlkit.ErrMountConflict) {
+		t.Fatalf("expected ErrMountConflict for self mount, got %v", err)
+	}
+
+	if err := manager.Mount("billing", other); err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	if err := manager.Mount("billing", urlkit.NewRouteManager()); !errors.Is(err, urlkit.ErrMountConflict) {
+		t.Fatalf("expected ErrMountConflict for duplicate prefix, got %v", err)
+	}
+	if err := other.Mount("parent", manager); !errors.Is(err, urlkit.ErrMountConflict) {
+		t.Fatalf("expected ErrMountConflict for cycle, got %v", err)
+	}
+	if _, _, err := manager.RegisterGroup("billing", "https://example.com", nil); err == nil {
+		t.Fatal("expected RegisterGroup to reject a mounted prefix")
+	}
+}
@@ -0,0 +1,278 @@
+package urlkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NavigationCache stores encoded navigation snapshots. Values are opaque bytes
+// so implementations can be shared between processes.
+type NavigationCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// MemoryNavigationCache is an in-process NavigationCache.
+type MemoryNavigationCache struct {
+	mu      sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewMemoryNavigationCache returns an empty in-memory cache.
+func NewMemoryNavigationCache() *MemoryNavigationCache {
+	return &MemoryNavigationCache{entries: map[string][]byte{}}
+}
+
+func (c *MemoryNavigationCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.entries[key]
+	return value, ok, nil
+}
+
+func (c *MemoryNavigationCache) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = slices.Clone(value)
+	return nil
+}
+
+func (c *MemoryNavigationCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// RedisClient is the subset of a Redis client used by RedisNavigationCache,
+// so urlkit does not depend on a particular driver. With go-redis:
+//
+//	type redisAdapter struct{ *redis.Client }
+//
+//	func (a redisAdapter) Get(ctx context.Context, key string) ([]byte, bool, error) {
+//		value, err := a.Client.Get(ctx, key).Bytes()
+//		if errors.Is(err, redis.Nil) {
+//			return nil, false, nil
+//		}
+//		return value, err == nil, err
+//	}
+//
+//	func (a redisAdapter) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+//		return a.Client.Set(ctx, key, value, ttl).Err()
+//	}
+//
+//	func (a redisAdapter) Del(ctx context.Context, keys ...string) error {
+//		return a.Client.Del(ctx, keys...).Err()
+//	}
+type RedisClient interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
+
+// RedisNavigationCache is a NavigationCache backed by Redis. Keys are stored
+// under prefix and expire after ttl (zero keeps them until invalidated).
+type RedisNavigationCache struct {
+	client RedisClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisNavigationCache returns a cache storing snapshots through client.
+func NewRedisNavigationCache(client RedisClient, prefix string, ttl time.Duration) *RedisNavigationCache {
+	return &RedisNavigationCache{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (c *RedisNavigationCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return c.client.Get(ctx, c.prefix+key)
+}
+
+func (c *RedisNavigationCache) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.Set(ctx, c.prefix+key, value, c.ttl)
+}
+
+func (c *RedisNavigationCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...)
+}
+
+// NavigationSpec describes a navigation snapshot. With Routes set it is a flat
+// Navigation of those routes; without, the NavigationTree of Group.
+type NavigationSpec struct {
+	Key    string
+	Group  string
+	Routes []string
+	// Params supplies route params: route names for Navigation, fully
+	// qualified route names for NavigationTree.
+	Params func(route string) Params
+}
+
+// NavigationSnapshots serves precomputed navigation from a NavigationCache and
+// invalidates snapshots when the registry changes.
+type NavigationSnapshots struct {
+	manager     *RouteManager
+	cache       NavigationCache
+	specs       map[string]NavigationSpec
+	unsubscribe func()
+}
+
+// NavigationSnapshots precomputes every spec into cache and keeps it in sync:
+// registry mutation events (including route metadata, aliases and policy
+// changes) delete the snapshots of the affected group, its ancestors and its
+// descendants, and the next read recomputes them. Manager-wide events delete
+// every snapshot. Snapshots
+// round-trip through JSON, so numeric params read back as float64. Call Close
+// to stop listening for events.
+//
+// Example:
+//
+//	snapshots, err := manager.NavigationSnapshots(ctx, urlkit.NewMemoryNavigationCache(),
+//		urlkit.NavigationSpec{Key: "main", Group: "frontend", Routes: []string{"home", "about"}},
+//		urlkit.NavigationSpec{Key: "docs", Group: "docs"},
+//	)
+//	nodes, err := snapshots.Navigation(ctx, "main")
+func (m *RouteManager) NavigationSnapshots(ctx context.Context, cache NavigationCache, specs ...NavigationSpec) (*NavigationSnapshots, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("navigation snapshots: cache is required")
+	}
+	s := &NavigationSnapshots{manager: m, cache: cache, specs: make(map[string]NavigationSpec, len(specs))}
+	for _, spec := range specs {
+		if spec.Key == "" {
+			return nil, fmt.Errorf("navigation snapshots: spec for group %q has no key", spec.Group)
+		}
+		if _, exists := s.specs[spec.Key]; exists {
+			return nil, fmt.Errorf("navigation snapshots: duplicate key %q", spec.Key)
+		}
+		s.specs[spec.Key] = spec
+	}
+	if err := s.Warm(ctx); err != nil {
+		return nil, err
+	}
+	s.unsubscribe = m.Subscribe(s.invalidate)
+	return s, nil
+}
+
+// Warm recomputes and stores every snapshot, e.g. after a config reload.
+func (s *NavigationSnapshots) Warm(ctx context.Context) error {
+	for _, key := range slices.Sorted(maps.Keys(s.specs)) {
+		if _, err := s.refresh(ctx, s.specs[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Navigation returns the flat navigation snapshot stored under key.
+func (s *NavigationSnapshots) Navigation(ctx context.Context, key string) ([]NavigationNode, error) {
+	spec, ok := s.specs[key]
+	if !ok || spec.Routes == nil {
+		return nil, fmt.Errorf("navigation snapshots: no navigation registered under %q", key)
+	}
+	var nodes []NavigationNode
+	return nodes, s.load(ctx, spec, &nodes)
+}
+
+// Tree returns the navigation tree snapshot stored under key.
+func (s *NavigationSnapshots) Tree(ctx context.Context, key string) (NavigationTree, error) {
+	spec, ok := s.specs[key]
+	if !ok || spec.Routes != nil {
+		return NavigationTree{}, fmt.Errorf("navigation snapshots: no navigation tree registered under %q", key)
+	}
+	var tree NavigationTree
+	return tree, s.load(ctx, spec, &tree)
+}
+
+// Close stops invalidating snapshots on registry events.
+func (s *NavigationSnapshots) Close() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+func (s *NavigationSnapshots) load(ctx context.Context, spec NavigationSpec, target any) error {
+	encoded, ok, err := s.cache.Get(ctx, spec.Key)
+	if err != nil {
+		return fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+	if !ok {
+		if encoded, err = s.refresh(ctx, spec); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(encoded, target); err != nil {
+		return fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+	return nil
+}
+
+func (s *NavigationSnapshots) refresh(ctx context.Context, spec NavigationSpec) ([]byte, error) {
+	group, err := s.manager.GetGroup(spec.Group)
+	if err != nil {
+		return nil, fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+	// A mutation while rendering invalidates before the stale render is
+	// stored, so the render is only kept if the registry did not move
+	generation := s.manager.registryGeneration()
+
+	var snapshot any
+	if spec.Routes != nil {
+		snapshot, err = group.Navigation(spec.Routes, spec.Params)
+	} else {
+		snapshot, err = group.NavigationTree(spec.Params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+	if s.manager.registryGeneration() != generation {
+		return encoded, nil
+	}
+	if err := s.cache.Set(ctx, spec.Key, encoded); err != nil {
+		return nil, fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+	}
+	if s.manager.registryGeneration() != generation {
+		if err := s.cache.Delete(ctx, spec.Key); err != nil {
+			return nil, fmt.Errorf("navigation snapshot %q: %w", spec.Key, err)
+		}
+	}
+	return encoded, nil
+}
+
+// invalidate deletes the snapshots whose group is related to the event's.
+func (s *NavigationSnapshots) invalidate(event Event) {
+	var stale []string
+	for key, spec := range s.specs {
+		if event.Group == "" || groupsRelated(event.Group, spec.Group) {
+			stale = append(stale, key)
+		}
+	}
+	if len(stale) > 0 {
+		slices.Sort(stale)
+		_ = s.cache.Delete(context.Background(), stale...)
+	}
+}
+
+// groupsRelated reports whether a and b are the same group or one is an
+// ancestor of the other.
+func groupsRelated(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
@@ -0,0 +1,181 @@
+package urlkit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+type fakeRedis struct {
+	values map[string][]byte
+	ttls   map[string]time.Duration
+}
+
+func (f *fakeRedis) Get(_ context.Context, key string) ([]byte, bool, error) {
+	value, ok := f.values[key]
+	return value, ok, nil
+}
+
+func (f *fakeRedis) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	f.values[key], f.ttls[key] = value, ttl
+	return nil
+}
+
+func (f *fakeRedis) Del(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return nil
+}
+
+func TestNavigationSnapshots(t *testing.T) {
+	ctx := context.Background()
+	redis := &fakeRedis{values: map[string][]byte{}, ttls: map[string]time.Duration{}}
+
+	for name, cache := range map[string]urlkit.NavigationCache{
+		"memory": urlkit.NewMemoryNavigationCache(),
+		"redis":  urlkit.NewRedisNavigationCache(redis, "nav:", time.Hour),
+	} {
+		manager := mustManagerFromConfig(t, urlkit.Config{
+			Groups: []urlkit.GroupConfig{
+				{Name: "frontend", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "about": "/about"}},
+				{Name: "docs", BaseURL: "https://docs.example.com", Routes: map[string]string{"intro": "/intro"}},
+			},
+		})
+		snapshots, err := manager.NavigationSnapshots(ctx, cache,
+			urlkit.NavigationSpec{Key: "main", Group: "frontend", Routes: []string{"home", "about"}},
+			urlkit.NavigationSpec{Key: "frontend-tree", Group: "frontend"},
+			urlkit.NavigationSpec{Key: "docs-tree", Group: "docs"},
+		)
+		if err != nil {
+			t.Fatalf("%s: NavigationSnapshots failed: %v", name, err)
+		}
+
+		nodes, err := snapshots.Navigation(ctx, "main")
+		if err != nil || len(nodes) != 2 || nodes[1].URL != "https://example.com/about" {
+			t.Fatalf("%s: unexpected navigation %+v (%v)", name, nodes, err)
+		}
+		if _, ok, _ := cache.Get(ctx, "docs-tree"); !ok {
+			t.Fatalf("%s: expected snapshots to be precomputed", name)
+		}
+
+		if _, err := manager.Group("frontend").AddRoutes(map[string]string{"pricing": "/pricing"}); err != nil {
+			t.Fatalf("%s: AddRoutes failed: %v", name, err)
+		}
+		if _, ok, _ := cache.Get(ctx, "frontend-tree"); ok {
+			t.Fatalf("%s: expected frontend snapshot to be invalidated", name)
+		}
+		if _, ok, _ := cache.Get(ctx, "docs-tree"); !ok {
+			t.Fatalf("%s: expected unrelated snapshot to be kept", name)
+		}
+
+		tree, err := snapshots.Tree(ctx, "frontend-tree")
+		if err != nil || len(tree.Items) != 3 {
+			t.Fatalf("%s: expected recomputed tree with 3 items, got %+v (%v)", name, tree, err)
+		}
+		if _, err := snapshots.Tree(ctx, "main"); err == nil {
+			t.Fatalf("%s: expected error reading a flat navigation as a tree", name)
+		}
+		snapshots.Close()
+
+		if _, err := manager.Group("frontend").AddRoutes(map[string]string{"faq": "/faq"}); err != nil {
+			t.Fatalf("%s: AddRoutes failed: %v", name, err)
+		}
+		if _, ok, _ := cache.Get(ctx, "frontend-tree"); !ok {
+			t.Fatalf("%s: expected closed snapshots to stop invalidating", name)
+		}
+	}
+
+	if redis.ttls["nav:main"] != time.Hour {
+		t.Fatalf("expected redis keys to be prefixed with ttl, got %v", redis.ttls)
+	}
+}
+
+func TestNavigationSnapshotsFollowMetadataChanges(t *testing.T) {
+	ctx := context.Background()
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "frontend", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "about": "/about"}},
+		},
+	})
+	cache := urlkit.NewMemoryNavigationCache()
+	snapshots, err := manager.NavigationSnapshots(ctx, cache,
+		urlkit.NavigationSpec{Key: "main", Group: "frontend", Routes: []string{"home", "about"}},
+	)
+	if err != nil {
+		t.Fatalf("NavigationSnapshots failed: %v", err)
+	}
+	defer snapshots.Close()
+
+	if err := manager.Group("frontend").SetRouteMetadata("about", urlkit.RouteMetadata{Hidden: true}); err != nil {
+		t.Fatalf("SetRouteMetadata failed: %v", err)
+	}
+	nodes, err := snapshots.Navigation(ctx, "main")
+	if err != nil || len(nodes) != 1 || nodes[0].Route != "home" {
+		t.Fatalf("expected hidden route to drop out of the snapshot, got %+v (%v)", nodes, err)
+	}
+
+	if err := manager.Group("frontend").SetSchemePolicy(urlkit.SchemePolicyUpgradeHTTPS); err != nil {
+		t.Fatalf("SetSchemePolicy failed: %v", err)
+	}
+	if _, ok, _ := cache.Get(ctx, "main"); ok {
+		t.Fatal("expected a scheme policy change to invalidate the snapshot")
+	}
+	if _, err := snapshots.Navigation(ctx, "main"); err != nil {
+		t.Fatalf("Navigation failed: %v", err)
+	}
+
+	if err := manager.AddRedirects(urlkit.RedirectRule{From: "/old", Route: "frontend.about"}); err != nil {
+		t.Fatalf("AddRedirects failed: %v", err)
+	}
+	if _, ok, _ := cache.Get(ctx, "main"); ok {
+		t.Fatal("expected a manager-wide event to invalidate the snapshot")
+	}
+}
+
+// racingCache runs mutate before storing the first snapshot, as if the
+// registry changed between rendering and storing it.
+type racingCache struct {
+	*urlkit.MemoryNavigationCache
+	mutate func()
+}
+
+func (c *racingCache) Set(ctx context.Context, key string, value []byte) error {
+	if mutate := c.mutate; mutate != nil {
+		c.mutate = nil
+		mutate()
+	}
+	return c.MemoryNavigationCache.Set(ctx, key, value)
+}
+
+func TestNavigationSnapshotsDropRacingRefresh(t *testing.T) {
+	ctx := context.Background()
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "frontend", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "about": "/about"}},
+		},
+	})
+	cache := &racingCache{MemoryNavigationCache: urlkit.NewMemoryNavigationCache()}
+	cache.mutate = func() {
+		if err := manager.Group("frontend").SetRouteMetadata("about", urlkit.RouteMetadata{Hidden: true}); err != nil {
+			t.Errorf("SetRouteMetadata failed: %v", err)
+		}
+	}
+	snapshots, err := manager.NavigationSnapshots(ctx, cache,
+		urlkit.NavigationSpec{Key: "main", Group: "frontend", Routes: []string{"home", "about"}},
+	)
+	if err != nil {
+		t.Fatalf("NavigationSnapshots failed: %v", err)
+	}
+	defer snapshots.Close()
+
+	if _, ok, _ := cache.Get(ctx, "main"); ok {
+		t.Fatal("expected the render that raced a mutation not to be kept")
+	}
+	nodes, err := snapshots.Navigation(ctx, "main")
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("expected the snapshot to be recomputed, got %+v (%v)", nodes, err)
+	}
+}
@@ -0,0 +1,47 @@
+package urlkit
+
+import (
+	"maps"
+	"slices"
+)
+
+// NavigationAllLocales builds navigation nodes for the given routes across every
+// locale child group of groupBase (e.g. "frontend" -> "frontend.en",
+// "frontend.es"). The result maps each child group name (the locale) to its
+// nodes, in the order of routes (see Navigation for weights). Children that do not define every requested
+// route are not locale siblings and are skipped.
+//
+// The params callback receives the route and locale, allowing per-locale slugs.
+func (m *RouteManager) NavigationAllLocales(groupBase string, routes []string, params func(route, locale string) Params) (map[string][]NavigationNode, error) {
+	base, err := m.GetGroup(groupBase)
+	if err != nil {
+		return nil, err
+	}
+
+	base.mu.RLock()
+	children := maps.Clone(base.children)
+	base.mu.RUnlock()
+
+	result := make(map[string][]NavigationNode, len(children))
+	for _, locale := range slices.Sorted(maps.Keys(children)) {
+		child := children[locale]
+		if child.Validate(routes) != nil {
+			continue
+		}
+
+		var localeParams func(route string) Params
+		if params != nil {
+			localeParams = func(route string) Params {
+				return params(route, locale)
+			}
+		}
+
+		nodes, err := child.Navigation(routes, localeParams)
+		if err != nil {
+			return nil, err
+		}
+		result[locale] = nodes
+	}
+
+	return result, nil
+}
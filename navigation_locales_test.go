@@ -0,0 +1,48 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestNavigationAllLocales(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Groups: []urlkit.GroupConfig{
+					{Name: "en", Path: "/en", Routes: map[string]string{"home": "/", "product": "/products/:slug"}},
+					{Name: "es", Path: "/es", Routes: map[string]string{"home": "/", "product": "/productos/:slug"}},
+					{Name: "assets", Path: "/static", Routes: map[string]string{"logo": "/logo.svg"}},
+				},
+			},
+		},
+	})
+
+	slugs := map[string]string{"en": "shoes", "es": "zapatos"}
+	nav, err := manager.NavigationAllLocales("frontend", []string{"home", "product"}, func(route, locale string) urlkit.Params {
+		if route == "product" {
+			return urlkit.Params{"slug": slugs[locale]}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NavigationAllLocales failed: %v", err)
+	}
+
+	if len(nav) != 2 {
+		t.Fatalf("expected en and es locales only, got %v", nav)
+	}
+	if got := nav["es"][1].URL; got != "https://example.com/es/productos/zapatos" {
+		t.Fatalf("unexpected es product URL: %s", got)
+	}
+	if got := nav["en"][0].FullRoute; got != "frontend.en.home" {
+		t.Fatalf("unexpected en full route: %s", got)
+	}
+
+	if _, err := manager.NavigationAllLocales("missing", []string{"home"}, nil); err == nil {
+		t.Fatal("expected error for unknown group")
+	}
+}
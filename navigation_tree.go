@@ -0,0 +1,54 @@
+package urlkit
+
+import (
+	"maps"
+	"slices"
+)
+
+// NavigationTree is a menu built from every visible route of a group and its
+// descendants.
+type NavigationTree struct {
+	Group    string           `json:"group"`              // Dot-qualified group name
+	Items    []NavigationNode `json:"items,omitempty"`    // Visible routes ordered by weight, then name
+	Children []NavigationTree `json:"children,omitempty"` // Child groups ordered by name
+}
+
+// NavigationTree builds a menu from all routes registered on the group and its
+// descendants, so menu order is driven by route metadata instead of call sites.
+// Routes marked Hidden are skipped; items are ordered by Weight and then by
+// route name. The params callback receives the fully qualified route name
+// (e.g. "frontend.en.about") and may return nil.
+func (u *Group) NavigationTree(params func(fullRoute string) Params) (NavigationTree, error) {
+	u.mu.RLock()
+	routes := slices.Sorted(maps.Keys(u.routes))
+	children := maps.Clone(u.children)
+	u.mu.RUnlock()
+
+	groupName := u.FQN()
+
+	var routeParams func(route string) Params
+	if params != nil {
+		routeParams = func(route string) Params {
+			if groupName == "" {
+				return params(route)
+			}
+			return params(groupName + "." + route)
+		}
+	}
+
+	items, err := u.Navigation(routes, routeParams)
+	if err != nil {
+		return NavigationTree{}, err
+	}
+
+	tree := NavigationTree{Group: groupName, Items: items}
+	for _, name := range slices.Sorted(maps.Keys(children)) {
+		child, err := children[name].NavigationTree(params)
+		if err != nil {
+			return NavigationTree{}, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,75 @@
+package urlkit_test
+
+import (
+	"slices"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestNavigationWeightsAndHidden(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes: map[string]string{
+					"home":    "/",
+					"about":   "/about",
+					"pricing": "/pricing",
+					"admin":   "/admin",
+				},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"home":    {Weight: -10},
+					"pricing": {Weight: 5},
+					"admin":   {Hidden: true},
+				},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:   "blog",
+						Path:   "/blog",
+						Routes: map[string]string{"index": "/", "post": "/:slug"},
+						Metadata: map[string]urlkit.RouteMetadata{
+							"post": {Hidden: true},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	group, err := manager.GetGroup("frontend")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+
+	nodes, err := group.Navigation([]string{"pricing", "about", "admin", "home"}, nil)
+	if err != nil {
+		t.Fatalf("Navigation failed: %v", err)
+	}
+	var order []string
+	for _, node := range nodes {
+		order = append(order, node.Route)
+	}
+	if want := []string{"home", "about", "pricing"}; !slices.Equal(order, want) {
+		t.Fatalf("unexpected navigation order %v, want %v", order, want)
+	}
+
+	tree, err := group.NavigationTree(nil)
+	if err != nil {
+		t.Fatalf("NavigationTree failed: %v", err)
+	}
+	order = order[:0]
+	for _, node := range tree.Items {
+		order = append(order, node.Route)
+	}
+	if want := []string{"home", "about", "pricing"}; !slices.Equal(order, want) {
+		t.Fatalf("unexpected tree order %v, want %v", order, want)
+	}
+	if len(tree.Children) != 1 || tree.Children[0].Group != "frontend.blog" {
+		t.Fatalf("unexpected children: %+v", tree.Children)
+	}
+	if items := tree.Children[0].Items; len(items) != 1 || items[0].URL != "https://example.com/blog/" {
+		t.Fatalf("unexpected blog items: %+v", items)
+	}
+}
@@ -0,0 +1,62 @@
+package oauth2
+
+import (
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2/endpoints"
+)
+
+// ErrUserInfoUnsupported is returned by providers that have no user info
+// endpoint.
+var ErrUserInfoUnsupported = errors.New("provider has no user info endpoint")
+
+// appleIssuer is the issuer of Sign in with Apple ID tokens.
+const appleIssuer = "https://appleid.apple.com"
+
+// AppleDefaultScopes request the user's name and email address.
+var AppleDefaultScopes = []string{"name", "email"}
+
+// AppleProvider is the Provider for Sign in with Apple. Apple has no user
+// info endpoint: user details come from the ID token returned by the token
+// exchange, so GetUserInfo returns ErrUserInfoUnsupported and callers use
+// Client.ValidateIDToken instead.
+//
+// Apple requires the client secret to be a JWT signed with the key
+// registered for the service id, and the name and email scopes require the
+// authorization request to use response_mode=form_post, which the provider
+// sets: the callback is a POST with code and state in the form body.
+type AppleProvider struct {
+	*GenericProvider
+}
+
+// NewAppleProvider creates a pre-configured AppleProvider with
+// AppleDefaultScopes.
+//
+// Example:
+//
+//	provider, err := NewAppleProvider()
+//	client, err := NewClient[MyUserData](provider, serviceID, signedClientSecret, redirectURL, encryptionKey)
+//	_, userData, nonce, err := client.ValidateStateWithNonce(state)
+//	token, err := client.Exchange(ctx, code)
+//	idToken, err := client.ValidateIDToken(ctx, token, nonce) // idToken.Subject, idToken.Email
+func NewAppleProvider() (*AppleProvider, error) {
+	scopes := make([]string, len(AppleDefaultScopes))
+	copy(scopes, AppleDefaultScopes)
+	return &AppleProvider{GenericProvider: &GenericProvider{
+		name:     "apple",
+		scopes:   scopes,
+		endpoint: endpoints.Apple,
+		issuer:   appleIssuer,
+		jwksURL:  appleIssuer + "/auth/keys",
+		authParams: map[string]string{
+			"response_mode": "form_post",
+		},
+	}}, nil
+}
+
+// GetUserInfo implements Provider. It always returns ErrUserInfoUnsupported;
+// use Client.ValidateIDToken on the exchanged token instead.
+func (a *AppleProvider) GetUserInfo(*http.Client) (map[string]any, error) {
+	return nil, ErrUserInfoUnsupported
+}
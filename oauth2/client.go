@@ -52,6 +52,8 @@ type Client[T any] struct {
 	provider      Provider       // Provider implementation for OAuth2 endpoints and user info
 	states        StateStore     // State storage for CSRF protection
 	encryptionKey string         // Encryption key for state data (24-32 characters)
+	options       clientOptions  // Optional behavior configured via ClientOption
+	jwks          *jwksCache     // Provider signing keys for ValidateIDToken
 }
 
 // NewClient creates a new OAuth2 client with the specified provider and configuration.
@@ -63,6 +65,7 @@ type Client[T any] struct {
 //   - clientSecret: OAuth2 client secret from your OAuth app registration
 //   - redirectURL: callback URL where the provider will send authorization results
 //   - encryptionKey: key for encrypting state data (must be 24-32 characters for AES)
+//   - opts: optional ClientOption values (state compression, size limits, etc.)
 //
 // Returns:
 //   - *Client[T]: configured OAuth2 client
@@ -97,7 +100,7 @@ type Client[T any] struct {
 //   - Use HTTPS for redirect URLs in production
 //   - Generate strong encryption keys and store them securely
 //   - Validate redirect URLs match your registered OAuth app configuration
-func NewClient[T any](provider Provider, clientID, clientSecret, redirectURL, encryptionKey string) (*Client[T], error) {
+func NewClient[T any](provider Provider, clientID, clientSecret, redirectURL, encryptionKey string, opts ...ClientOption) (*Client[T], error) {
 	// Validate required parameters
 	if provider == nil {
 		return nil, fmt.Errorf("provider cannot be nil")
@@ -130,11 +133,25 @@ func NewClient[T any](provider Provider, clientID, clientSecret, redirectURL, en
 		Endpoint:     provider.Endpoint(),
 	}
 
+	options := defaultClientOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	var states StateStore = NewMemoryStateStore() // Default to memory store, can be replaced
+	if options.stateStore != nil {
+		states = options.stateStore
+	}
+
 	return &Client[T]{
 		config:        config,
 		provider:      provider,
-		states:        NewMemoryStateStore(), // Default to memory store, can be replaced
+		states:        states,
 		encryptionKey: encryptionKey,
+		options:       options,
+		jwks:          &jwksCache{},
 	}, nil
 }
 
@@ -148,13 +165,15 @@ func NewClient[T any](provider Provider, clientID, clientSecret, redirectURL, en
 // Usage Example:
 //
 //	// Use custom Redis-backed state store
-//	redisStore := NewRedisStateStore(redisClient)
+//	redisStore := NewRedisStateStore(redisClient, "oauth2:", 10*time.Minute)
 //	client.SetStateStore(redisStore)
 //
 //	// Use database-backed state store
-//	dbStore := NewDatabaseStateStore(db)
+//	dbStore, err := NewSQLStateStore(db, 10*time.Minute)
 //	client.SetStateStore(dbStore)
 //
+// The WithStateStore option sets the store when the client is created.
+//
 // Thread Safety:
 //   - Safe to call before starting OAuth2 flows
 //   - Should not be called concurrently with active OAuth2 flows
@@ -187,6 +206,8 @@ func (c *Client[T]) SetStateStore(store StateStore) {
 //   - Requested scopes from provider
 //   - Encrypted state parameter with user data
 //   - Access type and approval prompt for optimal token handling
+//   - A nonce for ValidateIDToken when the provider supports OpenID Connect
+//   - Extra params of providers implementing AuthURLParamsProvider
 //
 // Example:
 //
@@ -219,23 +240,57 @@ func (c *Client[T]) GenerateURL(state string, userData T) (string, error) {
 		state = uuid.New().String()
 	}
 
+	// Bind ID tokens to this request with a nonce kept in the encrypted state
+	var nonce string
+	if _, _, ok := c.oidc(); ok {
+		nonce = uuid.New().String()
+	}
+
 	// Encrypt state with user data
-	encryptedState, err := EncryptState([]byte(c.encryptionKey), state, userData)
+	encryptedState, err := encryptState([]byte(c.encryptionKey), state, nonce, userData, c.options.compressState)
 	if err != nil {
 		return "", fmt.Errorf("failed to encrypt state: %w", err)
 	}
 
+	// Keep the payload server side and only send a reference, if configured
+	if c.options.serverSideState {
+		payloads, ok := c.states.(PayloadStateStore)
+		if !ok {
+			return "", fmt.Errorf("server side state requires a StateStore implementing PayloadStateStore")
+		}
+		reference := serverSideStatePrefix + uuid.New().String()
+		if err := c.options.checkStateSize(reference); err != nil {
+			return "", err
+		}
+		if !payloads.StorePayload(reference, encryptedState) {
+			return "", fmt.Errorf("failed to store state payload")
+		}
+		encryptedState = reference
+	} else if err := c.options.checkStateSize(encryptedState); err != nil {
+		return "", err
+	}
+
 	// Store encrypted state for later validation
 	if !c.states.Store(encryptedState) {
 		return "", fmt.Errorf("failed to store state for validation")
 	}
 
-	// Build authorization URL with encrypted state
-	authURL := c.config.AuthCodeURL(
-		encryptedState,
+	authOptions := []oauth2.AuthCodeOption{
 		oauth2.AccessTypeOffline, // Request refresh tokens
 		oauth2.ApprovalForce,     // Force approval prompt for consistent UX
-	)
+	}
+
+	if nonce != "" {
+		authOptions = append(authOptions, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+	if provider, ok := c.provider.(AuthURLParamsProvider); ok {
+		for key, value := range provider.AuthURLParams() {
+			authOptions = append(authOptions, oauth2.SetAuthURLParam(key, value))
+		}
+	}
+
+	// Build authorization URL with encrypted state
+	authURL := c.config.AuthCodeURL(encryptedState, authOptions...)
 
 	// Clean up URL encoding for better readability
 	authURL = strings.ReplaceAll(authURL, "\\u0026", "&")
@@ -292,15 +347,46 @@ func (c *Client[T]) GenerateURL(state string, userData T) (string, error) {
 //   - ErrDecryptionFailed: invalid encryption key or corrupted state data
 //   - ErrDeserializationFailed: state data doesn't match expected type T
 func (c *Client[T]) ValidateState(encryptedState string) (string, T, error) {
+	state, data, _, err := c.ValidateStateWithNonce(encryptedState)
+	return state, data, err
+}
+
+// ValidateStateWithNonce is like ValidateState and also returns the OIDC
+// nonce GenerateURL sent with the authorization request, to be passed to
+// ValidateIDToken. The nonce is empty for providers without OpenID Connect.
+//
+// Example:
+//
+//	_, userData, nonce, err := client.ValidateStateWithNonce(r.URL.Query().Get("state"))
+//	token, err := client.Exchange(ctx, r.URL.Query().Get("code"))
+//	idToken, err := client.ValidateIDToken(ctx, token, nonce)
+func (c *Client[T]) ValidateStateWithNonce(encryptedState string) (string, T, string, error) {
 	var empty T
 
 	// Validate state exists and remove it (consume-once pattern)
 	if !c.states.Validate(encryptedState) {
-		return "", empty, ErrStateNotFound
+		return "", empty, "", ErrStateNotFound
+	}
+
+	// Resolve server side payload references
+	if strings.HasPrefix(encryptedState, serverSideStatePrefix) {
+		payloads, ok := c.states.(PayloadStateStore)
+		if !ok {
+			return "", empty, "", ErrStateNotFound
+		}
+		payload, ok := payloads.ConsumePayload(encryptedState)
+		if !ok {
+			return "", empty, "", ErrStateNotFound
+		}
+		encryptedState = payload
 	}
 
 	// Decrypt and deserialize state data
-	return DecryptState[T]([]byte(c.encryptionKey), encryptedState)
+	wrapper, err := decryptState[T]([]byte(c.encryptionKey), encryptedState)
+	if err != nil {
+		return "", empty, "", err
+	}
+	return wrapper.OriginalState, wrapper.Data, wrapper.Nonce, nil
 }
 
 // Exchange trades an authorization code for OAuth2 access and refresh tokens.
@@ -351,6 +437,10 @@ func (c *Client[T]) ValidateState(encryptedState string) (string, T, error) {
 //   - OAuth2 provider errors (invalid_grant, etc.)
 //   - Client authentication failures
 func (c *Client[T]) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	if c.options.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.options.httpClient)
+	}
+
 	token, err := c.config.Exchange(ctx, code)
 	if err != nil {
 		return nil, fmt.Errorf("OAuth2 token exchange failed: %w", err)
@@ -420,8 +510,30 @@ func (c *Client[T]) Exchange(ctx context.Context, code string) (*oauth2.Token, e
 //   - Email access typically requires "email" or "userinfo.email" scope
 //   - Check provider documentation for specific scope requirements
 func (c *Client[T]) GetUserInfo(token *oauth2.Token) (map[string]any, error) {
-	// Create authenticated HTTP client
-	httpClient := c.config.Client(context.Background(), token)
+	return c.GetUserInfoCtx(context.Background(), token)
+}
+
+// GetUserInfoCtx is like GetUserInfo but bound to ctx. Requests are retried with
+// exponential backoff on network errors, 5xx responses, and 429 rate limiting
+// (honoring Retry-After), following DefaultRetryPolicy unless WithRetryPolicy is
+// set. WithUserInfoTimeout bounds the whole call, and WithHTTPClient supplies
+// the underlying transport.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+//	defer cancel()
+//	userInfo, err := client.GetUserInfoCtx(ctx, token)
+func (c *Client[T]) GetUserInfoCtx(ctx context.Context, token *oauth2.Token) (map[string]any, error) {
+	if c.options.userInfoTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.options.userInfoTimeout)
+		defer cancel()
+	}
+
+	// Create authenticated HTTP client on top of the retrying base client
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.options.userInfoHTTPClient(ctx))
+	httpClient := c.config.Client(ctx, token)
 
 	// Use provider's GetUserInfo method
 	return c.provider.GetUserInfo(httpClient)
@@ -0,0 +1,99 @@
+package oauth2
+
+import (
+	"fmt"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// RouteRef identifies a urlkit route by group path and route name, with the
+// params needed to build it.
+type RouteRef struct {
+	Group  string        `json:"group" yaml:"group"`
+	Route  string        `json:"route" yaml:"route"`
+	Params urlkit.Params `json:"params,omitempty" yaml:"params,omitempty"`
+	Query  urlkit.Query  `json:"query,omitempty" yaml:"query,omitempty"`
+}
+
+// IsZero reports whether the reference is unset.
+func (r RouteRef) IsZero() bool {
+	return r.Group == "" && r.Route == ""
+}
+
+// resolve builds the referenced route with the given resolver.
+func (r RouteRef) resolve(routes urlkit.Resolver) (string, error) {
+	if r.Group == "" || r.Route == "" {
+		return "", fmt.Errorf("route reference requires both group and route, got %q/%q", r.Group, r.Route)
+	}
+	return routes.Resolve(r.Group, r.Route, r.Params, r.Query)
+}
+
+// Config describes an OAuth2 client whose redirect, authorize, and token URLs
+// may be derived from a urlkit route registry instead of raw strings. When both
+// a raw URL and a route reference are set, the route reference wins.
+type Config struct {
+	ClientID      string `json:"client_id" yaml:"client_id"`
+	ClientSecret  string `json:"client_secret" yaml:"client_secret"`
+	EncryptionKey string `json:"encryption_key" yaml:"encryption_key"`
+
+	// RedirectURL is the raw callback URL, used when RedirectRoute is unset.
+	RedirectURL string `json:"redirect_url,omitempty" yaml:"redirect_url,omitempty"`
+	// RedirectRoute resolves the callback URL from the route registry.
+	RedirectRoute RouteRef `json:"redirect_route,omitzero" yaml:"redirect_route,omitempty"`
+	// AuthorizeRoute overrides the provider's authorization endpoint.
+	AuthorizeRoute RouteRef `json:"authorize_route,omitzero" yaml:"authorize_route,omitempty"`
+	// TokenRoute overrides the provider's token endpoint.
+	TokenRoute RouteRef `json:"token_route,omitzero" yaml:"token_route,omitempty"`
+}
+
+// NewClientFromConfig creates a Client from cfg, resolving any route references
+// against routes (typically a *urlkit.RouteManager). URLs are resolved once at
+// construction time, so the active registry profile at that moment determines the
+// environment the client targets.
+//
+// Example:
+//
+//	client, err := NewClientFromConfig[UserContext](provider, manager, Config{
+//	    ClientID:      os.Getenv("OAUTH_CLIENT_ID"),
+//	    ClientSecret:  os.Getenv("OAUTH_CLIENT_SECRET"),
+//	    EncryptionKey: os.Getenv("OAUTH_STATE_KEY"),
+//	    RedirectRoute: RouteRef{Group: "frontend.auth", Route: "callback", Params: urlkit.Params{"provider": "google"}},
+//	})
+func NewClientFromConfig[T any](provider Provider, routes urlkit.Resolver, cfg Config) (*Client[T], error) {
+	needsRoutes := !cfg.RedirectRoute.IsZero() || !cfg.AuthorizeRoute.IsZero() || !cfg.TokenRoute.IsZero()
+	if needsRoutes && routes == nil {
+		return nil, fmt.Errorf("route resolver cannot be nil when route references are configured")
+	}
+
+	redirectURL := cfg.RedirectURL
+	if !cfg.RedirectRoute.IsZero() {
+		resolved, err := cfg.RedirectRoute.resolve(routes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve redirect route: %w", err)
+		}
+		redirectURL = resolved
+	}
+
+	client, err := NewClient[T](provider, cfg.ClientID, cfg.ClientSecret, redirectURL, cfg.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.AuthorizeRoute.IsZero() {
+		authURL, err := cfg.AuthorizeRoute.resolve(routes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve authorize route: %w", err)
+		}
+		client.config.Endpoint.AuthURL = authURL
+	}
+
+	if !cfg.TokenRoute.IsZero() {
+		tokenURL, err := cfg.TokenRoute.resolve(routes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve token route: %w", err)
+		}
+		client.config.Endpoint.TokenURL = tokenURL
+	}
+
+	return client, nil
+}
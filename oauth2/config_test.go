@@ -0,0 +1,99 @@
+package oauth2
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// TestNewClientFromConfigRoutes tests deriving OAuth2 URLs from a route registry
+func TestNewClientFromConfigRoutes(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("app", "https://app.example.com", map[string]string{
+		"callback": "/auth/:provider/callback",
+	}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, _, err := manager.RegisterGroup("idp", "https://idp.example.com", map[string]string{
+		"authorize": "/oauth/authorize",
+		"token":     "/oauth/token",
+	}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	provider, err := NewGoogleProvider()
+	if err != nil {
+		t.Fatalf("NewGoogleProvider failed: %v", err)
+	}
+
+	client, err := NewClientFromConfig[TestUserData](provider, manager, Config{
+		ClientID:       "client-id",
+		ClientSecret:   "client-secret",
+		EncryptionKey:  "this-is-a-24-char-key-ok",
+		RedirectURL:    "https://ignored.example.com/callback",
+		RedirectRoute:  RouteRef{Group: "app", Route: "callback", Params: urlkit.Params{"provider": "google"}},
+		AuthorizeRoute: RouteRef{Group: "idp", Route: "authorize"},
+		TokenRoute:     RouteRef{Group: "idp", Route: "token"},
+	})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+
+	if client.config.RedirectURL != "https://app.example.com/auth/google/callback" {
+		t.Errorf("unexpected redirect URL: %s", client.config.RedirectURL)
+	}
+	if client.config.Endpoint.TokenURL != "https://idp.example.com/oauth/token" {
+		t.Errorf("unexpected token URL: %s", client.config.Endpoint.TokenURL)
+	}
+
+	authURL, err := client.GenerateURL("state", TestUserData{UserID: "1"})
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	if !strings.HasPrefix(authURL, "https://idp.example.com/oauth/authorize?") {
+		t.Errorf("unexpected authorize URL: %s", authURL)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("failed to parse authorize URL: %v", err)
+	}
+	if got := parsed.Query().Get("redirect_uri"); got != "https://app.example.com/auth/google/callback" {
+		t.Errorf("unexpected redirect_uri: %s", got)
+	}
+}
+
+// TestNewClientFromConfigErrors tests route reference validation
+func TestNewClientFromConfigErrors(t *testing.T) {
+	provider, err := NewGoogleProvider()
+	if err != nil {
+		t.Fatalf("NewGoogleProvider failed: %v", err)
+	}
+
+	base := Config{
+		ClientID:      "client-id",
+		ClientSecret:  "client-secret",
+		EncryptionKey: "this-is-a-24-char-key-ok",
+	}
+
+	withRoute := base
+	withRoute.RedirectRoute = RouteRef{Group: "app", Route: "callback"}
+	if _, err := NewClientFromConfig[TestUserData](provider, nil, withRoute); err == nil {
+		t.Error("expected error for nil resolver")
+	}
+
+	if _, err := NewClientFromConfig[TestUserData](provider, urlkit.NewRouteManager(), withRoute); err == nil {
+		t.Error("expected error for unknown route")
+	}
+
+	partial := base
+	partial.RedirectRoute = RouteRef{Group: "app"}
+	if _, err := NewClientFromConfig[TestUserData](provider, urlkit.NewRouteManager(), partial); err == nil {
+		t.Error("expected error for incomplete route reference")
+	}
+
+	if _, err := NewClientFromConfig[TestUserData](provider, nil, base); err == nil {
+		t.Error("expected error for missing redirect URL")
+	}
+}
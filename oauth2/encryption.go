@@ -2,6 +2,7 @@ package oauth2
 
 import (
 	"bytes"
+	"compress/flate"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -21,16 +22,38 @@ var (
 	ErrDeserializationFailed = errors.New("failed to deserialize state data")
 )
 
-const stateEncryptionPrefix = "v1:"
+const (
+	stateEncryptionPrefix = "v1:"
+	// stateCompressedPrefix marks AES-GCM state whose plaintext is deflate compressed.
+	stateCompressedPrefix = "v2:"
+)
 
 // EncryptState serializes and encrypts the data with the state
 func EncryptState[T any](key []byte, state string, data T) (string, error) {
-	wrapper := struct {
-		OriginalState string `json:"original_state"`
-		Data          T      `json:"data"`
-	}{
+	return encryptState(key, state, "", data, false)
+}
+
+// EncryptStateCompressed is like EncryptState but deflate compresses the
+// serialized payload before encryption when that makes it smaller, which keeps
+// large user payloads within provider state length limits. DecryptState handles
+// both forms transparently.
+func EncryptStateCompressed[T any](key []byte, state string, data T) (string, error) {
+	return encryptState(key, state, "", data, true)
+}
+
+// stateWrapper is the encrypted state payload. Nonce holds the OIDC nonce
+// sent with the authorization request, binding ID tokens to the state.
+type stateWrapper[T any] struct {
+	OriginalState string `json:"original_state"`
+	Data          T      `json:"data"`
+	Nonce         string `json:"nonce,omitempty"`
+}
+
+func encryptState[T any](key []byte, state, oidcNonce string, data T, compress bool) (string, error) {
+	wrapper := stateWrapper[T]{
 		OriginalState: state,
 		Data:          data,
+		Nonce:         oidcNonce,
 	}
 
 	jsonData, err := json.Marshal(wrapper)
@@ -38,6 +61,18 @@ func EncryptState[T any](key []byte, state string, data T) (string, error) {
 		return "", fmt.Errorf("%w: %w", ErrSerializationFailed, err)
 	}
 
+	prefix := stateEncryptionPrefix
+	if compress {
+		compressed, err := deflateBytes(jsonData)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrEncryptionFailed, err)
+		}
+		if len(compressed) < len(jsonData) {
+			jsonData = compressed
+			prefix = stateCompressedPrefix
+		}
+	}
+
 	// Encrypt using AES-GCM for confidentiality and integrity.
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -56,39 +91,45 @@ func EncryptState[T any](key []byte, state string, data T) (string, error) {
 
 	ciphertext := gcm.Seal(nil, nonce, jsonData, nil)
 	encryptedData := append(nonce, ciphertext...)
-	return stateEncryptionPrefix + base64.URLEncoding.EncodeToString(encryptedData), nil
+	return prefix + base64.URLEncoding.EncodeToString(encryptedData), nil
 }
 
 // DecryptState decrypts and deserializes the encrypted state
 func DecryptState[T any](key []byte, state string) (string, T, error) {
-	var empty T
+	wrapper, err := decryptState[T](key, state)
+	return wrapper.OriginalState, wrapper.Data, err
+}
+
+func decryptState[T any](key []byte, state string) (stateWrapper[T], error) {
+	var empty stateWrapper[T]
 
-	isV1 := strings.HasPrefix(state, stateEncryptionPrefix)
+	isCompressed := strings.HasPrefix(state, stateCompressedPrefix)
+	isV1 := isCompressed || strings.HasPrefix(state, stateEncryptionPrefix)
 	payload := state
 	if isV1 {
-		payload = strings.TrimPrefix(state, stateEncryptionPrefix)
+		payload = state[len(stateEncryptionPrefix):]
 	}
 
 	// base64 decode
 	encryptedData, err := base64.URLEncoding.DecodeString(payload)
 	if err != nil {
-		return "", empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+		return empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
 	}
 
 	if isV1 {
 		block, err := aes.NewCipher(key)
 		if err != nil {
-			return "", empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+			return empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
 		}
 
 		gcm, err := cipher.NewGCM(block)
 		if err != nil {
-			return "", empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+			return empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
 		}
 
 		nonceSize := gcm.NonceSize()
 		if len(encryptedData) < nonceSize {
-			return "", empty, fmt.Errorf("%w: encrypted data too short", ErrDecryptionFailed)
+			return empty, fmt.Errorf("%w: encrypted data too short", ErrDecryptionFailed)
 		}
 
 		nonce := encryptedData[:nonceSize]
@@ -96,24 +137,27 @@ func DecryptState[T any](key []byte, state string) (string, T, error) {
 
 		plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 		if err != nil {
-			return "", empty, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+			return empty, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 		}
 
-		var wrapper struct {
-			OriginalState string `json:"original_state"`
-			Data          T      `json:"data"`
+		if isCompressed {
+			if plaintext, err = inflateBytes(plaintext); err != nil {
+				return empty, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+			}
 		}
+
+		var wrapper stateWrapper[T]
 		if err := json.Unmarshal(plaintext, &wrapper); err != nil {
-			return "", empty, fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
+			return empty, fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
 		}
 
-		return wrapper.OriginalState, wrapper.Data, nil
+		return wrapper, nil
 	}
 
 	// Legacy CBC fallback (unauthenticated).
 	// need at leas IV + one block of data
 	if len(encryptedData) < aes.BlockSize*2 {
-		return "", empty, fmt.Errorf("%w: encrypted data too short", ErrDecryptionFailed)
+		return empty, fmt.Errorf("%w: encrypted data too short", ErrDecryptionFailed)
 	}
 
 	// extract IV and chipertext
@@ -122,7 +166,7 @@ func DecryptState[T any](key []byte, state string) (string, T, error) {
 
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return "", empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+		return empty, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
 	}
 
 	plaintext := make([]byte, len(ciphertext))
@@ -131,18 +175,48 @@ func DecryptState[T any](key []byte, state string) (string, T, error) {
 
 	unpaddedData, err := pkcs7Unpad(plaintext)
 	if err != nil {
-		return "", empty, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+		return empty, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 	}
 
-	var wrapper struct {
-		OriginalState string `json:"original_state"`
-		Data          T      `json:"data"`
-	}
+	var wrapper stateWrapper[T]
 	if err := json.Unmarshal(unpaddedData, &wrapper); err != nil {
-		return "", empty, fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
+		return empty, fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
+	}
+
+	return wrapper, nil
+}
+
+// maxInflatedStateSize bounds decompression so a crafted state cannot expand
+// into an arbitrarily large allocation.
+const maxInflatedStateSize = 1 << 20
+
+func deflateBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
 	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func inflateBytes(data []byte) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(data))
+	defer reader.Close()
 
-	return wrapper.OriginalState, wrapper.Data, nil
+	out, err := io.ReadAll(io.LimitReader(reader, maxInflatedStateSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxInflatedStateSize {
+		return nil, fmt.Errorf("decompressed state exceeds %d bytes", maxInflatedStateSize)
+	}
+	return out, nil
 }
 
 func pkcs7Pad(data []byte, blockSize int) []byte {
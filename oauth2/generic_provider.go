@@ -3,6 +3,7 @@ package oauth2
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 
 	"golang.org/x/oauth2"
@@ -25,10 +26,13 @@ import (
 //   - All methods are safe for concurrent use
 //   - Scope modifications are atomic operations
 type GenericProvider struct {
-	name        string          // Provider name (e.g., "github", "custom")
-	scopes      []string        // OAuth2 scopes to request
-	endpoint    oauth2.Endpoint // OAuth2 authorization and token endpoints
-	userInfoURL string          // URL for fetching user information
+	name        string            // Provider name (e.g., "github", "custom")
+	scopes      []string          // OAuth2 scopes to request
+	endpoint    oauth2.Endpoint   // OAuth2 authorization and token endpoints
+	userInfoURL string            // URL for fetching user information
+	issuer      string            // OpenID Connect issuer, empty for plain OAuth2
+	jwksURL     string            // OpenID Connect JWKS URL, empty for plain OAuth2
+	authParams  map[string]string // Extra authorization URL params
 }
 
 // NewGenericProvider creates a new GenericProvider with the specified configuration.
@@ -264,3 +268,50 @@ func (g *GenericProvider) GetUserInfo(client *http.Client) (map[string]any, erro
 
 	return userInfo, nil
 }
+
+// SetOIDC declares the provider's OpenID Connect issuer and JWKS URL, which
+// enables nonces in GenerateURL and Client.ValidateIDToken. Empty values
+// disable OpenID Connect support.
+//
+// Example:
+//
+//	provider.SetOIDC("https://accounts.example.com", "https://accounts.example.com/jwks")
+func (g *GenericProvider) SetOIDC(issuer, jwksURL string) {
+	g.issuer = issuer
+	g.jwksURL = jwksURL
+}
+
+// Issuer returns the OpenID Connect issuer set with SetOIDC. This implements
+// the OIDCProvider interface.
+func (g *GenericProvider) Issuer() string {
+	return g.issuer
+}
+
+// JWKSURL returns the OpenID Connect JWKS URL set with SetOIDC. This
+// implements the OIDCProvider interface.
+func (g *GenericProvider) JWKSURL() string {
+	return g.jwksURL
+}
+
+// SetAuthURLParam adds a query param to every authorization URL generated for
+// the provider. An empty value removes the param.
+//
+// Example:
+//
+//	provider.SetAuthURLParam("prompt", "select_account")
+func (g *GenericProvider) SetAuthURLParam(key, value string) {
+	if value == "" {
+		delete(g.authParams, key)
+		return
+	}
+	if g.authParams == nil {
+		g.authParams = make(map[string]string)
+	}
+	g.authParams[key] = value
+}
+
+// AuthURLParams returns a copy of the params set with SetAuthURLParam. This
+// implements the AuthURLParamsProvider interface.
+func (g *GenericProvider) AuthURLParams() map[string]string {
+	return maps.Clone(g.authParams)
+}
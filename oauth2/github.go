@@ -0,0 +1,30 @@
+package oauth2
+
+import (
+	"golang.org/x/oauth2/endpoints"
+)
+
+// GitHubDefaultScopes grant read access to the user's profile and email
+// addresses.
+var GitHubDefaultScopes = []string{"read:user", "user:email"}
+
+// NewGitHubProvider creates a pre-configured GenericProvider for GitHub OAuth
+// apps, using GitHub's OAuth2 endpoints, the https://api.github.com/user user
+// info URL and GitHubDefaultScopes.
+//
+// GitHub only includes "email" in the user info when the user made it public;
+// the user:email scope allows listing private addresses from
+// https://api.github.com/user/emails.
+//
+// Example:
+//
+//	provider, err := NewGitHubProvider()
+//	client, err := NewClient[MyUserData](provider, clientID, clientSecret, redirectURL, encryptionKey)
+func NewGitHubProvider() (*GenericProvider, error) {
+	return NewGenericProvider(
+		"github",
+		endpoints.GitHub,
+		"https://api.github.com/user",
+		GitHubDefaultScopes,
+	)
+}
@@ -0,0 +1,197 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// idTokenLeeway tolerates clock skew between the provider and this host when
+// checking exp and iat.
+const idTokenLeeway = time.Minute
+
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+var (
+	// ErrOIDCUnsupported is returned by ValidateIDToken when the client's
+	// provider has no OpenID Connect issuer and JWKS URL.
+	ErrOIDCUnsupported = errors.New("provider does not support OpenID Connect")
+	// ErrInvalidIDToken is returned when an ID token has an invalid signature
+	// or invalid iss, aud, azp, exp or iat claims.
+	ErrInvalidIDToken = errors.New("invalid OIDC id_token")
+	// ErrIDTokenNonce is returned when an ID token's nonce is missing or is
+	// not the nonce of the state the token was requested with.
+	ErrIDTokenNonce = errors.New("OIDC id_token nonce is missing or does not match")
+)
+
+// OIDCProvider is implemented by providers that issue OpenID Connect ID
+// tokens. Clients of providers returning a non-empty issuer and JWKS URL
+// embed a nonce in GenerateURL and can verify ID tokens with
+// ValidateIDToken.
+type OIDCProvider interface {
+	Provider
+
+	// Issuer returns the expected "iss" claim of ID tokens.
+	Issuer() string
+
+	// JWKSURL returns the URL of the JSON Web Key Set that signs ID tokens.
+	JWKSURL() string
+}
+
+// IDToken holds the verified claims of an OpenID Connect ID token.
+type IDToken struct {
+	Issuer        string    `json:"iss"`
+	Subject       string    `json:"sub"`
+	Audience      []string  `json:"aud"`
+	Expiry        time.Time `json:"exp"`
+	IssuedAt      time.Time `json:"iat"`
+	Nonce         string    `json:"nonce,omitempty"`
+	Email         string    `json:"email,omitempty"`
+	EmailVerified bool      `json:"email_verified,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Picture       string    `json:"picture,omitempty"`
+	// Claims holds every claim of the token, including the ones above.
+	Claims map[string]any `json:"-"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty string       `json:"azp"`
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+	Picture         string       `json:"picture"`
+}
+
+// flexibleBool accepts JSON booleans and the "true"/"false" strings some
+// providers (e.g. Apple) use for boolean claims.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = flexibleBool(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	value, err := strconv.ParseBool(text)
+	if err != nil {
+		return err
+	}
+	*b = flexibleBool(value)
+	return nil
+}
+
+// ValidateIDToken verifies the OpenID Connect ID token of token, as returned
+// by Exchange, and returns its claims. The signature is checked against the
+// provider's JWKS (cached and refetched on key rotation), iss must match the
+// provider's issuer, aud must contain the client ID, exp and iat must be
+// current, and the nonce must equal nonce, the one ValidateStateWithNonce
+// returned for the callback's state. Since states are consumed, this binds
+// the ID token to the single flow that requested it.
+//
+// Example:
+//
+//	_, userData, nonce, err := client.ValidateStateWithNonce(state)
+//	token, err := client.Exchange(ctx, code)
+//	idToken, err := client.ValidateIDToken(ctx, token, nonce)
+//	if err != nil {
+//		return err
+//	}
+//	log.Printf("signed in %s (%s)", idToken.Subject, idToken.Email)
+//
+// Error Conditions:
+//   - ErrOIDCUnsupported: the provider has no issuer and JWKS URL
+//   - ErrNoIDToken: the token response carried no id_token
+//   - ErrInvalidIDToken: bad signature or claims
+//   - ErrIDTokenNonce: missing nonce or one from another flow
+func (c *Client[T]) ValidateIDToken(ctx context.Context, token *oauth2.Token, nonce string) (*IDToken, error) {
+	issuer, jwksURL, ok := c.oidc()
+	if !ok {
+		return nil, ErrOIDCUnsupported
+	}
+	if token == nil {
+		return nil, ErrNoIDToken
+	}
+	raw, _ := token.Extra("id_token").(string)
+	if raw == "" {
+		return nil, ErrNoIDToken
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return c.jwks.key(ctx, c.options.httpClient, jwksURL, kid)
+		},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(c.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+	var parsed idTokenClaims
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if parsed.AuthorizedParty != "" && parsed.AuthorizedParty != c.config.ClientID {
+		return nil, fmt.Errorf("%w: azp %q is not the client ID", ErrInvalidIDToken, parsed.AuthorizedParty)
+	}
+	if len(parsed.Audience) > 1 && parsed.AuthorizedParty == "" {
+		return nil, fmt.Errorf("%w: azp is required with multiple audiences", ErrInvalidIDToken)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(parsed.Nonce), []byte(nonce)) != 1 {
+		return nil, ErrIDTokenNonce
+	}
+
+	idToken := &IDToken{
+		Issuer:        parsed.Issuer,
+		Subject:       parsed.Subject,
+		Audience:      parsed.Audience,
+		Nonce:         parsed.Nonce,
+		Email:         parsed.Email,
+		EmailVerified: bool(parsed.EmailVerified),
+		Name:          parsed.Name,
+		Picture:       parsed.Picture,
+		Claims:        claims,
+	}
+	if parsed.ExpiresAt != nil {
+		idToken.Expiry = parsed.ExpiresAt.Time
+	}
+	if parsed.IssuedAt != nil {
+		idToken.IssuedAt = parsed.IssuedAt.Time
+	}
+	return idToken, nil
+}
+
+// oidc returns the issuer and JWKS URL of the client's provider, if it
+// supports OpenID Connect.
+func (c *Client[T]) oidc() (string, string, bool) {
+	provider, ok := c.provider.(OIDCProvider)
+	if !ok {
+		return "", "", false
+	}
+	issuer, jwksURL := provider.Issuer(), provider.JWKSURL()
+	return issuer, jwksURL, issuer != "" && jwksURL != ""
+}
@@ -0,0 +1,258 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// oidcTestServer serves a JWKS document whose keys can be rotated.
+type oidcTestServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []map[string]string
+	fetches atomic.Int32
+}
+
+func newOIDCTestServer(t *testing.T) *oidcTestServer {
+	t.Helper()
+	s := &oidcTestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *oidcTestServer) addRSA(kid string, key *rsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	})
+}
+
+func (s *oidcTestServer) addEC(kid string, key *ecdsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = append(s.keys, map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y": base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	})
+}
+
+func newOIDCClient(t *testing.T, server *oidcTestServer) *Client[map[string]string] {
+	t.Helper()
+	provider, err := NewGenericProvider("test", oauth2.Endpoint{
+		AuthURL:  server.URL + "/authorize",
+		TokenURL: server.URL + "/token",
+	}, server.URL+"/userinfo", []string{"openid", "email"})
+	if err != nil {
+		t.Fatalf("NewGenericProvider failed: %v", err)
+	}
+	provider.SetOIDC(server.URL, server.URL+"/jwks")
+
+	client, err := NewClient[map[string]string](provider, "client-id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+// issueNonce runs GenerateURL and the callback's state validation and
+// returns the nonce of the flow.
+func issueNonce(t *testing.T, client *Client[map[string]string]) string {
+	t.Helper()
+	authURL, err := client.GenerateURL("", nil)
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("invalid auth URL: %v", err)
+	}
+	sent := parsed.Query().Get("nonce")
+	if sent == "" {
+		t.Fatalf("expected a nonce in %s", authURL)
+	}
+	_, _, nonce, err := client.ValidateStateWithNonce(parsed.Query().Get("state"))
+	if err != nil {
+		t.Fatalf("ValidateStateWithNonce failed: %v", err)
+	}
+	if nonce != sent {
+		t.Fatalf("state nonce %q does not match the sent nonce %q", nonce, sent)
+	}
+	return nonce
+}
+
+func signIDToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) *oauth2.Token {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	return (&oauth2.Token{AccessToken: "access"}).WithExtra(map[string]any{"id_token": raw})
+}
+
+func idTokenClaimsFor(server *oidcTestServer, nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            server.URL,
+		"sub":            "user-1",
+		"aud":            "client-id",
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          nonce,
+		"email":          "ada@example.com",
+		"email_verified": "true",
+	}
+}
+
+func TestValidateIDToken(t *testing.T) {
+	server := newOIDCTestServer(t)
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server.addRSA("rsa-1", &key.PublicKey)
+	client := newOIDCClient(t, server)
+
+	nonce := issueNonce(t, client)
+	token := signIDToken(t, jwt.SigningMethodRS256, key, "rsa-1", idTokenClaimsFor(server, nonce))
+	idToken, err := client.ValidateIDToken(context.Background(), token, nonce)
+	if err != nil {
+		t.Fatalf("ValidateIDToken failed: %v", err)
+	}
+	if idToken.Subject != "user-1" || idToken.Email != "ada@example.com" || !idToken.EmailVerified ||
+		idToken.Audience[0] != "client-id" || idToken.Expiry.IsZero() || idToken.Claims["sub"] != "user-1" {
+		t.Errorf("unexpected claims %+v", idToken)
+	}
+
+	// A token obtained in another flow does not validate in this one
+	if _, err := client.ValidateIDToken(context.Background(), token, issueNonce(t, client)); !errors.Is(err, ErrIDTokenNonce) {
+		t.Errorf("expected a foreign nonce to fail with ErrIDTokenNonce, got %v", err)
+	}
+	if _, err := client.ValidateIDToken(context.Background(), token, ""); !errors.Is(err, ErrIDTokenNonce) {
+		t.Errorf("expected an empty nonce to fail with ErrIDTokenNonce, got %v", err)
+	}
+	if _, err := client.ValidateIDToken(context.Background(), &oauth2.Token{AccessToken: "access"}, nonce); !errors.Is(err, ErrNoIDToken) {
+		t.Errorf("expected ErrNoIDToken, got %v", err)
+	}
+}
+
+func TestValidateIDTokenRejectsInvalidTokens(t *testing.T) {
+	server := newOIDCTestServer(t)
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server.addRSA("rsa-1", &key.PublicKey)
+	client := newOIDCClient(t, server)
+
+	tests := map[string]struct {
+		key    *rsa.PrivateKey
+		mutate func(jwt.MapClaims)
+		want   error
+	}{
+		"wrong signature": {key: other, want: ErrInvalidIDToken},
+		"wrong issuer":    {key: key, mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, want: ErrInvalidIDToken},
+		"wrong audience":  {key: key, mutate: func(c jwt.MapClaims) { c["aud"] = "other-client" }, want: ErrInvalidIDToken},
+		"expired":         {key: key, mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, want: ErrInvalidIDToken},
+		"foreign azp": {key: key, mutate: func(c jwt.MapClaims) {
+			c["aud"] = []string{"client-id", "other-client"}
+			c["azp"] = "other-client"
+		}, want: ErrInvalidIDToken},
+		"missing nonce": {key: key, mutate: func(c jwt.MapClaims) { delete(c, "nonce") }, want: ErrIDTokenNonce},
+		"unknown nonce": {key: key, mutate: func(c jwt.MapClaims) { c["nonce"] = "forged" }, want: ErrIDTokenNonce},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nonce := issueNonce(t, client)
+			claims := idTokenClaimsFor(server, nonce)
+			if tc.mutate != nil {
+				tc.mutate(claims)
+			}
+			token := signIDToken(t, jwt.SigningMethodRS256, tc.key, "rsa-1", claims)
+			if _, err := client.ValidateIDToken(context.Background(), token, nonce); !errors.Is(err, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateIDTokenKeyRotation(t *testing.T) {
+	server := newOIDCTestServer(t)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server.addRSA("rsa-1", &rsaKey.PublicKey)
+	client := newOIDCClient(t, server)
+
+	nonce := issueNonce(t, client)
+	token := signIDToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", idTokenClaimsFor(server, nonce))
+	if _, err := client.ValidateIDToken(context.Background(), token, nonce); err != nil {
+		t.Fatalf("ValidateIDToken failed: %v", err)
+	}
+
+	// A token signed with a new key triggers a refetch once the refresh
+	// interval has passed
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	server.addEC("ec-1", &ecKey.PublicKey)
+	client.jwks.fetched = time.Now().Add(-2 * jwksRefreshInterval)
+
+	nonce = issueNonce(t, client)
+	token = signIDToken(t, jwt.SigningMethodES256, ecKey, "ec-1", idTokenClaimsFor(server, nonce))
+	if _, err := client.ValidateIDToken(context.Background(), token, nonce); err != nil {
+		t.Fatalf("ValidateIDToken with rotated key failed: %v", err)
+	}
+	if server.fetches.Load() != 2 {
+		t.Errorf("expected 2 JWKS fetches, got %d", server.fetches.Load())
+	}
+
+	// Unknown key IDs do not refetch within the refresh interval
+	nonce = issueNonce(t, client)
+	token = signIDToken(t, jwt.SigningMethodES256, ecKey, "unknown", idTokenClaimsFor(server, nonce))
+	if _, err := client.ValidateIDToken(context.Background(), token, nonce); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("expected ErrInvalidIDToken, got %v", err)
+	}
+	if server.fetches.Load() != 2 {
+		t.Errorf("expected no extra JWKS fetch, got %d", server.fetches.Load())
+	}
+}
+
+func TestValidateIDTokenRequiresOIDCProvider(t *testing.T) {
+	provider, _ := NewGitHubProvider()
+	client, err := NewClient[map[string]string](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	authURL, err := client.GenerateURL("state", nil)
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	if parsed, _ := url.Parse(authURL); parsed.Query().Has("nonce") {
+		t.Errorf("unexpected nonce for a plain OAuth2 provider: %s", authURL)
+	}
+	if _, err := client.ValidateIDToken(context.Background(), &oauth2.Token{}, "nonce"); !errors.Is(err, ErrOIDCUnsupported) {
+		t.Errorf("expected ErrOIDCUnsupported, got %v", err)
+	}
+}
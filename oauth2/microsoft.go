@@ -0,0 +1,34 @@
+package oauth2
+
+import (
+	"golang.org/x/oauth2/endpoints"
+)
+
+// MicrosoftDefaultScopes are the OpenID Connect scopes required by the
+// Microsoft Graph user info endpoint.
+var MicrosoftDefaultScopes = []string{"openid", "profile", "email"}
+
+// NewMicrosoftProvider creates a pre-configured GenericProvider for the
+// Microsoft identity platform that accepts both work or school and personal
+// Microsoft accounts (the "common" tenant). User info is read from
+// https://graph.microsoft.com/oidc/userinfo.
+//
+// Example:
+//
+//	provider, err := NewMicrosoftProvider()
+//	client, err := NewClient[MyUserData](provider, clientID, clientSecret, redirectURL, encryptionKey)
+func NewMicrosoftProvider() (*GenericProvider, error) {
+	return NewMicrosoftProviderForTenant("common")
+}
+
+// NewMicrosoftProviderForTenant is like NewMicrosoftProvider but restricted to
+// tenant: a directory id or domain, or one of "common", "organizations" and
+// "consumers".
+func NewMicrosoftProviderForTenant(tenant string) (*GenericProvider, error) {
+	return NewGenericProvider(
+		"microsoft",
+		endpoints.AzureAD(tenant),
+		"https://graph.microsoft.com/oidc/userinfo",
+		MicrosoftDefaultScopes,
+	)
+}
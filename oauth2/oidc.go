@@ -0,0 +1,153 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// oidcDiscoveryTimeout bounds the discovery request made by NewOIDCProvider.
+const oidcDiscoveryTimeout = 10 * time.Second
+
+// ErrNoIDToken is returned by IDTokenClaims when the token response carried no
+// id_token.
+var ErrNoIDToken = errors.New("OAuth2 token has no id_token")
+
+// OIDCConfiguration is the subset of an OpenID Connect discovery document used
+// to configure a provider.
+type OIDCConfiguration struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	ScopesSupported       []string `json:"scopes_supported"`
+}
+
+// DiscoverOIDC fetches issuerURL's /.well-known/openid-configuration document
+// with client (http.DefaultClient when nil). The document's issuer must match
+// issuerURL, as required by OpenID Connect Discovery.
+func DiscoverOIDC(ctx context.Context, client *http.Client, issuerURL string) (*OIDCConfiguration, error) {
+	if issuerURL == "" {
+		return nil, fmt.Errorf("issuer URL cannot be empty")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	issuer := strings.TrimSuffix(issuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("OIDC discovery failed with status %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	var config OIDCConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery JSON: %w", err)
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery issuer mismatch: expected %q, got %q", issuerURL, config.Issuer)
+	}
+	return &config, nil
+}
+
+// NewOIDCProvider creates a GenericProvider for any OpenID Connect provider by
+// performing discovery against issuerURL. The authorization, token and user
+// info endpoints come from the discovery document, the provider is named
+// after the issuer host, and the default scopes are "openid" plus "profile"
+// and "email" when the provider supports them. The issuer and JWKS URL are
+// set for Client.ValidateIDToken.
+//
+// Example:
+//
+//	provider, err := NewOIDCProvider("https://accounts.example.com")
+//	client, err := NewClient[MyUserData](provider, clientID, clientSecret, redirectURL, encryptionKey)
+func NewOIDCProvider(issuerURL string) (*GenericProvider, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), oidcDiscoveryTimeout)
+	defer cancel()
+	return NewOIDCProviderCtx(ctx, nil, issuerURL)
+}
+
+// NewOIDCProviderCtx is like NewOIDCProvider but performs discovery with ctx
+// and client (http.DefaultClient when nil).
+func NewOIDCProviderCtx(ctx context.Context, client *http.Client, issuerURL string) (*GenericProvider, error) {
+	config, err := DiscoverOIDC(ctx, client, issuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer, err := url.Parse(config.Issuer)
+	if err != nil || issuer.Host == "" {
+		return nil, fmt.Errorf("invalid OIDC issuer %q", config.Issuer)
+	}
+
+	scopes := []string{"openid"}
+	for _, scope := range []string{"profile", "email"} {
+		if len(config.ScopesSupported) == 0 || slices.Contains(config.ScopesSupported, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	provider, err := NewGenericProvider(
+		issuer.Host,
+		oauth2.Endpoint{AuthURL: config.AuthorizationEndpoint, TokenURL: config.TokenEndpoint},
+		config.UserInfoEndpoint,
+		scopes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider.SetOIDC(config.Issuer, config.JWKSURI)
+	return provider, nil
+}
+
+// IDTokenClaims returns the claims of the OpenID Connect ID token included in
+// token's response, such as "sub" and "email".
+//
+// The token signature is not verified. This is only safe for tokens obtained
+// directly from the provider's token endpoint over TLS (as returned by
+// Client.Exchange), which OpenID Connect allows in place of signature
+// validation; never use it on ID tokens received from browsers or other
+// parties. Use Client.ValidateIDToken to verify the token instead.
+func IDTokenClaims(token *oauth2.Token) (map[string]any, error) {
+	if token == nil {
+		return nil, ErrNoIDToken
+	}
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return nil, ErrNoIDToken
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed id_token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id_token payload: %w", err)
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode id_token claims: %w", err)
+	}
+	return claims, nil
+}
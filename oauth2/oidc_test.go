@@ -0,0 +1,87 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func newDiscoveryServer(t *testing.T, issuer *string, scopes []string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 *issuer,
+			"authorization_endpoint": *issuer + "/authorize",
+			"token_endpoint":         *issuer + "/token",
+			"userinfo_endpoint":      *issuer + "/userinfo",
+			"jwks_uri":               *issuer + "/jwks",
+			"scopes_supported":       scopes,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewOIDCProvider(t *testing.T) {
+	var issuer string
+	server := newDiscoveryServer(t, &issuer, []string{"openid", "email", "offline_access"})
+	issuer = server.URL
+
+	provider, err := NewOIDCProvider(server.URL + "/")
+	if err != nil {
+		t.Fatalf("NewOIDCProvider failed: %v", err)
+	}
+	if want := server.Listener.Addr().String(); provider.Name() != want {
+		t.Errorf("provider name = %q, want %q", provider.Name(), want)
+	}
+	if provider.Endpoint().TokenURL != server.URL+"/token" || provider.UserInfoURL() != server.URL+"/userinfo" {
+		t.Errorf("unexpected endpoints %+v, %s", provider.Endpoint(), provider.UserInfoURL())
+	}
+	if provider.Issuer() != server.URL || provider.JWKSURL() != server.URL+"/jwks" {
+		t.Errorf("unexpected OIDC settings %q, %q", provider.Issuer(), provider.JWKSURL())
+	}
+	if want := []string{"openid", "email"}; !reflect.DeepEqual(provider.Scopes(), want) {
+		t.Errorf("scopes = %v, want %v", provider.Scopes(), want)
+	}
+
+	issuer = "https://evil.example.com"
+	if _, err := NewOIDCProviderCtx(context.Background(), server.Client(), server.URL); err == nil {
+		t.Error("expected issuer mismatch error")
+	}
+	if _, err := NewOIDCProvider(server.URL + "/missing"); err == nil {
+		t.Error("expected discovery error")
+	}
+}
+
+func TestIDTokenClaims(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"001234.abcd","email":"user@example.com"}`))
+	token := (&oauth2.Token{AccessToken: "access"}).WithExtra(map[string]any{"id_token": "header." + payload + ".signature"})
+
+	claims, err := IDTokenClaims(token)
+	if err != nil {
+		t.Fatalf("IDTokenClaims failed: %v", err)
+	}
+	if claims["sub"] != "001234.abcd" || claims["email"] != "user@example.com" {
+		t.Errorf("unexpected claims %v", claims)
+	}
+
+	if _, err := IDTokenClaims(&oauth2.Token{AccessToken: "access"}); !errors.Is(err, ErrNoIDToken) {
+		t.Errorf("expected ErrNoIDToken, got %v", err)
+	}
+	malformed := (&oauth2.Token{}).WithExtra(map[string]any{"id_token": "not-a-jwt"})
+	if _, err := IDTokenClaims(malformed); err == nil {
+		t.Error("expected error for malformed id_token")
+	}
+}
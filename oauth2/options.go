@@ -0,0 +1,81 @@
+package oauth2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrStateTooLarge is returned by GenerateURL when the encoded state parameter
+// exceeds the configured maximum size.
+var ErrStateTooLarge = errors.New("state parameter exceeds maximum size")
+
+// serverSideStatePrefix marks state values that are references to payloads kept
+// in a PayloadStateStore rather than the encrypted payload itself.
+const serverSideStatePrefix = "ref:"
+
+// ClientOption customizes a Client created by NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	compressState   bool
+	maxStateSize    int
+	serverSideState bool
+	httpClient      *http.Client
+	userInfoTimeout time.Duration
+	retry           *RetryPolicy
+	stateStore      StateStore
+}
+
+func defaultClientOptions() clientOptions {
+	return clientOptions{compressState: true}
+}
+
+// WithStateCompression toggles deflate compression of the state payload before
+// encryption. Compression is enabled by default and only applied when it
+// shrinks the payload.
+func WithStateCompression(enabled bool) ClientOption {
+	return func(o *clientOptions) {
+		o.compressState = enabled
+	}
+}
+
+// WithMaxStateSize makes GenerateURL fail with ErrStateTooLarge when the state
+// parameter placed in the authorization URL is longer than maxBytes. Zero or a
+// negative value disables the check.
+func WithMaxStateSize(maxBytes int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxStateSize = maxBytes
+	}
+}
+
+// WithServerSideState keeps the encrypted payload in the client's StateStore and
+// places only a short reference ID in the authorization URL. The StateStore must
+// implement PayloadStateStore (MemoryStateStore does).
+func WithServerSideState() ClientOption {
+	return func(o *clientOptions) {
+		o.serverSideState = true
+	}
+}
+
+// WithStateStore replaces the default MemoryStateStore. Multi-instance
+// deployments need a shared store such as RedisStateStore or SQLStateStore so
+// the callback can be validated by any instance.
+func WithStateStore(store StateStore) ClientOption {
+	return func(o *clientOptions) {
+		o.stateStore = store
+	}
+}
+
+func (o clientOptions) checkStateSize(state string) error {
+	if o.maxStateSize <= 0 || len(state) <= o.maxStateSize {
+		return nil
+	}
+
+	hint := "reduce the user data or enable WithServerSideState"
+	if o.serverSideState {
+		hint = "reduce the state identifier length"
+	}
+	return fmt.Errorf("%w: state is %d bytes, limit is %d; %s", ErrStateTooLarge, len(state), o.maxStateSize, hint)
+}
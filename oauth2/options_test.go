@@ -0,0 +1,121 @@
+package oauth2
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type largeUserData struct {
+	UserID      string   `json:"user_id"`
+	Permissions []string `json:"permissions"`
+}
+
+func newLargeUserData() largeUserData {
+	permissions := make([]string, 0, 60)
+	for range 60 {
+		permissions = append(permissions, "workspace:documents:read")
+	}
+	return largeUserData{UserID: "user-123", Permissions: permissions}
+}
+
+func stateFromAuthURL(t *testing.T, authURL string) string {
+	t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("failed to parse auth URL: %v", err)
+	}
+	return parsed.Query().Get("state")
+}
+
+// TestClientStateCompression tests transparent state compression
+func TestClientStateCompression(t *testing.T) {
+	provider, _ := NewGoogleProvider()
+	data := newLargeUserData()
+
+	plain, err := NewClient[largeUserData](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok", WithStateCompression(false))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	compressed, err := NewClient[largeUserData](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	plainURL, err := plain.GenerateURL("state", data)
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	compressedURL, err := compressed.GenerateURL("state", data)
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+
+	plainState := stateFromAuthURL(t, plainURL)
+	compressedState := stateFromAuthURL(t, compressedURL)
+	if !strings.HasPrefix(compressedState, stateCompressedPrefix) {
+		t.Fatalf("expected compressed state prefix, got %q", compressedState[:3])
+	}
+	if len(compressedState) >= len(plainState) {
+		t.Fatalf("expected compressed state (%d) to be smaller than plain state (%d)", len(compressedState), len(plainState))
+	}
+
+	original, got, err := compressed.ValidateState(compressedState)
+	if err != nil {
+		t.Fatalf("ValidateState failed: %v", err)
+	}
+	if original != "state" || len(got.Permissions) != len(data.Permissions) {
+		t.Fatalf("unexpected decoded state: %q %+v", original, got)
+	}
+}
+
+// TestClientMaxStateSize tests the state size guard
+func TestClientMaxStateSize(t *testing.T) {
+	provider, _ := NewGoogleProvider()
+	client, err := NewClient[largeUserData](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok",
+		WithStateCompression(false), WithMaxStateSize(256))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.GenerateURL("state", newLargeUserData())
+	if !errors.Is(err, ErrStateTooLarge) {
+		t.Fatalf("expected ErrStateTooLarge, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "limit is 256") {
+		t.Fatalf("expected descriptive error, got %v", err)
+	}
+}
+
+// TestClientServerSideState tests keeping the payload in the state store
+func TestClientServerSideState(t *testing.T) {
+	provider, _ := NewGoogleProvider()
+	client, err := NewClient[largeUserData](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok",
+		WithServerSideState(), WithMaxStateSize(64))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	authURL, err := client.GenerateURL("state", newLargeUserData())
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+
+	state := stateFromAuthURL(t, authURL)
+	if !strings.HasPrefix(state, serverSideStatePrefix) {
+		t.Fatalf("expected reference state, got %q", state)
+	}
+
+	original, data, err := client.ValidateState(state)
+	if err != nil {
+		t.Fatalf("ValidateState failed: %v", err)
+	}
+	if original != "state" || data.UserID != "user-123" {
+		t.Fatalf("unexpected decoded state: %q %+v", original, data)
+	}
+
+	if _, _, err := client.ValidateState(state); !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("expected replay to fail with ErrStateNotFound, got %v", err)
+	}
+}
@@ -130,3 +130,13 @@ type Provider interface {
 	//   name := userInfo["name"].(string)
 	GetUserInfo(client *http.Client) (map[string]any, error)
 }
+
+// AuthURLParamsProvider is implemented by providers that need extra query
+// params on the authorization URL (e.g. Apple's response_mode=form_post).
+// Client.GenerateURL adds them to every authorization URL.
+type AuthURLParamsProvider interface {
+	Provider
+
+	// AuthURLParams returns the extra authorization URL params.
+	AuthURLParams() map[string]string
+}
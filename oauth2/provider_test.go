@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"golang.org/x/oauth2"
@@ -557,3 +558,59 @@ func BenchmarkGenericProviderSetScopes(b *testing.B) {
 		provider.SetScopes(scopes)
 	}
 }
+
+// TestBuiltinProviders tests the GitHub, Microsoft and Apple providers
+func TestBuiltinProviders(t *testing.T) {
+	var _ Provider = (*AppleProvider)(nil)
+
+	github, err := NewGitHubProvider()
+	if err != nil {
+		t.Fatalf("NewGitHubProvider failed: %v", err)
+	}
+	if github.Name() != "github" || github.UserInfoURL() != "https://api.github.com/user" {
+		t.Errorf("unexpected GitHub provider %q (%s)", github.Name(), github.UserInfoURL())
+	}
+	if !reflect.DeepEqual(github.Scopes(), GitHubDefaultScopes) {
+		t.Errorf("GitHub provider default scopes = %v, want %v", github.Scopes(), GitHubDefaultScopes)
+	}
+
+	microsoft, err := NewMicrosoftProviderForTenant("contoso.onmicrosoft.com")
+	if err != nil {
+		t.Fatalf("NewMicrosoftProviderForTenant failed: %v", err)
+	}
+	if want := "https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/v2.0/authorize"; microsoft.Endpoint().AuthURL != want {
+		t.Errorf("Microsoft auth URL = %q, want %q", microsoft.Endpoint().AuthURL, want)
+	}
+	if common, _ := NewMicrosoftProvider(); common.Endpoint().TokenURL != "https://login.microsoftonline.com/common/oauth2/v2.0/token" {
+		t.Errorf("unexpected Microsoft token URL %q", common.Endpoint().TokenURL)
+	}
+
+	apple, err := NewAppleProvider()
+	if err != nil {
+		t.Fatalf("NewAppleProvider failed: %v", err)
+	}
+	if apple.Name() != "apple" || apple.Endpoint().TokenURL != "https://appleid.apple.com/auth/token" {
+		t.Errorf("unexpected Apple provider %q (%s)", apple.Name(), apple.Endpoint().TokenURL)
+	}
+	if _, err := apple.GetUserInfo(http.DefaultClient); err != ErrUserInfoUnsupported {
+		t.Errorf("expected ErrUserInfoUnsupported, got %v", err)
+	}
+
+	// The name and email scopes require form_post responses
+	client, err := NewClient[map[string]string](apple, "service-id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	authURL, err := client.GenerateURL("state", nil)
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	if !strings.Contains(authURL, "response_mode=form_post") {
+		t.Errorf("expected response_mode=form_post in %s", authURL)
+	}
+
+	apple.SetAuthURLParam("response_mode", "")
+	if params := apple.AuthURLParams(); len(params) != 0 {
+		t.Errorf("expected the param to be removed, got %v", params)
+	}
+}
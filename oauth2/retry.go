@@ -0,0 +1,174 @@
+package oauth2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how user info requests are retried. Idempotent requests
+// (GET, HEAD, OPTIONS, or any request carrying an Idempotency-Key header) are
+// retried on network errors, 5xx responses, and 429 Too Many Requests; others,
+// such as token refresh POSTs, are sent once so a rotating refresh token is
+// never spent twice. A Retry-After
+// header on 429/503 responses takes precedence over the computed backoff.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first; values < 1 mean 1
+	InitialBackoff time.Duration // delay before the first retry, doubled after each attempt
+	MaxBackoff     time.Duration // upper bound for any single delay, including Retry-After
+}
+
+// DefaultRetryPolicy is used by GetUserInfoCtx unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// WithHTTPClient sets the base HTTP client used for token exchange and user info
+// requests, e.g. to route through a proxy or inject a test transport. The OAuth2
+// authorization header is layered on top of its transport.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithUserInfoTimeout bounds the total time GetUserInfoCtx may spend, including
+// retries. Zero disables the timeout.
+func WithUserInfoTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.userInfoTimeout = timeout
+	}
+}
+
+// WithRetryPolicy overrides DefaultRetryPolicy for user info requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retry = &policy
+	}
+}
+
+// retryTransport retries idempotent requests and binds every request to ctx,
+// since Provider.GetUserInfo issues requests without a context.
+type retryTransport struct {
+	ctx    context.Context
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(t.ctx)
+
+	attempts := max(t.policy.MaxAttempts, 1)
+	if !idempotent(req) || (req.Body != nil && req.GetBody == nil) {
+		attempts = 1 // not safe to repeat, or body cannot be replayed
+	}
+
+	backoff := t.policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= attempts || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := t.delay(resp, backoff)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-t.ctx.Done():
+			timer.Stop()
+			return nil, t.ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+func (t *retryTransport) delay(resp *http.Response, backoff time.Duration) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			backoff = wait
+		}
+	}
+	if t.policy.MaxBackoff > 0 && backoff > t.policy.MaxBackoff {
+		return t.policy.MaxBackoff
+	}
+	return backoff
+}
+
+// idempotent reports whether req may be sent more than once, following the
+// rules net/http applies to its own retries.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	_, key := req.Header["Idempotency-Key"]
+	_, xKey := req.Header["X-Idempotency-Key"]
+	return key || xKey
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// userInfoHTTPClient returns the base client for user info requests with the
+// retry transport installed and bound to ctx.
+func (o clientOptions) userInfoHTTPClient(ctx context.Context) *http.Client {
+	base := o.httpClient
+	if base == nil {
+		base = http.DefaultClient
+	}
+
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	policy := DefaultRetryPolicy
+	if o.retry != nil {
+		policy = *o.retry
+	}
+
+	clone := *base
+	clone.Transport = &retryTransport{ctx: ctx, base: transport, policy: policy}
+	return &clone
+}
@@ -0,0 +1,155 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type countingTransport struct {
+	calls atomic.Int32
+	base  http.RoundTripper
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls.Add(1)
+	return c.base.RoundTrip(req)
+}
+
+func newUserInfoClient(t *testing.T, userInfoURL string, opts ...ClientOption) *Client[TestUserData] {
+	t.Helper()
+	provider, err := NewGenericProvider("test", oauth2.Endpoint{
+		AuthURL:  userInfoURL + "/auth",
+		TokenURL: userInfoURL + "/token",
+	}, userInfoURL, []string{"profile"})
+	if err != nil {
+		t.Fatalf("NewGenericProvider failed: %v", err)
+	}
+
+	client, err := NewClient[TestUserData](provider, "id", "secret", "http://localhost/callback", "this-is-a-24-char-key-ok", opts...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+// TestGetUserInfoCtxRetries tests retry on 5xx and 429 responses
+func TestGetUserInfoCtxRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"id": "user123"})
+		}
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{base: http.DefaultTransport}
+	client := newUserInfoClient(t, server.URL,
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+
+	userInfo, err := client.GetUserInfoCtx(context.Background(), &oauth2.Token{AccessToken: "token"})
+	if err != nil {
+		t.Fatalf("GetUserInfoCtx failed: %v", err)
+	}
+	if userInfo["id"] != "user123" {
+		t.Errorf("unexpected user info: %v", userInfo)
+	}
+	if got := transport.calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts through injected client, got %d", got)
+	}
+}
+
+// TestGetUserInfoCtxGivesUp tests that retries stop after MaxAttempts
+func TestGetUserInfoCtxGivesUp(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newUserInfoClient(t, server.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if _, err := client.GetUserInfoCtx(context.Background(), &oauth2.Token{AccessToken: "token"}); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+// TestGetUserInfoCtxTimeout tests the configured timeout
+func TestGetUserInfoCtxTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := newUserInfoClient(t, server.URL, WithUserInfoTimeout(50*time.Millisecond), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	start := time.Now()
+	_, err := client.GetUserInfoCtx(context.Background(), &oauth2.Token{AccessToken: "token"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timeout not honored, took %v", elapsed)
+	}
+}
+
+// TestGetUserInfoCtxDoesNotRetryRefresh tests that token refresh POSTs are sent
+// once, so a rotating refresh token is never spent twice
+func TestGetUserInfoCtxDoesNotRetryRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			refreshes.Add(1)
+		}
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	provider, err := NewGenericProvider("test", oauth2.Endpoint{
+		AuthURL:   server.URL + "/auth",
+		TokenURL:  server.URL + "/token",
+		AuthStyle: oauth2.AuthStyleInHeader,
+	}, server.URL, []string{"profile"})
+	if err != nil {
+		t.Fatalf("NewGenericProvider failed: %v", err)
+	}
+	client, err := NewClient[TestUserData](provider, "id", "secret", "http://localhost/callback", "this-is-a-24-char-key-ok",
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	expired := &oauth2.Token{AccessToken: "token", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}
+	if _, err := client.GetUserInfoCtx(context.Background(), expired); err == nil {
+		t.Fatal("expected the refresh to fail")
+	}
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("expected 1 refresh attempt, got %d", got)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, server.URL, nil)
+	req.Header.Set("Idempotency-Key", "key-1")
+	if !idempotent(req) {
+		t.Error("expected a POST with an Idempotency-Key to be idempotent")
+	}
+}
@@ -0,0 +1,126 @@
+package oauth2
+
+import (
+	"fmt"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// Standard route names registered by RegisterCallbackRoutes.
+const (
+	CallbackRouteName      = "auth.callback"
+	CallbackErrorRouteName = "auth.callback.error"
+)
+
+// Default route templates registered by RegisterCallbackRoutes. Both take the
+// provider name as the :provider param.
+const (
+	DefaultCallbackPath      = "/auth/:provider/callback"
+	DefaultCallbackErrorPath = "/auth/:provider/callback/error"
+)
+
+// CallbackRouteOption customizes RegisterCallbackRoutes.
+type CallbackRouteOption func(*callbackRouteOptions)
+
+type callbackRouteOptions struct {
+	callbackPath string
+	errorPath    string
+}
+
+// WithCallbackPaths overrides the callback and error route templates. Templates
+// may reference :provider, which is filled with the provider name.
+func WithCallbackPaths(callbackPath, errorPath string) CallbackRouteOption {
+	return func(o *callbackRouteOptions) {
+		if callbackPath != "" {
+			o.callbackPath = callbackPath
+		}
+		if errorPath != "" {
+			o.errorPath = errorPath
+		}
+	}
+}
+
+// CallbackRoutes gives access to the routes registered for a provider.
+type CallbackRoutes struct {
+	Group    *urlkit.Group
+	Provider string
+}
+
+// Callback returns a builder for the provider's callback route with the
+// provider param already set.
+func (r *CallbackRoutes) Callback() *urlkit.Builder {
+	return r.Group.Builder(CallbackRouteName).WithParam("provider", r.Provider)
+}
+
+// Error returns a builder for the provider's error route with the provider param
+// already set.
+func (r *CallbackRoutes) Error() *urlkit.Builder {
+	return r.Group.Builder(CallbackErrorRouteName).WithParam("provider", r.Provider)
+}
+
+// RedirectRoute returns a RouteRef for the callback route, suitable for
+// Config.RedirectRoute.
+func (r *CallbackRoutes) RedirectRoute() RouteRef {
+	return RouteRef{
+		Group:  r.Group.FQN(),
+		Route:  CallbackRouteName,
+		Params: urlkit.Params{"provider": r.Provider},
+	}
+}
+
+// RegisterCallbackRoutes registers the standard OAuth2 callback and error routes
+// ("auth.callback" and "auth.callback.error") under groupPath, creating the group
+// if needed, so the redirect URI sent to the provider always matches the route
+// registry. Registering the same routes again is a no-op; conflicting templates
+// follow the manager's conflict policy.
+//
+// Example:
+//
+//	routes, err := oauth2.RegisterCallbackRoutes(manager, "frontend", provider)
+//	client, err := oauth2.NewClientFromConfig[UserContext](provider, manager, oauth2.Config{
+//	    ClientID:      clientID,
+//	    ClientSecret:  clientSecret,
+//	    EncryptionKey: key,
+//	    RedirectRoute: routes.RedirectRoute(),
+//	})
+func RegisterCallbackRoutes(manager *urlkit.RouteManager, groupPath string, provider Provider, opts ...CallbackRouteOption) (*CallbackRoutes, error) {
+	if manager == nil {
+		return nil, fmt.Errorf("route manager cannot be nil")
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("provider cannot be nil")
+	}
+
+	options := callbackRouteOptions{
+		callbackPath: DefaultCallbackPath,
+		errorPath:    DefaultCallbackErrorPath,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	group, err := manager.EnsureGroup(groupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure callback group: %w", err)
+	}
+
+	routes := map[string]string{
+		CallbackRouteName:      options.callbackPath,
+		CallbackErrorRouteName: options.errorPath,
+	}
+	for name, path := range routes {
+		if existing, err := group.Route(name); err == nil && existing == path {
+			delete(routes, name)
+		}
+	}
+
+	if len(routes) > 0 {
+		if _, err := group.AddRoutes(routes); err != nil {
+			return nil, fmt.Errorf("failed to register callback routes: %w", err)
+		}
+	}
+
+	return &CallbackRoutes{Group: group, Provider: provider.Name()}, nil
+}
@@ -0,0 +1,88 @@
+package oauth2
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// TestRegisterCallbackRoutes tests callback route registration and builders
+func TestRegisterCallbackRoutes(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("frontend", "https://app.example.com", nil); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	provider, err := NewGoogleProvider()
+	if err != nil {
+		t.Fatalf("NewGoogleProvider failed: %v", err)
+	}
+
+	routes, err := RegisterCallbackRoutes(manager, "frontend", provider)
+	if err != nil {
+		t.Fatalf("RegisterCallbackRoutes failed: %v", err)
+	}
+
+	callbackURL, err := routes.Callback().Build()
+	if err != nil {
+		t.Fatalf("Callback build failed: %v", err)
+	}
+	if callbackURL != "https://app.example.com/auth/google/callback" {
+		t.Errorf("unexpected callback URL: %s", callbackURL)
+	}
+
+	errorURL, err := routes.Error().WithQuery("reason", "denied").Build()
+	if err != nil {
+		t.Fatalf("Error build failed: %v", err)
+	}
+	if errorURL != "https://app.example.com/auth/google/callback/error?reason=denied" {
+		t.Errorf("unexpected error URL: %s", errorURL)
+	}
+
+	if _, err := RegisterCallbackRoutes(manager, "frontend", provider); err != nil {
+		t.Fatalf("re-registering identical routes should succeed: %v", err)
+	}
+
+	client, err := NewClientFromConfig[TestUserData](provider, manager, Config{
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		EncryptionKey: "this-is-a-24-char-key-ok",
+		RedirectRoute: routes.RedirectRoute(),
+	})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig failed: %v", err)
+	}
+	if client.config.RedirectURL != callbackURL {
+		t.Errorf("redirect URL %s does not match callback route %s", client.config.RedirectURL, callbackURL)
+	}
+}
+
+// TestRegisterCallbackRoutesCustomPaths tests overriding the route templates
+func TestRegisterCallbackRoutesCustomPaths(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", nil); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	provider, _ := NewGoogleProvider()
+	routes, err := RegisterCallbackRoutes(manager, "api.oauth", provider,
+		WithCallbackPaths("/integrations/:provider/done", "/integrations/:provider/failed"))
+	if err != nil {
+		t.Fatalf("RegisterCallbackRoutes failed: %v", err)
+	}
+
+	callbackURL, err := manager.Resolve("api.oauth", CallbackRouteName, urlkit.Params{"provider": "google"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if callbackURL != "https://api.example.com/oauth/integrations/google/done" {
+		t.Errorf("unexpected callback URL: %s", callbackURL)
+	}
+	if routes.Group.FQN() != "api.oauth" {
+		t.Errorf("unexpected group: %s", routes.Group.FQN())
+	}
+
+	if _, err := RegisterCallbackRoutes(nil, "api", provider); err == nil {
+		t.Error("expected error for nil manager")
+	}
+}
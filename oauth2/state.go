@@ -1,10 +1,18 @@
 package oauth2
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sync"
+	"time"
 )
 
+// stateStoreTimeout bounds each operation of the shared state stores, whose
+// StateStore methods take no context.
+const stateStoreTimeout = 5 * time.Second
+
 // StateStore defines the interface for managing OAuth state tokens during the authorization flow.
 // State tokens are used to prevent CSRF attacks by ensuring that the authorization response
 // corresponds to a request that was initiated by the same client.
@@ -39,26 +47,58 @@ type StateStore interface {
 	Debug()
 }
 
+// PayloadStateStore is a StateStore that can also hold the encrypted state
+// payload server side, keyed by a short reference ID. It is required by
+// WithServerSideState.
+type PayloadStateStore interface {
+	StateStore
+
+	// StorePayload saves payload under id.
+	// Returns true if the payload was successfully stored, false otherwise.
+	StorePayload(id, payload string) bool
+
+	// ConsumePayload returns the payload stored under id and removes it.
+	// Returns false if no payload exists for id.
+	ConsumePayload(id string) (string, bool)
+}
+
+// StateCleaner is implemented by state stores whose expired entries must be
+// removed explicitly. Applications call Cleanup periodically, e.g. from a
+// ticker; stores with native expiration such as RedisStateStore do not need it.
+type StateCleaner interface {
+	// Cleanup removes expired states and payloads and returns how many
+	// entries were removed.
+	Cleanup(ctx context.Context) (int, error)
+}
+
 // Compile-time check to ensure MemoryStateStore implements StateStore interface
 var _ StateStore = &MemoryStateStore{}
 
+var _ PayloadStateStore = &MemoryStateStore{}
+
+var _ StateCleaner = &MemoryStateStore{}
+
 // MemoryStateStore is an in-memory implementation of StateStore interface.
 // It stores state tokens in a map and provides thread-safe operations using a mutex.
 //
 // This implementation is suitable for development, testing, and single-instance
 // applications. For distributed applications or high-availability scenarios,
-// consider implementing a persistent StateStore using Redis, database, or
-// other shared storage systems.
+// use a shared store such as RedisStateStore or SQLStateStore.
 //
 // Security considerations:
 // - States are stored in memory and will be lost on application restart
-// - No automatic expiration - states persist until validated or app restart
+// - No expiration by default (see NewMemoryStateStoreWithTTL)
 // - All operations are protected by mutex for thread-safety
 // - States are permanently removed after validation (consume-once pattern)
 type MemoryStateStore struct {
-	// states maps state tokens to empty structs for memory efficiency
-	// Using struct{} as value type minimizes memory overhead
-	states map[string]struct{}
+	// states maps state tokens to their expiry (zero when they never expire)
+	states map[string]time.Time
+
+	// payloads maps reference IDs to encrypted state payloads (see PayloadStateStore)
+	payloads map[string]memoryPayload
+
+	// ttl is how long entries remain valid; zero disables expiration
+	ttl time.Duration
 
 	// mx protects concurrent access to the states map
 	// All public methods must acquire this mutex before accessing states
@@ -84,10 +124,37 @@ type MemoryStateStore struct {
 //	}
 func NewMemoryStateStore() *MemoryStateStore {
 	return &MemoryStateStore{
-		states: make(map[string]struct{}),
+		states:   make(map[string]time.Time),
+		payloads: make(map[string]memoryPayload),
 	}
 }
 
+// NewMemoryStateStoreWithTTL creates an in-memory state store whose states and
+// payloads expire ttl after being stored. Expired entries fail validation and
+// are removed by Cleanup.
+func NewMemoryStateStoreWithTTL(ttl time.Duration) *MemoryStateStore {
+	store := NewMemoryStateStore()
+	store.ttl = ttl
+	return store
+}
+
+type memoryPayload struct {
+	payload string
+	expires time.Time
+}
+
+// expiry returns the expiry of an entry stored now.
+func (s *MemoryStateStore) expiry() time.Time {
+	if s.ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(s.ttl)
+}
+
+func expired(expires, now time.Time) bool {
+	return !expires.IsZero() && !now.Before(expires)
+}
+
 // Store saves a state token for later validation.
 // This method is thread-safe and can be called concurrently.
 //
@@ -104,7 +171,7 @@ func (s *MemoryStateStore) Store(state string) bool {
 	s.mx.Lock()
 	defer s.mx.Unlock()
 
-	s.states[state] = struct{}{}
+	s.states[state] = s.expiry()
 	return true
 }
 
@@ -126,15 +193,75 @@ func (s *MemoryStateStore) Validate(state string) bool {
 	s.mx.Lock()
 	defer s.mx.Unlock()
 
-	_, exists := s.states[state]
+	expires, exists := s.states[state]
 	if exists {
 		// Remove the state after validation (consume-once pattern)
 		delete(s.states, state)
-		return true
+		return !expired(expires, time.Now())
 	}
 	return false
 }
 
+// StorePayload saves an encrypted state payload under a reference ID.
+// This method is thread-safe and can be called concurrently.
+func (s *MemoryStateStore) StorePayload(id, payload string) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.payloads == nil {
+		s.payloads = make(map[string]memoryPayload)
+	}
+	s.payloads[id] = memoryPayload{payload: payload, expires: s.expiry()}
+	return true
+}
+
+// ConsumePayload returns the payload stored under id and removes it
+// (consume-once pattern). This method is thread-safe and can be called concurrently.
+func (s *MemoryStateStore) ConsumePayload(id string) (string, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	entry, exists := s.payloads[id]
+	if !exists {
+		return "", false
+	}
+	delete(s.payloads, id)
+	if expired(entry.expires, time.Now()) {
+		return "", false
+	}
+	return entry.payload, true
+}
+
+// Cleanup removes expired states and payloads. It never fails for this
+// implementation.
+func (s *MemoryStateStore) Cleanup(_ context.Context) (int, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	now := time.Now()
+	removed := 0
+	for state, expires := range s.states {
+		if expired(expires, now) {
+			delete(s.states, state)
+			removed++
+		}
+	}
+	for id, entry := range s.payloads {
+		if expired(entry.expires, now) {
+			delete(s.payloads, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// hashStateKey derives a fixed-length storage key from kind and value, so
+// shared stores neither keep raw state tokens nor depend on their length.
+func hashStateKey(kind, value string) string {
+	sum := sha256.Sum256([]byte(kind + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
+
 // Debug outputs information about currently stored states to stdout.
 // This method is intended for development and debugging purposes only.
 // This method is thread-safe and can be called concurrently.
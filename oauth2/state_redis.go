@@ -0,0 +1,100 @@
+package oauth2
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RedisStateClient is the subset of a Redis client used by RedisStateStore, so
+// this package does not depend on a particular driver. GetDel must atomically
+// return and delete the key (Redis GETDEL), which is what makes validation
+// consume-once across instances. With go-redis:
+//
+//	type redisStateAdapter struct{ *redis.Client }
+//
+//	func (a redisStateAdapter) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+//		return a.Client.SetNX(ctx, key, value, ttl).Result()
+//	}
+//
+//	func (a redisStateAdapter) GetDel(ctx context.Context, key string) (string, bool, error) {
+//		value, err := a.Client.GetDel(ctx, key).Result()
+//		if errors.Is(err, redis.Nil) {
+//			return "", false, nil
+//		}
+//		return value, err == nil, err
+//	}
+type RedisStateClient interface {
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	GetDel(ctx context.Context, key string) (string, bool, error)
+}
+
+var _ PayloadStateStore = &RedisStateStore{}
+
+// RedisStateStore is a PayloadStateStore backed by Redis, for deployments
+// where the callback may reach a different instance than the one that
+// generated the authorization URL. Keys are stored under prefix as SHA-256
+// digests of the state and expire natively after ttl (zero keeps them until
+// consumed). Redis errors make Store and Validate return false.
+type RedisStateStore struct {
+	client RedisStateClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStateStore returns a state store persisting states through client.
+//
+// Example:
+//
+//	store := NewRedisStateStore(redisStateAdapter{rdb}, "oauth2:", 10*time.Minute)
+//	client, err := NewClient[MyUserData](provider, id, secret, redirect, key, WithStateStore(store))
+func NewRedisStateStore(client RedisStateClient, prefix string, ttl time.Duration) *RedisStateStore {
+	return &RedisStateStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Store saves state. It returns false if the state already exists or Redis
+// fails.
+func (s *RedisStateStore) Store(state string) bool {
+	return s.set("state", state, "1")
+}
+
+// Validate atomically consumes state, returning true only for the first
+// validation of a stored, unexpired state.
+func (s *RedisStateStore) Validate(state string) bool {
+	_, ok := s.consume("state", state)
+	return ok
+}
+
+// StorePayload saves payload under id.
+func (s *RedisStateStore) StorePayload(id, payload string) bool {
+	return s.set("payload", id, payload)
+}
+
+// ConsumePayload atomically returns and removes the payload stored under id.
+func (s *RedisStateStore) ConsumePayload(id string) (string, bool) {
+	return s.consume("payload", id)
+}
+
+// Debug prints the store configuration; stored states are not listed.
+func (s *RedisStateStore) Debug() {
+	fmt.Printf("=== Redis Store Debug ====\nprefix %q ttl %s\n=== End Redis Store Debug ====\n", s.prefix, s.ttl)
+}
+
+func (s *RedisStateStore) set(kind, key, value string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), stateStoreTimeout)
+	defer cancel()
+
+	ok, err := s.client.SetNX(ctx, s.prefix+hashStateKey(kind, key), value, s.ttl)
+	return err == nil && ok
+}
+
+func (s *RedisStateStore) consume(kind, key string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), stateStoreTimeout)
+	defer cancel()
+
+	value, ok, err := s.client.GetDel(ctx, s.prefix+hashStateKey(kind, key))
+	if err != nil || !ok {
+		return "", false
+	}
+	return value, true
+}
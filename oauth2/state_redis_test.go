@@ -0,0 +1,106 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeStateRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func (f *fakeStateRedis) SetNX(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	if _, exists := f.values[key]; exists {
+		return false, nil
+	}
+	f.values[key] = value
+	f.ttls[key] = ttl
+	return true, nil
+}
+
+func (f *fakeStateRedis) GetDel(_ context.Context, key string) (string, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", false, f.err
+	}
+	value, ok := f.values[key]
+	delete(f.values, key)
+	return value, ok, nil
+}
+
+func TestRedisStateStore(t *testing.T) {
+	redis := &fakeStateRedis{values: map[string]string{}, ttls: map[string]time.Duration{}}
+	store := NewRedisStateStore(redis, "oauth2:", 10*time.Minute)
+
+	if !store.Store("state-1") || store.Store("state-1") {
+		t.Fatal("expected the first Store to succeed and a duplicate to fail")
+	}
+	for key, ttl := range redis.ttls {
+		if !strings.HasPrefix(key, "oauth2:") || strings.Contains(key, "state-1") || ttl != 10*time.Minute {
+			t.Errorf("unexpected key %q with ttl %s", key, ttl)
+		}
+	}
+	if !store.Validate("state-1") || store.Validate("state-1") {
+		t.Error("expected state to validate exactly once")
+	}
+
+	if !store.StorePayload("ref", "payload") {
+		t.Fatal("StorePayload failed")
+	}
+	if store.Validate("ref") {
+		t.Error("payload IDs must not validate as states")
+	}
+	if payload, ok := store.ConsumePayload("ref"); !ok || payload != "payload" {
+		t.Errorf("ConsumePayload = %q, %v", payload, ok)
+	}
+	if _, ok := store.ConsumePayload("ref"); ok {
+		t.Error("expected payload to be consumed once")
+	}
+
+	redis.err = errors.New("connection refused")
+	if store.Store("state-2") || store.Validate("state-2") {
+		t.Error("expected Redis errors to fail the operation")
+	}
+}
+
+func TestClientWithStateStore(t *testing.T) {
+	redis := &fakeStateRedis{values: map[string]string{}, ttls: map[string]time.Duration{}}
+	store := NewRedisStateStore(redis, "oauth2:", time.Minute)
+	provider, _ := NewGoogleProvider()
+	newClient := func() *Client[largeUserData] {
+		client, err := NewClient[largeUserData](provider, "id", "secret", "https://app.example.com/cb", "this-is-a-24-char-key-ok",
+			WithStateStore(store), WithServerSideState())
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		return client
+	}
+
+	// The callback is handled by a different instance sharing the store
+	authURL, err := newClient().GenerateURL("state", newLargeUserData())
+	if err != nil {
+		t.Fatalf("GenerateURL failed: %v", err)
+	}
+	state := stateFromAuthURL(t, authURL)
+
+	other := newClient()
+	if original, _, err := other.ValidateState(state); err != nil || original != "state" {
+		t.Fatalf("ValidateState = %q, %v", original, err)
+	}
+	if _, _, err := other.ValidateState(state); !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("expected replay to fail with ErrStateNotFound, got %v", err)
+	}
+}
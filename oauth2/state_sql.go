@@ -0,0 +1,200 @@
+package oauth2
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultStateTable is the table used by SQLStateStore unless
+// WithStateTable is given.
+const DefaultStateTable = "oauth2_states"
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+var (
+	_ PayloadStateStore = &SQLStateStore{}
+	_ StateCleaner      = &SQLStateStore{}
+)
+
+// SQLStateStore is a PayloadStateStore backed by a database/sql database, for
+// multi-instance deployments without Redis. Rows are keyed by SHA-256 digests
+// of the state, and a state is consumed by deleting its row, so only one
+// concurrent validation can succeed. Expired rows fail validation and are
+// removed by Cleanup. Database errors make Store and Validate return false.
+//
+// The table (see CreateTable) is:
+//
+//	CREATE TABLE oauth2_states (
+//		id         VARCHAR(64) PRIMARY KEY,
+//		payload    TEXT NOT NULL,
+//		expires_at BIGINT NOT NULL -- Unix nanoseconds, 0 never expires
+//	)
+type SQLStateStore struct {
+	db     *sql.DB
+	table  string
+	ttl    time.Duration
+	dollar bool
+}
+
+// SQLStateStoreOption configures a SQLStateStore.
+type SQLStateStoreOption func(*SQLStateStore)
+
+// WithStateTable stores states in table instead of DefaultStateTable. The name
+// may be schema qualified.
+func WithStateTable(table string) SQLStateStoreOption {
+	return func(s *SQLStateStore) {
+		s.table = table
+	}
+}
+
+// WithDollarPlaceholders uses $1, $2... placeholders (PostgreSQL) instead of ?
+// (MySQL, SQLite).
+func WithDollarPlaceholders() SQLStateStoreOption {
+	return func(s *SQLStateStore) {
+		s.dollar = true
+	}
+}
+
+// NewSQLStateStore returns a state store keeping states in db for ttl (zero
+// keeps them until consumed).
+//
+// Example:
+//
+//	store, err := NewSQLStateStore(db, 10*time.Minute, WithDollarPlaceholders())
+//	if err == nil {
+//		err = store.CreateTable(ctx)
+//	}
+//	client, err := NewClient[MyUserData](provider, id, secret, redirect, key, WithStateStore(store))
+func NewSQLStateStore(db *sql.DB, ttl time.Duration, opts ...SQLStateStoreOption) (*SQLStateStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database cannot be nil")
+	}
+	store := &SQLStateStore{db: db, table: DefaultStateTable, ttl: ttl}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(store)
+		}
+	}
+	if !sqlIdentifier.MatchString(store.table) {
+		return nil, fmt.Errorf("invalid state table name %q", store.table)
+	}
+	return store, nil
+}
+
+// CreateTable creates the state table if it does not exist.
+func (s *SQLStateStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+s.table+
+		" (id VARCHAR(64) PRIMARY KEY, payload TEXT NOT NULL, expires_at BIGINT NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create state table: %w", err)
+	}
+	return nil
+}
+
+// Store saves state. It returns false if the state already exists or the
+// database fails.
+func (s *SQLStateStore) Store(state string) bool {
+	return s.insert("state", state, "")
+}
+
+// Validate consumes state, returning true only for the first validation of a
+// stored, unexpired state.
+func (s *SQLStateStore) Validate(state string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), stateStoreTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx,
+		s.query("DELETE FROM %s WHERE id = ? AND (expires_at = 0 OR expires_at > ?)"),
+		hashStateKey("state", state), time.Now().UnixNano())
+	return err == nil && affected(result) == 1
+}
+
+// StorePayload saves payload under id.
+func (s *SQLStateStore) StorePayload(id, payload string) bool {
+	return s.insert("payload", id, payload)
+}
+
+// ConsumePayload returns and removes the payload stored under id. When two
+// callers race, only the one whose delete succeeds gets the payload.
+func (s *SQLStateStore) ConsumePayload(id string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), stateStoreTimeout)
+	defer cancel()
+
+	key := hashStateKey("payload", id)
+	var payload string
+	err := s.db.QueryRowContext(ctx,
+		s.query("SELECT payload FROM %s WHERE id = ? AND (expires_at = 0 OR expires_at > ?)"),
+		key, time.Now().UnixNano()).Scan(&payload)
+	if err != nil {
+		return "", false
+	}
+
+	result, err := s.db.ExecContext(ctx, s.query("DELETE FROM %s WHERE id = ?"), key)
+	if err != nil || affected(result) != 1 {
+		return "", false
+	}
+	return payload, true
+}
+
+// Cleanup deletes expired rows.
+func (s *SQLStateStore) Cleanup(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx,
+		s.query("DELETE FROM %s WHERE expires_at <> 0 AND expires_at <= ?"), time.Now().UnixNano())
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up states: %w", err)
+	}
+	return int(affected(result)), nil
+}
+
+// Debug prints the store configuration; stored states are not listed.
+func (s *SQLStateStore) Debug() {
+	fmt.Printf("=== SQL Store Debug ====\ntable %s ttl %s\n=== End SQL Store Debug ====\n", s.table, s.ttl)
+}
+
+func (s *SQLStateStore) insert(kind, key, payload string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), stateStoreTimeout)
+	defer cancel()
+
+	var expires int64
+	if s.ttl > 0 {
+		expires = time.Now().Add(s.ttl).UnixNano()
+	}
+	_, err := s.db.ExecContext(ctx,
+		s.query("INSERT INTO %s (id, payload, expires_at) VALUES (?, ?, ?)"),
+		hashStateKey(kind, key), payload, expires)
+	return err == nil
+}
+
+// query inserts the table name into format and rewrites ? placeholders for
+// the configured dialect.
+func (s *SQLStateStore) query(format string) string {
+	query := fmt.Sprintf(format, s.table)
+	if !s.dollar {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func affected(result sql.Result) int64 {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,189 @@
+package oauth2
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStateDB is a database/sql driver that understands the statements issued
+// by SQLStateStore.
+type fakeStateDB struct {
+	mu      sync.Mutex
+	rows    map[string]fakeStateRow
+	queries []string
+}
+
+type fakeStateRow struct {
+	payload string
+	expires int64
+}
+
+func (d *fakeStateDB) Open(string) (driver.Conn, error) { return &fakeStateConn{db: d}, nil }
+
+type fakeStateConn struct{ db *fakeStateDB }
+
+func (c *fakeStateConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *fakeStateConn) Close() error              { return nil }
+func (c *fakeStateConn) Begin() (driver.Tx, error) { return nil, errors.New("tx not supported") }
+
+func (c *fakeStateConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	d := c.db
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE"):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(query, "INSERT INTO"):
+		id := args[0].Value.(string)
+		if _, exists := d.rows[id]; exists {
+			return nil, errors.New("duplicate key")
+		}
+		d.rows[id] = fakeStateRow{payload: args[1].Value.(string), expires: args[2].Value.(int64)}
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "WHERE id = "):
+		id := args[0].Value.(string)
+		row, exists := d.rows[id]
+		if !exists || (len(args) > 1 && row.expires != 0 && row.expires <= args[1].Value.(int64)) {
+			return driver.RowsAffected(0), nil
+		}
+		delete(d.rows, id)
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "WHERE expires_at <> 0"):
+		now := args[0].Value.(int64)
+		var removed int64
+		for id, row := range d.rows {
+			if row.expires != 0 && row.expires <= now {
+				delete(d.rows, id)
+				removed++
+			}
+		}
+		return driver.RowsAffected(removed), nil
+	}
+	return nil, errors.New("unexpected query " + query)
+}
+
+func (c *fakeStateConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	d := c.db
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+
+	rows := &fakeStateRows{}
+	if row, ok := d.rows[args[0].Value.(string)]; ok && (row.expires == 0 || row.expires > args[1].Value.(int64)) {
+		rows.values = []string{row.payload}
+	}
+	return rows, nil
+}
+
+type fakeStateRows struct{ values []string }
+
+func (r *fakeStateRows) Columns() []string { return []string{"payload"} }
+func (r *fakeStateRows) Close() error      { return nil }
+func (r *fakeStateRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func newFakeStateDB(t *testing.T) (*sql.DB, *fakeStateDB) {
+	t.Helper()
+	fake := &fakeStateDB{rows: map[string]fakeStateRow{}}
+	db := sql.OpenDB(fakeStateConnector{fake})
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+type fakeStateConnector struct{ db *fakeStateDB }
+
+func (c fakeStateConnector) Connect(context.Context) (driver.Conn, error) { return c.db.Open("") }
+func (c fakeStateConnector) Driver() driver.Driver                        { return c.db }
+
+func TestSQLStateStore(t *testing.T) {
+	db, fake := newFakeStateDB(t)
+	store, err := NewSQLStateStore(db, time.Minute)
+	if err != nil {
+		t.Fatalf("NewSQLStateStore failed: %v", err)
+	}
+	if err := store.CreateTable(context.Background()); err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+
+	if !store.Store("state-1") || store.Store("state-1") {
+		t.Fatal("expected the first Store to succeed and a duplicate to fail")
+	}
+	if !store.Validate("state-1") || store.Validate("state-1") {
+		t.Error("expected state to validate exactly once")
+	}
+
+	if !store.StorePayload("ref", "payload") {
+		t.Fatal("StorePayload failed")
+	}
+	if payload, ok := store.ConsumePayload("ref"); !ok || payload != "payload" {
+		t.Errorf("ConsumePayload = %q, %v", payload, ok)
+	}
+	if _, ok := store.ConsumePayload("ref"); ok {
+		t.Error("expected payload to be consumed once")
+	}
+
+	// Expired rows fail validation and are removed by Cleanup
+	store.Store("stale")
+	store.StorePayload("stale-ref", "payload")
+	fake.mu.Lock()
+	for id, row := range fake.rows {
+		row.expires = time.Now().Add(-time.Second).UnixNano()
+		fake.rows[id] = row
+	}
+	fake.mu.Unlock()
+	if _, ok := store.ConsumePayload("stale-ref"); ok {
+		t.Error("expected expired payload to be rejected")
+	}
+	removed, err := store.Cleanup(context.Background())
+	if err != nil || removed != 2 {
+		t.Errorf("Cleanup = %d, %v; want 2 removed", removed, err)
+	}
+	if store.Validate("stale") {
+		t.Error("expected cleaned up state to be rejected")
+	}
+
+	for _, query := range fake.queries {
+		if strings.Contains(query, "$") || (strings.Contains(query, "FROM") && !strings.Contains(query, DefaultStateTable)) {
+			t.Errorf("unexpected query %q", query)
+		}
+	}
+}
+
+func TestSQLStateStoreOptions(t *testing.T) {
+	db, fake := newFakeStateDB(t)
+	store, err := NewSQLStateStore(db, 0, WithStateTable("auth.states"), WithDollarPlaceholders())
+	if err != nil {
+		t.Fatalf("NewSQLStateStore failed: %v", err)
+	}
+	store.Store("state")
+	if !store.Validate("state") {
+		t.Error("expected state without TTL to validate")
+	}
+	want := "DELETE FROM auth.states WHERE id = $1 AND (expires_at = 0 OR expires_at > $2)"
+	if got := fake.queries[len(fake.queries)-1]; got != want {
+		t.Errorf("query = %q, want %q", got, want)
+	}
+
+	if _, err := NewSQLStateStore(db, 0, WithStateTable("states; DROP TABLE users")); err == nil {
+		t.Error("expected invalid table name to be rejected")
+	}
+	if _, err := NewSQLStateStore(nil, 0); err == nil {
+		t.Error("expected nil database to be rejected")
+	}
+}
@@ -1,6 +1,7 @@
 package oauth2
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -413,3 +414,26 @@ func BenchmarkMemoryStateStoreConcurrent(b *testing.B) {
 		}
 	})
 }
+
+func TestMemoryStateStoreTTL(t *testing.T) {
+	store := NewMemoryStateStoreWithTTL(20 * time.Millisecond)
+	store.Store("expiring")
+	store.Store("consumed")
+	store.StorePayload("ref", "payload")
+
+	if !store.Validate("consumed") {
+		t.Fatal("expected unexpired state to validate")
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	removed, err := store.Cleanup(context.Background())
+	if err != nil || removed != 2 {
+		t.Errorf("Cleanup = %d, %v; want 2 removed", removed, err)
+	}
+	if store.Validate("expiring") {
+		t.Error("expected expired state to be rejected")
+	}
+	if _, ok := store.ConsumePayload("ref"); ok {
+		t.Error("expected expired payload to be rejected")
+	}
+}
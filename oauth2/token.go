@@ -0,0 +1,135 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoRefreshToken is returned when a token needs refreshing but carries no
+// refresh token.
+var ErrNoRefreshToken = errors.New("OAuth2 token has no refresh token")
+
+// TokenRefreshHook is called with the previous and the refreshed token after
+// every refresh performed by a TokenSource, e.g. to persist a rotated refresh
+// token. The refreshed token keeps the previous refresh token when the
+// provider did not issue a new one.
+type TokenRefreshHook func(ctx context.Context, previous, refreshed *oauth2.Token) error
+
+// TokenSourceOption configures a TokenSource.
+type TokenSourceOption func(*refreshingTokenSource)
+
+// WithTokenRefreshHook registers hook to run after each refresh.
+func WithTokenRefreshHook(hook TokenRefreshHook) TokenSourceOption {
+	return func(s *refreshingTokenSource) {
+		s.hook = hook
+	}
+}
+
+// Refresh exchanges token's refresh token for a new token, regardless of
+// whether token has expired. Providers that do not rotate refresh tokens
+// return none; the refreshed token then keeps the original refresh token.
+//
+// Example:
+//
+//	fresh, err := client.Refresh(ctx, stored)
+//	if err != nil {
+//		return err
+//	}
+//	err = saveToken(userID, fresh)
+func (c *Client[T]) Refresh(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+	if c.options.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.options.httpClient)
+	}
+
+	// A token without an access token is never valid, which forces the
+	// underlying source to hit the token endpoint.
+	refreshed, err := c.config.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+	if err != nil {
+		return nil, fmt.Errorf("OAuth2 token refresh failed: %w", err)
+	}
+	return refreshed, nil
+}
+
+// TokenSource returns a golang.org/x/oauth2 TokenSource that serves token
+// until it expires and then refreshes it with Refresh, caching the result.
+// ctx is used for every refresh request made by the source. If a refresh hook
+// fails, Token returns its error and keeps the refreshed token, calling the
+// hook again on every later call until it succeeds; the token is only served
+// once the hook has persisted it, so a rotated refresh token is never lost.
+//
+// Example:
+//
+//	source := client.TokenSource(ctx, stored, WithTokenRefreshHook(
+//		func(ctx context.Context, previous, refreshed *oauth2.Token) error {
+//			return saveToken(userID, refreshed)
+//		},
+//	))
+//	httpClient := oauth2.NewClient(ctx, source) // golang.org/x/oauth2
+func (c *Client[T]) TokenSource(ctx context.Context, token *oauth2.Token, opts ...TokenSourceOption) oauth2.TokenSource {
+	source := &refreshingTokenSource{ctx: ctx, token: token, refresh: c.Refresh}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(source)
+		}
+	}
+	return source
+}
+
+type refreshingTokenSource struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	token   *oauth2.Token
+	refresh func(context.Context, *oauth2.Token) (*oauth2.Token, error)
+	hook    TokenRefreshHook
+
+	// unsaved holds the token replaced by a refresh whose hook failed; the
+	// hook is retried with it before token is served again.
+	unsaved *oauth2.Token
+}
+
+// Token implements oauth2.TokenSource.
+func (s *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unsaved != nil {
+		if err := s.runHook(s.unsaved, s.token); err != nil {
+			return nil, err
+		}
+	}
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	previous := s.token
+	refreshed, err := s.refresh(s.ctx, previous)
+	if err != nil {
+		return nil, err
+	}
+	s.token = refreshed
+	if err := s.runHook(previous, refreshed); err != nil {
+		return nil, err
+	}
+	return refreshed, nil
+}
+
+// runHook calls the refresh hook and records whether it still has to persist
+// refreshed.
+func (s *refreshingTokenSource) runHook(previous, refreshed *oauth2.Token) error {
+	if s.hook == nil {
+		return nil
+	}
+	if err := s.hook(s.ctx, previous, refreshed); err != nil {
+		s.unsaved = previous
+		return fmt.Errorf("OAuth2 token refresh hook failed: %w", err)
+	}
+	s.unsaved = nil
+	return nil
+}
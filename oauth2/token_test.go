@@ -0,0 +1,145 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newRefreshServer issues access-N tokens and rotates the refresh token on
+// every request when rotate is set.
+func newRefreshServer(t *testing.T, rotate bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.FormValue("grant_type") != "refresh_token" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("refresh_token") == "revoked" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"error": "invalid_grant"})
+			return
+		}
+
+		n := calls.Add(1)
+		response := map[string]any{
+			"access_token": "access-" + strconv.Itoa(int(n)),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		}
+		if rotate {
+			response["refresh_token"] = "refresh-" + strconv.Itoa(int(n))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClientRefresh(t *testing.T) {
+	server, _ := newRefreshServer(t, false)
+	client := newUserInfoClient(t, server.URL)
+	client.config.Endpoint.TokenURL = server.URL
+
+	token, err := client.Refresh(context.Background(), &oauth2.Token{AccessToken: "old", RefreshToken: "refresh-0", Expiry: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-0" {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	if _, err := client.Refresh(context.Background(), &oauth2.Token{AccessToken: "old"}); !errors.Is(err, ErrNoRefreshToken) {
+		t.Errorf("expected ErrNoRefreshToken, got %v", err)
+	}
+	if _, err := client.Refresh(context.Background(), &oauth2.Token{RefreshToken: "revoked"}); err == nil {
+		t.Error("expected error for revoked refresh token")
+	}
+}
+
+func TestClientTokenSource(t *testing.T) {
+	server, calls := newRefreshServer(t, true)
+	client := newUserInfoClient(t, server.URL)
+	client.config.Endpoint.TokenURL = server.URL
+
+	var persisted []string
+	source := client.TokenSource(context.Background(),
+		&oauth2.Token{AccessToken: "expired", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)},
+		WithTokenRefreshHook(func(_ context.Context, previous, refreshed *oauth2.Token) error {
+			persisted = append(persisted, previous.RefreshToken+"->"+refreshed.RefreshToken)
+			return nil
+		}),
+	)
+
+	for range 3 {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token.AccessToken != "access-1" {
+			t.Fatalf("unexpected access token %q", token.AccessToken)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single refresh, got %d", calls.Load())
+	}
+	if len(persisted) != 1 || persisted[0] != "refresh-0->refresh-1" {
+		t.Errorf("unexpected persisted rotations %v", persisted)
+	}
+
+	// Valid tokens are served without refreshing
+	valid := &oauth2.Token{AccessToken: "live", Expiry: time.Now().Add(time.Hour)}
+	if token, err := client.TokenSource(context.Background(), valid).Token(); err != nil || token != valid {
+		t.Errorf("expected the original token, got %v (%v)", token, err)
+	}
+}
+
+func TestClientTokenSourceHookError(t *testing.T) {
+	server, calls := newRefreshServer(t, true)
+	client := newUserInfoClient(t, server.URL)
+	client.config.Endpoint.TokenURL = server.URL
+
+	failure := errors.New("store unavailable")
+	var persisted []string
+	hookErr := failure
+	source := client.TokenSource(context.Background(), &oauth2.Token{RefreshToken: "refresh-0"},
+		WithTokenRefreshHook(func(_ context.Context, previous, refreshed *oauth2.Token) error {
+			if hookErr != nil {
+				return hookErr
+			}
+			persisted = append(persisted, previous.RefreshToken+"->"+refreshed.RefreshToken)
+			return nil
+		}),
+	)
+
+	// The hook is retried on every call until the rotated token is persisted
+	for range 2 {
+		if _, err := source.Token(); !errors.Is(err, failure) {
+			t.Fatalf("expected hook error, got %v", err)
+		}
+	}
+	hookErr = nil
+
+	// The rotated token was kept, so the old refresh token is not replayed
+	token, err := source.Token()
+	if err != nil || token.RefreshToken != "refresh-1" || calls.Load() != 1 {
+		t.Fatalf("expected cached rotated token, got %+v (%v, %d calls)", token, err, calls.Load())
+	}
+	if len(persisted) != 1 || persisted[0] != "refresh-0->refresh-1" {
+		t.Errorf("unexpected persisted rotations %v", persisted)
+	}
+	if _, err := source.Token(); err != nil || len(persisted) != 1 {
+		t.Errorf("expected the hook to run once after succeeding, got %v (%v)", persisted, err)
+	}
+}
@@ -0,0 +1,81 @@
+package urlkit
+
+import "fmt"
+
+// WarningCode identifies the kind of diagnostic carried by a Warning.
+type WarningCode string
+
+const (
+	// WarningParamCaseMismatch is reported when a supplied param key only
+	// matches a route param when compared case-insensitively (e.g. "userID"
+	// against ":userId").
+	WarningParamCaseMismatch WarningCode = "param_case_mismatch"
+
+	// WarningEnvReloadFailed is reported when template variables bound with
+	// BindEnvVars cannot be re-applied after SIGHUP.
+	WarningEnvReloadFailed WarningCode = "env_reload_failed"
+
+	// WarningRouteUnavailable is reported when a route is built outside the
+	// availability window declared in its metadata.
+	WarningRouteUnavailable WarningCode = "route_unavailable"
+)
+
+// Warning describes a non-fatal problem noticed while building a URL.
+type Warning struct {
+	Code    WarningCode
+	Group   string // FQN of the group rendering the route
+	Route   string
+	Param   string
+	Message string
+}
+
+// Observer receives diagnostics from the manager. Nil callbacks are skipped, so
+// callers only set the hooks they care about.
+type Observer struct {
+	// OnWarning is called synchronously, on the building goroutine, for every
+	// Warning. It must not block.
+	OnWarning func(Warning)
+}
+
+// WithObserver installs observer hooks on the manager, replacing any previous
+// observer.
+//
+// Example:
+//
+//	manager := urlkit.NewRouteManager(urlkit.WithObserver(urlkit.Observer{
+//		OnWarning: func(w urlkit.Warning) { log.Printf("urlkit: %s", w.Message) },
+//	}))
+func WithObserver(observer Observer) Option {
+	return func(m *RouteManager) {
+		if m == nil || m.runtime == nil {
+			return
+		}
+		m.runtime.mu.Lock()
+		m.runtime.observer = observer
+		m.runtime.mu.Unlock()
+	}
+}
+
+func (r *runtimeState) currentObserver() Observer {
+	if r == nil {
+		return Observer{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.observer
+}
+
+// warn reports a warning for routeName to the observer, if any.
+func (u *Group) warn(code WarningCode, routeName, param, format string, args ...any) {
+	observer := u.runtime.currentObserver()
+	if observer.OnWarning == nil {
+		return
+	}
+	observer.OnWarning(Warning{
+		Code:    code,
+		Group:   u.FQN(),
+		Route:   routeName,
+		Param:   param,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
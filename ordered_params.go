@@ -0,0 +1,218 @@
+package urlkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// OrderedParams is a params builder that remembers insertion order, for callers
+// that serialize params (logs, cache keys, signed payloads) and need a stable
+// layout. It can be passed anywhere params are accepted as a struct or map,
+// such as Builder.WithOrderedParams or template helpers.
+//
+// Example:
+//
+//	params := urlkit.NewOrderedParams().Set("org", "acme").Set("id", 42)
+//	group.Builder("user").WithOrderedParams(params).Build()
+//	params.String() // org=acme&id=42
+type OrderedParams struct {
+	keys   []string
+	values Params
+}
+
+// NewOrderedParams returns an empty OrderedParams.
+func NewOrderedParams() *OrderedParams {
+	return &OrderedParams{values: make(Params)}
+}
+
+// Set stores value under key. Setting an existing key keeps its position.
+func (p *OrderedParams) Set(key string, value any) *OrderedParams {
+	if p.values == nil {
+		p.values = make(Params)
+	}
+	if _, exists := p.values[key]; !exists {
+		p.keys = append(p.keys, key)
+	}
+	p.values[key] = value
+	return p
+}
+
+// Get returns the value stored under key.
+func (p *OrderedParams) Get(key string) (any, bool) {
+	value, ok := p.values[key]
+	return value, ok
+}
+
+// Keys returns the keys in insertion order.
+func (p *OrderedParams) Keys() []string {
+	return slices.Clone(p.keys)
+}
+
+// Len returns the number of params.
+func (p *OrderedParams) Len() int {
+	return len(p.keys)
+}
+
+// Params returns the params as a plain Params map.
+func (p *OrderedParams) Params() Params {
+	params := make(Params, len(p.keys))
+	for _, key := range p.keys {
+		params[key] = normalizeParamValue(p.values[key])
+	}
+	return params
+}
+
+// String encodes the params like a query string, in insertion order.
+func (p *OrderedParams) String() string {
+	var b strings.Builder
+	for i, key := range p.keys {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(fmt.Sprint(p.values[key])))
+	}
+	return b.String()
+}
+
+// MarshalJSON encodes the params as a JSON object, in insertion order.
+func (p *OrderedParams) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range p.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, keeping the order of its keys.
+func (p *OrderedParams) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("ordered params: expected JSON object")
+	}
+
+	*p = OrderedParams{values: make(Params)}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		p.Set(key, value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// WithOrderedParams merges params into the builder in insertion order.
+func (b *Builder) WithOrderedParams(params *OrderedParams) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	if err := mergeParamsInput(b.params, params); err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// WithCaseInsensitiveParams makes builds match supplied param keys against route
+// params case-insensitively, so "userID" fills ":userId". Every rename is still
+// reported to the Observer as a WarningParamCaseMismatch. Without this option
+// mismatched keys are left alone and only the warning is reported.
+func WithCaseInsensitiveParams() Option {
+	return func(m *RouteManager) {
+		if m == nil || m.runtime == nil {
+			return
+		}
+		m.runtime.mu.Lock()
+		m.runtime.caseInsensitiveParams = true
+		m.runtime.mu.Unlock()
+	}
+}
+
+func (r *runtimeState) paramsCaseInsensitive() bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.caseInsensitiveParams
+}
+
+// reconcileParamCase finds supplied keys that only match a route param when
+// case is ignored, warns about them, and renames them when the manager matches
+// params case-insensitively.
+func (u *Group) reconcileParamCase(routeName, template string, params Params) Params {
+	insensitive := u.runtime.paramsCaseInsensitive()
+	if len(params) == 0 || (!insensitive && u.runtime.currentObserver().OnWarning == nil) {
+		return params
+	}
+
+	names, err := routeParamNames(template)
+	if err != nil {
+		return params
+	}
+
+	var renamed Params
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		if slices.Contains(names, key) {
+			continue
+		}
+		index := slices.IndexFunc(names, func(name string) bool { return strings.EqualFold(name, key) })
+		if index < 0 {
+			continue
+		}
+		name := names[index]
+		if _, taken := params[name]; taken {
+			continue
+		}
+		if _, taken := renamed[name]; taken {
+			continue
+		}
+
+		if !insensitive {
+			u.warn(WarningParamCaseMismatch, routeName, key, "param %q does not match route param %q; keys are case-sensitive", key, name)
+			continue
+		}
+		u.warn(WarningParamCaseMismatch, routeName, key, "param %q matched route param %q case-insensitively", key, name)
+		if renamed == nil {
+			renamed = cloneParamsMap(params)
+		}
+		renamed[name] = renamed[key]
+		delete(renamed, key)
+	}
+	if renamed != nil {
+		return renamed
+	}
+	return params
+}
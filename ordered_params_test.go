@@ -0,0 +1,87 @@
+package urlkit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestOrderedParams(t *testing.T) {
+	params := urlkit.NewOrderedParams().Set("org", "acme").Set("id", 42).Set("org", "globex")
+
+	if got, want := params.String(), "org=globex&id=42"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if got, want := string(data), `{"org":"globex","id":42}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	var decoded urlkit.OrderedParams
+	if err := json.Unmarshal([]byte(`{"z":1,"a":"x"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if keys := decoded.Keys(); len(keys) != 2 || keys[0] != "z" || keys[1] != "a" {
+		t.Fatalf("expected keys in document order, got %v", keys)
+	}
+
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "api",
+			BaseURL: "https://api.example.com",
+			Routes:  map[string]string{"user": "/orgs/:org/users/:id"},
+		}},
+	})
+	api, err := manager.GetGroup("api")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	got, err := api.Builder("user").WithOrderedParams(params).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://api.example.com/orgs/globex/users/42"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCaseInsensitiveParams(t *testing.T) {
+	cfg := urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "api",
+			BaseURL: "https://api.example.com",
+			Routes:  map[string]string{"user": "/users/:userId"},
+		}},
+	}
+
+	var warnings []urlkit.Warning
+	observer := urlkit.WithObserver(urlkit.Observer{
+		OnWarning: func(w urlkit.Warning) { warnings = append(warnings, w) },
+	})
+
+	strict := mustManagerFromConfig(t, cfg, observer)
+	if _, err := strict.Group("api").Render("user", urlkit.Params{"userID": 7}); err == nil {
+		t.Fatal("expected case-sensitive build to fail")
+	}
+	if len(warnings) != 1 || warnings[0].Code != urlkit.WarningParamCaseMismatch || warnings[0].Param != "userID" {
+		t.Fatalf("expected a case mismatch warning, got %+v", warnings)
+	}
+
+	warnings = nil
+	lenient := mustManagerFromConfig(t, cfg, observer, urlkit.WithCaseInsensitiveParams())
+	got, err := lenient.Group("api").Render("user", urlkit.Params{"userID": 7})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if want := "https://api.example.com/users/7"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if len(warnings) != 1 || warnings[0].Route != "user" || warnings[0].Group != "api" {
+		t.Fatalf("expected a warning for the rename, got %+v", warnings)
+	}
+}
@@ -0,0 +1,178 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ParamType names a typed constraint for a route path param.
+type ParamType string
+
+const (
+	ParamTypeInt   ParamType = "int"
+	ParamTypeUint  ParamType = "uint"
+	ParamTypeFloat ParamType = "float"
+	ParamTypeBool  ParamType = "bool"
+	ParamTypeUUID  ParamType = "uuid"
+	ParamTypeSlug  ParamType = "slug"
+	ParamTypeAlpha ParamType = "alpha"
+	ParamTypeAlnum ParamType = "alnum"
+	// ParamTypeDate accepts values in the group's date layout (see
+	// Group.SetDateFormat). time.Time values are formatted before validation.
+	ParamTypeDate ParamType = "date"
+)
+
+// Param error codes reported in ParamError.Code. Codes are stable identifiers
+// meant to be used as translation keys.
+const (
+	ParamErrorInvalidInt   = "param.invalid_int"
+	ParamErrorInvalidUint  = "param.invalid_uint"
+	ParamErrorInvalidFloat = "param.invalid_float"
+	ParamErrorInvalidBool  = "param.invalid_bool"
+	ParamErrorInvalidUUID  = "param.invalid_uuid"
+	ParamErrorInvalidSlug  = "param.invalid_slug"
+	ParamErrorInvalidAlpha = "param.invalid_alpha"
+	ParamErrorInvalidAlnum = "param.invalid_alnum"
+	ParamErrorInvalidDate  = "param.invalid_date"
+)
+
+// DefaultParamErrorMessages is the English message catalog used by
+// ParamError.Error. Messages use the same {placeholder} syntax as URL templates
+// and may reference any key in ParamError.Args.
+var DefaultParamErrorMessages = map[string]string{
+	ParamErrorInvalidInt:   "param {param} must be an integer, got {value}",
+	ParamErrorInvalidUint:  "param {param} must be a non-negative integer, got {value}",
+	ParamErrorInvalidFloat: "param {param} must be a number, got {value}",
+	ParamErrorInvalidBool:  "param {param} must be a boolean, got {value}",
+	ParamErrorInvalidUUID:  "param {param} must be a UUID, got {value}",
+	ParamErrorInvalidSlug:  "param {param} must be a slug, got {value}",
+	ParamErrorInvalidAlpha: "param {param} must contain only letters, got {value}",
+	ParamErrorInvalidAlnum: "param {param} must contain only letters and digits, got {value}",
+	ParamErrorInvalidDate:  "param {param} must be a date, got {value}",
+}
+
+var (
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	slugPattern  = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	alphaPattern = regexp.MustCompile(`^[a-zA-Z]+$`)
+	alnumPattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+type paramTypeCheck struct {
+	code  string
+	valid func(string) bool
+}
+
+var paramTypeChecks = map[ParamType]paramTypeCheck{
+	ParamTypeInt: {ParamErrorInvalidInt, func(v string) bool {
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	}},
+	ParamTypeUint: {ParamErrorInvalidUint, func(v string) bool {
+		_, err := strconv.ParseUint(v, 10, 64)
+		return err == nil
+	}},
+	ParamTypeFloat: {ParamErrorInvalidFloat, func(v string) bool {
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	}},
+	ParamTypeBool: {ParamErrorInvalidBool, func(v string) bool {
+		_, err := strconv.ParseBool(v)
+		return err == nil
+	}},
+	ParamTypeUUID:  {ParamErrorInvalidUUID, uuidPattern.MatchString},
+	ParamTypeSlug:  {ParamErrorInvalidSlug, slugPattern.MatchString},
+	ParamTypeAlpha: {ParamErrorInvalidAlpha, alphaPattern.MatchString},
+	ParamTypeAlnum: {ParamErrorInvalidAlnum, alnumPattern.MatchString},
+	ParamTypeDate: {ParamErrorInvalidDate, func(v string) bool {
+		_, err := time.Parse(DefaultDateLayout, v)
+		return err == nil
+	}},
+}
+
+func (t ParamType) valid() bool {
+	_, ok := paramTypeChecks[t]
+	return ok
+}
+
+// ParamError reports a path param that failed its typed constraint. It carries
+// a stable Code and the Args needed to render a message so callers such as API
+// gateways can localize it instead of relying on the English Error string.
+type ParamError struct {
+	Code  string
+	Group string
+	Route string
+	Param string
+	Type  ParamType
+	Value string
+}
+
+// Args returns the message arguments available to message catalogs.
+func (e ParamError) Args() map[string]string {
+	return map[string]string{
+		"group": e.Group,
+		"route": e.Route,
+		"param": e.Param,
+		"type":  string(e.Type),
+		"value": e.Value,
+	}
+}
+
+// Localize renders the error using the message registered for its Code in the
+// provided catalog, falling back to DefaultParamErrorMessages.
+func (e ParamError) Localize(catalog map[string]string) string {
+	message, ok := catalog[e.Code]
+	if !ok {
+		message, ok = DefaultParamErrorMessages[e.Code]
+	}
+	if !ok {
+		return fmt.Sprintf("param %s failed constraint %s", e.Param, e.Type)
+	}
+	return SubstituteTemplate(message, e.Args())
+}
+
+func (e ParamError) Error() string {
+	return fmt.Sprintf("route %q in group %q: %s", e.Route, e.Group, e.Localize(nil))
+}
+
+func (u *Group) checkParamConstraints(routeName string, constraints map[string]ParamType, params Params) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(constraints)) {
+		raw, ok := params[name]
+		if !ok || raw == nil {
+			continue
+		}
+
+		paramType := constraints[name]
+		value := fmt.Sprint(raw)
+		check := paramTypeChecks[paramType]
+		if paramType == ParamTypeDate {
+			layout, _ := u.DateFormat()
+			check.valid = func(v string) bool {
+				_, err := time.Parse(layout, v)
+				return err == nil
+			}
+		}
+		if value == "" || check.valid(value) {
+			continue
+		}
+
+		return ParamError{
+			Code:  check.code,
+			Group: groupDisplayName(u),
+			Route: routeName,
+			Param: name,
+			Type:  paramType,
+			Value: value,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestParamConstraintsReturnStructuredErrors(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com",
+				Routes:  map[string]string{"user": "/users/:id/:slug"},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"user": {Params: map[string]urlkit.ParamType{"id": urlkit.ParamTypeInt, "slug": urlkit.ParamTypeSlug}},
+				},
+			},
+		},
+	})
+
+	url, err := manager.Resolve("api", "user", urlkit.Params{"id": 42, "slug": "jane-doe"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if url != "https://api.example.com/users/42/jane-doe" {
+		t.Fatalf("unexpected URL: %s", url)
+	}
+
+	_, err = manager.Group("api").Builder("user").WithParam("id", "abc").WithParam("slug", "ok").Build()
+	var paramErr urlkit.ParamError
+	if !errors.As(err, &paramErr) {
+		t.Fatalf("expected ParamError, got %v", err)
+	}
+	if paramErr.Code != urlkit.ParamErrorInvalidInt || paramErr.Param != "id" || paramErr.Value != "abc" {
+		t.Fatalf("unexpected param error: %+v", paramErr)
+	}
+	if got := paramErr.Error(); got != `route "user" in group "api": param id must be an integer, got abc` {
+		t.Fatalf("unexpected message: %s", got)
+	}
+
+	spanish := map[string]string{urlkit.ParamErrorInvalidInt: "el parámetro {param} debe ser un entero (recibido {value})"}
+	if got := paramErr.Localize(spanish); got != "el parámetro id debe ser un entero (recibido abc)" {
+		t.Fatalf("unexpected localized message: %s", got)
+	}
+}
+
+func TestParamConstraintsRejectUnknownType(t *testing.T) {
+	group := urlkit.NewURIHelper("https://api.example.com", map[string]string{"user": "/users/:id"})
+	err := group.SetRouteMetadata("user", urlkit.RouteMetadata{Params: map[string]urlkit.ParamType{"id": "integer"}})
+	if err == nil {
+		t.Fatal("expected error for unsupported param type")
+	}
+}
@@ -0,0 +1,202 @@
+package urlkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ptre "github.com/soongo/path-to-regexp"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanOptions configures ExportPostman.
+type PostmanOptions struct {
+	// Name is the collection name. Defaults to "urlkit routes".
+	Name string
+	// Groups limits the export to these root groups. Defaults to all.
+	Groups []string
+}
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []*postmanItem    `json:"item"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []*postmanItem  `json:"item,omitempty"`
+	Request *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	Header []postmanHeader `json:"header"`
+	URL    postmanURL      `json:"url"`
+	Body   *postmanBody    `json:"body,omitempty"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanVariable `json:"bearer"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// ExportPostman returns a Postman v2.1 collection of the registered routes:
+// a folder per group (nested like the groups), a collection variable holding
+// each root group's base URL ({{api_base_url}}) and a request per route. As
+// with ExampleCommand, the method comes from the route metadata (GET when
+// unset), path params are Postman path variables pre-filled with example
+// values, and requests authenticate with a {{token}} bearer variable. The
+// output imports into Postman and Insomnia.
+//
+// Example:
+//
+//	collection, err := manager.ExportPostman(urlkit.PostmanOptions{Name: "Shop API", Groups: []string{"api"}})
+//	os.WriteFile("shop.postman_collection.json", collection, 0o644)
+func (m *RouteManager) ExportPostman(opts PostmanOptions) ([]byte, error) {
+	collection := postmanCollection{
+		Info: postmanInfo{Name: opts.Name, Schema: postmanSchema},
+		Item: []*postmanItem{},
+		Auth: &postmanAuth{Type: "bearer", Bearer: []postmanVariable{{Key: "token", Value: "{{token}}", Type: "string"}}},
+	}
+	if collection.Info.Name == "" {
+		collection.Info.Name = "urlkit routes"
+	}
+
+	for _, name := range opts.Groups {
+		if _, err := m.GetGroup(name); err != nil {
+			return nil, fmt.Errorf("export postman: %w", err)
+		}
+	}
+
+	folders := map[string]*postmanItem{}
+	var folderFor func(fqn string) *postmanItem
+	folderFor = func(fqn string) *postmanItem {
+		if folder, ok := folders[fqn]; ok {
+			return folder
+		}
+		parent, name := "", fqn
+		if idx := strings.LastIndex(fqn, "."); idx >= 0 {
+			parent, name = fqn[:idx], fqn[idx+1:]
+		}
+		folder := &postmanItem{Name: name, Item: []*postmanItem{}}
+		folders[fqn] = folder
+		if parent == "" {
+			collection.Item = append(collection.Item, folder)
+		} else {
+			parentFolder := folderFor(parent)
+			parentFolder.Item = append(parentFolder.Item, folder)
+		}
+		return folder
+	}
+
+	for _, entry := range m.Manifest() {
+		root, _, _ := strings.Cut(entry.GroupFQN, ".")
+		if len(opts.Groups) > 0 && !slices.Contains(opts.Groups, root) {
+			continue
+		}
+		group, err := m.GetGroup(entry.GroupFQN)
+		if err != nil {
+			return nil, fmt.Errorf("export postman: %w", err)
+		}
+
+		baseVar := root + "_base_url"
+		if _, ok := folders[root]; !ok {
+			rootGroup := group.getRootGroup()
+			rootGroup.mu.RLock()
+			baseURL := strings.TrimSuffix(rootGroup.baseURL, "/")
+			rootGroup.mu.RUnlock()
+			collection.Variable = append(collection.Variable, postmanVariable{Key: baseVar, Value: baseURL, Type: "string"})
+		}
+
+		request, err := group.postmanRequest(entry, baseVar)
+		if err != nil {
+			return nil, fmt.Errorf("export postman: %s.%s: %w", entry.GroupFQN, entry.RouteKey, err)
+		}
+		folder := folderFor(entry.GroupFQN)
+		folder.Item = append(folder.Item, &postmanItem{Name: entry.RouteKey, Request: request})
+	}
+	collection.Variable = append(collection.Variable, postmanVariable{Key: "token", Value: "", Type: "string"})
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+// postmanRequest builds the request for a manifest entry of this group.
+func (u *Group) postmanRequest(entry RouteManifestEntry, baseVar string) (*postmanRequest, error) {
+	tokens, err := ptre.Parse(u.runtime.applyGlobalPrefix(entry.FullPathTemplate), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := u.exampleParams(entry.FullPathTemplate, entry.RouteKey, nil)
+	var (
+		path      strings.Builder
+		variables []postmanVariable
+	)
+	for _, token := range tokens {
+		switch t := token.(type) {
+		case string:
+			path.WriteString(t)
+		case ptre.Token:
+			name, ok := t.Name.(string)
+			if !ok {
+				continue
+			}
+			path.WriteString(t.Prefix + ":" + name + t.Suffix)
+			variables = append(variables, postmanVariable{Key: name, Value: fmt.Sprint(examples[name])})
+		}
+	}
+
+	method := http.MethodGet
+	if meta, ok := u.RouteMetadata(entry.RouteKey); ok && meta.Method != "" {
+		method = meta.Method
+	}
+
+	request := &postmanRequest{
+		Method: method,
+		Header: []postmanHeader{},
+		URL: postmanURL{
+			Raw:      "{{" + baseVar + "}}" + path.String(),
+			Host:     []string{"{{" + baseVar + "}}"},
+			Path:     strings.FieldsFunc(path.String(), func(r rune) bool { return r == '/' }),
+			Variable: variables,
+		},
+	}
+	if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+		request.Header = append(request.Header, postmanHeader{Key: "Content-Type", Value: "application/json"})
+		request.Body = &postmanBody{Mode: "raw", Raw: "{}"}
+	}
+	return request, nil
+}
@@ -0,0 +1,110 @@
+package urlkit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestExportPostman(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com/",
+				Routes:  map[string]string{"status": "/status"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "v1", Path: "/v1", Routes: map[string]string{"user": "/users/:id(\\d+)", "create_user": "/users"}},
+				},
+			},
+			{Name: "web", BaseURL: "https://example.com", Routes: map[string]string{"home": "/"}},
+		},
+	})
+	v1, err := manager.GetGroup("api.v1")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if err := v1.SetRouteMetadata("user", urlkit.RouteMetadata{Params: map[string]urlkit.ParamType{"id": urlkit.ParamTypeInt}}); err != nil {
+		t.Fatalf("SetRouteMetadata failed: %v", err)
+	}
+	if err := v1.SetRouteMetadata("create_user", urlkit.RouteMetadata{Method: "post"}); err != nil {
+		t.Fatalf("SetRouteMetadata failed: %v", err)
+	}
+
+	data, err := manager.ExportPostman(urlkit.PostmanOptions{Name: "API", Groups: []string{"api"}})
+	if err != nil {
+		t.Fatalf("ExportPostman failed: %v", err)
+	}
+
+	var collection struct {
+		Info struct {
+			Name   string `json:"name"`
+			Schema string `json:"schema"`
+		} `json:"info"`
+		Item     []postmanTestItem `json:"item"`
+		Variable []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"variable"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatalf("invalid collection JSON: %v", err)
+	}
+	if collection.Info.Name != "API" || collection.Info.Schema == "" {
+		t.Fatalf("unexpected info: %+v", collection.Info)
+	}
+	if len(collection.Item) != 1 || collection.Item[0].Name != "api" {
+		t.Fatalf("expected only the api folder, got %+v", collection.Item)
+	}
+	if len(collection.Variable) == 0 || collection.Variable[0].Key != "api_base_url" || collection.Variable[0].Value != "https://api.example.com" {
+		t.Fatalf("unexpected variables: %+v", collection.Variable)
+	}
+
+	api := collection.Item[0]
+	if len(api.Item) != 2 || api.Item[0].Name != "status" || api.Item[1].Name != "v1" {
+		t.Fatalf("unexpected api folder: %+v", api.Item)
+	}
+	v1Folder := api.Item[1]
+	if len(v1Folder.Item) != 2 {
+		t.Fatalf("expected two v1 requests, got %+v", v1Folder.Item)
+	}
+
+	create := v1Folder.Item[0].Request
+	if v1Folder.Item[0].Name != "create_user" || create.Method != "POST" || create.Body == nil {
+		t.Fatalf("unexpected create request: %+v", v1Folder.Item[0])
+	}
+
+	user := v1Folder.Item[1].Request
+	if user.URL.Raw != "{{api_base_url}}/v1/users/:id" {
+		t.Fatalf("unexpected raw url %q", user.URL.Raw)
+	}
+	if len(user.URL.Variable) != 1 || user.URL.Variable[0].Key != "id" || user.URL.Variable[0].Value != "1" {
+		t.Fatalf("unexpected path variables: %+v", user.URL.Variable)
+	}
+	if user.Method != "GET" {
+		t.Fatalf("expected GET default, got %q", user.Method)
+	}
+
+	if _, err := manager.ExportPostman(urlkit.PostmanOptions{Groups: []string{"missing"}}); err == nil {
+		t.Fatal("expected error for unknown group")
+	}
+}
+
+type postmanTestItem struct {
+	Name    string            `json:"name"`
+	Item    []postmanTestItem `json:"item"`
+	Request *struct {
+		Method string `json:"method"`
+		URL    struct {
+			Raw      string `json:"raw"`
+			Variable []struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			} `json:"variable"`
+		} `json:"url"`
+		Body *struct {
+			Raw string `json:"raw"`
+		} `json:"body"`
+	} `json:"request"`
+}
@@ -0,0 +1,134 @@
+package urlkit
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrProfileNotFound is returned when activating a profile that no group defines.
+var ErrProfileNotFound = errors.New("profile not found")
+
+func (r *runtimeState) activeProfile() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.profile
+}
+
+func (r *runtimeState) setProfile(profile string) string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous := r.profile
+	r.profile = profile
+	return previous
+}
+
+// SetProfileVars registers the template variables contributed by this group when
+// the named profile is active. Profile variables overlay the group's regular
+// template variables and follow the same child-overrides-parent precedence.
+// Calling it again for the same profile replaces the previous variable set.
+//
+// Example:
+//
+//	group.SetProfileVars("staging", map[string]string{"host": "staging.example.com"})
+//	manager.UseProfile("staging")
+func (u *Group) SetProfileVars(profile string, vars map[string]string) error {
+	if profile == "" {
+		return fmt.Errorf("set profile vars: profile name is required")
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set profile vars", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.profiles == nil {
+		u.profiles = make(map[string]map[string]string)
+	}
+	u.profiles[profile] = maps.Clone(vars)
+	if u.profiles[profile] == nil {
+		u.profiles[profile] = map[string]string{}
+	}
+	return nil
+}
+
+// ProfileVars returns a copy of the variables this group contributes for the
+// given profile. It does not search the hierarchy.
+func (u *Group) ProfileVars(profile string) (map[string]string, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	vars, ok := u.profiles[profile]
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(vars), true
+}
+
+// UseProfile activates the named variable profile for every group in the manager.
+// Switching is atomic: all subsequent renders observe the new profile's variables
+// at once. Pass an empty name to return to the base template variables.
+// Returns ErrProfileNotFound when no group defines the requested profile.
+func (m *RouteManager) UseProfile(name string) error {
+	if name != "" && !slices.Contains(m.Profiles(), name) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	if previous := m.runtime.setProfile(name); previous != name {
+		m.runtime.publish(Event{Type: EventTemplateChanged, Profile: name})
+	}
+	return nil
+}
+
+// ActiveProfile returns the currently active profile name, or an empty string
+// when the base template variables are in use.
+func (m *RouteManager) ActiveProfile() string {
+	if m == nil {
+		return ""
+	}
+	return m.runtime.activeProfile()
+}
+
+// Profiles returns the sorted set of profile names defined by any group.
+func (m *RouteManager) Profiles() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	roots := make([]*Group, 0, len(m.groups))
+	for _, group := range m.groups {
+		roots = append(roots, group)
+	}
+	m.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	for _, root := range roots {
+		collectProfileNames(root, seen)
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+func collectProfileNames(group *Group, seen map[string]struct{}) {
+	group.mu.RLock()
+	for name := range group.profiles {
+		seen[name] = struct{}{}
+	}
+	children := slices.Collect(maps.Values(group.children))
+	group.mu.RUnlock()
+
+	for _, child := range children {
+		collectProfileNames(child, seen)
+	}
+}
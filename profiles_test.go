@@ -0,0 +1,103 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestUseProfileSwitchesTemplateVarsAtomically(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "api",
+				BaseURL:     "https://api.example.com",
+				URLTemplate: "{protocol}://{host}/{version}{route_path}",
+				TemplateVars: map[string]string{
+					"protocol": "https",
+					"host":     "api.example.com",
+					"version":  "v1",
+				},
+				Profiles: map[string]map[string]string{
+					"staging": {"host": "staging.example.com"},
+					"dev":     {"protocol": "http", "host": "localhost:8080"},
+				},
+				Routes: map[string]string{"status": "/status"},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:     "v2",
+						Routes:   map[string]string{"users": "/users"},
+						Profiles: map[string]map[string]string{"staging": {"version": "v2-beta"}},
+						TemplateVars: map[string]string{
+							"version": "v2",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	build := func(group, route string) string {
+		t.Helper()
+		url, err := manager.Resolve(group, route, nil, nil)
+		if err != nil {
+			t.Fatalf("Resolve(%s, %s) failed: %v", group, route, err)
+		}
+		return url
+	}
+
+	if got := build("api", "status"); got != "https://api.example.com/v1/status/" {
+		t.Fatalf("unexpected base URL: %s", got)
+	}
+
+	if err := manager.UseProfile("staging"); err != nil {
+		t.Fatalf("UseProfile failed: %v", err)
+	}
+	if manager.ActiveProfile() != "staging" {
+		t.Fatalf("expected staging profile, got %q", manager.ActiveProfile())
+	}
+	if got := build("api", "status"); got != "https://staging.example.com/v1/status/" {
+		t.Fatalf("unexpected staging URL: %s", got)
+	}
+	if got := build("api.v2", "users"); got != "https://staging.example.com/v2-beta/users/" {
+		t.Fatalf("unexpected staging child URL: %s", got)
+	}
+
+	if err := manager.UseProfile("dev"); err != nil {
+		t.Fatalf("UseProfile failed: %v", err)
+	}
+	if got := build("api.v2", "users"); got != "http://localhost:8080/v2/users/" {
+		t.Fatalf("unexpected dev child URL: %s", got)
+	}
+
+	if err := manager.UseProfile(""); err != nil {
+		t.Fatalf("UseProfile reset failed: %v", err)
+	}
+	if got := build("api", "status"); got != "https://api.example.com/v1/status/" {
+		t.Fatalf("expected base vars after reset, got %s", got)
+	}
+}
+
+func TestUseProfileUnknownProfile(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{"status": "/status"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := group.SetProfileVars("prod", map[string]string{"env": "prod"}); err != nil {
+		t.Fatalf("SetProfileVars failed: %v", err)
+	}
+
+	if err := manager.UseProfile("qa"); !errors.Is(err, urlkit.ErrProfileNotFound) {
+		t.Fatalf("expected ErrProfileNotFound, got %v", err)
+	}
+	if manager.ActiveProfile() != "" {
+		t.Fatalf("expected no active profile, got %q", manager.ActiveProfile())
+	}
+
+	profiles := manager.Profiles()
+	if len(profiles) != 1 || profiles[0] != "prod" {
+		t.Fatalf("unexpected profiles: %v", profiles)
+	}
+}
@@ -0,0 +1,101 @@
+package urlkit
+
+import (
+	"maps"
+	"net/url"
+)
+
+// SetQueryNames registers localized query parameter names for this group and
+// its descendants, keyed by canonical name (e.g. {"page": "seite"}). Builders
+// keep using canonical names; the localized ones are emitted at build time.
+// Child groups override mappings inherited from their parents. Calling it again
+// replaces the group's previous mapping.
+//
+// Example:
+//
+//	frontend.Group("de").SetQueryNames(map[string]string{"page": "seite"})
+//	de.Builder("search").WithQuery("page", 2).Build() // /de/search?seite=2
+func (u *Group) SetQueryNames(names map[string]string) error {
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set query names", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.queryNames = maps.Clone(names)
+	return nil
+}
+
+// QueryNames returns the effective canonical-to-localized query name mapping,
+// merged from the root down to this group.
+func (u *Group) QueryNames() map[string]string {
+	var chain []*Group
+	for current := u; current != nil; {
+		current.mu.RLock()
+		parent := current.parent
+		current.mu.RUnlock()
+
+		chain = append(chain, current)
+		current = parent
+	}
+
+	names := make(map[string]string)
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].mu.RLock()
+		maps.Copy(names, chain[i].queryNames)
+		chain[i].mu.RUnlock()
+	}
+	return names
+}
+
+// CanonicalQuery maps localized query names in values back to their canonical
+// names, for handlers and matchers that parse inbound URLs. Unmapped names are
+// kept as they are.
+func (u *Group) CanonicalQuery(values url.Values) url.Values {
+	names := u.QueryNames()
+	if len(names) == 0 {
+		return values
+	}
+
+	reverse := make(map[string]string, len(names))
+	for canonical, localized := range names {
+		reverse[localized] = canonical
+	}
+
+	out := make(url.Values, len(values))
+	for key, vals := range values {
+		if canonical, ok := reverse[key]; ok {
+			key = canonical
+		}
+		out[key] = append(out[key], vals...)
+	}
+	return out
+}
+
+// localizeQueries renames query keys using the group's query name mapping.
+func (u *Group) localizeQueries(queries []Query) []Query {
+	if len(queries) == 0 {
+		return queries
+	}
+	names := u.QueryNames()
+	if len(names) == 0 {
+		return queries
+	}
+
+	localized := make([]Query, len(queries))
+	for i, query := range queries {
+		renamed := make(Query, len(query))
+		for key, value := range query {
+			if name, ok := names[key]; ok {
+				key = name
+			}
+			renamed[key] = value
+		}
+		localized[i] = renamed
+	}
+	return localized
+}
@@ -0,0 +1,55 @@
+package urlkit_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestLocalizedQueryNames(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"search": "/search"},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:       "de",
+						Path:       "/de",
+						Routes:     map[string]string{"search": "/suche"},
+						QueryNames: map[string]string{"page": "seite", "sort": "sortierung"},
+					},
+				},
+			},
+		},
+	})
+	frontend, err := manager.GetGroup("frontend")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	de := frontend.Group("de")
+
+	got, err := frontend.Builder("search").WithQuery("page", 2).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://example.com/search?page=2"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	got, err = de.Builder("search").WithQuery("page", 2).WithQuery("q", "schuhe").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://example.com/de/suche?q=schuhe&seite=2"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	canonical := de.CanonicalQuery(url.Values{"seite": {"2"}, "q": {"schuhe"}})
+	if want := (url.Values{"page": {"2"}, "q": {"schuhe"}}); !reflect.DeepEqual(canonical, want) {
+		t.Fatalf("expected %v, got %v", want, canonical)
+	}
+}
@@ -0,0 +1,177 @@
+package urlkit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WithQueryStruct sets query parameters from the exported fields of a struct
+// (or pointer to struct), so filter and pagination types can be passed as is.
+// Fields are named by their `url` tag, falling back to the lower-cased field
+// name; `url:"-"` skips a field. Tag options:
+//
+//   - omitempty skips zero values and empty slices
+//   - layout=<layout> formats time.Time fields (default time.RFC3339)
+//
+// Slices and arrays become repeated keys (?tag=a&tag=b), nil pointers are
+// omitted and other pointers are dereferenced. Embedded structs without a tag
+// are flattened. A nil value is a no-op.
+//
+// Example:
+//
+//	type ListUsers struct {
+//		Page   int       `url:"page,omitempty"`
+//		Tags   []string  `url:"tag"`
+//		Since  time.Time `url:"since,omitempty,layout=2006-01-02"`
+//		Active *bool     `url:"active"`
+//	}
+//
+//	b.WithQueryStruct(ListUsers{Page: 2, Tags: []string{"go", "urls"}}) // ?page=2&tag=go&tag=urls
+func (b *Builder) WithQueryStruct(v any) *Builder {
+	if b.err != nil {
+		return b
+	}
+
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return b
+	}
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return b
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		b.err = fmt.Errorf("unsupported query struct type %T", v)
+		return b
+	}
+
+	if err := b.addQueryStruct(value); err != nil {
+		b.err = err
+	}
+	return b
+}
+
+func (b *Builder) addQueryStruct(value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Anonymous && tag == "" {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded.Type() != timeType {
+				if err := b.addQueryStruct(embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options := parseQueryTag(tag)
+		if name == "" {
+			name = lowerFirst(field.Name)
+		}
+
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if options.omitEmpty && isEmptyQueryValue(fieldValue) {
+			continue
+		}
+
+		if (fieldValue.Kind() == reflect.Slice || fieldValue.Kind() == reflect.Array) && fieldValue.Type().Elem().Kind() != reflect.Uint8 {
+			values := make([]string, 0, fieldValue.Len())
+			for j := 0; j < fieldValue.Len(); j++ {
+				formatted, err := formatQueryValue(fieldValue.Index(j), options)
+				if err != nil {
+					return fmt.Errorf("query field %s: %w", field.Name, err)
+				}
+				values = append(values, formatted)
+			}
+			b.setMultiQueryValues(name, values)
+			continue
+		}
+
+		formatted, err := formatQueryValue(fieldValue, options)
+		if err != nil {
+			return fmt.Errorf("query field %s: %w", field.Name, err)
+		}
+		b.WithQuery(name, formatted)
+	}
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type queryTagOptions struct {
+	omitEmpty bool
+	layout    string
+}
+
+// parseQueryTag splits a `url:"name,omitempty,layout=..."` tag. The layout
+// option must come last since layouts may contain commas.
+func parseQueryTag(tag string) (string, queryTagOptions) {
+	options := queryTagOptions{layout: time.RFC3339}
+	name, rest, _ := strings.Cut(tag, ",")
+	for rest != "" {
+		if layout, ok := strings.CutPrefix(rest, "layout="); ok {
+			options.layout = layout
+			break
+		}
+		var option string
+		option, rest, _ = strings.Cut(rest, ",")
+		if option == "omitempty" {
+			options.omitEmpty = true
+		}
+	}
+	return name, options
+}
+
+func isEmptyQueryValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+func formatQueryValue(value reflect.Value, options queryTagOptions) (string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(options.layout), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Func, reflect.Chan:
+		if stringer, ok := value.Interface().(fmt.Stringer); ok {
+			return stringer.String(), nil
+		}
+		return "", fmt.Errorf("unsupported query value type %s", value.Type())
+	}
+	return fmt.Sprint(value.Interface()), nil
+}
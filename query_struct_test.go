@@ -0,0 +1,65 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+type pagination struct {
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+type userFilter struct {
+	pagination
+	Query    string    `url:"q"`
+	Tags     []string  `url:"tag,omitempty"`
+	Since    time.Time `url:"since,omitempty,layout=2006-01-02"`
+	Until    time.Time `url:"until,omitempty"`
+	Active   *bool     `url:"active"`
+	Limit    *int      `url:"limit"`
+	Internal string    `url:"-"`
+	Sort     string
+}
+
+func TestBuilderWithQueryStruct(t *testing.T) {
+	group := urlkit.NewURIHelper("https://api.example.com", map[string]string{"users": "/users"})
+
+	active := true
+	urlStr, err := group.Builder("users").WithQueryStruct(&userFilter{
+		pagination: pagination{Page: 2},
+		Query:      "a b",
+		Tags:       []string{"go", "urls"},
+		Since:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Active:     &active,
+		Internal:   "secret",
+		Sort:       "name",
+	}).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://api.example.com/users?active=true&page=2&q=a+b&since=2024-03-01&sort=name&tag=go&tag=urls"; urlStr != want {
+		t.Fatalf("expected %q, got %q", want, urlStr)
+	}
+
+	urlStr, err = group.Builder("users").
+		WithQuery("page", 9).
+		WithQueryStruct(userFilter{Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if want := "https://api.example.com/users?page=9&q=&sort=&until=2024-03-01T00%3A00%3A00Z"; urlStr != want {
+		t.Fatalf("expected %q, got %q", want, urlStr)
+	}
+
+	if _, err := group.Builder("users").WithQueryStruct("page=1").Build(); err == nil || !strings.Contains(err.Error(), "unsupported query struct type string") {
+		t.Fatalf("expected unsupported type error, got %v", err)
+	}
+	if _, err := group.Builder("users").WithQueryStruct((*userFilter)(nil)).Build(); err != nil {
+		t.Fatalf("expected nil struct to be a no-op, got %v", err)
+	}
+}
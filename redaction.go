@@ -0,0 +1,155 @@
+package urlkit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ptre "github.com/soongo/path-to-regexp"
+)
+
+// Redaction selects how a sensitive param value is hidden from build
+// interceptors, logs and metrics.
+type Redaction string
+
+const (
+	// RedactMask replaces the value with RedactedValue.
+	RedactMask Redaction = "mask"
+	// RedactHash replaces the value with a short SHA-256 digest, so equal
+	// values stay correlatable without being exposed.
+	RedactHash Redaction = "hash"
+)
+
+// RedactedValue replaces params marked with RedactMask.
+const RedactedValue = "REDACTED"
+
+func (r Redaction) valid() bool {
+	return r == RedactMask || r == RedactHash
+}
+
+func (r Redaction) apply(value string) string {
+	if r == RedactHash {
+		sum := sha256.Sum256([]byte(value))
+		return "sha256-" + hex.EncodeToString(sum[:6])
+	}
+	return RedactedValue
+}
+
+// redactBuild hides the route's sensitive param values, as declared in
+// RouteMetadata.Sensitive, in a build reported to observers. Values are
+// replaced in params, in same-named query params and in the route's part of
+// the URL path; the host and literal path segments are left alone. Empty
+// values are not redacted. The caller still receives the real URL.
+func (u *Group) redactBuild(req *BuildRequest, built string) string {
+	meta, ok := u.RouteMetadata(req.Route)
+	if !ok || len(meta.Sensitive) == 0 {
+		return built
+	}
+
+	target, err := url.Parse(built)
+	if err != nil {
+		return RedactedValue
+	}
+
+	real := cloneParamsMap(req.Params)
+	redacted := false
+	for name, mode := range meta.Sensitive {
+		if value, ok := req.Params[name]; ok {
+			if raw := fmt.Sprint(value); raw != "" {
+				req.Params[name] = mode.apply(raw)
+				redacted = true
+			}
+		}
+	}
+	if redacted {
+		u.redactPath(target, req, real, meta.Sensitive)
+	}
+
+	queries := make([]Query, len(req.Queries))
+	for i, query := range req.Queries {
+		queries[i] = make(Query, len(query))
+		for key, value := range query {
+			if mode, ok := meta.Sensitive[key]; ok && value != "" {
+				value = mode.apply(value)
+			}
+			queries[i][key] = value
+		}
+	}
+	req.Queries = queries
+
+	values := target.Query()
+	queryRedacted := false
+	for key, list := range values {
+		mode, ok := meta.Sensitive[key]
+		if !ok {
+			continue
+		}
+		for i, value := range list {
+			if value != "" {
+				list[i] = mode.apply(value)
+				queryRedacted = true
+			}
+		}
+	}
+	if queryRedacted {
+		target.RawQuery = values.Encode()
+	}
+	return target.String()
+}
+
+// redactPath replaces the rendered route path inside target's path with the
+// route rendered from the redacted params in req. When the rendered route
+// cannot be located, path segments equal to a sensitive value are masked
+// instead.
+func (u *Group) redactPath(target *url.URL, req *BuildRequest, real Params, sensitive map[string]Redaction) {
+	template, err := u.Route(req.Route)
+	if vanity, ok := u.VanityRoute(req.Route); ok && req.Variant == RouteVariantVanity {
+		template = vanity
+	}
+	path := target.EscapedPath()
+	if err == nil {
+		realPath, realErr := renderRedactionPath(template, u.formatDateParams(real))
+		maskedPath, maskedErr := renderRedactionPath(template, req.Params)
+		if realErr == nil && maskedErr == nil && realPath != "" && realPath != "/" {
+			if at := strings.LastIndex(path, realPath); at >= 0 {
+				target.RawPath = path[:at] + maskedPath + path[at+len(realPath):]
+				target.Path, _ = url.PathUnescape(target.RawPath)
+				return
+			}
+		}
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil || decoded == "" {
+			continue
+		}
+		for name, mode := range sensitive {
+			if value, ok := real[name]; ok && fmt.Sprint(value) == decoded {
+				segments[i] = url.PathEscape(mode.apply(decoded))
+				break
+			}
+		}
+	}
+	target.RawPath = strings.Join(segments, "/")
+	target.Path, _ = url.PathUnescape(target.RawPath)
+}
+
+// renderRedactionPath renders template without checking params against their
+// patterns, since redacted values rarely match them.
+func renderRedactionPath(template string, params Params) (string, error) {
+	validate := false
+	compiled, err := ptre.Compile(template, &ptre.Options{
+		Validate: &validate,
+		Encode: func(uri string, token any) string {
+			return url.PathEscape(uri)
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return compiled(params)
+}
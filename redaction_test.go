@@ -0,0 +1,100 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSensitiveParamsRedactedForInterceptor(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "frontend",
+			BaseURL: "https://example.com",
+			Routes:  map[string]string{"verify": "/verify/:email/:token"},
+			Metadata: map[string]urlkit.RouteMetadata{
+				"verify": {Sensitive: map[string]urlkit.Redaction{
+					"email":  urlkit.RedactHash,
+					"token":  urlkit.RedactMask,
+					"secret": urlkit.RedactMask,
+				}},
+			},
+		}},
+	})
+
+	var logged string
+	var params urlkit.Params
+	var queries []urlkit.Query
+	manager.SetBuildInterceptor(func(req urlkit.BuildRequest, url string) {
+		logged, params, queries = url, req.Params, req.Queries
+	})
+
+	built, err := manager.Group("frontend").Render("verify",
+		urlkit.Params{"email": "ana@example.com", "token": "t0k3n"},
+		urlkit.Query{"secret": "s3cr3t", "ref": "mail"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if built != "https://example.com/verify/ana@example.com/t0k3n?ref=mail&secret=s3cr3t" {
+		t.Fatalf("expected caller to get the real URL, got %s", built)
+	}
+
+	for _, secret := range []string{"ana@example.com", "t0k3n", "s3cr3t"} {
+		if strings.Contains(logged, secret) {
+			t.Fatalf("expected %q to be redacted from %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "/verify/sha256-") || !strings.Contains(logged, "/"+urlkit.RedactedValue+"?") || !strings.Contains(logged, "ref=mail") {
+		t.Fatalf("unexpected redacted URL %s", logged)
+	}
+	if params["token"] != urlkit.RedactedValue || params["email"] == "ana@example.com" || queries[0]["secret"] != urlkit.RedactedValue {
+		t.Fatalf("expected params and queries to be redacted, got %v %v", params, queries)
+	}
+
+	// Hashing is stable so equal values stay correlatable.
+	first := logged
+	manager.Group("frontend").Render("verify", urlkit.Params{"email": "ana@example.com", "token": "other"})
+	if strings.Split(first, "/")[4] != strings.Split(logged, "/")[4] {
+		t.Fatalf("expected stable hash, got %s and %s", first, logged)
+	}
+
+	err = manager.Group("frontend").SetRouteMetadata("verify", urlkit.RouteMetadata{Sensitive: map[string]urlkit.Redaction{"email": "drop"}})
+	if err == nil {
+		t.Fatal("expected error for unsupported redaction")
+	}
+}
+
+func TestSensitiveParamsRedactedStructurally(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "frontend",
+			BaseURL: "https://v1.example.com",
+			Routes:  map[string]string{"user": "/v1/users/:id", "file": "/files/:name.pdf"},
+			Metadata: map[string]urlkit.RouteMetadata{
+				"user": {Sensitive: map[string]urlkit.Redaction{"id": urlkit.RedactMask, "code": urlkit.RedactMask}},
+				"file": {Sensitive: map[string]urlkit.Redaction{"name": urlkit.RedactMask}},
+			},
+		}},
+	})
+
+	var logged string
+	manager.SetBuildInterceptor(func(_ urlkit.BuildRequest, url string) {
+		logged = url
+	})
+	frontend := manager.Group("frontend")
+
+	if _, err := frontend.Render("user", urlkit.Params{"id": 1}, urlkit.Query{"code": "", "v": "1"}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if logged != "https://v1.example.com/v1/users/"+urlkit.RedactedValue+"?code=&v=1" {
+		t.Fatalf("expected only the id segment to be redacted, got %s", logged)
+	}
+
+	if _, err := frontend.Render("file", urlkit.Params{"name": "report"}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if logged != "https://v1.example.com/files/"+urlkit.RedactedValue+".pdf" {
+		t.Fatalf("expected the partial segment to be redacted, got %s", logged)
+	}
+}
@@ -0,0 +1,151 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// GroupType selects how a group configuration is interpreted.
+type GroupType string
+
+const (
+	// GroupTypeRoutes is the default: a group of routes that build URLs.
+	GroupTypeRoutes GroupType = ""
+	// GroupTypeRedirects declares redirect rules instead of routes. See
+	// GroupConfig.Redirects and RouteManager.ResolveRedirect.
+	GroupTypeRedirects GroupType = "redirects"
+)
+
+// redirectStatuses are the HTTP statuses accepted for redirect rules.
+var redirectStatuses = []int{
+	http.StatusMovedPermanently,
+	http.StatusFound,
+	http.StatusSeeOther,
+	http.StatusTemporaryRedirect,
+	http.StatusPermanentRedirect,
+}
+
+// RedirectRule maps an inbound path pattern to the route it redirects to.
+// Params captured by From render the target route.
+type RedirectRule struct {
+	// From is the source path pattern, e.g. "/old/users/:id".
+	From string `json:"from" yaml:"from"`
+	// Route is the fully qualified target route, e.g. "api.users.show".
+	Route string `json:"route" yaml:"route"`
+	// Status is the redirect status code. Defaults to 301.
+	Status int `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type compiledRedirect struct {
+	compiledRewriteRule
+	status int
+}
+
+// AddRedirects registers redirect rules resolved by ResolveRedirect. Rules are
+// tried in registration order. Every rule is validated up front: the target
+// route must exist, From must capture each required param of the route, and
+// Status must be a redirect status.
+//
+// Redirects can also be declared in configuration with a group of type
+// "redirects":
+//
+//	groups:
+//	  - name: legacy
+//	    type: redirects
+//	    redirects:
+//	      - from: /old/users/:id
+//	        route: api.users.show
+//	        status: 308
+func (m *RouteManager) AddRedirects(rules ...RedirectRule) error {
+	compiled := make([]compiledRedirect, 0, len(rules))
+	for _, rule := range rules {
+		status := rule.Status
+		if status == 0 {
+			status = http.StatusMovedPermanently
+		}
+		if !slices.Contains(redirectStatuses, status) {
+			return fmt.Errorf("redirect %q: status %d is not a redirect status", rule.From, status)
+		}
+		entry, err := m.compileRewriteRule(RewriteRule{From: rule.From, Route: rule.Route})
+		if err != nil {
+			return err
+		}
+		compiled = append(compiled, compiledRedirect{compiledRewriteRule: entry, status: status})
+	}
+
+	releaseMutation, err := m.runtime.beginMutation("add redirects", "")
+	if err != nil {
+		return err
+	}
+	defer m.runtime.publish(Event{Type: EventRoutesChanged})
+	defer releaseMutation()
+
+	m.mu.Lock()
+	m.redirects = append(m.redirects, compiled...)
+	m.mu.Unlock()
+	return nil
+}
+
+// ResolveRedirect returns the target URL and status of the first redirect rule
+// matching path, for use in a redirect handler. The global prefix, if set, is
+// stripped from path first.
+//
+// Example:
+//
+//	if target, status, ok := manager.ResolveRedirect(r.URL.Path); ok {
+//		http.Redirect(w, r, target, status)
+//		return
+//	}
+func (m *RouteManager) ResolveRedirect(path string) (string, int, bool) {
+	if m == nil {
+		return "", 0, false
+	}
+	path, ok := m.runtime.stripGlobalPrefix(path)
+	if !ok {
+		return "", 0, false
+	}
+
+	m.mu.RLock()
+	redirects := m.redirects
+	m.mu.RUnlock()
+
+	for _, redirect := range redirects {
+		params, ok := redirect.match(path)
+		if !ok {
+			continue
+		}
+		target, err := redirect.group.Render(redirect.route, coerceParams(params))
+		if err != nil {
+			return "", 0, false
+		}
+		return target, redirect.status, true
+	}
+	return "", 0, false
+}
+
+// redirectsFromConfig validates a redirects group and returns its rules. They
+// are registered after every group has loaded so they can target groups
+// declared later in the same configuration.
+func redirectsFromConfig(cfg GroupConfig) ([]RedirectRule, error) {
+	if cfg.BaseURL != "" || cfg.Path != "" || len(cfg.effectiveRoutes()) > 0 || len(cfg.Groups) > 0 {
+		return nil, fmt.Errorf("configuration error: redirects group %s can only declare redirects", cfg.Name)
+	}
+	return cfg.Redirects, nil
+}
+
+// checkGroupType rejects unknown group types and redirect rules declared on a
+// regular group.
+func checkGroupType(cfg GroupConfig) error {
+	switch cfg.Type {
+	case GroupTypeRoutes:
+		if len(cfg.Redirects) > 0 {
+			return fmt.Errorf("configuration error: group %s declares redirects but is not of type %q", cfg.Name, GroupTypeRedirects)
+		}
+		return nil
+	case GroupTypeRedirects:
+		return fmt.Errorf("configuration error: redirects group %s must be a root group", cfg.Name)
+	default:
+		return fmt.Errorf("configuration error: group %s has unknown type %q", cfg.Name, cfg.Type)
+	}
+}
@@ -0,0 +1,57 @@
+package urlkit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRedirectsGroup(t *testing.T) {
+	var cfg urlkit.Config
+	raw := `{"groups": [
+		{"name": "legacy", "type": "redirects", "redirects": [
+			{"from": "/old/users/:id", "route": "api.user"},
+			{"from": "/promo", "route": "frontend.home", "status": 302}
+		]},
+		{"name": "api", "base_url": "https://api.example.com", "routes": {"user": "/users/:id"}},
+		{"name": "frontend", "base_url": "https://example.com", "routes": {"home": "/"}}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	manager := mustManagerFromConfig(t, cfg)
+
+	target, status, ok := manager.ResolveRedirect("/old/users/42")
+	if !ok || target != "https://api.example.com/users/42" || status != http.StatusMovedPermanently {
+		t.Fatalf("unexpected redirect %q %d %v", target, status, ok)
+	}
+	target, status, ok = manager.ResolveRedirect("/promo")
+	if !ok || target != "https://example.com/" || status != http.StatusFound {
+		t.Fatalf("unexpected redirect %q %d %v", target, status, ok)
+	}
+	if _, _, ok := manager.ResolveRedirect("/users/42"); ok {
+		t.Fatal("expected no redirect for unmatched path")
+	}
+	if _, err := manager.GetGroup("legacy"); err == nil {
+		t.Fatal("expected redirects group not to be registered as a route group")
+	}
+
+	invalid := []urlkit.GroupConfig{
+		{Name: "legacy", Type: urlkit.GroupTypeRedirects, Redirects: []urlkit.RedirectRule{{From: "/x", Route: "missing.route"}}},
+		{Name: "legacy", Type: urlkit.GroupTypeRedirects, Redirects: []urlkit.RedirectRule{{From: "/old/users/:id", Route: "api.user", Status: 200}}},
+		{Name: "legacy", Type: urlkit.GroupTypeRedirects, Routes: map[string]string{"home": "/"}},
+		{Name: "legacy", BaseURL: "https://example.com", Redirects: []urlkit.RedirectRule{{From: "/x", Route: "api.user"}}},
+		{Name: "legacy", Type: "proxy"},
+	}
+	for i, group := range invalid {
+		cfg := urlkit.Config{Groups: []urlkit.GroupConfig{
+			{Name: "api", BaseURL: "https://api.example.com", Routes: map[string]string{"user": "/users/:id"}},
+			group,
+		}}
+		if _, err := urlkit.NewRouteManagerFromConfig(cfg); err == nil {
+			t.Fatalf("case %d: expected configuration error", i)
+		}
+	}
+}
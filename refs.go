@@ -0,0 +1,112 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var refPlaceholderPattern = regexp.MustCompile(`\{ref:([a-zA-Z0-9_\-]+(?:\.[a-zA-Z0-9_\-]+)+)\}`)
+
+// TemplateReferenceError reports a {ref:...} placeholder that could not be resolved.
+type TemplateReferenceError struct {
+	Group     string
+	Route     string
+	Reference string
+	Err       error
+}
+
+func (e TemplateReferenceError) Error() string {
+	return fmt.Sprintf(
+		"template reference %q failed for group %q route %q: %v",
+		e.Reference,
+		e.Group,
+		e.Route,
+		e.Err,
+	)
+}
+
+func (e TemplateReferenceError) Unwrap() error {
+	return e.Err
+}
+
+// splitRouteFQN splits a fully qualified route name (e.g. "api.v1.status") into
+// its group path ("api.v1") and route name ("status").
+func splitRouteFQN(fqn string) (string, string, error) {
+	idx := strings.LastIndex(fqn, ".")
+	if idx <= 0 || idx == len(fqn)-1 {
+		return "", "", fmt.Errorf("invalid route reference %q: expected group.route", fqn)
+	}
+	return fqn[:idx], fqn[idx+1:], nil
+}
+
+// resolveTemplateRefs replaces {ref:group.route} placeholders with the URL built
+// for the referenced route. References are resolved lazily at build time using
+// the same path params as the current render. Placeholders that appear in the
+// query portion of the template are query-escaped so the embedded URL survives
+// as a single parameter value.
+func (u *Group) resolveTemplateRefs(template, routeName string, params Params, visiting []string) (string, error) {
+	if !strings.Contains(template, "{ref:") {
+		return template, nil
+	}
+
+	self := routeName
+	if fqn := u.FQN(); fqn != "" {
+		self = fqn + "." + routeName
+	}
+	visiting = append(slices.Clone(visiting), self)
+
+	queryStart := strings.Index(template, "?")
+
+	var (
+		builder strings.Builder
+		last    int
+	)
+	for _, match := range refPlaceholderPattern.FindAllStringSubmatchIndex(maskEscapedBraces(template), -1) {
+		reference := template[match[2]:match[3]]
+		resolved, err := u.resolveTemplateRef(reference, params, visiting)
+		if err != nil {
+			return "", TemplateReferenceError{
+				Group:     groupDisplayName(u),
+				Route:     routeName,
+				Reference: reference,
+				Err:       err,
+			}
+		}
+
+		if queryStart >= 0 && match[0] > queryStart {
+			resolved = url.QueryEscape(resolved)
+		}
+
+		builder.WriteString(template[last:match[0]])
+		builder.WriteString(resolved)
+		last = match[1]
+	}
+	builder.WriteString(template[last:])
+
+	return builder.String(), nil
+}
+
+func (u *Group) resolveTemplateRef(reference string, params Params, visiting []string) (string, error) {
+	if slices.Contains(visiting, reference) {
+		return "", fmt.Errorf("reference cycle detected: %s -> %s", strings.Join(visiting, " -> "), reference)
+	}
+
+	if u.runtime == nil || u.runtime.lookup == nil {
+		return "", fmt.Errorf("references require a group registered in a route manager")
+	}
+
+	groupPath, route, err := splitRouteFQN(reference)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := u.runtime.lookup(groupPath)
+	if err != nil {
+		return "", err
+	}
+
+	return target.render(route, RouteVariantCanonical, "", params, visiting)
+}
@@ -0,0 +1,91 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestTemplateReferencesResolveOtherGroups(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com",
+				Groups: []urlkit.GroupConfig{
+					{Name: "v1", Path: "/v1", Routes: map[string]string{"status": "/status", "user": "/users/:id"}},
+				},
+			},
+			{
+				Name:        "hooks",
+				BaseURL:     "https://hooks.example.com",
+				URLTemplate: "{base_url}{route_path}?callback={ref:api.v1.user}",
+				Routes:      map[string]string{"subscribe": "/subscribe/:id"},
+			},
+			{
+				Name:        "mirror",
+				BaseURL:     "https://mirror.example.com",
+				URLTemplate: "{ref:api.v1.status}",
+				Routes:      map[string]string{"status": "/status"},
+			},
+		},
+	})
+
+	url, err := manager.Resolve("hooks", "subscribe", urlkit.Params{"id": 42}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	expected := "https://hooks.example.com/subscribe/42/?callback=https%3A%2F%2Fapi.example.com%2Fv1%2Fusers%2F42"
+	if url != expected {
+		t.Fatalf("expected %s, got %s", expected, url)
+	}
+
+	url, err = manager.Resolve("mirror", "status", nil, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if url != "https://api.example.com/v1/status" {
+		t.Fatalf("expected raw reference outside query, got %s", url)
+	}
+}
+
+func TestTemplateReferencesReportMissingAndCycles(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "a",
+				BaseURL:     "https://a.example.com",
+				URLTemplate: "{base_url}{route_path}?next={ref:b.home}",
+				Routes:      map[string]string{"home": "/"},
+			},
+			{
+				Name:        "b",
+				BaseURL:     "https://b.example.com",
+				URLTemplate: "{base_url}{route_path}?next={ref:a.home}",
+				Routes:      map[string]string{"home": "/"},
+			},
+			{
+				Name:        "c",
+				BaseURL:     "https://c.example.com",
+				URLTemplate: "{base_url}{route_path}?next={ref:missing.home}",
+				Routes:      map[string]string{"home": "/"},
+			},
+		},
+	})
+
+	_, err := manager.Resolve("a", "home", nil, nil)
+	var refErr urlkit.TemplateReferenceError
+	if !errors.As(err, &refErr) {
+		t.Fatalf("expected TemplateReferenceError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+
+	_, err = manager.Resolve("c", "home", nil, nil)
+	if !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+}
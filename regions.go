@@ -0,0 +1,105 @@
+package urlkit
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RegionRouting maps client hints (country or continent codes) to the regional
+// child groups that should serve them, in order of preference.
+type RegionRouting struct {
+	// Regions maps a hint such as "DE" or "EU" to child group names, tried in
+	// order until one exists.
+	Regions map[string][]string `json:"regions,omitempty" yaml:"regions,omitempty"`
+	// Continents maps country codes to continent codes, so a country without
+	// its own entry in Regions uses its continent's (e.g. {"DE": "EU"}).
+	Continents map[string]string `json:"continents,omitempty" yaml:"continents,omitempty"`
+	// Fallback lists child groups tried when nothing else matches.
+	Fallback []string `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+}
+
+// SetRegionRouting configures how ForRegion picks a regional child group.
+// Hints are case-insensitive. Child names are resolved at lookup time, so
+// routing can be set before the children are registered.
+func (u *Group) SetRegionRouting(routing RegionRouting) error {
+	normalized := RegionRouting{
+		Regions:    make(map[string][]string, len(routing.Regions)),
+		Continents: make(map[string]string, len(routing.Continents)),
+		Fallback:   slices.Clone(routing.Fallback),
+	}
+	for hint, children := range routing.Regions {
+		normalized.Regions[strings.ToUpper(hint)] = slices.Clone(children)
+	}
+	for country, continent := range routing.Continents {
+		normalized.Continents[strings.ToUpper(country)] = strings.ToUpper(continent)
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set region routing", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.regionRouting = &normalized
+	u.mu.Unlock()
+	return nil
+}
+
+// RegionRouting returns the routing configured with SetRegionRouting.
+func (u *Group) RegionRouting() (RegionRouting, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if u.regionRouting == nil {
+		return RegionRouting{}, false
+	}
+	routing := *u.regionRouting
+	routing.Regions = maps.Clone(routing.Regions)
+	routing.Continents = maps.Clone(routing.Continents)
+	routing.Fallback = slices.Clone(routing.Fallback)
+	return routing, true
+}
+
+// ForRegion returns the regional child group for a client hint. Candidates are
+// tried in order: the Regions entry for the hint, the Regions entry for its
+// continent, a child named after the hint (lowercased), then Fallback. The
+// first candidate that is a registered child wins; when none is, the group
+// itself is returned, so the result can always be chained.
+//
+// Example:
+//
+//	cdn.SetRegionRouting(urlkit.RegionRouting{
+//	    Regions:    map[string][]string{"EU": {"eu"}, "AS": {"asia", "eu"}},
+//	    Continents: map[string]string{"DE": "EU", "JP": "AS"},
+//	})
+//	url, err := cdn.ForRegion(r.Header.Get("CF-IPCountry")).Builder("assets").Build()
+func (u *Group) ForRegion(hint string) *Group {
+	hint = strings.ToUpper(strings.TrimSpace(hint))
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var candidates []string
+	if routing := u.regionRouting; routing != nil {
+		candidates = append(candidates, routing.Regions[hint]...)
+		if continent, ok := routing.Continents[hint]; ok {
+			candidates = append(candidates, routing.Regions[continent]...)
+		}
+	}
+	if hint != "" {
+		candidates = append(candidates, strings.ToLower(hint))
+	}
+	if routing := u.regionRouting; routing != nil {
+		candidates = append(candidates, routing.Fallback...)
+	}
+
+	for _, name := range candidates {
+		if child, ok := u.children[name]; ok {
+			return child
+		}
+	}
+	return u
+}
@@ -0,0 +1,63 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestForRegion(t *testing.T) {
+	assets := map[string]string{"assets": "/assets/:file"}
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:         "cdn",
+			BaseURL:      "https://cdn.example.com",
+			URLTemplate:  "https://{cdn_region}.cdn.example.com{route_path}",
+			TemplateVars: map[string]string{"cdn_region": "us-west-2"},
+			Routes:       assets,
+			RegionRouting: &urlkit.RegionRouting{
+				Regions:    map[string][]string{"eu": {"eu"}, "AS": {"apac", "asia"}, "OC": {"apac"}},
+				Continents: map[string]string{"de": "eu", "JP": "AS", "AU": "OC"},
+				Fallback:   []string{"us"},
+			},
+			Groups: []urlkit.GroupConfig{
+				{Name: "eu", TemplateVars: map[string]string{"cdn_region": "eu-west-1"}, Routes: assets},
+				{Name: "asia", TemplateVars: map[string]string{"cdn_region": "ap-southeast-1"}, Routes: assets},
+				{Name: "us", TemplateVars: map[string]string{"cdn_region": "us-east-1"}, Routes: assets},
+			},
+		}},
+	})
+	cdn := manager.Group("cdn")
+
+	cases := map[string]string{
+		"DE":   "cdn.eu",   // country -> continent mapping
+		"eu":   "cdn.eu",   // direct region entry
+		"JP":   "cdn.asia", // apac missing, next in chain
+		"asia": "cdn.asia", // child named after the hint
+		"AU":   "cdn.us",   // chain exhausted, fallback
+		"":     "cdn.us",
+	}
+	for hint, want := range cases {
+		if got := cdn.ForRegion(hint).FQN(); got != want {
+			t.Fatalf("ForRegion(%q): expected %s, got %s", hint, want, got)
+		}
+	}
+
+	url, err := cdn.ForRegion("DE").Builder("assets").WithParam("file", "app.js").Build()
+	if err != nil || url != "https://eu-west-1.cdn.example.com/assets/app.js/" {
+		t.Fatalf("unexpected url %q (%v)", url, err)
+	}
+
+	if got := manager.Group("cdn.eu").ForRegion("DE"); got.FQN() != "cdn.eu" {
+		t.Fatalf("expected group without routing to return itself, got %s", got.FQN())
+	}
+
+	helpers := urlkit.TemplateHelpers(manager, nil)
+	urlRegion := helpers["url_region"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	value, perr := urlRegion(pongo2.AsValue("cdn"), pongo2.AsValue("JP"), pongo2.AsValue("assets"), pongo2.AsValue(map[string]any{"file": "app.css"}))
+	if perr != nil || value.String() != "https://ap-southeast-1.cdn.example.com/assets/app.css/" {
+		t.Fatalf("unexpected helper output %q (%v)", value.String(), perr)
+	}
+}
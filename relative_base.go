@@ -0,0 +1,97 @@
+package urlkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrUntrustedHost is returned by OriginFromRequest when the request's Host
+// header is not one of the allowed hosts.
+var ErrUntrustedHost = errors.New("request host is not allowed")
+
+type requestOriginKey struct{}
+
+// WithRequestOrigin returns a context carrying the origin (scheme://host[:port])
+// of the request being served. Groups declared with a relative base URL such
+// as "/api" build root-relative URLs ("/api/users/42"), which is what
+// same-origin deployments want in links; Builder.BuildCtx turns them into
+// absolute URLs against this origin where one is needed, e.g. in emails or
+// redirects to another service. Absolute URLs are never changed.
+//
+// Example:
+//
+//	origin, err := urlkit.OriginFromRequest(r, "shop.example.com")
+//	ctx := urlkit.WithRequestOrigin(r.Context(), origin)
+//	link, err := api.Builder("user").WithParam("id", 42).BuildCtx(ctx)
+//	// https://shop.example.com/api/users/42
+func WithRequestOrigin(ctx context.Context, origin string) context.Context {
+	return context.WithValue(ctx, requestOriginKey{}, strings.TrimSuffix(origin, "/"))
+}
+
+// RequestOrigin returns the origin stored by WithRequestOrigin.
+func RequestOrigin(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	origin, ok := ctx.Value(requestOriginKey{}).(string)
+	return origin, ok && origin != ""
+}
+
+// OriginFromRequest returns the origin a request was served on, using https
+// when the connection is TLS. The Host header is client controlled, so it
+// must match one of allowedHosts (host patterns as in MapHost, e.g.
+// "shop.example.com" or "*.example.com:8443"); otherwise ErrUntrustedHost is
+// returned and links cannot be poisoned to point at another host.
+// Forwarding headers such as X-Forwarded-Proto are not trusted; behind a
+// proxy, build the origin from your own trusted config.
+func OriginFromRequest(r *http.Request, allowedHosts ...string) (string, error) {
+	host, port := splitHostPort(strings.ToLower(r.Host))
+	allowed := false
+	for _, pattern := range allowedHosts {
+		parsed, err := parseHostPattern(pattern)
+		if err != nil {
+			return "", fmt.Errorf("origin from request: %w", err)
+		}
+		if parsed.matches(host, port) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("%w: %q", ErrUntrustedHost, r.Host)
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + strings.ToLower(r.Host), nil
+}
+
+// IsRelativeBaseURL reports whether the root group's base URL is relative
+// (empty or starting with "/"), meaning "same origin as the serving host".
+func (u *Group) IsRelativeBaseURL() bool {
+	root := u.getRootGroup()
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	return root.baseURL == "" || strings.HasPrefix(root.baseURL, "/") && !strings.HasPrefix(root.baseURL, "//")
+}
+
+// absolutizeURL resolves a root-relative URL against the origin in ctx.
+func absolutizeURL(ctx context.Context, built string) string {
+	if !strings.HasPrefix(built, "/") || strings.HasPrefix(built, "//") {
+		return built
+	}
+	origin, ok := RequestOrigin(ctx)
+	if !ok {
+		return built
+	}
+	if parsed, err := url.Parse(origin); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return built
+	}
+	return origin + built
+}
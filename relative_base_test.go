@@ -0,0 +1,69 @@
+package urlkit_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRelativeBaseURL(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "api", BaseURL: "/api", Routes: map[string]string{"user": "/users/:id"}},
+			{Name: "cdn", BaseURL: "https://cdn.example.com", Routes: map[string]string{"asset": "/:file"}},
+		},
+	})
+	api, _ := manager.GetGroup("api")
+	cdn, _ := manager.GetGroup("cdn")
+	if !api.IsRelativeBaseURL() || cdn.IsRelativeBaseURL() {
+		t.Fatalf("unexpected IsRelativeBaseURL: api=%v cdn=%v", api.IsRelativeBaseURL(), cdn.IsRelativeBaseURL())
+	}
+
+	relative, err := api.Builder("user").WithParam("id", 42).Build()
+	if err != nil || relative != "/api/users/42" {
+		t.Fatalf("expected root-relative URL, got %q, %v", relative, err)
+	}
+	if ctxURL, err := api.Builder("user").WithParam("id", 42).BuildCtx(context.Background()); err != nil || ctxURL != relative {
+		t.Fatalf("expected BuildCtx without origin to stay relative, got %q, %v", ctxURL, err)
+	}
+
+	req := httptest.NewRequest("GET", "https://shop.example.com/checkout", nil)
+	req.TLS = &tls.ConnectionState{}
+	origin, err := urlkit.OriginFromRequest(req, "*.example.com")
+	if err != nil || origin != "https://shop.example.com" {
+		t.Fatalf("OriginFromRequest = %q, %v", origin, err)
+	}
+	ctx := urlkit.WithRequestOrigin(req.Context(), origin)
+
+	absolute, err := api.Builder("user").WithParam("id", 42).WithQuery("tab", "orders").BuildCtx(ctx)
+	if err != nil || absolute != "https://shop.example.com/api/users/42?tab=orders" {
+		t.Fatalf("expected absolute URL, got %q, %v", absolute, err)
+	}
+
+	asset, err := cdn.Builder("asset").WithParam("file", "app.js").BuildCtx(ctx)
+	if err != nil || asset != "https://cdn.example.com/app.js" {
+		t.Fatalf("expected absolute base URLs to be untouched, got %q, %v", asset, err)
+	}
+}
+
+func TestOriginFromRequestRejectsUntrustedHosts(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://evil.example.net/reset", nil)
+	if _, err := urlkit.OriginFromRequest(req, "shop.example.com", "*.example.com"); !errors.Is(err, urlkit.ErrUntrustedHost) {
+		t.Fatalf("expected ErrUntrustedHost, got %v", err)
+	}
+	if _, err := urlkit.OriginFromRequest(req); !errors.Is(err, urlkit.ErrUntrustedHost) {
+		t.Fatalf("expected ErrUntrustedHost without allowed hosts, got %v", err)
+	}
+
+	req.Host = "Shop.Example.com:8080"
+	if origin, err := urlkit.OriginFromRequest(req, "shop.example.com"); err != nil || origin != "http://shop.example.com:8080" {
+		t.Fatalf("OriginFromRequest = %q, %v", origin, err)
+	}
+	if _, err := urlkit.OriginFromRequest(req, "shop.example.com:443"); !errors.Is(err, urlkit.ErrUntrustedHost) {
+		t.Fatalf("expected a port mismatch to fail, got %v", err)
+	}
+}
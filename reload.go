@@ -0,0 +1,162 @@
+package urlkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Reload replaces the manager's groups and redirects with the ones declared by
+// config. The new tree is built and validated off to the side first, so a
+// failing config leaves the manager untouched; on success it is swapped in
+// under the write lock and EventConfigReloaded is published. Consumers keep
+// their *RouteManager and see either the old or the new registry, never a mix.
+//
+// Root groups whose configuration is identical to the one they were loaded
+// with keep their existing *Group (including routes added at runtime), so
+// cached group pointers stay valid. Every other root group is replaced, and
+// root groups not declared in config are removed. Mounts, host mappings and
+// pending lazy groups are kept. A frozen manager cannot be reloaded.
+//
+// Example:
+//
+//	cfg, _ := loadConfig("routes.json")
+//	if err := manager.Reload(cfg); err != nil {
+//		log.Printf("keeping previous routes: %v", err)
+//	}
+func (m *RouteManager) Reload(config Configurator) error {
+	if config == nil {
+		return fmt.Errorf("reload: config is required")
+	}
+	if m.runtime.isFrozen() {
+		return FrozenRouteManagerError{Operation: "reload"}
+	}
+
+	config, err := migrateConfigurator(config)
+	if err != nil {
+		return fmt.Errorf("reload: %w", err)
+	}
+
+	staged := &RouteManager{groups: map[string]*Group{}, runtime: m.runtime.cloneSettings()}
+	staged.runtime.lookup = staged.GetGroup
+
+	var redirects []RedirectRule
+	sources := map[string][]byte{}
+	for _, groupConfig := range config.GetGroups() {
+		if groupConfig.Type == GroupTypeRedirects {
+			rules, err := redirectsFromConfig(groupConfig)
+			if err != nil {
+				return fmt.Errorf("reload: %w", err)
+			}
+			redirects = append(redirects, rules...)
+			continue
+		}
+		if _, err := staged.loadGroupFromConfig(groupConfig, nil); err != nil {
+			return fmt.Errorf("reload: %w", err)
+		}
+		sources[groupConfig.Name] = encodeSource(sources, groupConfig)
+	}
+	if err := staged.AddRedirects(redirects...); err != nil {
+		return fmt.Errorf("reload: configuration error: %w", err)
+	}
+
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+
+	releaseMutation, err := m.runtime.beginMutation("reload", "")
+	if err != nil {
+		return err
+	}
+	defer releaseMutation()
+
+	m.mu.Lock()
+	for name := range staged.groups {
+		if _, mounted := m.mounts[name]; mounted {
+			m.mu.Unlock()
+			return fmt.Errorf("reload: %q is used as a mount prefix", name)
+		}
+	}
+
+	groups := make(map[string]*Group, len(staged.groups))
+	for name, group := range staged.groups {
+		if existing, ok := m.groups[name]; ok && sources[name] != nil && bytes.Equal(m.sources[name], sources[name]) {
+			groups[name] = existing
+			continue
+		}
+		setGroupRuntime(group, m.runtime)
+		groups[name] = group
+	}
+	m.groups = groups
+	m.sources = sources
+	for name := range groups {
+		delete(m.lazy, name)
+	}
+
+	// Redirects were compiled against the staged tree; point them at the
+	// groups that were kept.
+	m.redirects = make([]compiledRedirect, 0, len(staged.redirects))
+	for _, redirect := range staged.redirects {
+		if group := m.findGroupByPath(redirect.group.FQN()); group != nil {
+			redirect.group = group
+		}
+		m.redirects = append(m.redirects, redirect)
+	}
+	m.mu.Unlock()
+
+	events = append(events, Event{Type: EventConfigReloaded})
+	return nil
+}
+
+// recordSource remembers the configuration a root group was loaded from, so
+// Reload can tell whether its definition changed.
+func (m *RouteManager) recordSource(cfg GroupConfig) {
+	m.mu.Lock()
+	if m.sources == nil {
+		m.sources = make(map[string][]byte)
+	}
+	m.sources[cfg.Name] = encodeSource(m.sources, cfg)
+	m.mu.Unlock()
+}
+
+// encodeSource encodes cfg for comparison. Groups declared more than once, or
+// that cannot be encoded, get no source and are always rebuilt.
+func encodeSource(sources map[string][]byte, cfg GroupConfig) []byte {
+	if _, seen := sources[cfg.Name]; seen {
+		return nil
+	}
+	source, err := json.Marshal(cfg)
+	if err != nil {
+		return nil
+	}
+	return source
+}
+
+// setGroupRuntime points a group tree built by a staging manager at runtime.
+func setGroupRuntime(group *Group, runtime *runtimeState) {
+	group.mu.Lock()
+	group.runtime = runtime
+	children := slices.Collect(maps.Values(group.children))
+	group.mu.Unlock()
+	for _, child := range children {
+		setGroupRuntime(child, runtime)
+	}
+}
+
+// cloneSettings returns a runtime with the same policies, used to build groups
+// off to the side before they are swapped in.
+func (r *runtimeState) cloneSettings() *runtimeState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &runtimeState{
+		conflictPolicy:        r.conflictPolicy,
+		profile:               r.profile,
+		escapeMode:            r.escapeMode,
+		globalPrefix:          r.globalPrefix,
+		interceptor:           r.interceptor,
+		duplicateGroup:        r.duplicateGroup,
+		observer:              r.observer,
+		caseInsensitiveParams: r.caseInsensitiveParams,
+	}
+}
@@ -0,0 +1,93 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestReload(t *testing.T) {
+	api := urlkit.GroupConfig{Name: "api", BaseURL: "https://api.example.com", Routes: map[string]string{"user": "/users/:id"}}
+	manager := mustManagerFromConfig(t, urlkit.Config{Groups: []urlkit.GroupConfig{
+		api,
+		{Name: "web", BaseURL: "https://example.com", Routes: map[string]string{"home": "/"}},
+		{Name: "legacy", BaseURL: "https://old.example.com", Routes: map[string]string{"home": "/"}},
+	}})
+	apiBefore := manager.Group("api")
+	webBefore := manager.Group("web")
+
+	var events []urlkit.Event
+	manager.Subscribe(func(event urlkit.Event) { events = append(events, event) })
+
+	err := manager.Reload(urlkit.Config{Groups: []urlkit.GroupConfig{
+		api,
+		{Name: "web", BaseURL: "https://www.example.com", Routes: map[string]string{"home": "/", "about": "/about"}},
+		{Name: "legacy", Type: urlkit.GroupTypeRedirects, Redirects: []urlkit.RedirectRule{{From: "/old/users/:id", Route: "api.user"}}},
+	}})
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if manager.Group("api") != apiBefore {
+		t.Fatal("expected unchanged group to keep its pointer")
+	}
+	web := manager.Group("web")
+	if web == webBefore {
+		t.Fatal("expected changed group to be replaced")
+	}
+	if url, err := web.Render("about", nil); err != nil || url != "https://www.example.com/about" {
+		t.Fatalf("unexpected URL from reloaded group: %q, %v", url, err)
+	}
+	if _, err := manager.GetGroup("legacy"); !errors.Is(err, urlkit.ErrGroupNotFound) {
+		t.Fatalf("expected removed group to be gone, got %v", err)
+	}
+	if target, status, ok := manager.ResolveRedirect("/old/users/7"); !ok || target != "https://api.example.com/users/7" || status != 301 {
+		t.Fatalf("unexpected redirect: %q %d %v", target, status, ok)
+	}
+	if len(events) != 1 || events[0].Type != urlkit.EventConfigReloaded {
+		t.Fatalf("expected a single reload event, got %+v", events)
+	}
+
+	// Reloaded groups share the manager's runtime, so manager settings apply.
+	if err := manager.SetGlobalPrefix("/app"); err != nil {
+		t.Fatalf("SetGlobalPrefix failed: %v", err)
+	}
+	if url, _ := web.Render("about", nil); url != "https://www.example.com/app/about" {
+		t.Fatalf("expected reloaded group to use the manager runtime, got %q", url)
+	}
+
+	invalid := urlkit.Config{Groups: []urlkit.GroupConfig{{Name: "api", Routes: map[string]string{"bad": "/users/(unclosed"}}}}
+	if err := manager.Reload(invalid); err == nil {
+		t.Fatal("expected invalid config to fail")
+	}
+	if manager.Group("web") != web {
+		t.Fatal("expected a failed reload to leave the manager untouched")
+	}
+
+	manager.Freeze()
+	var frozen urlkit.FrozenRouteManagerError
+	if err := manager.Reload(urlkit.Config{}); !errors.As(err, &frozen) {
+		t.Fatalf("expected FrozenRouteManagerError, got %v", err)
+	}
+}
+
+func TestReloadInvalidatesTemplateHelperGroups(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{Groups: []urlkit.GroupConfig{
+		{Name: "web", BaseURL: "https://example.com", Routes: map[string]string{"home": "/"}},
+	}})
+	cache := urlkit.NewGroupCache(manager)
+	if cache.Get("web") != manager.Group("web") {
+		t.Fatal("expected cached group")
+	}
+
+	err := manager.Reload(urlkit.Config{Groups: []urlkit.GroupConfig{
+		{Name: "web", BaseURL: "https://www.example.com", Routes: map[string]string{"home": "/"}},
+	}})
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if cache.Get("web") != manager.Group("web") {
+		t.Fatal("expected the cache to drop groups replaced by Reload")
+	}
+}
@@ -0,0 +1,255 @@
+package urlkit
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ResourceAction is one of the standard REST routes created by RegisterResource.
+type ResourceAction string
+
+const (
+	ResourceIndex  ResourceAction = "index"
+	ResourceShow   ResourceAction = "show"
+	ResourceNew    ResourceAction = "new"
+	ResourceCreate ResourceAction = "create"
+	ResourceEdit   ResourceAction = "edit"
+	ResourceUpdate ResourceAction = "update"
+	ResourceDelete ResourceAction = "delete"
+)
+
+// ResourceActions lists every action in registration order.
+var ResourceActions = []ResourceAction{
+	ResourceIndex, ResourceShow, ResourceNew, ResourceCreate, ResourceEdit, ResourceUpdate, ResourceDelete,
+}
+
+// ResourceOptions customizes RegisterResource. The zero value registers every
+// action under "/<name>" with an ":id" param.
+type ResourceOptions struct {
+	// Path is the collection path. Defaults to "/" + name.
+	Path string
+	// Param is the member param name. Defaults to "id".
+	Param string
+	// Singular names member routes. Defaults to Singularize(name).
+	Singular string
+	// Only restricts registration to these actions.
+	Only []ResourceAction
+	// Except skips these actions.
+	Except []ResourceAction
+}
+
+type resourceRoute struct {
+	name    string
+	pattern string
+	method  string
+}
+
+// RegisterResource registers the standard REST route set for a collection,
+// naming collection routes with the plural name and member routes with the
+// singular one. Each route's HTTP method is stored as route metadata.
+//
+//	index   GET    /users             users
+//	show    GET    /users/:id         user
+//	new     GET    /users/new         new_user
+//	create  POST   /users             create_user
+//	edit    GET    /users/:id/edit    edit_user
+//	update  PUT    /users/:id         update_user
+//	delete  DELETE /users/:id         delete_user
+//
+// Example:
+//
+//	result, err := group.RegisterResource("users", urlkit.ResourceOptions{
+//	    Except: []urlkit.ResourceAction{urlkit.ResourceNew, urlkit.ResourceEdit},
+//	})
+func (u *Group) RegisterResource(name string, opts ResourceOptions) (RouteMutationResult, error) {
+	definitions, err := resourceRoutes(name, opts)
+	if err != nil {
+		return RouteMutationResult{}, err
+	}
+
+	routes := make(map[string]string, len(definitions))
+	for _, def := range definitions {
+		routes[def.name] = def.pattern
+	}
+
+	result, err := u.AddRoutes(routes)
+	if err != nil {
+		return result, err
+	}
+
+	for _, def := range definitions {
+		if slices.Contains(result.Skipped, def.name) {
+			continue
+		}
+		if err := u.SetRouteMetadata(def.name, RouteMetadata{Method: def.method}); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func resourceRoutes(name string, opts ResourceOptions) ([]resourceRoute, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("register resource: name is required")
+	}
+	for _, action := range append(slices.Clone(opts.Only), opts.Except...) {
+		if !slices.Contains(ResourceActions, action) {
+			return nil, fmt.Errorf("register resource %q: unknown action %q", name, action)
+		}
+	}
+
+	collection := opts.Path
+	if collection == "" {
+		collection = "/" + name
+	}
+	collection = "/" + strings.Trim(collection, "/")
+	param := cmp.Or(opts.Param, "id")
+	singular := cmp.Or(opts.Singular, Singularize(name))
+	if singular == name {
+		return nil, fmt.Errorf("register resource %q: singular name must differ from the collection name", name)
+	}
+	member := collection + "/:" + param
+
+	all := map[ResourceAction]resourceRoute{
+		ResourceIndex:  {name, collection, http.MethodGet},
+		ResourceShow:   {singular, member, http.MethodGet},
+		ResourceNew:    {"new_" + singular, collection + "/new", http.MethodGet},
+		ResourceCreate: {"create_" + singular, collection, http.MethodPost},
+		ResourceEdit:   {"edit_" + singular, member + "/edit", http.MethodGet},
+		ResourceUpdate: {"update_" + singular, member, http.MethodPut},
+		ResourceDelete: {"delete_" + singular, member, http.MethodDelete},
+	}
+
+	definitions := make([]resourceRoute, 0, len(all))
+	for _, action := range ResourceActions {
+		if len(opts.Only) > 0 && !slices.Contains(opts.Only, action) {
+			continue
+		}
+		if slices.Contains(opts.Except, action) {
+			continue
+		}
+		definitions = append(definitions, all[action])
+	}
+	return definitions, nil
+}
+
+// irregularPlurals maps common irregular singular nouns to their plural, and
+// nouns whose singular the suffix rules of Singularize cannot recover.
+var irregularPlurals = map[string]string{
+	"person": "people",
+	"child":  "children",
+	"man":    "men",
+	"woman":  "women",
+	"mouse":  "mice",
+	"goose":  "geese",
+	"tooth":  "teeth",
+	"foot":   "feet",
+	"quiz":   "quizzes",
+
+	// Singulars ending in "s" after a vowel
+	"alias":  "aliases",
+	"atlas":  "atlases",
+	"bias":   "biases",
+	"canvas": "canvases",
+	"gas":    "gases",
+
+	// Singulars ending in "e" after a sibilant
+	"abuse":     "abuses",
+	"ache":      "aches",
+	"cache":     "caches",
+	"course":    "courses",
+	"eclipse":   "eclipses",
+	"excuse":    "excuses",
+	"expense":   "expenses",
+	"fuse":      "fuses",
+	"headache":  "headaches",
+	"horse":     "horses",
+	"license":   "licenses",
+	"niche":     "niches",
+	"nurse":     "nurses",
+	"pulse":     "pulses",
+	"response":  "responses",
+	"sense":     "senses",
+	"universe":  "universes",
+	"use":       "uses",
+	"verse":     "verses",
+	"warehouse": "warehouses",
+}
+
+// Pluralize returns the English plural of a lowercase singular noun using
+// common suffix rules and a small irregular list (e.g. "category" ->
+// "categories", "person" -> "people").
+func Pluralize(word string) string {
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+	switch {
+	case word == "":
+		return ""
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !isVowel(word[len(word)-2]):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+// Singularize returns the English singular of a lowercase plural noun; it is
+// the inverse of Pluralize. "-es" after s, x, z, ch and sh is dropped, as
+// Pluralize adds it ("statuses" -> "status", "boxes" -> "box"), except after
+// a vowel and "s" or "z", which usually belong to an "-se" or "-ze" singular
+// ("cases" -> "case", "houses" -> "house", "sizes" -> "size").
+func Singularize(word string) string {
+	for singular, plural := range irregularPlurals {
+		if word == plural {
+			return singular
+		}
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "es") && len(word) > 3:
+		stem := word[:len(word)-2]
+		switch {
+		case strings.HasSuffix(stem, "s"), strings.HasSuffix(stem, "z"):
+			if vowelSibilant(stem) {
+				return stem + "e"
+			}
+			return stem
+		case strings.HasSuffix(stem, "x"), strings.HasSuffix(stem, "ch"), strings.HasSuffix(stem, "sh"):
+			return stem
+		}
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "ss"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return word[:len(word)-1]
+	default:
+		return word
+	}
+}
+
+// vowelSibilant reports whether stem ends in a vowel followed by its final
+// "s" or "z", the shape of "-se" and "-ze" singulars. A "u" counts only after
+// another vowel, so "status" and "bus" keep their "-us" ending while "house"
+// and "cause" get their "e".
+func vowelSibilant(stem string) bool {
+	if len(stem) < 2 {
+		return false
+	}
+	before := stem[len(stem)-2]
+	if before == 'u' {
+		return len(stem) >= 3 && isVowel(stem[len(stem)-3])
+	}
+	return isVowel(before)
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
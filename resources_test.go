@@ -0,0 +1,85 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRegisterResource(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{Name: "api", BaseURL: "https://api.example.com"}},
+	})
+	api := manager.Group("api")
+
+	result, err := api.RegisterResource("categories", urlkit.ResourceOptions{})
+	if err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	if len(result.Added) != 7 {
+		t.Fatalf("expected 7 routes, got %v", result.Added)
+	}
+
+	expected := map[string]struct{ path, method string }{
+		"categories":      {"/categories", "GET"},
+		"category":        {"/categories/:id", "GET"},
+		"new_category":    {"/categories/new", "GET"},
+		"create_category": {"/categories", "POST"},
+		"edit_category":   {"/categories/:id/edit", "GET"},
+		"update_category": {"/categories/:id", "PUT"},
+		"delete_category": {"/categories/:id", "DELETE"},
+	}
+	for name, want := range expected {
+		path, err := api.Route(name)
+		if err != nil || path != want.path {
+			t.Fatalf("route %s: expected %s, got %s (%v)", name, want.path, path, err)
+		}
+		if meta, _ := api.RouteMetadata(name); meta.Method != want.method {
+			t.Fatalf("route %s: expected method %s, got %s", name, want.method, meta.Method)
+		}
+	}
+
+	if _, err := api.RegisterResource("people", urlkit.ResourceOptions{
+		Path:  "/v1/people/",
+		Param: "person_id",
+		Only:  []urlkit.ResourceAction{urlkit.ResourceIndex, urlkit.ResourceShow},
+	}); err != nil {
+		t.Fatalf("RegisterResource failed: %v", err)
+	}
+	if got, _ := api.Render("person", urlkit.Params{"person_id": 3}); got != "https://api.example.com/v1/people/3" {
+		t.Fatalf("unexpected url %s", got)
+	}
+	if _, err := api.Route("create_person"); err == nil {
+		t.Fatal("expected create_person to be skipped by Only")
+	}
+
+	if _, err := api.RegisterResource("users", urlkit.ResourceOptions{Except: []urlkit.ResourceAction{"archive"}}); err == nil {
+		t.Fatal("expected error for unknown action")
+	}
+	if _, err := api.RegisterResource("news", urlkit.ResourceOptions{Singular: "news"}); err == nil {
+		t.Fatal("expected error when singular equals collection name")
+	}
+}
+
+func TestPluralizeSingularize(t *testing.T) {
+	for singular, plural := range map[string]string{
+		"user": "users", "category": "categories", "day": "days", "box": "boxes",
+		"match": "matches", "address": "addresses", "person": "people",
+		"status": "statuses", "bus": "buses", "campus": "campuses", "virus": "viruses",
+		"brush": "brushes", "buzz": "buzzes", "quiz": "quizzes", "alias": "aliases",
+		"case": "cases", "database": "databases", "house": "houses", "cause": "causes",
+		"phase": "phases", "release": "releases", "purpose": "purposes", "size": "sizes",
+		"response": "responses", "license": "licenses", "course": "courses", "cache": "caches",
+		"note": "notes", "type": "types", "key": "keys", "class": "classes",
+	} {
+		if got := urlkit.Pluralize(singular); got != plural {
+			t.Fatalf("Pluralize(%q) = %q, want %q", singular, got, plural)
+		}
+		if got := urlkit.Singularize(plural); got != singular {
+			t.Fatalf("Singularize(%q) = %q, want %q", plural, got, singular)
+		}
+		if got := urlkit.Singularize(urlkit.Pluralize(singular)); got != singular {
+			t.Fatalf("round trip of %q = %q", singular, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package urlkit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query parameters written by RetryURL and read by ParseRetryState.
+const (
+	RetryAfterParam   = "retry_after"
+	RetryCursorParam  = "cursor"
+	RetryAttemptParam = "attempt"
+)
+
+// RetryState is the polling state carried by a retry URL.
+type RetryState struct {
+	// RetryAfter is how long the client should wait before polling again. It is
+	// encoded in whole seconds, rounded up.
+	RetryAfter time.Duration
+	// Cursor is an opaque position in the work being polled.
+	Cursor string
+	// Attempt counts the polls made so far.
+	Attempt int
+}
+
+// RetryURL builds a self-referential polling URL for a rate limited or pending
+// request from the registered route, encoding state as query parameters, so
+// handlers never concatenate retry URLs by hand. Extra queries are appended as
+// with Render.
+//
+// Example:
+//
+//	next, _ := api.RetryURL("job_status", urlkit.Params{"id": job.ID}, urlkit.RetryState{
+//		RetryAfter: 30 * time.Second,
+//		Cursor:     job.Cursor,
+//	})
+//	urlkit.WriteRetry(w, http.StatusTooManyRequests, next, 30*time.Second)
+func (u *Group) RetryURL(routeName string, params Params, state RetryState, queries ...Query) (string, error) {
+	if state.RetryAfter < 0 {
+		return "", fmt.Errorf("retry url: negative retry-after %s", state.RetryAfter)
+	}
+
+	retry := Query{}
+	if state.RetryAfter > 0 {
+		retry[RetryAfterParam] = strconv.Itoa(retryAfterSeconds(state.RetryAfter))
+	}
+	if state.Cursor != "" {
+		retry[RetryCursorParam] = state.Cursor
+	}
+	if state.Attempt > 0 {
+		retry[RetryAttemptParam] = strconv.Itoa(state.Attempt)
+	}
+	return u.Render(routeName, params, append(append([]Query(nil), queries...), retry)...)
+}
+
+// ParseRetryState reads the state written by RetryURL from inbound query values.
+// Missing parameters are left at their zero value.
+func ParseRetryState(values url.Values) (RetryState, error) {
+	var state RetryState
+	if raw := values.Get(RetryAfterParam); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			return RetryState{}, fmt.Errorf("parse retry state: invalid %s %q", RetryAfterParam, raw)
+		}
+		state.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	if raw := values.Get(RetryAttemptParam); raw != "" {
+		attempt, err := strconv.Atoi(raw)
+		if err != nil || attempt < 0 {
+			return RetryState{}, fmt.Errorf("parse retry state: invalid %s %q", RetryAttemptParam, raw)
+		}
+		state.Attempt = attempt
+	}
+	state.Cursor = values.Get(RetryCursorParam)
+	return state, nil
+}
+
+// WriteRetry points the client at retryURL with a Location header and a
+// Retry-After header in seconds, then writes status (typically 429 or 202).
+func WriteRetry(w http.ResponseWriter, status int, retryURL string, after time.Duration) {
+	w.Header().Set("Location", retryURL)
+	if after > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(after)))
+	}
+	w.WriteHeader(status)
+}
+
+func retryAfterSeconds(after time.Duration) int {
+	return int(math.Ceil(after.Seconds()))
+}
@@ -0,0 +1,55 @@
+package urlkit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRetryURL(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{{
+			Name:    "api",
+			BaseURL: "https://api.example.com",
+			Routes:  map[string]string{"job_status": "/jobs/:id/status"},
+		}},
+	})
+	api := manager.Group("api")
+
+	next, err := api.RetryURL("job_status", urlkit.Params{"id": "j1"}, urlkit.RetryState{
+		RetryAfter: 1500 * time.Millisecond,
+		Cursor:     "c/42",
+		Attempt:    2,
+	}, urlkit.Query{"verbose": "1"})
+	if err != nil {
+		t.Fatalf("RetryURL failed: %v", err)
+	}
+	if want := "https://api.example.com/jobs/j1/status?verbose=1&attempt=2&cursor=c%2F42&retry_after=2"; next != want {
+		t.Fatalf("expected %q, got %q", want, next)
+	}
+
+	parsed, err := url.Parse(next)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	state, err := urlkit.ParseRetryState(parsed.Query())
+	if err != nil {
+		t.Fatalf("ParseRetryState failed: %v", err)
+	}
+	if state.RetryAfter != 2*time.Second || state.Cursor != "c/42" || state.Attempt != 2 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if _, err := urlkit.ParseRetryState(url.Values{"retry_after": {"soon"}}); err == nil {
+		t.Fatal("expected error for invalid retry_after")
+	}
+
+	rec := httptest.NewRecorder()
+	urlkit.WriteRetry(rec, http.StatusTooManyRequests, next, 2*time.Second)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "2" || rec.Header().Get("Location") != next {
+		t.Fatalf("unexpected response: %d %v", rec.Code, rec.Header())
+	}
+}
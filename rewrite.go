@@ -0,0 +1,108 @@
+package urlkit
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LinkScheme prefixes logical route links understood by ResolveLink and
+// RewriteLinks, e.g. "urlkit://frontend.en.about?id=42".
+const LinkScheme = "urlkit://"
+
+var linkAttrPattern = regexp.MustCompile(`(?i)\b(href|src)(\s*=\s*)(["'])(urlkit://[^"']*)(["'])`)
+
+// ResolveLink builds the URL for a logical route link of the form
+// "urlkit://group.route?key=value#fragment". Query keys that name a route param
+// fill that param; the rest are kept as query parameters.
+func (m *RouteManager) ResolveLink(link string) (string, error) {
+	rest, ok := strings.CutPrefix(link, LinkScheme)
+	if !ok {
+		return "", fmt.Errorf("link %q does not use the %s scheme", link, LinkScheme)
+	}
+
+	rest, fragment, _ := strings.Cut(rest, "#")
+	target, rawQuery, _ := strings.Cut(rest, "?")
+
+	groupPath, routeName, err := splitRouteFQN(target)
+	if err != nil {
+		return "", err
+	}
+
+	group, err := m.GetGroup(groupPath)
+	if err != nil {
+		return "", err
+	}
+
+	template, err := group.Route(routeName)
+	if err != nil {
+		return "", err
+	}
+
+	names, err := routeParamNames(template)
+	if err != nil {
+		return "", fmt.Errorf("parse route %q: %w", routeName, err)
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("parse link query: %w", err)
+	}
+
+	builder := group.Builder(routeName)
+	query := make(map[string][]string)
+	for key, items := range values {
+		if slices.Contains(names, key) && len(items) > 0 {
+			builder.WithParam(key, items[0])
+			continue
+		}
+		query[key] = items
+	}
+	builder.WithQueryValues(query)
+
+	built, err := builder.Build()
+	if err != nil {
+		return "", err
+	}
+	if fragment != "" {
+		built += "#" + fragment
+	}
+	return built, nil
+}
+
+// RewriteLinks replaces every href or src attribute in content whose value uses
+// the urlkit:// scheme with the built URL, so email and HTML templates can
+// reference logical routes instead of hardcoded hosts. Run it on the rendered
+// template, after placeholders such as {{.ID}} have been substituted. It fails
+// on the first link that cannot be built.
+//
+// Example:
+//
+//	<a href="urlkit://frontend.orders.detail?id=42&utm_source=email">View order</a>
+//	becomes
+//	<a href="https://shop.example.com/orders/42?utm_source=email">View order</a>
+func (m *RouteManager) RewriteLinks(content string) (string, error) {
+	var rewriteErr error
+	out := linkAttrPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if rewriteErr != nil {
+			return match
+		}
+
+		parts := linkAttrPattern.FindStringSubmatch(match)
+		link := html.UnescapeString(parts[4])
+		resolved, err := m.ResolveLink(link)
+		if err != nil {
+			rewriteErr = fmt.Errorf("rewrite link %q: %w", link, err)
+			return match
+		}
+		return parts[1] + parts[2] + parts[3] + html.EscapeString(resolved) + parts[5]
+	})
+
+	if rewriteErr != nil {
+		return "", rewriteErr
+	}
+	return out, nil
+}
@@ -0,0 +1,65 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestRewriteLinks(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	shop, _, err := manager.RegisterGroup("shop", "https://shop.example.com", map[string]string{
+		"home": "/",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if _, _, err := shop.RegisterGroup("orders", "/orders", map[string]string{"detail": "/:id"}); err != nil {
+		t.Fatalf("RegisterGroup child failed: %v", err)
+	}
+
+	content := `<p><a href="urlkit://shop.orders.detail?id=42&amp;utm_source=email#items">View order</a>
+<img src='urlkit://shop.home'> <a href="https://other.example.com">keep</a></p>`
+
+	got, err := manager.RewriteLinks(content)
+	if err != nil {
+		t.Fatalf("RewriteLinks failed: %v", err)
+	}
+
+	want := `<p><a href="https://shop.example.com/orders/42?utm_source=email#items">View order</a>
+<img src='https://shop.example.com/'> <a href="https://other.example.com">keep</a></p>`
+	if got != want {
+		t.Fatalf("unexpected rewrite:\n got: %s\nwant: %s", got, want)
+	}
+
+	_, err = manager.RewriteLinks(`<a href="urlkit://shop.missing">x</a>`)
+	if !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "urlkit://shop.missing") {
+		t.Fatalf("expected error to mention the link, got %v", err)
+	}
+}
+
+func TestResolveLinkMultiValueQuery(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("app", "https://app.example.com", map[string]string{
+		"search": "/search",
+	}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	got, err := manager.ResolveLink("urlkit://app.search?tag=a&tag=b")
+	if err != nil {
+		t.Fatalf("ResolveLink failed: %v", err)
+	}
+	if got != "https://app.example.com/search?tag=a&tag=b" {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+
+	if _, err := manager.ResolveLink("https://app.example.com/search"); err == nil {
+		t.Fatal("expected error for non urlkit link")
+	}
+}
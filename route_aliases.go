@@ -0,0 +1,156 @@
+package urlkit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+)
+
+type routeAlias struct {
+	route string
+	uses  *atomic.Uint64
+}
+
+// SetRouteAlias makes alias an alternate name of routeName in this group, so
+// renaming a route does not break templates and configs that still use the
+// old name. Aliases resolve transparently wherever a route name is accepted:
+// Render, Builder, Route, Validate, MatchRoute, RouteMetadata and the template
+// helpers. Pass an empty routeName to remove the alias.
+// Returns ErrRouteNotFound when routeName is not registered and an error when
+// alias is itself a route.
+//
+// Example:
+//
+//	group.SetRouteAlias("profile", "user_profile")
+//	group.Render("profile", params) // renders "user_profile"
+func (u *Group) SetRouteAlias(alias, routeName string) error {
+	if alias == "" {
+		return fmt.Errorf("route alias cannot be empty")
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set route alias", groupFQN)
+	if err != nil {
+		return err
+	}
+	var events []Event
+	defer func() { u.runtime.publish(events...) }()
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if routeName == "" {
+		delete(u.aliases, alias)
+		events = []Event{{Type: EventRoutesChanged, Group: groupFQN}}
+		return nil
+	}
+	if _, ok := u.routes[alias]; ok {
+		return fmt.Errorf("route alias %q in group %s: a route with that name exists", alias, groupFQN)
+	}
+	if _, ok := u.routes[routeName]; !ok {
+		return fmt.Errorf("route alias %q: %w: route %q in group %s", alias, ErrRouteNotFound, routeName, groupFQN)
+	}
+	if u.aliases == nil {
+		u.aliases = make(map[string]routeAlias)
+	}
+	u.aliases[alias] = routeAlias{route: routeName, uses: new(atomic.Uint64)}
+	events = []Event{{Type: EventRoutesChanged, Group: groupFQN}}
+	return nil
+}
+
+// RouteAliases returns a copy of the group's aliases, mapping each alias to
+// its route name.
+func (u *Group) RouteAliases() map[string]string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	out := make(map[string]string, len(u.aliases))
+	for alias, entry := range u.aliases {
+		out[alias] = entry.route
+	}
+	return out
+}
+
+// canonicalRouteName returns the route name that routeName refers to: the
+// name itself for routes and unknown names, the target route for aliases.
+func (u *Group) canonicalRouteName(routeName string) string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.canonicalRouteNameLocked(routeName)
+}
+
+// canonicalRouteNameLocked is canonicalRouteName for callers holding u.mu.
+// Every resolved alias counts as a use, reported by Lint.
+func (u *Group) canonicalRouteNameLocked(routeName string) string {
+	if _, ok := u.routes[routeName]; ok {
+		return routeName
+	}
+	if entry, ok := u.aliases[routeName]; ok {
+		entry.uses.Add(1)
+		return entry.route
+	}
+	return routeName
+}
+
+// CanonicalName normalizes a fully qualified route name ("group.route"),
+// replacing a route alias with the name of its route, so tooling can rewrite
+// references in templates and configs. Returns ErrRouteNotFound when the name
+// is neither a route nor an alias.
+//
+// Example:
+//
+//	name, err := manager.CanonicalName("frontend.profile")
+//	// "frontend.user_profile"
+func (m *RouteManager) CanonicalName(fqn string) (string, error) {
+	groupPath, route, err := splitRouteFQN(fqn)
+	if err != nil {
+		return "", err
+	}
+	group, err := m.GetGroup(groupPath)
+	if err != nil {
+		return "", err
+	}
+
+	group.mu.RLock()
+	_, isRoute := group.routes[route]
+	entry, isAlias := group.aliases[route]
+	group.mu.RUnlock()
+
+	switch {
+	case isRoute:
+		return groupPath + "." + route, nil
+	case isAlias:
+		return groupPath + "." + entry.route, nil
+	}
+	return "", fmt.Errorf("%w: route %q in group %s", ErrRouteNotFound, route, groupPath)
+}
+
+// lintRouteAlias reports every alias of the group with how many times it has
+// been resolved, so callers still using old names can be found and unused
+// aliases removed.
+func lintRouteAlias(group *Group) []LintIssue {
+	group.mu.RLock()
+	aliases := maps.Clone(group.aliases)
+	group.mu.RUnlock()
+
+	groupName := groupDisplayName(group)
+	var issues []LintIssue
+	for _, alias := range slices.Sorted(maps.Keys(aliases)) {
+		entry := aliases[alias]
+		issue := LintIssue{
+			Rule:     LintRuleRouteAlias,
+			Severity: LintWarning,
+			Group:    groupName,
+		}
+		if uses := entry.uses.Load(); uses > 0 {
+			issue.Message = fmt.Sprintf("alias %q of route %q has been used %d time(s)", alias, entry.route, uses)
+			issue.Suggestion = fmt.Sprintf("replace references to %q with %q", alias, entry.route)
+		} else {
+			issue.Message = fmt.Sprintf("alias %q of route %q has not been used", alias, entry.route)
+			issue.Suggestion = fmt.Sprintf("remove the alias once no template or config references %q", alias)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
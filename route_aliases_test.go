@@ -0,0 +1,110 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+	"github.com/goliatone/go-urlkit"
+)
+
+func newAliasManager(t *testing.T) *urlkit.RouteManager {
+	t.Helper()
+	return mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"user_profile": "/users/:id", "home": "/"},
+				Aliases: map[string]string{"profile": "user_profile"},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"user_profile": {Method: "GET"},
+				},
+			},
+		},
+	})
+}
+
+func TestRouteAliasResolution(t *testing.T) {
+	manager := newAliasManager(t)
+	group := manager.Group("frontend")
+
+	built, err := manager.Resolve("frontend", "profile", urlkit.Params{"id": 42}, nil)
+	if err != nil || built != "https://example.com/users/42" {
+		t.Fatalf("Resolve via alias = %q, %v", built, err)
+	}
+	if template, err := group.Route("profile"); err != nil || template != "/users/:id" {
+		t.Errorf("Route via alias = %q, %v", template, err)
+	}
+	if meta, ok := group.RouteMetadata("profile"); !ok || meta.Method != "GET" {
+		t.Errorf("RouteMetadata via alias = %+v, %v", meta, ok)
+	}
+	if params, _, ok := group.MatchRoute("profile", "/users/7"); !ok || params["id"] != "7" {
+		t.Errorf("MatchRoute via alias = %v, %v", params, ok)
+	}
+	if err := manager.Validate(map[string][]string{"frontend": {"profile", "home"}}); err != nil {
+		t.Errorf("Validate rejected alias: %v", err)
+	}
+
+	helpers := urlkit.TemplateHelpers(manager, nil)
+	urlHelper := helpers["url"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	value, perr := urlHelper(pongo2.AsValue("frontend"), pongo2.AsValue("profile"), pongo2.AsValue(map[string]any{"id": 1}))
+	if perr != nil || value.String() != "https://example.com/users/1" {
+		t.Errorf("url helper via alias = %q, %v", value.String(), perr)
+	}
+}
+
+func TestCanonicalName(t *testing.T) {
+	manager := newAliasManager(t)
+
+	for input, want := range map[string]string{
+		"frontend.profile":      "frontend.user_profile",
+		"frontend.user_profile": "frontend.user_profile",
+	} {
+		if got, err := manager.CanonicalName(input); err != nil || got != want {
+			t.Errorf("CanonicalName(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := manager.CanonicalName("frontend.missing"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Errorf("expected ErrRouteNotFound, got %v", err)
+	}
+}
+
+func TestRouteAliasErrorsAndLint(t *testing.T) {
+	manager := newAliasManager(t)
+	group := manager.Group("frontend")
+
+	if err := group.SetRouteAlias("home", "user_profile"); err == nil {
+		t.Error("expected alias shadowing a route to fail")
+	}
+	if err := group.SetRouteAlias("start", "missing"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Errorf("expected ErrRouteNotFound, got %v", err)
+	}
+	if err := group.SetRouteAlias("index", "home"); err != nil {
+		t.Fatalf("SetRouteAlias failed: %v", err)
+	}
+
+	if _, err := group.Render("profile", urlkit.Params{"id": 1}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var messages []string
+	for _, issue := range manager.Lint() {
+		if issue.Rule == urlkit.LintRuleRouteAlias {
+			messages = append(messages, issue.Message)
+		}
+	}
+	if len(messages) != 2 ||
+		!strings.Contains(messages[0], `alias "index" of route "home" has not been used`) ||
+		!strings.Contains(messages[1], `alias "profile" of route "user_profile" has been used 1 time(s)`) {
+		t.Errorf("unexpected alias lint messages %v", messages)
+	}
+
+	if err := group.SetRouteAlias("index", ""); err != nil {
+		t.Fatalf("removing alias failed: %v", err)
+	}
+	if _, ok := group.RouteAliases()["index"]; ok {
+		t.Error("expected alias to be removed")
+	}
+}
@@ -0,0 +1,187 @@
+package routesyntax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Change is a single pattern rewritten by RewriteJSON.
+type Change struct {
+	// Path locates the value in the document, e.g. "groups[0].routes.user".
+	Path   string
+	Before string
+	After  string
+}
+
+// valueKind tells the rewriter what a JSON value holds in a urlkit config.
+type valueKind int
+
+const (
+	kindOther valueKind = iota
+	kindConfig
+	kindGroupList
+	kindGroup
+	kindPatternMap
+	kindRedirectList
+	kindRedirect
+	kindPattern
+)
+
+// childKind returns the kind of the value under key (or array element, when
+// key is empty) of a value of kind parent.
+func childKind(parent valueKind, key string) valueKind {
+	switch parent {
+	case kindConfig:
+		if key == "groups" {
+			return kindGroupList
+		}
+	case kindGroupList:
+		return kindGroup
+	case kindGroup:
+		switch key {
+		case "routes", "paths", "vanity_routes":
+			return kindPatternMap
+		case "groups":
+			return kindGroupList
+		case "redirects":
+			return kindRedirectList
+		}
+	case kindPatternMap:
+		return kindPattern
+	case kindRedirectList:
+		return kindRedirect
+	case kindRedirect:
+		if key == "from" {
+			return kindPattern
+		}
+	}
+	return kindOther
+}
+
+type edit struct {
+	start, end int
+	value      []byte
+}
+
+type rewriter struct {
+	data     []byte
+	dec      *json.Decoder
+	from, to Syntax
+	edits    []edit
+	changes  []Change
+	errs     []error
+}
+
+// RewriteJSON converts every route pattern in a urlkit JSON config (group
+// routes, paths and vanity_routes at any depth, and redirect sources) from one
+// syntax to another. Only the pattern strings are replaced, so formatting, key
+// order and unrelated values are preserved. The returned changes describe each
+// rewrite; pass them to Diff for a dry run without writing the result.
+//
+// Patterns that cannot be converted are left untouched and reported together
+// in the returned error, alongside the partially rewritten document.
+func RewriteJSON(data []byte, from, to Syntax) ([]byte, []Change, error) {
+	r := &rewriter{data: data, dec: json.NewDecoder(bytes.NewReader(data)), from: from, to: to}
+	r.dec.UseNumber()
+	if err := r.value("", kindConfig); err != nil {
+		return nil, nil, fmt.Errorf("routesyntax: decode config: %w", err)
+	}
+	if _, err := r.dec.Token(); err != io.EOF {
+		return nil, nil, fmt.Errorf("routesyntax: decode config: unexpected data after document")
+	}
+
+	out := bytes.Clone(data)
+	for i := len(r.edits) - 1; i >= 0; i-- {
+		e := r.edits[i]
+		out = append(out[:e.start], append(e.value, out[e.end:]...)...)
+	}
+	return out, r.changes, errors.Join(r.errs...)
+}
+
+func (r *rewriter) value(path string, kind valueKind) error {
+	start := int(r.dec.InputOffset())
+	tok, err := r.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			for i := 0; r.dec.More(); i++ {
+				if err := r.value(path+"["+strconv.Itoa(i)+"]", childKind(kind, "")); err != nil {
+					return err
+				}
+			}
+		} else {
+			for r.dec.More() {
+				key, err := r.dec.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := key.(string)
+				childPath := name
+				if path != "" {
+					childPath = path + "." + name
+				}
+				if err := r.value(childPath, childKind(kind, name)); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := r.dec.Token()
+		return err
+	case string:
+		if kind == kindPattern {
+			r.rewrite(path, t, start)
+		}
+	}
+	return nil
+}
+
+func (r *rewriter) rewrite(path, before string, start int) {
+	converted, err := Convert(before, r.from, r.to)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %w", path, err))
+		return
+	}
+	if converted == before {
+		return
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(converted); err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %w", path, err))
+		return
+	}
+
+	// start points just after the previous token; the string begins at the
+	// first quote after it.
+	quote := start + bytes.IndexByte(r.data[start:], '"')
+	r.edits = append(r.edits, edit{
+		start: quote,
+		end:   int(r.dec.InputOffset()),
+		value: bytes.TrimSuffix(buf.Bytes(), []byte("\n")),
+	})
+	r.changes = append(r.changes, Change{Path: path, Before: before, After: converted})
+}
+
+// Diff formats changes as a dry-run listing:
+//
+//	groups[0].routes.user
+//	- /users/:id
+//	+ /users/{id}
+func Diff(changes []Change) string {
+	var out strings.Builder
+	for _, change := range changes {
+		fmt.Fprintf(&out, "%s\n- %s\n+ %s\n", change.Path, change.Before, change.After)
+	}
+	return out.String()
+}
@@ -0,0 +1,306 @@
+// Package routesyntax converts route patterns between the ":param" syntax
+// used by urlkit, the "{param}" syntax used by OpenAPI and gorilla/mux, and
+// RFC 6570 URI templates, and rewrites JSON config files in place so teams can
+// standardize the syntax across many files.
+//
+//	routesyntax.Convert("/users/:id(\\d+)", routesyntax.Colon, routesyntax.Brace)
+//	// /users/{id:\d+}
+//	routesyntax.Convert("/files/:path*", routesyntax.Colon, routesyntax.RFC6570)
+//	// /files{/path*}
+package routesyntax
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Syntax identifies a route pattern syntax.
+type Syntax string
+
+const (
+	// Colon is urlkit's path-to-regexp syntax: ":id", ":id(\\d+)", ":id?",
+	// ":path*".
+	Colon Syntax = "colon"
+	// Brace is the "{id}" / "{id:pattern}" syntax used by OpenAPI and mux. It
+	// has no optional or repeated params.
+	Brace Syntax = "brace"
+	// RFC6570 is the URI template syntax. Required params are "{id}", optional
+	// path segments "{/id}" and repeated segments "{/path*}". It has no
+	// pattern constraints.
+	RFC6570 Syntax = "rfc6570"
+)
+
+// token is a literal run or a param in a parsed pattern.
+type token struct {
+	literal  string
+	name     string
+	pattern  string
+	modifier string // "", "?", "*" or "+"
+}
+
+func (t token) isParam() bool {
+	return t.name != ""
+}
+
+// Convert rewrites pattern from one syntax to another. Features the target
+// syntax cannot express, such as pattern constraints in RFC 6570 or optional
+// params in Brace, are reported as errors rather than dropped.
+func Convert(pattern string, from, to Syntax) (string, error) {
+	var (
+		tokens []token
+		err    error
+	)
+	switch from {
+	case Colon:
+		tokens, err = parseColon(pattern)
+	case Brace:
+		tokens, err = parseBrace(pattern)
+	case RFC6570:
+		tokens, err = parseRFC6570(pattern)
+	default:
+		return "", fmt.Errorf("routesyntax: unknown source syntax %q", from)
+	}
+	if err != nil {
+		return "", fmt.Errorf("routesyntax: parse %q: %w", pattern, err)
+	}
+
+	var out string
+	switch to {
+	case Colon:
+		out, err = formatColon(tokens)
+	case Brace:
+		out, err = formatBrace(tokens)
+	case RFC6570:
+		out, err = formatRFC6570(tokens)
+	default:
+		return "", fmt.Errorf("routesyntax: unknown target syntax %q", to)
+	}
+	if err != nil {
+		return "", fmt.Errorf("routesyntax: convert %q to %s: %w", pattern, to, err)
+	}
+	return out, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// appendLiteral adds text to the trailing literal token, if any.
+func appendLiteral(tokens []token, text string) []token {
+	if n := len(tokens); n > 0 && !tokens[n-1].isParam() {
+		tokens[n-1].literal += text
+		return tokens
+	}
+	return append(tokens, token{literal: text})
+}
+
+// closing returns the index of the delimiter closing the one at start,
+// honouring nesting.
+func closing(s string, start int, open, close byte) (int, error) {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced %q", string(open))
+}
+
+func parseColon(pattern string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			tokens = appendLiteral(tokens, pattern[i+1:i+2])
+			i++
+		case c == ':':
+			end := i + 1
+			for end < len(pattern) && isNameChar(pattern[end]) {
+				end++
+			}
+			if end == i+1 {
+				return nil, fmt.Errorf("missing param name at offset %d", i)
+			}
+			param := token{name: pattern[i+1 : end]}
+			if end < len(pattern) && pattern[end] == '(' {
+				close, err := closing(pattern, end, '(', ')')
+				if err != nil {
+					return nil, err
+				}
+				param.pattern = pattern[end+1 : close]
+				end = close + 1
+			}
+			if end < len(pattern) && strings.ContainsRune("?*+", rune(pattern[end])) {
+				param.modifier = pattern[end : end+1]
+				end++
+			}
+			tokens = append(tokens, param)
+			i = end - 1
+		case c == '(' || c == '{':
+			return nil, fmt.Errorf("unescaped %q at offset %d", string(c), i)
+		default:
+			tokens = appendLiteral(tokens, string(c))
+		}
+	}
+	return tokens, nil
+}
+
+func parseBrace(pattern string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '}':
+			return nil, fmt.Errorf("unbalanced '}' at offset %d", i)
+		case '{':
+			close, err := closing(pattern, i, '{', '}')
+			if err != nil {
+				return nil, err
+			}
+			name, constraint, _ := strings.Cut(pattern[i+1:close], ":")
+			if name == "" || strings.IndexFunc(name, func(r rune) bool { return r > 127 || !isNameChar(byte(r)) }) >= 0 {
+				return nil, fmt.Errorf("invalid param name %q", name)
+			}
+			tokens = append(tokens, token{name: name, pattern: constraint})
+			i = close
+		default:
+			tokens = appendLiteral(tokens, pattern[i:i+1])
+		}
+	}
+	return tokens, nil
+}
+
+func parseRFC6570(pattern string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '}':
+			return nil, fmt.Errorf("unbalanced '}' at offset %d", i)
+		case '{':
+			close := strings.IndexByte(pattern[i:], '}')
+			if close < 0 {
+				return nil, fmt.Errorf("unbalanced '{' at offset %d", i)
+			}
+			expr := pattern[i+1 : i+close]
+			i += close
+
+			segment := strings.HasPrefix(expr, "/")
+			if segment {
+				expr = expr[1:]
+			} else if expr != "" && strings.ContainsRune("+#.;?&=,!@|", rune(expr[0])) {
+				return nil, fmt.Errorf("operator %q is not supported in route patterns", expr[:1])
+			}
+			for _, spec := range strings.Split(expr, ",") {
+				param := token{name: spec}
+				if name, ok := strings.CutSuffix(spec, "*"); ok {
+					param = token{name: name, modifier: "*"}
+				}
+				if strings.Contains(param.name, ":") {
+					return nil, fmt.Errorf("prefix modifier in %q is not supported", spec)
+				}
+				if param.name == "" {
+					return nil, fmt.Errorf("empty variable in %q", pattern)
+				}
+				if !segment {
+					if param.modifier != "" {
+						return nil, fmt.Errorf("explode modifier on %q requires a path segment expression", param.name)
+					}
+					tokens = append(tokens, param)
+					continue
+				}
+				if param.modifier == "" {
+					param.modifier = "?"
+				}
+				tokens = appendLiteral(tokens, "/")
+				tokens = append(tokens, param)
+			}
+		default:
+			tokens = appendLiteral(tokens, pattern[i:i+1])
+		}
+	}
+	return tokens, nil
+}
+
+func formatColon(tokens []token) (string, error) {
+	var out strings.Builder
+	for i, t := range tokens {
+		if !t.isParam() {
+			for j := 0; j < len(t.literal); j++ {
+				if strings.IndexByte(":(){}?*+\\", t.literal[j]) >= 0 {
+					out.WriteByte('\\')
+				}
+				out.WriteByte(t.literal[j])
+			}
+			continue
+		}
+		if t.pattern == "" && t.modifier == "" && i+1 < len(tokens) && !tokens[i+1].isParam() && isNameChar(tokens[i+1].literal[0]) {
+			return "", fmt.Errorf("param %q is directly followed by name characters", t.name)
+		}
+		out.WriteString(":" + t.name)
+		if t.pattern != "" {
+			out.WriteString("(" + t.pattern + ")")
+		}
+		out.WriteString(t.modifier)
+	}
+	return out.String(), nil
+}
+
+func formatBrace(tokens []token) (string, error) {
+	var out strings.Builder
+	for _, t := range tokens {
+		switch {
+		case !t.isParam():
+			out.WriteString(t.literal)
+		case t.modifier != "":
+			return "", fmt.Errorf("param %q with modifier %q has no brace equivalent", t.name, t.modifier)
+		case t.pattern != "":
+			out.WriteString("{" + t.name + ":" + t.pattern + "}")
+		default:
+			out.WriteString("{" + t.name + "}")
+		}
+	}
+	return out.String(), nil
+}
+
+func formatRFC6570(tokens []token) (string, error) {
+	var out strings.Builder
+	for i, t := range tokens {
+		if !t.isParam() {
+			literal := t.literal
+			// A "/" before an optional or repeated param moves into the
+			// expression, as "{/name}".
+			if i+1 < len(tokens) && tokens[i+1].modifier != "" && tokens[i+1].modifier != "+" {
+				var ok bool
+				if literal, ok = strings.CutSuffix(literal, "/"); !ok {
+					return "", fmt.Errorf("optional param %q must follow a '/'", tokens[i+1].name)
+				}
+			}
+			out.WriteString(literal)
+			continue
+		}
+
+		switch {
+		case (t.modifier == "?" || t.modifier == "*") && (i == 0 || tokens[i-1].isParam()):
+			return "", fmt.Errorf("optional param %q must follow a '/'", t.name)
+		case t.pattern != "":
+			return "", fmt.Errorf("param %q has a pattern constraint, which RFC 6570 cannot express", t.name)
+		case t.modifier == "+":
+			return "", fmt.Errorf("param %q with modifier \"+\" has no RFC 6570 equivalent", t.name)
+		case t.modifier == "?":
+			out.WriteString("{/" + t.name + "}")
+		case t.modifier == "*":
+			out.WriteString("{/" + t.name + "*}")
+		default:
+			out.WriteString("{" + t.name + "}")
+		}
+	}
+	return out.String(), nil
+}
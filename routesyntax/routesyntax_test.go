@@ -0,0 +1,101 @@
+package routesyntax
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		colon, brace, rfc string
+	}{
+		{"/users/:id", "/users/{id}", "/users/{id}"},
+		{"/posts/:postId/comments/:commentId", "/posts/{postId}/comments/{commentId}", "/posts/{postId}/comments/{commentId}"},
+		{`/users/:id(\d+)`, `/users/{id:\d+}`, ""},
+		{"/search/:query?", "", "/search{/query}"},
+		{"/files/:path*", "", "/files{/path*}"},
+		{"/js/:bundle.js", "/js/{bundle}.js", "/js/{bundle}.js"},
+	}
+	for _, tc := range cases {
+		for _, target := range []struct {
+			syntax Syntax
+			want   string
+		}{{Brace, tc.brace}, {RFC6570, tc.rfc}} {
+			got, err := Convert(tc.colon, Colon, target.syntax)
+			if target.want == "" {
+				if err == nil {
+					t.Fatalf("Convert(%q, %s): expected error, got %q", tc.colon, target.syntax, got)
+				}
+				continue
+			}
+			if err != nil || got != target.want {
+				t.Fatalf("Convert(%q, %s) = %q, %v; want %q", tc.colon, target.syntax, got, err, target.want)
+			}
+			back, err := Convert(got, target.syntax, Colon)
+			if err != nil || back != tc.colon {
+				t.Fatalf("Convert(%q, %s -> colon) = %q, %v; want %q", got, target.syntax, back, err, tc.colon)
+			}
+		}
+	}
+
+	if _, err := Convert("/users/{id}name", Brace, Colon); err == nil {
+		t.Fatal("expected error for param followed by name characters")
+	}
+	if _, err := Convert("/search{?q}", RFC6570, Colon); err == nil {
+		t.Fatal("expected error for query expression")
+	}
+	if got, _ := Convert("/items{/a,b}", RFC6570, Colon); got != "/items/:a?/:b?" {
+		t.Fatalf("unexpected multi-variable conversion %q", got)
+	}
+}
+
+func TestRewriteJSON(t *testing.T) {
+	config := `{
+  "groups": [
+    {
+      "name": "api",
+      "base_url": "https://api.example.com",
+      "template_vars": {"path": "/not/:a/route"},
+      "routes": {
+        "user": "/users/:id",
+        "status": "/status"
+      },
+      "groups": [
+        {"name": "v2", "routes": {"search": "/search/:query?"}}
+      ]
+    },
+    {
+      "name": "legacy",
+      "type": "redirects",
+      "redirects": [{"from": "/old/users/:id", "route": "api.user"}]
+    }
+  ]
+}`
+	out, changes, err := RewriteJSON([]byte(config), Colon, RFC6570)
+	if err != nil {
+		t.Fatalf("RewriteJSON failed: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+
+	want := strings.NewReplacer(
+		`"/users/:id"`, `"/users/{id}"`,
+		`"/search/:query?"`, `"/search{/query}"`,
+		`"/old/users/:id"`, `"/old/users/{id}"`,
+	).Replace(config)
+	if string(out) != want {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+
+	diff := Diff(changes)
+	if !strings.Contains(diff, "groups[0].routes.user\n- /users/:id\n+ /users/{id}\n") ||
+		!strings.Contains(diff, "groups[1].redirects[0].from\n") {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	_, _, err = RewriteJSON([]byte(`{"groups": [{"name": "api", "routes": {"user": "/users/:id(\\d+)"}}]}`), Colon, RFC6570)
+	if err == nil || !strings.Contains(err.Error(), "groups[0].routes.user") {
+		t.Fatalf("expected conversion error with path, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package urlkit
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SchemePolicy controls how a group treats URLs that resolve to plain http.
+// Policies are inherited: the nearest group in the chain that sets one wins.
+type SchemePolicy string
+
+const (
+	// SchemePolicyNone leaves schemes untouched (default).
+	SchemePolicyNone SchemePolicy = ""
+	// SchemePolicyRequireHTTPS makes builds fail with InsecureURLError when the
+	// URL uses http.
+	SchemePolicyRequireHTTPS SchemePolicy = "require_https"
+	// SchemePolicyUpgradeHTTPS rewrites http URLs to https.
+	SchemePolicyUpgradeHTTPS SchemePolicy = "upgrade_https"
+)
+
+func (p SchemePolicy) valid() bool {
+	switch p {
+	case SchemePolicyNone, SchemePolicyRequireHTTPS, SchemePolicyUpgradeHTTPS:
+		return true
+	default:
+		return false
+	}
+}
+
+// InsecureURLError is returned when a group with SchemePolicyRequireHTTPS
+// produces an http URL.
+type InsecureURLError struct {
+	Group string
+	Route string
+	URL   string
+}
+
+func (e InsecureURLError) Error() string {
+	return fmt.Sprintf("group %q route %q produced insecure URL %q: https is required", e.Group, e.Route, e.URL)
+}
+
+// SetSchemePolicy declares how this group and its descendants treat http URLs.
+func (u *Group) SetSchemePolicy(policy SchemePolicy) error {
+	if !policy.valid() {
+		return fmt.Errorf("set scheme policy: unsupported policy %q", policy)
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set scheme policy", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.schemePolicy = policy
+	u.mu.Unlock()
+	return nil
+}
+
+// SchemePolicy returns the effective scheme policy, searching up the hierarchy.
+func (u *Group) SchemePolicy() SchemePolicy {
+	for current := u; current != nil; {
+		current.mu.RLock()
+		policy := current.schemePolicy
+		parent := current.parent
+		current.mu.RUnlock()
+
+		if policy != SchemePolicyNone {
+			return policy
+		}
+		current = parent
+	}
+	return SchemePolicyNone
+}
+
+// enforceScheme applies the effective scheme policy to a built URL. Relative
+// URLs and non-http schemes are returned unchanged.
+func (u *Group) enforceScheme(routeName, built string) (string, error) {
+	rest, ok := cutPrefixFold(built, "http://")
+	if !ok {
+		return built, nil
+	}
+
+	switch u.SchemePolicy() {
+	case SchemePolicyRequireHTTPS:
+		return "", InsecureURLError{Group: groupDisplayName(u), Route: routeName, URL: built}
+	case SchemePolicyUpgradeHTTPS:
+		return "https://" + rest, nil
+	default:
+		return built, nil
+	}
+}
+
+func cutPrefixFold(value, prefix string) (string, bool) {
+	if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return value, false
+	}
+	return value[len(prefix):], true
+}
+
+// devProfiles lists profile names under which http base URLs are expected.
+var devProfiles = []string{"dev", "development", "local", "test"}
+
+// isDevHost reports whether host is a loopback or reserved development host.
+func isDevHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") ||
+		strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".test") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified())
+}
+
+// lintInsecureBaseURL flags root groups with http base URLs, and templates that
+// hardcode http, unless they point at development hosts or a development profile
+// is active.
+func lintInsecureBaseURL(group *Group) []LintIssue {
+	if slices.Contains(devProfiles, group.runtime.activeProfile()) {
+		return nil
+	}
+
+	group.mu.RLock()
+	isRoot := group.parent == nil
+	baseURL := group.baseURL
+	template := group.urlTemplate
+	group.mu.RUnlock()
+
+	groupName := groupDisplayName(group)
+	var issues []LintIssue
+
+	if isRoot {
+		if parsed, err := url.Parse(baseURL); err == nil && strings.EqualFold(parsed.Scheme, "http") && !isDevHost(parsed.Hostname()) {
+			issues = append(issues, LintIssue{
+				Rule:       LintRuleInsecureBaseURL,
+				Severity:   LintWarning,
+				Group:      groupName,
+				Message:    fmt.Sprintf("base URL %q uses http, links will trigger mixed-content warnings on https pages", baseURL),
+				Suggestion: fmt.Sprintf("switch %s to https or set scheme_policy to %q", groupName, SchemePolicyUpgradeHTTPS),
+			})
+		}
+	}
+
+	if _, ok := cutPrefixFold(template, "http://"); ok {
+		issues = append(issues, LintIssue{
+			Rule:       LintRuleInsecureBaseURL,
+			Severity:   LintWarning,
+			Group:      groupName,
+			Message:    fmt.Sprintf("template %q hardcodes http", template),
+			Suggestion: "use https or a {protocol} template variable",
+		})
+	}
+
+	return issues
+}
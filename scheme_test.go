@@ -0,0 +1,84 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSchemePolicy(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:         "legacy",
+				BaseURL:      "http://legacy.example.com",
+				SchemePolicy: urlkit.SchemePolicyUpgradeHTTPS,
+				Routes:       map[string]string{"home": "/"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "docs", Path: "/docs", Routes: map[string]string{"page": "/:slug"}},
+				},
+			},
+			{
+				Name:         "secure",
+				BaseURL:      "https://secure.example.com",
+				URLTemplate:  "{protocol}://{host}{route_path}",
+				TemplateVars: map[string]string{"protocol": "http", "host": "secure.example.com"},
+				SchemePolicy: urlkit.SchemePolicyRequireHTTPS,
+				Routes:       map[string]string{"login": "/login"},
+			},
+		},
+	})
+
+	got, err := manager.Resolve("legacy.docs", "page", urlkit.Params{"slug": "intro"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got != "https://legacy.example.com/docs/intro" {
+		t.Fatalf("expected upgraded URL, got %s", got)
+	}
+
+	_, err = manager.Resolve("secure", "login", nil, nil)
+	var insecure urlkit.InsecureURLError
+	if !errors.As(err, &insecure) {
+		t.Fatalf("expected InsecureURLError, got %v", err)
+	}
+	if insecure.URL != "http://secure.example.com/login/" {
+		t.Fatalf("unexpected insecure URL: %s", insecure.URL)
+	}
+
+	group, err := manager.GetGroup("secure")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if err := group.SetTemplateVar("protocol", "https"); err != nil {
+		t.Fatalf("SetTemplateVar failed: %v", err)
+	}
+	if _, err := manager.Resolve("secure", "login", nil, nil); err != nil {
+		t.Fatalf("expected https URL to pass, got %v", err)
+	}
+
+	if err := group.SetSchemePolicy("bogus"); err == nil {
+		t.Fatal("expected error for unsupported policy")
+	}
+}
+
+func TestLintInsecureBaseURL(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "api", BaseURL: "http://api.example.com", Routes: map[string]string{"status": "/status"}},
+			{Name: "local", BaseURL: "http://localhost:8080", Routes: map[string]string{"status": "/status"}},
+			{Name: "web", BaseURL: "https://www.example.com", Routes: map[string]string{"home": "/"}},
+		},
+	})
+
+	var found []string
+	for _, issue := range manager.Lint() {
+		if issue.Rule == urlkit.LintRuleInsecureBaseURL {
+			found = append(found, issue.Group)
+		}
+	}
+	if len(found) != 1 || found[0] != "api" {
+		t.Fatalf("expected only api to be flagged, got %v", found)
+	}
+}
@@ -0,0 +1,64 @@
+package securelink
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchManager is a Manager that also generates links in bulk. Managers
+// created by NewManager and NewManagerWithGroup implement it.
+//
+// Example:
+//
+//	items := []securelink.Payload{{"user_id": "1"}, {"user_id": "2"}}
+//	links, err := manager.(securelink.BatchManager).GenerateBatch("unsubscribe", items)
+type BatchManager interface {
+	Manager
+
+	// GenerateBatch creates one secure link per payload for the specified
+	// route, spreading the work across a worker pool. Links are returned in
+	// the same order as items. If any item fails, no links are returned and
+	// the error reports the first failing index.
+	GenerateBatch(route string, items []Payload) ([]string, error)
+}
+
+func (m *manager) GenerateBatch(route string, items []Payload) ([]string, error) {
+	if _, err := m.routeTemplate(route); err != nil {
+		return nil, err
+	}
+
+	links := make([]string, len(items))
+	if len(items) == 0 {
+		return links, nil
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), len(items))
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				links[i], errs[i] = m.Generate(route, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	// Report the lowest failing index so errors are deterministic as well
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+	}
+	return links, nil
+}
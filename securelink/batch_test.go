@@ -0,0 +1,55 @@
+package securelink
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateBatch(t *testing.T) {
+	manager, err := NewManager(Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "token",
+		AsQuery:    true,
+		Routes:     map[string]string{"unsubscribe": "/newsletter/unsubscribe"},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	items := make([]Payload, 500)
+	for i := range items {
+		items[i] = Payload{"subscriber": fmt.Sprintf("sub-%d", i)}
+	}
+
+	links, err := manager.(BatchManager).GenerateBatch("unsubscribe", items)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	if len(links) != len(items) {
+		t.Fatalf("expected %d links, got %d", len(items), len(links))
+	}
+
+	for _, i := range []int{0, 137, 499} {
+		_, token, _ := strings.Cut(links[i], "token=")
+		payload, err := manager.Validate(token)
+		if err != nil {
+			t.Fatalf("Validate link %d failed: %v", i, err)
+		}
+		if want := fmt.Sprintf("sub-%d", i); payload["subscriber"] != want {
+			t.Errorf("link %d: expected subscriber %s, got %v", i, want, payload["subscriber"])
+		}
+	}
+
+	if _, err := manager.(BatchManager).GenerateBatch("missing", items); err == nil {
+		t.Error("expected error for unknown route")
+	}
+
+	links, err = manager.(BatchManager).GenerateBatch("unsubscribe", nil)
+	if err != nil || len(links) != 0 {
+		t.Errorf("expected empty result for no items, got %v (%v)", links, err)
+	}
+}
@@ -0,0 +1,164 @@
+package securelink
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// purposeClaim is the JWT claim carrying the flow a token was minted for.
+const purposeClaim = "pur"
+
+var (
+	// ErrPurposeMismatch is returned by Verify when the token was minted for a
+	// different purpose than the one required.
+	ErrPurposeMismatch = errors.New("token purpose mismatch")
+	// ErrAudienceMismatch is returned by Verify when the token audience does not
+	// include the required audience.
+	ErrAudienceMismatch = errors.New("token audience mismatch")
+)
+
+// VerifyingManager is a Manager that also enforces purpose, audience and
+// tenant requirements when validating a token. Managers created by NewManager
+// and NewManagerWithGroup implement it.
+//
+// Example:
+//
+//	verifier := manager.(securelink.VerifyingManager)
+//	payload, err := verifier.Verify(token, securelink.WithPurpose("password_reset"))
+type VerifyingManager interface {
+	Manager
+
+	// Verify validates a token like Validate and additionally enforces the
+	// given requirements, such as WithPurpose and WithAudience, so tokens
+	// minted for one flow cannot be replayed in another. It returns
+	// validation errors, ErrPurposeMismatch, ErrAudienceMismatch or
+	// ErrTenantMismatch.
+	Verify(token string, opts ...VerifyOption) (Payload, error)
+}
+
+// VerifyOption adds a requirement checked by VerifyingManager.Verify.
+type VerifyOption func(*verifyOptions)
+
+type verifyOptions struct {
+	purpose  string
+	audience string
+	tenant   string
+}
+
+// WithPurpose requires the token to have been generated for the given purpose
+// (see Config.Purposes).
+func WithPurpose(purpose string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.purpose = purpose
+	}
+}
+
+// WithAudience requires the token's audience to include audience (see
+// Config.Audience).
+func WithAudience(audience string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.audience = audience
+	}
+}
+
+// purposeFor returns the purpose stamped on tokens generated for route. Routes
+// without an explicit purpose use the route name, so tokens are always bound to
+// the flow that minted them.
+func (m *manager) purposeFor(route string) string {
+	if purpose, ok := m.purposes[route]; ok && purpose != "" {
+		return purpose
+	}
+	return route
+}
+
+func (m *manager) Verify(token string, opts ...VerifyOption) (Payload, error) {
+	claims, err := m.verifyClaims(token, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return payloadFromClaims(claims)
+}
+
+// verifyClaims parses token and checks the requirements in opts.
+func (m *manager) verifyClaims(token string, opts ...VerifyOption) (jwt.MapClaims, error) {
+	options := verifyOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+
+	claims, err := parseClaims(token, m.keyFor, m.signingMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tenant tokens are only accepted when that tenant was asked for, so a
+	// tenant's key cannot mint tokens accepted by untenanted flows
+	if tenant, _ := claims[tenantClaim].(string); tenant != options.tenant {
+		return nil, ErrTenantMismatch
+	}
+
+	if options.purpose != "" {
+		if purpose, _ := claims[purposeClaim].(string); purpose != options.purpose {
+			return nil, ErrPurposeMismatch
+		}
+	}
+
+	if options.audience != "" {
+		audience, err := claims.GetAudience()
+		if err != nil || !slices.Contains(audience, options.audience) {
+			return nil, ErrAudienceMismatch
+		}
+	}
+
+	return claims, nil
+}
+
+// staticKey returns a key lookup that uses signingKey for every token.
+func staticKey(signingKey string) func(string) ([]byte, error) {
+	return func(string) ([]byte, error) {
+		return []byte(signingKey), nil
+	}
+}
+
+// parseClaims verifies tokenString with the key returned by keyFor for the
+// token's (unverified) tenant claim.
+func parseClaims(tokenString string, keyFor func(tenantID string) ([]byte, error), signingMethod jwt.SigningMethod) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		// Check that the token's signing method matches the expected one
+		if token.Method != signingMethod {
+			return nil, errors.New("token signing method validation failed")
+		}
+		var tenantID string
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			if value, exists := claims[tenantClaim]; exists {
+				if tenantID, ok = value.(string); !ok || tenantID == "" {
+					return nil, errors.New("invalid tenant claim")
+				}
+			}
+		}
+		return keyFor(tenantID)
+	})
+
+	if err != nil {
+		// Don't expose JWT library internal errors that might leak sensitive data
+		return nil, errors.New("token validation failed")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+	return claims, nil
+}
+
+func payloadFromClaims(claims jwt.MapClaims) (map[string]any, error) {
+	dat, ok := claims["dat"].(map[string]any)
+	if !ok {
+		return nil, errors.New("token payload extraction failed")
+	}
+	return dat, nil
+}
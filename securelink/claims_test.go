@@ -0,0 +1,96 @@
+package securelink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newPurposeManager(t *testing.T) VerifyingManager {
+	t.Helper()
+	manager, err := NewManager(Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "token",
+		AsQuery:    true,
+		Routes: map[string]string{
+			"reset":  "/auth/reset",
+			"verify": "/auth/verify",
+		},
+		Purposes: map[string]string{"reset": "password_reset"},
+		Audience: "web",
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return manager.(VerifyingManager)
+}
+
+func tokenFromLink(t *testing.T, link string) string {
+	t.Helper()
+	_, token, ok := strings.Cut(link, "token=")
+	if !ok {
+		t.Fatalf("link has no token: %s", link)
+	}
+	return token
+}
+
+func TestVerifyPurpose(t *testing.T) {
+	manager := newPurposeManager(t)
+
+	link, err := manager.Generate("reset", Payload{"user_id": "123"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	payload, err := manager.Verify(token, WithPurpose("password_reset"), WithAudience("web"))
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if payload["user_id"] != "123" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+
+	if _, err := manager.Verify(token, WithPurpose("verify")); !errors.Is(err, ErrPurposeMismatch) {
+		t.Errorf("expected ErrPurposeMismatch, got %v", err)
+	}
+	if _, err := manager.Verify(token, WithAudience("mobile")); !errors.Is(err, ErrAudienceMismatch) {
+		t.Errorf("expected ErrAudienceMismatch, got %v", err)
+	}
+
+	// Routes without an explicit purpose are bound to the route name
+	link, err = manager.Generate("verify")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := manager.Verify(tokenFromLink(t, link), WithPurpose("verify")); err != nil {
+		t.Errorf("expected route name purpose, got %v", err)
+	}
+	if _, err := manager.Verify(tokenFromLink(t, link), WithPurpose("password_reset")); !errors.Is(err, ErrPurposeMismatch) {
+		t.Errorf("expected ErrPurposeMismatch for replayed token, got %v", err)
+	}
+}
+
+func TestVerifyLegacyTokenWithoutPurpose(t *testing.T) {
+	manager := newPurposeManager(t)
+
+	token, err := Generate(map[string]any{"user_id": "123"}, "a-very-secure-key-of-at-least-32-bytes", time.Hour, jwt.SigningMethodHS256)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := manager.Verify(token); err != nil {
+		t.Errorf("Verify without requirements should accept token: %v", err)
+	}
+	if _, err := manager.Verify(token, WithPurpose("password_reset")); !errors.Is(err, ErrPurposeMismatch) {
+		t.Errorf("expected ErrPurposeMismatch, got %v", err)
+	}
+	if _, err := manager.Verify("not-a-token"); err == nil {
+		t.Error("expected error for malformed token")
+	}
+}
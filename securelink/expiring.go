@@ -6,8 +6,14 @@
 //   - Thread-safe, stateless design for concurrent access
 //   - Configurable JWT signing algorithms (HS256, HS384, HS512)
 //   - Automatic signing key length validation for security
-//   - Flexible URL generation (path-based or query parameter)
+//   - Flexible URL generation (path-based, named path param, or query parameter)
 //   - Support for custom payload data in tokens
+//   - Typed payloads via generics (see ManagerFor and NewManagerFor)
+//   - Purpose and audience claims enforced at verify time (see VerifyingManager)
+//   - Per-tenant signing keys (see KeyProvider and TenantManager)
+//   - Links built through a urlkit route group (see NewManagerWithGroup)
+//   - Signed, versioned query state for multi-step flows (see StateCodec)
+//   - Optional single-use tokens for reset and verification links (see UsedTokenStore and SingleUseManager)
 //   - Backward compatibility with legacy API
 //
 // # Basic Usage
@@ -83,6 +89,9 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	urlkit "github.com/goliatone/go-urlkit"
 )
 
 const (
@@ -99,6 +108,12 @@ type manager struct {
 	routes        map[string]string
 	queryKey      string
 	asQuery       bool
+	tokenParam    string
+	purposes      map[string]string
+	audience      string
+	keyProvider   KeyProvider
+	usedTokens    UsedTokenStore
+	group         *urlkit.Group
 	signingMethod jwt.SigningMethod
 }
 
@@ -136,6 +151,11 @@ type Config struct {
 	Routes        map[string]string // Map of route names to URL paths (e.g., {"reset": "/auth/reset"})
 	AsQuery       bool              // false=path URLs (/path/{token}), true=query URLs (/path?key={token})
 	SigningMethod jwt.SigningMethod // JWT algorithm (HS256, HS384, HS512). Defaults to HS256 if nil
+	TokenParam    string            // Path param receiving the token in path mode (e.g. ":token" in "/reset/:token/confirm"). Defaults to QueryKey, then "token"
+	Purposes      map[string]string // Purpose claim per route (e.g. {"reset": "password_reset"}). Defaults to the route name
+	Audience      string            // Optional audience claim stamped on every token (e.g. "web")
+	KeyProvider   KeyProvider       // Per-tenant signing keys used by GenerateForTenant. SigningKey may be empty when set
+	UsedTokens    UsedTokenStore    // Records consumed tokens for ValidateAndConsume (e.g. NewMemoryUsedTokenStore())
 }
 
 // GetSigningKey implements the Configurator interface for the Config struct.
@@ -227,7 +247,8 @@ type Manager interface {
 	Generate(route string, payloads ...Payload) (string, error)
 
 	// Validate verifies a JWT token and returns the embedded payload data.
-	// Validates signature, expiration, and token structure.
+	// Validates signature, expiration, and token structure. Tokens generated
+	// with a tenant are rejected; verify them with Verify and WithTenant.
 	//
 	// Parameters:
 	//   token: JWT token string to validate
@@ -335,9 +356,12 @@ func NewManager(cfg Config) (Manager, error) {
 		signingMethod = jwt.SigningMethodHS256
 	}
 
-	// Validate signing key length based on the algorithm
-	if err := validateSigningKey(cfg.SigningKey, signingMethod); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	// Validate signing key length based on the algorithm. Managers that only
+	// sign tenant tokens may leave SigningKey empty.
+	if cfg.SigningKey != "" || cfg.KeyProvider == nil {
+		if err := validateSigningKey(cfg.SigningKey, signingMethod); err != nil {
+			return nil, fmt.Errorf("configuration validation failed: %w", err)
+		}
 	}
 
 	return &manager{
@@ -348,6 +372,11 @@ func NewManager(cfg Config) (Manager, error) {
 		routes:        cfg.Routes,
 		queryKey:      cfg.QueryKey,
 		asQuery:       cfg.AsQuery,
+		tokenParam:    tokenParamOrDefault(cfg.TokenParam, cfg.QueryKey),
+		purposes:      cfg.Purposes,
+		audience:      cfg.Audience,
+		keyProvider:   cfg.KeyProvider,
+		usedTokens:    cfg.UsedTokens,
 		signingMethod: signingMethod,
 	}, nil
 }
@@ -385,6 +414,10 @@ func NewManagerFromConfig(cfg Configurator) (Manager, error) {
 }
 
 func (m *manager) Generate(route string, payloads ...Payload) (string, error) {
+	return m.generate("", route, payloads...)
+}
+
+func (m *manager) generate(tenantID, route string, payloads ...Payload) (string, error) {
 	// Merge all payloads into one map
 	var combinedPayload map[string]any
 	if len(payloads) > 0 {
@@ -396,22 +429,42 @@ func (m *manager) Generate(route string, payloads ...Payload) (string, error) {
 		}
 	}
 
-	token, err := Generate(combinedPayload, m.signingKey, m.expiration, m.signingMethod)
+	segment, err := m.routeTemplate(route)
+	if err != nil {
+		return "", err
+	}
+
+	extra := jwt.MapClaims{
+		purposeClaim: m.purposeFor(route),
+		"jti":        uuid.NewString(),
+	}
+	if m.audience != "" {
+		extra["aud"] = m.audience
+	}
+	if tenantID != "" {
+		extra[tenantClaim] = tenantID
+	}
+
+	key, err := m.keyFor(tenantID)
 	if err != nil {
 		return "", fmt.Errorf("token generation failed: %w", err)
 	}
 
-	var ok bool
-	var segment string
+	token, err := generateToken(combinedPayload, string(key), m.expiration, m.signingMethod, extra)
+	if err != nil {
+		return "", fmt.Errorf("token generation failed: %w", err)
+	}
 
-	if segment, ok = m.routes[route]; !ok {
-		return "", fmt.Errorf("route '%s' not found in configured routes", route)
+	if m.group != nil {
+		return m.groupURL(route, segment, token, combinedPayload)
 	}
 
 	var u *url.URL
 	if m.asQuery {
 		u = m.url.JoinPath(segment)
 		u.RawQuery = fmt.Sprintf("%s=%s", m.queryKey, url.QueryEscape(token))
+	} else if hasPathParam(segment, m.tokenParam) {
+		u = m.url.JoinPath(fillPathParam(segment, m.tokenParam, token))
 	} else {
 		u = m.url.JoinPath(segment, token)
 	}
@@ -419,6 +472,16 @@ func (m *manager) Generate(route string, payloads ...Payload) (string, error) {
 	return u.String(), nil
 }
 
+func tokenParamOrDefault(tokenParam, queryKey string) string {
+	if tokenParam != "" {
+		return tokenParam
+	}
+	if queryKey != "" {
+		return queryKey
+	}
+	return defaultTokenParam
+}
+
 func (m *manager) GetAndValidate(fn func(string) string) (Payload, error) {
 	token := fn(m.queryKey)
 	return m.Validate(token)
@@ -429,7 +492,11 @@ func (m *manager) GetExpiration() time.Duration {
 }
 
 func (m *manager) Validate(token string) (map[string]any, error) {
-	return Validate(token, m.signingKey, m.signingMethod)
+	claims, err := m.verifyClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	return payloadFromClaims(claims)
 }
 
 // Generate creates a JWT token containing the provided data with the specified expiration.
@@ -456,6 +523,10 @@ func (m *manager) Validate(token string) (map[string]any, error) {
 // Security note: This function does not validate key length. Use NewManager
 // for automatic key validation.
 func Generate(data map[string]any, signingKey string, expiration time.Duration, signingMethod jwt.SigningMethod) (string, error) {
+	return generateToken(data, signingKey, expiration, signingMethod, nil)
+}
+
+func generateToken(data map[string]any, signingKey string, expiration time.Duration, signingMethod jwt.SigningMethod, extra jwt.MapClaims) (string, error) {
 	// Ensure data is not nil to prevent validation issues
 	if data == nil {
 		data = make(map[string]any)
@@ -469,6 +540,10 @@ func Generate(data map[string]any, signingKey string, expiration time.Duration,
 		),
 	}
 
+	for key, value := range extra {
+		claims[key] = value
+	}
+
 	token := jwt.NewWithClaims(signingMethod, claims)
 
 	signedToken, err := token.SignedString([]byte(signingKey))
@@ -506,30 +581,9 @@ func Generate(data map[string]any, signingKey string, expiration time.Duration,
 //   - Expired tokens are automatically rejected
 //   - Error messages are generic to prevent information leakage
 func Validate(tokenString, signingKey string, signingMethod jwt.SigningMethod) (map[string]any, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		// Check that the token's signing method matches the expected one
-		if token.Method != signingMethod {
-			return nil, errors.New("token signing method validation failed")
-		}
-		return []byte(signingKey), nil
-	})
-
+	claims, err := parseClaims(tokenString, staticKey(signingKey), signingMethod)
 	if err != nil {
-		// Don't expose JWT library internal errors that might leak sensitive data
-		return nil, errors.New("token validation failed")
-	}
-
-	var ok bool
-	var claims jwt.MapClaims
-
-	if claims, ok = token.Claims.(jwt.MapClaims); !ok || !token.Valid {
-		return nil, errors.New("invalid token claims")
-	}
-
-	dat, ok := claims["dat"].(map[string]any)
-	if !ok {
-		return nil, errors.New("token payload extraction failed")
+		return nil, err
 	}
-
-	return dat, nil
+	return payloadFromClaims(claims)
 }
@@ -0,0 +1,95 @@
+package securelink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// routeParamPattern matches the named params of a urlkit route template.
+var routeParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// NewManagerWithGroup creates a Manager whose links are built by group, so
+// they honor its path prefixes, URL template and template variables (e.g. a
+// locale subdomain) and routes are defined once in the urlkit registry.
+// Route names passed to Generate are the group's route names; cfg.BaseURL and
+// cfg.Routes are ignored. The token is placed as in NewManager: in the
+// QueryKey query param when AsQuery is set, in the TokenParam path param when
+// the route has one, or appended as a final path segment. Other route params
+// are filled from the payload values of the same name.
+//
+// Example:
+//
+//	// routes: {"reset": "/auth/reset/:token"} in group "frontend.es"
+//	manager, err := securelink.NewManagerWithGroup(rm.Group("frontend").Group("es"), securelink.Config{
+//		SigningKey: key,
+//		Expiration: time.Hour,
+//	})
+//	link, err := manager.Generate("reset", securelink.Payload{"user_id": "42"})
+//	// https://es.example.com/auth/reset/eyJhbGciOi...
+func NewManagerWithGroup(group *urlkit.Group, cfg Config) (Manager, error) {
+	if group == nil {
+		return nil, errors.New("route group is required")
+	}
+
+	cfg.BaseURL = ""
+	cfg.Routes = nil
+	m, err := NewManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	m.(*manager).group = group
+	return m, nil
+}
+
+// routeTemplate returns the path template of route, from the group when the
+// manager has one.
+func (m *manager) routeTemplate(route string) (string, error) {
+	if m.group != nil {
+		template, err := m.group.Route(route)
+		if err != nil {
+			return "", fmt.Errorf("route '%s' not found in group: %w", route, err)
+		}
+		return template, nil
+	}
+	segment, ok := m.routes[route]
+	if !ok {
+		return "", fmt.Errorf("route '%s' not found in configured routes", route)
+	}
+	return segment, nil
+}
+
+// groupURL builds the link for route through the manager's group.
+func (m *manager) groupURL(route, template, token string, payload map[string]any) (string, error) {
+	builder := m.group.Builder(route)
+	for _, match := range routeParamPattern.FindAllStringSubmatch(template, -1) {
+		if value, ok := payload[match[1]]; ok && match[1] != m.tokenParam {
+			builder.WithParam(match[1], value)
+		}
+	}
+
+	inPath := !m.asQuery && hasPathParam(template, m.tokenParam)
+	switch {
+	case m.asQuery:
+		builder.WithQuery(m.queryKey, token)
+	case inPath:
+		builder.WithParam(m.tokenParam, token)
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		return "", fmt.Errorf("link build failed: %w", err)
+	}
+	if m.asQuery || inPath {
+		return built, nil
+	}
+
+	u, err := url.Parse(built)
+	if err != nil {
+		return "", fmt.Errorf("link build failed: %w", err)
+	}
+	return u.JoinPath(token).String(), nil
+}
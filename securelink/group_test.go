@@ -0,0 +1,137 @@
+package securelink
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func newLinkGroups(t *testing.T) *urlkit.RouteManager {
+	t.Helper()
+	manager, err := urlkit.NewRouteManagerFromConfig(urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "frontend",
+				BaseURL:     "https://example.com",
+				URLTemplate: "https://{locale}.example.com/{section}{route_path}",
+				TemplateVars: map[string]string{
+					"route_path_suffix": "",
+				},
+				Groups: []urlkit.GroupConfig{
+					{
+						Name:         "es",
+						TemplateVars: map[string]string{"locale": "es", "section": "cuenta"},
+						Routes: map[string]string{
+							"reset":   "/restablecer/:token",
+							"verify":  "/verificar",
+							"confirm": "/usuarios/:user_id/confirmar/:token",
+						},
+					},
+				},
+			},
+			{
+				Name:    "app",
+				BaseURL: "https://app.example.com",
+				Groups: []urlkit.GroupConfig{
+					{Name: "auth", Path: "/auth", Routes: map[string]string{"reset": "/reset/:token"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRouteManagerFromConfig failed: %v", err)
+	}
+	return manager
+}
+
+func TestNewManagerWithGroup(t *testing.T) {
+	group := newLinkGroups(t).Group("frontend").Group("es")
+	manager, err := NewManagerWithGroup(group, Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://ignored.example.com",
+	})
+	if err != nil {
+		t.Fatalf("NewManagerWithGroup failed: %v", err)
+	}
+
+	link, err := manager.Generate("reset", Payload{"user_id": "42"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token, found := strings.CutPrefix(link, "https://es.example.com/cuenta/restablecer/")
+	if !found || token == "" {
+		t.Fatalf("unexpected link %q", link)
+	}
+	if payload, err := manager.(VerifyingManager).Verify(token, WithPurpose("reset")); err != nil || payload["user_id"] != "42" {
+		t.Fatalf("Verify = %v, %v", payload, err)
+	}
+
+	// Routes without a token param get the token as a final segment
+	link, err = manager.Generate("verify")
+	if err != nil || !strings.HasPrefix(link, "https://es.example.com/cuenta/verificar/ey") {
+		t.Fatalf("Generate(verify) = %q, %v", link, err)
+	}
+
+	// Other route params come from the payload
+	link, err = manager.Generate("confirm", Payload{"user_id": "42"})
+	if err != nil || !strings.HasPrefix(link, "https://es.example.com/cuenta/usuarios/42/confirmar/ey") {
+		t.Fatalf("Generate(confirm) = %q, %v", link, err)
+	}
+
+	links, err := manager.(BatchManager).GenerateBatch("reset", []Payload{{"n": 1}, {"n": 2}})
+	if err != nil || len(links) != 2 || !strings.HasPrefix(links[1], "https://es.example.com/cuenta/restablecer/") {
+		t.Fatalf("GenerateBatch = %v, %v", links, err)
+	}
+
+	if _, err := manager.Generate("missing"); err == nil {
+		t.Fatal("expected error for a route the group does not define")
+	}
+	if _, err := manager.(BatchManager).GenerateBatch("missing", []Payload{{}}); err == nil {
+		t.Fatal("expected batch error for a route the group does not define")
+	}
+}
+
+func TestNewManagerWithGroupQueryMode(t *testing.T) {
+	group := newLinkGroups(t).Group("frontend").Group("es")
+	manager, err := NewManagerWithGroup(group, Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		QueryKey:   "t",
+		AsQuery:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewManagerWithGroup failed: %v", err)
+	}
+
+	link, err := manager.Generate("verify")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Host != "es.example.com" || u.Path != "/cuenta/verificar" {
+		t.Fatalf("unexpected link %q", link)
+	}
+	if _, err := manager.GetAndValidate(u.Query().Get); err != nil {
+		t.Fatalf("GetAndValidate failed: %v", err)
+	}
+
+	// Path concatenation groups contribute their path prefixes
+	auth, err := NewManagerWithGroup(newLinkGroups(t).Group("app").Group("auth"), Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManagerWithGroup failed: %v", err)
+	}
+	if link, err := auth.Generate("reset"); err != nil || !strings.HasPrefix(link, "https://app.example.com/auth/reset/ey") {
+		t.Fatalf("Generate(auth.reset) = %q, %v", link, err)
+	}
+
+	if _, err := NewManagerWithGroup(nil, Config{SigningKey: "a-very-secure-key-of-at-least-32-bytes"}); err == nil {
+		t.Fatal("expected error for a nil group")
+	}
+}
@@ -0,0 +1,80 @@
+package securelink
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultTokenParam is the path param that receives the token when neither
+// Config.TokenParam nor Config.QueryKey is set.
+const defaultTokenParam = "token"
+
+// ErrTokenNotInPath is returned by ExtractPathToken when the request path does
+// not match the route pattern or the token segment is empty.
+var ErrTokenNotInPath = errors.New("token not found in path")
+
+// hasPathParam reports whether pattern contains the named param as a full path
+// segment (e.g. ":token" in "/reset/:token/confirm").
+func hasPathParam(pattern, param string) bool {
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ":"+param {
+			return true
+		}
+	}
+	return false
+}
+
+// fillPathParam replaces the named param segment in pattern with value.
+func fillPathParam(pattern, param, value string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if segment == ":"+param {
+			segments[i] = value
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ExtractPathToken returns the token found in requestPath at the position of the
+// named param in pattern. Other ":param" segments in the pattern match any
+// value; literal segments must match exactly. Trailing slashes are ignored.
+//
+// Example:
+//
+//	token, err := securelink.ExtractPathToken("/reset/:token/confirm", r.URL.Path, "token")
+//	if err != nil {
+//		return err
+//	}
+//	payload, err := manager.Validate(token)
+func ExtractPathToken(pattern, requestPath, param string) (string, error) {
+	if param == "" {
+		param = defaultTokenParam
+	}
+	if !hasPathParam(pattern, param) {
+		return "", fmt.Errorf("pattern %q has no :%s segment", pattern, param)
+	}
+
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return "", fmt.Errorf("%w: %q does not match %q", ErrTokenNotInPath, requestPath, pattern)
+	}
+
+	var token string
+	for i, segment := range patternSegments {
+		switch {
+		case segment == ":"+param:
+			token = pathSegments[i]
+		case strings.HasPrefix(segment, ":"):
+			// other params match any value
+		case segment != pathSegments[i]:
+			return "", fmt.Errorf("%w: %q does not match %q", ErrTokenNotInPath, requestPath, pattern)
+		}
+	}
+
+	if token == "" {
+		return "", fmt.Errorf("%w: empty :%s segment", ErrTokenNotInPath, param)
+	}
+	return token, nil
+}
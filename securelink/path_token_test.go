@@ -0,0 +1,109 @@
+package securelink
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateTokenInNamedPathParam(t *testing.T) {
+	manager, err := NewManager(Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		Routes: map[string]string{
+			"reset":    "/reset/:token/confirm",
+			"activate": "/activate",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	link, err := manager.Generate("reset", Payload{"user_id": "123"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("failed to parse link: %v", err)
+	}
+	if !strings.HasPrefix(parsed.Path, "/reset/") || !strings.HasSuffix(parsed.Path, "/confirm") {
+		t.Fatalf("token not placed in path param: %s", link)
+	}
+
+	token, err := ExtractPathToken("/reset/:token/confirm", parsed.Path, "token")
+	if err != nil {
+		t.Fatalf("ExtractPathToken failed: %v", err)
+	}
+	payload, err := manager.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if payload["user_id"] != "123" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+
+	// Routes without the param keep appending the token
+	link, err = manager.Generate("activate")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.HasPrefix(link, "https://example.com/activate/") {
+		t.Errorf("unexpected legacy link: %s", link)
+	}
+}
+
+func TestGenerateTokenParamFromConfig(t *testing.T) {
+	manager, err := NewManager(Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "t",
+		TokenParam: "code",
+		Routes:     map[string]string{"verify": "/accounts/:account/verify/:code"},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	link, err := manager.Generate("verify")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Contains(link, ":code") || !strings.Contains(link, "/accounts/:account/verify/") {
+		t.Errorf("unexpected link: %s", link)
+	}
+}
+
+func TestExtractPathTokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+	}{
+		{"length mismatch", "/reset/:token/confirm", "/reset/abc"},
+		{"literal mismatch", "/reset/:token/confirm", "/reset/abc/cancel"},
+		{"empty token", "/reset/:token", "/reset//"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExtractPathToken(tt.pattern, tt.path, "token"); !errors.Is(err, ErrTokenNotInPath) {
+				t.Errorf("expected ErrTokenNotInPath, got %v", err)
+			}
+		})
+	}
+
+	if _, err := ExtractPathToken("/reset", "/reset/abc", "token"); err == nil {
+		t.Error("expected error for pattern without token param")
+	}
+
+	token, err := ExtractPathToken("/orgs/:org/invite/:token/", "/orgs/acme/invite/abc.def/", "")
+	if err != nil || token != "abc.def" {
+		t.Errorf("expected abc.def, got %q (%v)", token, err)
+	}
+}
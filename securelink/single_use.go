@@ -0,0 +1,184 @@
+package securelink
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// usedTokenTimeout bounds the store round trip of ValidateAndConsume.
+const usedTokenTimeout = 5 * time.Second
+
+var (
+	// ErrTokenAlreadyUsed is returned by ValidateAndConsume when the token was
+	// consumed before.
+	ErrTokenAlreadyUsed = errors.New("token already used")
+	// ErrNoUsedTokenStore is returned by ValidateAndConsume when the manager
+	// was configured without Config.UsedTokens.
+	ErrNoUsedTokenStore = errors.New("no used token store configured")
+)
+
+// SingleUseManager is a Manager that also accepts each token only once.
+// Managers created by NewManager and NewManagerWithGroup implement it.
+//
+// Example:
+//
+//	consumer := manager.(securelink.SingleUseManager)
+//	payload, err := consumer.ValidateAndConsume(token, securelink.WithPurpose("password_reset"))
+//	if errors.Is(err, securelink.ErrTokenAlreadyUsed) {
+//		// link was already used
+//	}
+type SingleUseManager interface {
+	Manager
+
+	// ValidateAndConsume verifies a token like Verify and records it in
+	// Config.UsedTokens, so each token is accepted once. Use it for
+	// password-reset and email-verification links. It returns Verify errors,
+	// ErrTokenAlreadyUsed on replay, or ErrNoUsedTokenStore.
+	ValidateAndConsume(token string, opts ...VerifyOption) (Payload, error)
+}
+
+// UsedTokenStore records consumed tokens for SingleUseManager.ValidateAndConsume.
+// MarkUsed must atomically record id and report whether this was its first
+// use; entries may be forgotten after expiresAt, when the token no longer
+// validates anyway.
+type UsedTokenStore interface {
+	MarkUsed(ctx context.Context, id string, expiresAt time.Time) (bool, error)
+}
+
+func (m *manager) ValidateAndConsume(token string, opts ...VerifyOption) (Payload, error) {
+	if m.usedTokens == nil {
+		return nil, ErrNoUsedTokenStore
+	}
+
+	claims, err := m.verifyClaims(token, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt time.Time
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), usedTokenTimeout)
+	defer cancel()
+
+	first, err := m.usedTokens.MarkUsed(ctx, usedTokenID(token, claims["jti"]), expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("used token store failed: %w", err)
+	}
+	if !first {
+		return nil, ErrTokenAlreadyUsed
+	}
+	return payloadFromClaims(claims)
+}
+
+// usedTokenID identifies a token by its jti claim, falling back to a digest
+// of the token for tokens minted before jti was stamped.
+func usedTokenID(token string, jti any) string {
+	if id, ok := jti.(string); ok && id != "" {
+		return id
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+var _ UsedTokenStore = &MemoryUsedTokenStore{}
+
+// MemoryUsedTokenStore is an in-process UsedTokenStore. Entries are dropped
+// once their token expires. Use RedisUsedTokenStore when several instances
+// validate the same links.
+type MemoryUsedTokenStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+// NewMemoryUsedTokenStore returns an empty in-memory store.
+func NewMemoryUsedTokenStore() *MemoryUsedTokenStore {
+	return &MemoryUsedTokenStore{used: make(map[string]time.Time)}
+}
+
+// MarkUsed records id, returning false if it was already recorded and has not
+// expired.
+func (s *MemoryUsedTokenStore) MarkUsed(_ context.Context, id string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.cleanupLocked(now)
+	if _, ok := s.used[id]; ok {
+		return false, nil
+	}
+	s.used[id] = expiresAt
+	return true, nil
+}
+
+// Cleanup drops expired entries and returns how many were removed. MarkUsed
+// already prunes, so calling it is only needed to release memory on idle
+// managers.
+func (s *MemoryUsedTokenStore) Cleanup() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cleanupLocked(time.Now())
+}
+
+func (s *MemoryUsedTokenStore) cleanupLocked(now time.Time) int {
+	removed := 0
+	for id, expiresAt := range s.used {
+		if !expiresAt.IsZero() && !now.Before(expiresAt) {
+			delete(s.used, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RedisUsedTokenClient is the subset of a Redis client used by
+// RedisUsedTokenStore, so this package does not depend on a particular
+// driver. With go-redis:
+//
+//	type redisUsedTokenAdapter struct{ *redis.Client }
+//
+//	func (a redisUsedTokenAdapter) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+//		return a.Client.SetNX(ctx, key, value, ttl).Result()
+//	}
+type RedisUsedTokenClient interface {
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+}
+
+var _ UsedTokenStore = &RedisUsedTokenStore{}
+
+// RedisUsedTokenStore is a UsedTokenStore backed by Redis SETNX, so a token
+// is accepted once across all instances. Keys live under prefix and expire
+// with their token.
+type RedisUsedTokenStore struct {
+	client RedisUsedTokenClient
+	prefix string
+}
+
+// NewRedisUsedTokenStore returns a store recording used tokens through client.
+//
+// Example:
+//
+//	store := securelink.NewRedisUsedTokenStore(redisUsedTokenAdapter{rdb}, "securelink:used:")
+//	manager, err := securelink.NewManager(securelink.Config{..., UsedTokens: store})
+func NewRedisUsedTokenStore(client RedisUsedTokenClient, prefix string) *RedisUsedTokenStore {
+	return &RedisUsedTokenStore{client: client, prefix: prefix}
+}
+
+// MarkUsed records id until expiresAt, returning false if the key already
+// exists. Tokens without expiry are kept indefinitely.
+func (s *RedisUsedTokenStore) MarkUsed(ctx context.Context, id string, expiresAt time.Time) (bool, error) {
+	var ttl time.Duration
+	if !expiresAt.IsZero() {
+		// Keep the key at least briefly so a token validated right at its
+		// expiry is still recorded
+		ttl = max(time.Until(expiresAt), time.Second)
+	}
+	return s.client.SetNX(ctx, s.prefix+id, "1", ttl)
+}
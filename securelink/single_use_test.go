@@ -0,0 +1,197 @@
+package securelink
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newSingleUseManager(t *testing.T, store UsedTokenStore) SingleUseManager {
+	t.Helper()
+	manager, err := NewManager(Config{
+		SigningKey: stateTestKey,
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "token",
+		AsQuery:    true,
+		Routes:     map[string]string{"reset": "/reset"},
+		Purposes:   map[string]string{"reset": "password_reset"},
+		UsedTokens: store,
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return manager.(SingleUseManager)
+}
+
+func TestValidateAndConsume(t *testing.T) {
+	manager := newSingleUseManager(t, NewMemoryUsedTokenStore())
+
+	link, err := manager.Generate("reset", Payload{"user_id": "42"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	payload, err := manager.ValidateAndConsume(token, WithPurpose("password_reset"))
+	if err != nil {
+		t.Fatalf("first use failed: %v", err)
+	}
+	if payload["user_id"] != "42" {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+
+	if _, err := manager.ValidateAndConsume(token); !errors.Is(err, ErrTokenAlreadyUsed) {
+		t.Fatalf("expected ErrTokenAlreadyUsed, got %v", err)
+	}
+
+	// Validate stays stateless
+	if _, err := manager.Validate(token); err != nil {
+		t.Fatalf("Validate should not consume tokens: %v", err)
+	}
+
+	// Tokens for the same payload are distinct links
+	other, err := manager.Generate("reset", Payload{"user_id": "42"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := manager.ValidateAndConsume(tokenFromLink(t, other)); err != nil {
+		t.Fatalf("second link should be unused: %v", err)
+	}
+}
+
+func TestValidateAndConsumeRejectsBeforeConsuming(t *testing.T) {
+	manager := newSingleUseManager(t, NewMemoryUsedTokenStore())
+	link, err := manager.Generate("reset")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	if _, err := manager.ValidateAndConsume(token, WithPurpose("email_verification")); !errors.Is(err, ErrPurposeMismatch) {
+		t.Fatalf("expected ErrPurposeMismatch, got %v", err)
+	}
+	if _, err := manager.ValidateAndConsume(token, WithPurpose("password_reset")); err != nil {
+		t.Fatalf("failed verification must not consume the token: %v", err)
+	}
+	if _, err := manager.ValidateAndConsume("not-a-token"); err == nil {
+		t.Fatal("expected invalid token error")
+	}
+}
+
+func TestValidateAndConsumeWithoutStore(t *testing.T) {
+	manager := newSingleUseManager(t, nil)
+	link, err := manager.Generate("reset")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := manager.ValidateAndConsume(tokenFromLink(t, link)); !errors.Is(err, ErrNoUsedTokenStore) {
+		t.Fatalf("expected ErrNoUsedTokenStore, got %v", err)
+	}
+}
+
+func TestValidateAndConsumeConcurrent(t *testing.T) {
+	manager := newSingleUseManager(t, NewMemoryUsedTokenStore())
+	link, err := manager.Generate("reset")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := manager.ValidateAndConsume(token); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted != 1 {
+		t.Fatalf("expected exactly one accepted use, got %d", accepted)
+	}
+}
+
+func TestMemoryUsedTokenStoreExpiry(t *testing.T) {
+	store := NewMemoryUsedTokenStore()
+	ctx := context.Background()
+
+	if ok, _ := store.MarkUsed(ctx, "live", time.Now().Add(time.Hour)); !ok {
+		t.Fatal("expected first use")
+	}
+	if ok, _ := store.MarkUsed(ctx, "old", time.Now().Add(-time.Second)); !ok {
+		t.Fatal("expected first use")
+	}
+	if removed := store.Cleanup(); removed != 1 {
+		t.Fatalf("expected 1 expired entry removed, got %d", removed)
+	}
+	if ok, _ := store.MarkUsed(ctx, "old", time.Now().Add(time.Hour)); !ok {
+		t.Fatal("expired entry should be forgotten")
+	}
+	if ok, _ := store.MarkUsed(ctx, "live", time.Now().Add(time.Hour)); ok {
+		t.Fatal("live entry should still be recorded")
+	}
+}
+
+type fakeRedisUsedTokens struct {
+	mu   sync.Mutex
+	keys map[string]time.Duration
+	err  error
+}
+
+func (f *fakeRedisUsedTokens) SetNX(_ context.Context, key, _ string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	if _, ok := f.keys[key]; ok {
+		return false, nil
+	}
+	f.keys[key] = ttl
+	return true, nil
+}
+
+func TestRedisUsedTokenStore(t *testing.T) {
+	client := &fakeRedisUsedTokens{keys: map[string]time.Duration{}}
+	manager := newSingleUseManager(t, NewRedisUsedTokenStore(client, "used:"))
+
+	link, err := manager.Generate("reset")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	if _, err := manager.ValidateAndConsume(token); err != nil {
+		t.Fatalf("first use failed: %v", err)
+	}
+	if _, err := manager.ValidateAndConsume(token); !errors.Is(err, ErrTokenAlreadyUsed) {
+		t.Fatalf("expected ErrTokenAlreadyUsed, got %v", err)
+	}
+
+	if len(client.keys) != 1 {
+		t.Fatalf("expected one key, got %v", client.keys)
+	}
+	for key, ttl := range client.keys {
+		if len(key) <= len("used:") || key[:len("used:")] != "used:" {
+			t.Fatalf("key %q missing prefix", key)
+		}
+		if ttl <= 0 || ttl > time.Hour {
+			t.Fatalf("ttl should follow token expiry, got %s", ttl)
+		}
+	}
+
+	client.err = errors.New("connection refused")
+	other, _ := manager.Generate("reset")
+	if _, err := manager.ValidateAndConsume(tokenFromLink(t, other)); err == nil || errors.Is(err, ErrTokenAlreadyUsed) {
+		t.Fatalf("expected store error, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package securelink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultStateParam = "state"
+
+var (
+	// ErrStateSignature is returned when a state value was tampered with or
+	// signed with a different key.
+	ErrStateSignature = errors.New("state signature mismatch")
+	// ErrStateVersion is returned when a state value was encoded with a version
+	// that has no migration to the current one.
+	ErrStateVersion = errors.New("unsupported state version")
+	// ErrStateMalformed is returned when a state value cannot be parsed.
+	ErrStateMalformed = errors.New("malformed state")
+)
+
+// StateMigration upgrades the JSON state encoded by an older version to the
+// shape expected by the next version.
+type StateMigration func(data []byte) ([]byte, error)
+
+// StateConfig configures a StateCodec.
+type StateConfig struct {
+	// SigningKey signs state values with HMAC-SHA256; at least 32 bytes.
+	SigningKey string
+	// Version is stamped on encoded values. Defaults to 1.
+	Version int
+	// Param is the query parameter carrying the state. Defaults to "state".
+	Param string
+	// Migrations upgrade values encoded by older versions, keyed by the version
+	// they upgrade from (e.g. Migrations[1] turns v1 data into v2 data).
+	Migrations map[int]StateMigration
+}
+
+// WizardState is a ready-made shape for multi-step flows.
+type WizardState struct {
+	Step    int               `json:"s"`
+	Answers map[string]string `json:"a,omitempty"`
+}
+
+// StateCodec encodes small structured state into a compact, signed and
+// versioned query parameter value, for multi-step flows that must survive
+// reloads and back buttons without server-side sessions. Values are signed but
+// not encrypted; do not store secrets in them.
+//
+// Encoded values have the form "v<version>.<base64url json>.<base64url mac>".
+type StateCodec struct {
+	key        []byte
+	version    int
+	param      string
+	migrations map[int]StateMigration
+}
+
+// NewStateCodec creates a StateCodec from cfg.
+//
+// Example:
+//
+//	codec, err := securelink.NewStateCodec(securelink.StateConfig{SigningKey: key})
+//	value, _ := codec.Encode(securelink.WizardState{Step: 2, Answers: answers})
+//	next, _ := group.Builder("wizard").WithQuery(codec.Param(), value).Build()
+func NewStateCodec(cfg StateConfig) (*StateCodec, error) {
+	if len(cfg.SigningKey) < minKeyLengthHS256 {
+		return nil, fmt.Errorf("state signing key too short: got %d bytes, need at least %d bytes", len(cfg.SigningKey), minKeyLengthHS256)
+	}
+	version := cfg.Version
+	if version == 0 {
+		version = 1
+	}
+	if version < 0 {
+		return nil, fmt.Errorf("state version must be positive, got %d", version)
+	}
+	param := cfg.Param
+	if param == "" {
+		param = defaultStateParam
+	}
+
+	migrations := make(map[int]StateMigration, len(cfg.Migrations))
+	for from, migrate := range cfg.Migrations {
+		migrations[from] = migrate
+	}
+
+	return &StateCodec{
+		key:        []byte(cfg.SigningKey),
+		version:    version,
+		param:      param,
+		migrations: migrations,
+	}, nil
+}
+
+// Param returns the query parameter name that carries the state.
+func (c *StateCodec) Param() string {
+	return c.param
+}
+
+// Encode serializes value as JSON and signs it with the current version.
+func (c *StateCodec) Encode(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("encode state: %w", err)
+	}
+
+	body := "v" + strconv.Itoa(c.version) + "." + base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(c.sign(body)), nil
+}
+
+// Decode verifies encoded, upgrades it to the current version through the
+// configured migrations, and unmarshals it into value. It returns the version
+// the value was originally encoded with.
+func (c *StateCodec) Decode(encoded string, value any) (int, error) {
+	body, mac, ok := cutLast(encoded, ".")
+	if !ok {
+		return 0, ErrStateMalformed
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(mac)
+	if err != nil || !hmac.Equal(signature, c.sign(body)) {
+		return 0, ErrStateSignature
+	}
+
+	prefix, payload, ok := strings.Cut(body, ".")
+	if !ok || !strings.HasPrefix(prefix, "v") {
+		return 0, ErrStateMalformed
+	}
+	version, err := strconv.Atoi(prefix[1:])
+	if err != nil {
+		return 0, ErrStateMalformed
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, ErrStateMalformed
+	}
+
+	for current := version; current < c.version; current++ {
+		migrate, ok := c.migrations[current]
+		if !ok {
+			return version, fmt.Errorf("%w: v%d", ErrStateVersion, version)
+		}
+		if data, err = migrate(data); err != nil {
+			return version, fmt.Errorf("migrate state from v%d: %w", current, err)
+		}
+	}
+	if version > c.version {
+		return version, fmt.Errorf("%w: v%d", ErrStateVersion, version)
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return version, fmt.Errorf("%w: %v", ErrStateMalformed, err)
+	}
+	return version, nil
+}
+
+// DecodeFrom reads the state parameter through get (typically
+// r.URL.Query().Get) and decodes it like Decode.
+func (c *StateCodec) DecodeFrom(get func(string) string, value any) (int, error) {
+	return c.Decode(get(c.param), value)
+}
+
+func (c *StateCodec) sign(body string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	idx := strings.LastIndex(s, sep)
+	if idx < 0 {
+		return s, "", false
+	}
+	return s[:idx], s[idx+len(sep):], true
+}
@@ -0,0 +1,102 @@
+package securelink
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+)
+
+const stateTestKey = "a-very-secure-key-of-at-least-32-bytes"
+
+func TestStateCodecRoundTrip(t *testing.T) {
+	codec, err := NewStateCodec(StateConfig{SigningKey: stateTestKey, Param: "wiz"})
+	if err != nil {
+		t.Fatalf("NewStateCodec failed: %v", err)
+	}
+
+	encoded, err := codec.Encode(WizardState{Step: 2, Answers: map[string]string{"plan": "pro"}})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	query := url.Values{codec.Param(): {encoded}}
+	var decoded WizardState
+	version, err := codec.DecodeFrom(query.Get, &decoded)
+	if err != nil {
+		t.Fatalf("DecodeFrom failed: %v", err)
+	}
+	if version != 1 || decoded.Step != 2 || decoded.Answers["plan"] != "pro" {
+		t.Fatalf("unexpected decoded state: v%d %+v", version, decoded)
+	}
+
+	tampered := []byte(encoded)
+	tampered[3] ^= 1
+	if _, err := codec.Decode(string(tampered), &decoded); !errors.Is(err, ErrStateSignature) {
+		t.Fatalf("expected ErrStateSignature, got %v", err)
+	}
+
+	other, err := NewStateCodec(StateConfig{SigningKey: stateTestKey + "-other"})
+	if err != nil {
+		t.Fatalf("NewStateCodec failed: %v", err)
+	}
+	if _, err := other.Decode(encoded, &decoded); !errors.Is(err, ErrStateSignature) {
+		t.Fatalf("expected ErrStateSignature for a different key, got %v", err)
+	}
+
+	if _, err := codec.Decode("garbage", &decoded); !errors.Is(err, ErrStateMalformed) {
+		t.Fatalf("expected ErrStateMalformed, got %v", err)
+	}
+	if _, err := NewStateCodec(StateConfig{SigningKey: "short"}); err == nil {
+		t.Fatal("expected error for short signing key")
+	}
+}
+
+func TestStateCodecMigrations(t *testing.T) {
+	v1, err := NewStateCodec(StateConfig{SigningKey: stateTestKey})
+	if err != nil {
+		t.Fatalf("NewStateCodec failed: %v", err)
+	}
+	encoded, err := v1.Encode(map[string]int{"step": 3})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	v2, err := NewStateCodec(StateConfig{
+		SigningKey: stateTestKey,
+		Version:    2,
+		Migrations: map[int]StateMigration{
+			1: func(data []byte) ([]byte, error) {
+				return bytes.Replace(data, []byte(`"step"`), []byte(`"s"`), 1), nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewStateCodec failed: %v", err)
+	}
+
+	var state WizardState
+	version, err := v2.Decode(encoded, &state)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if version != 1 || state.Step != 3 {
+		t.Fatalf("unexpected migrated state: v%d %+v", version, state)
+	}
+
+	v3, err := NewStateCodec(StateConfig{SigningKey: stateTestKey, Version: 3})
+	if err != nil {
+		t.Fatalf("NewStateCodec failed: %v", err)
+	}
+	if _, err := v3.Decode(encoded, &state); !errors.Is(err, ErrStateVersion) {
+		t.Fatalf("expected ErrStateVersion without migrations, got %v", err)
+	}
+
+	newer, err := v3.Encode(state)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if _, err := v1.Decode(newer, &state); !errors.Is(err, ErrStateVersion) {
+		t.Fatalf("expected ErrStateVersion for a newer value, got %v", err)
+	}
+}
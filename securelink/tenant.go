@@ -0,0 +1,98 @@
+package securelink
+
+import (
+	"errors"
+	"fmt"
+)
+
+// tenantClaim is the JWT claim carrying the tenant a token was minted for.
+const tenantClaim = "tid"
+
+var (
+	// ErrTenantMismatch is returned when the token was minted for a different
+	// tenant than the one required. Validate and GetAndValidate return it for
+	// every tenant token, and Verify unless WithTenant names the token's tenant.
+	ErrTenantMismatch = errors.New("token tenant mismatch")
+	// ErrNoKeyProvider is returned when a tenant token is generated by a manager
+	// configured without a KeyProvider.
+	ErrNoKeyProvider = errors.New("no key provider configured")
+)
+
+// TenantManager is a Manager that also signs links with per-tenant keys.
+// Managers created by NewManager and NewManagerWithGroup implement it.
+//
+// Example:
+//
+//	tenants := manager.(securelink.TenantManager)
+//	link, err := tenants.GenerateForTenant("acme", "activate", payload)
+//	payload, err := tenants.Verify(token, securelink.WithTenant("acme"))
+type TenantManager interface {
+	VerifyingManager
+
+	// GenerateForTenant creates a secure link like Generate, signed with the
+	// tenant's key from Config.KeyProvider and stamped with the tenant id.
+	// Such tokens are only accepted by Verify with WithTenant naming the same
+	// tenant, and are checked against that tenant's key, so a compromised
+	// tenant key cannot forge links for other tenants or untenanted flows.
+	GenerateForTenant(tenantID, route string, payloads ...Payload) (string, error)
+}
+
+// KeyProvider returns the signing key of a tenant. Tokens generated with
+// GenerateForTenant are signed and verified with the tenant's key, so a leaked
+// key only allows forging links for its own tenant.
+type KeyProvider interface {
+	KeyFor(tenantID string) ([]byte, error)
+}
+
+// KeyProviderFunc adapts a function to the KeyProvider interface.
+//
+// Example:
+//
+//	provider := securelink.KeyProviderFunc(func(tenantID string) ([]byte, error) {
+//		return vault.TenantSecret(ctx, tenantID)
+//	})
+type KeyProviderFunc func(tenantID string) ([]byte, error)
+
+// KeyFor implements KeyProvider.
+func (f KeyProviderFunc) KeyFor(tenantID string) ([]byte, error) {
+	return f(tenantID)
+}
+
+// WithTenant requires the token to have been generated for the given tenant
+// with GenerateForTenant. Tokens carrying a tenant are rejected by Verify
+// without it.
+func WithTenant(tenantID string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.tenant = tenantID
+	}
+}
+
+func (m *manager) GenerateForTenant(tenantID, route string, payloads ...Payload) (string, error) {
+	if tenantID == "" {
+		return "", errors.New("tenant id is required")
+	}
+	return m.generate(tenantID, route, payloads...)
+}
+
+// keyFor returns the key that signs tokens of tenantID. Tokens without a
+// tenant use Config.SigningKey.
+func (m *manager) keyFor(tenantID string) ([]byte, error) {
+	if tenantID == "" {
+		if m.signingKey == "" {
+			return nil, errors.New("no signing key configured")
+		}
+		return []byte(m.signingKey), nil
+	}
+
+	if m.keyProvider == nil {
+		return nil, ErrNoKeyProvider
+	}
+	key, err := m.keyProvider.KeyFor(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q key lookup failed: %w", tenantID, err)
+	}
+	if err := validateSigningKey(string(key), m.signingMethod); err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", tenantID, err)
+	}
+	return key, nil
+}
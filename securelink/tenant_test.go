@@ -0,0 +1,144 @@
+package securelink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTenantManager(t *testing.T, signingKey string) TenantManager {
+	t.Helper()
+	keys := map[string]string{
+		"acme":   strings.Repeat("a", 32),
+		"globex": strings.Repeat("g", 32),
+		"weak":   "short",
+	}
+	manager, err := NewManager(Config{
+		SigningKey: signingKey,
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "token",
+		AsQuery:    true,
+		Routes:     map[string]string{"activate": "/activate"},
+		KeyProvider: KeyProviderFunc(func(tenantID string) ([]byte, error) {
+			key, ok := keys[tenantID]
+			if !ok {
+				return nil, errors.New("unknown tenant")
+			}
+			return []byte(key), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return manager.(TenantManager)
+}
+
+func TestGenerateForTenant(t *testing.T) {
+	manager := newTenantManager(t, "a-very-secure-key-of-at-least-32-bytes")
+
+	link, err := manager.GenerateForTenant("acme", "activate", Payload{"user_id": "123"})
+	if err != nil {
+		t.Fatalf("GenerateForTenant failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	payload, err := manager.Verify(token, WithTenant("acme"))
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if payload["user_id"] != "123" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+	if _, err := manager.Validate(token); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected Validate to reject a tenant token, got %v", err)
+	}
+	if _, err := manager.Verify(token); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected Verify without WithTenant to reject a tenant token, got %v", err)
+	}
+	if _, err := manager.Verify(token, WithTenant("globex")); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected ErrTenantMismatch, got %v", err)
+	}
+
+	// Tenant tokens are not signed with the shared key
+	if _, err := Validate(token, "a-very-secure-key-of-at-least-32-bytes", jwt.SigningMethodHS256); err == nil {
+		t.Error("expected tenant token to fail validation with the shared key")
+	}
+
+	// Untenanted tokens keep using the shared key and carry no tenant
+	link, err = manager.Generate("activate")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := manager.Verify(tokenFromLink(t, link), WithTenant("acme")); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected ErrTenantMismatch for untenanted token, got %v", err)
+	}
+
+	if _, err := manager.GenerateForTenant("initech", "activate"); err == nil {
+		t.Error("expected error for unknown tenant")
+	}
+	if _, err := manager.GenerateForTenant("weak", "activate"); err == nil || !strings.Contains(err.Error(), "too short") {
+		t.Errorf("expected short tenant key error, got %v", err)
+	}
+	if _, err := manager.GenerateForTenant("", "activate"); err == nil {
+		t.Error("expected error for empty tenant id")
+	}
+}
+
+func TestTenantKeyCannotForgeOtherTenants(t *testing.T) {
+	manager := newTenantManager(t, "")
+
+	// A token for globex signed with acme's (compromised) key
+	forged, err := generateToken(map[string]any{"user_id": "1"}, strings.Repeat("a", 32), time.Hour, jwt.SigningMethodHS256, jwt.MapClaims{tenantClaim: "globex"})
+	if err != nil {
+		t.Fatalf("generateToken failed: %v", err)
+	}
+	if _, err := manager.Validate(forged); err == nil {
+		t.Error("expected forged token to fail validation")
+	}
+
+	// acme's key can only mint tokens accepted for acme
+	acme, err := generateToken(map[string]any{"user_id": "1"}, strings.Repeat("a", 32), time.Hour, jwt.SigningMethodHS256, jwt.MapClaims{tenantClaim: "acme"})
+	if err != nil {
+		t.Fatalf("generateToken failed: %v", err)
+	}
+	if _, err := manager.Validate(acme); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected Validate to reject the tenant token, got %v", err)
+	}
+	if _, err := manager.GetAndValidate(func(string) string { return acme }); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected GetAndValidate to reject the tenant token, got %v", err)
+	}
+	if _, err := manager.Verify(acme, WithPurpose("activate")); !errors.Is(err, ErrTenantMismatch) {
+		t.Errorf("expected Verify without WithTenant to reject the tenant token, got %v", err)
+	}
+
+	// Without a shared key, untenanted tokens cannot be minted or accepted
+	if _, err := manager.Generate("activate"); err == nil {
+		t.Error("expected error generating untenanted token without a signing key")
+	}
+	untenanted, _ := Generate(nil, strings.Repeat("a", 32), time.Hour, jwt.SigningMethodHS256)
+	if _, err := manager.Validate(untenanted); err == nil {
+		t.Error("expected untenanted token to fail validation without a signing key")
+	}
+}
+
+func TestGenerateForTenantWithoutKeyProvider(t *testing.T) {
+	manager, err := NewManager(Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		BaseURL:    "https://example.com",
+		Routes:     map[string]string{"activate": "/activate"},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if _, err := manager.(TenantManager).GenerateForTenant("acme", "activate"); !errors.Is(err, ErrNoKeyProvider) {
+		t.Errorf("expected ErrNoKeyProvider, got %v", err)
+	}
+
+	if _, err := NewManager(Config{BaseURL: "https://example.com"}); err == nil {
+		t.Error("expected error without signing key or key provider")
+	}
+}
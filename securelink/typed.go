@@ -0,0 +1,176 @@
+package securelink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ManagerFor wraps a Manager with a typed payload: T is marshaled into the
+// token's payload claim on generation and unmarshaled back on validation, so
+// handlers work with a struct instead of a loose Payload map. T must encode
+// as a JSON object (a struct or map); json struct tags select the claim
+// names. Numbers travel as JSON numbers, so integers beyond 2^53 lose
+// precision; encode such ids as strings.
+//
+// Example:
+//
+//	type ResetLink struct {
+//		UserID string `json:"user_id"`
+//		Email  string `json:"email"`
+//	}
+//
+//	resets, err := securelink.NewManagerFor[ResetLink](cfg)
+//	link, err := resets.Generate("reset", ResetLink{UserID: "42", Email: "ada@example.com"})
+//	data, err := resets.Verify(token, securelink.WithPurpose("password_reset"))
+//	fmt.Println(data.UserID) // "42"
+type ManagerFor[T any] struct {
+	manager Manager
+}
+
+// NewManagerFor creates a Manager from cfg and wraps it for payloads of type T.
+func NewManagerFor[T any](cfg Config) (*ManagerFor[T], error) {
+	manager, err := NewManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ManagerFor[T]{manager: manager}, nil
+}
+
+// Typed wraps an existing Manager for payloads of type T. Several typed views
+// can share one manager, one per link flow.
+func Typed[T any](manager Manager) *ManagerFor[T] {
+	return &ManagerFor[T]{manager: manager}
+}
+
+// Manager returns the underlying untyped Manager.
+func (m *ManagerFor[T]) Manager() Manager {
+	return m.manager
+}
+
+// Generate creates a secure link for route carrying payload.
+func (m *ManagerFor[T]) Generate(route string, payload T) (string, error) {
+	data, err := encodeTypedPayload(payload)
+	if err != nil {
+		return "", err
+	}
+	return m.manager.Generate(route, data)
+}
+
+// GenerateForTenant creates a secure link for route signed with the tenant's
+// key. The wrapped Manager must implement TenantManager. See
+// TenantManager.GenerateForTenant.
+func (m *ManagerFor[T]) GenerateForTenant(tenantID, route string, payload T) (string, error) {
+	tenants, ok := m.manager.(TenantManager)
+	if !ok {
+		return "", fmt.Errorf("manager %T does not implement TenantManager: %w", m.manager, errors.ErrUnsupported)
+	}
+	data, err := encodeTypedPayload(payload)
+	if err != nil {
+		return "", err
+	}
+	return tenants.GenerateForTenant(tenantID, route, data)
+}
+
+// GenerateBatch creates one secure link per item. See
+// BatchManager.GenerateBatch. When the wrapped Manager does not implement
+// BatchManager, links are generated one at a time with Generate.
+func (m *ManagerFor[T]) GenerateBatch(route string, items []T) ([]string, error) {
+	payloads := make([]Payload, len(items))
+	for i, item := range items {
+		data, err := encodeTypedPayload(item)
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+		payloads[i] = data
+	}
+	if batch, ok := m.manager.(BatchManager); ok {
+		return batch.GenerateBatch(route, payloads)
+	}
+
+	links := make([]string, len(payloads))
+	for i, data := range payloads {
+		link, err := m.manager.Generate(route, data)
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+		links[i] = link
+	}
+	return links, nil
+}
+
+// Validate verifies token and returns its payload as T.
+func (m *ManagerFor[T]) Validate(token string) (T, error) {
+	data, err := m.manager.Validate(token)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return decodeTypedPayload[T](data)
+}
+
+// Verify verifies token against opts and returns its payload as T. The
+// wrapped Manager must implement VerifyingManager. See VerifyingManager.Verify.
+func (m *ManagerFor[T]) Verify(token string, opts ...VerifyOption) (T, error) {
+	var zero T
+	verifier, ok := m.manager.(VerifyingManager)
+	if !ok {
+		return zero, fmt.Errorf("manager %T does not implement VerifyingManager: %w", m.manager, errors.ErrUnsupported)
+	}
+	data, err := verifier.Verify(token, opts...)
+	if err != nil {
+		return zero, err
+	}
+	return decodeTypedPayload[T](data)
+}
+
+// ValidateAndConsume verifies token, marks it used and returns its payload as
+// T. The wrapped Manager must implement SingleUseManager. See
+// SingleUseManager.ValidateAndConsume.
+func (m *ManagerFor[T]) ValidateAndConsume(token string, opts ...VerifyOption) (T, error) {
+	var zero T
+	consumer, ok := m.manager.(SingleUseManager)
+	if !ok {
+		return zero, fmt.Errorf("manager %T does not implement SingleUseManager: %w", m.manager, errors.ErrUnsupported)
+	}
+	data, err := consumer.ValidateAndConsume(token, opts...)
+	if err != nil {
+		return zero, err
+	}
+	return decodeTypedPayload[T](data)
+}
+
+// GetAndValidate extracts a token with fn and validates it. See
+// Manager.GetAndValidate.
+func (m *ManagerFor[T]) GetAndValidate(fn func(string) string) (T, error) {
+	data, err := m.manager.GetAndValidate(fn)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return decodeTypedPayload[T](data)
+}
+
+func encodeTypedPayload[T any](payload T) (Payload, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("typed payload encoding failed: %w", err)
+	}
+	var data Payload
+	if err := json.Unmarshal(raw, &data); err != nil || data == nil {
+		return nil, fmt.Errorf("typed payload must encode as a JSON object, got %T", payload)
+	}
+	return data, nil
+}
+
+func decodeTypedPayload[T any](data map[string]any) (T, error) {
+	var value T
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return value, fmt.Errorf("typed payload decoding failed: %w", err)
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return value, fmt.Errorf("typed payload decoding failed: %w", err)
+	}
+	return value, nil
+}
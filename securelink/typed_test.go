@@ -0,0 +1,125 @@
+package securelink
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type resetLink struct {
+	UserID  string   `json:"user_id"`
+	Attempt int      `json:"attempt"`
+	Scopes  []string `json:"scopes,omitempty"`
+}
+
+func TestManagerForRoundTrip(t *testing.T) {
+	resets, err := NewManagerFor[resetLink](Config{
+		SigningKey: "a-very-secure-key-of-at-least-32-bytes",
+		Expiration: time.Hour,
+		BaseURL:    "https://example.com",
+		QueryKey:   "token",
+		AsQuery:    true,
+		Routes:     map[string]string{"reset": "/reset"},
+		Purposes:   map[string]string{"reset": "password_reset"},
+		UsedTokens: NewMemoryUsedTokenStore(),
+	})
+	if err != nil {
+		t.Fatalf("NewManagerFor failed: %v", err)
+	}
+
+	want := resetLink{UserID: "42", Attempt: 2, Scopes: []string{"email"}}
+	link, err := resets.Generate("reset", want)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	token := tokenFromLink(t, link)
+
+	got, err := resets.Verify(token, WithPurpose("password_reset"))
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got.UserID != want.UserID || got.Attempt != want.Attempt || len(got.Scopes) != 1 || got.Scopes[0] != "email" {
+		t.Fatalf("Verify = %+v, want %+v", got, want)
+	}
+
+	// The claim names follow the json tags, so untyped consumers still work
+	raw, err := resets.Manager().Validate(token)
+	if err != nil || raw["user_id"] != "42" {
+		t.Fatalf("untyped Validate = %v, %v", raw, err)
+	}
+
+	query := url.Values{"token": {token}}
+	if got, err := resets.GetAndValidate(query.Get); err != nil || got.UserID != "42" {
+		t.Fatalf("GetAndValidate = %+v, %v", got, err)
+	}
+
+	if _, err := resets.ValidateAndConsume(token); err != nil {
+		t.Fatalf("ValidateAndConsume failed: %v", err)
+	}
+	if _, err := resets.ValidateAndConsume(token); !errors.Is(err, ErrTokenAlreadyUsed) {
+		t.Fatalf("expected ErrTokenAlreadyUsed, got %v", err)
+	}
+	plain := Typed[resetLink](struct{ Manager }{resets.Manager()})
+	if _, err := plain.ValidateAndConsume(token); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("expected errors.ErrUnsupported, got %v", err)
+	}
+	if _, err := plain.Verify(token); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("expected errors.ErrUnsupported from Verify, got %v", err)
+	}
+
+	if _, err := resets.Validate("not-a-token"); err == nil {
+		t.Fatal("expected invalid token error")
+	}
+}
+
+func TestManagerForBatchAndTenant(t *testing.T) {
+	typed := Typed[resetLink](newTenantManager(t, "a-very-secure-key-of-at-least-32-bytes"))
+
+	links, err := typed.GenerateBatch("activate", []resetLink{{UserID: "1"}, {UserID: "2"}})
+	if err != nil || len(links) != 2 {
+		t.Fatalf("GenerateBatch = %v, %v", links, err)
+	}
+	if got, err := typed.Validate(tokenFromLink(t, links[1])); err != nil || got.UserID != "2" {
+		t.Fatalf("Validate(batch) = %+v, %v", got, err)
+	}
+
+	link, err := typed.GenerateForTenant("acme", "activate", resetLink{UserID: "7"})
+	if err != nil {
+		t.Fatalf("GenerateForTenant failed: %v", err)
+	}
+	if got, err := typed.Verify(tokenFromLink(t, link), WithTenant("acme")); err != nil || got.UserID != "7" {
+		t.Fatalf("Verify(tenant) = %+v, %v", got, err)
+	}
+
+	// Managers implementing only Manager still wrap, without tenant support
+	plain := Typed[resetLink](struct{ Manager }{newTenantManager(t, "a-very-secure-key-of-at-least-32-bytes")})
+	links, err = plain.GenerateBatch("activate", []resetLink{{UserID: "1"}, {UserID: "2"}})
+	if err != nil || len(links) != 2 {
+		t.Fatalf("GenerateBatch without BatchManager = %v, %v", links, err)
+	}
+	if got, err := typed.Validate(tokenFromLink(t, links[1])); err != nil || got.UserID != "2" {
+		t.Fatalf("Validate(sequential batch) = %+v, %v", got, err)
+	}
+	if _, err := plain.GenerateForTenant("acme", "activate", resetLink{UserID: "7"}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("expected errors.ErrUnsupported, got %v", err)
+	}
+}
+
+func TestManagerForRejectsNonObjectPayloads(t *testing.T) {
+	typed := Typed[string](newPurposeManager(t))
+	if _, err := typed.Generate("reset", "user-42"); err == nil {
+		t.Fatal("expected error for a payload that is not a JSON object")
+	}
+
+	mismatch := Typed[struct {
+		UserID int `json:"user_id"`
+	}](newPurposeManager(t))
+	link, err := newPurposeManager(t).Generate("reset", Payload{"user_id": "abc"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := mismatch.Validate(tokenFromLink(t, link)); err == nil {
+		t.Fatal("expected decoding error for mismatched payload type")
+	}
+}
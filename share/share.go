@@ -0,0 +1,159 @@
+// Package share builds share intent URLs for social platforms, messaging apps
+// and email from a target URL and text.
+//
+// Every platform has its own endpoint, parameter names and encoding rules; the
+// builders handle them and can tag the shared URL with UTM tracking parameters
+// naming the platform as the source:
+//
+//	target := share.Target{
+//		URL:      "https://example.com/posts/42",
+//		Text:     "Read this",
+//		Tracking: &share.Tracking{Campaign: "launch"},
+//	}
+//	link, _ := share.URL(share.X, target)
+//	// https://x.com/intent/tweet?text=Read%20this&url=https%3A%2F%2Fexample.com%2Fposts%2F42%3Futm_campaign%3Dlaunch...
+package share
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Platform identifies a share target.
+type Platform string
+
+const (
+	X        Platform = "x"
+	Facebook Platform = "facebook"
+	LinkedIn Platform = "linkedin"
+	WhatsApp Platform = "whatsapp"
+	Telegram Platform = "telegram"
+	Email    Platform = "email"
+)
+
+// Platforms lists every supported platform, in a stable order.
+var Platforms = []Platform{X, Facebook, LinkedIn, WhatsApp, Telegram, Email}
+
+// defaultMedium is the utm_medium used when Tracking.Medium is empty.
+const defaultMedium = "social"
+
+// Tracking adds UTM parameters to the shared URL. utm_source is always the
+// platform name.
+type Tracking struct {
+	Medium   string // utm_medium; defaults to "social" ("email" for Email)
+	Campaign string // utm_campaign
+	Content  string // utm_content
+}
+
+// Target is the content being shared.
+type Target struct {
+	URL  string
+	Text string
+	// Hashtags and Via are only used by X.
+	Hashtags []string
+	Via      string
+	// Tracking, when set, tags URL with UTM parameters for each platform.
+	Tracking *Tracking
+}
+
+// URL returns the share intent URL for platform.
+func URL(platform Platform, target Target) (string, error) {
+	if target.URL == "" {
+		return "", fmt.Errorf("share %s: target url is required", platform)
+	}
+	shared, err := trackedURL(platform, target)
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	switch platform {
+	case X:
+		values.Set("url", shared)
+		setIfNotEmpty(values, "text", target.Text)
+		if len(target.Hashtags) > 0 {
+			values.Set("hashtags", strings.Join(target.Hashtags, ","))
+		}
+		setIfNotEmpty(values, "via", strings.TrimPrefix(target.Via, "@"))
+		return "https://x.com/intent/tweet?" + encode(values), nil
+	case Facebook:
+		values.Set("u", shared)
+		return "https://www.facebook.com/sharer/sharer.php?" + encode(values), nil
+	case LinkedIn:
+		values.Set("url", shared)
+		return "https://www.linkedin.com/sharing/share-offsite/?" + encode(values), nil
+	case WhatsApp:
+		values.Set("text", joinText(target.Text, shared))
+		return "https://wa.me/?" + encode(values), nil
+	case Telegram:
+		values.Set("url", shared)
+		setIfNotEmpty(values, "text", target.Text)
+		return "https://t.me/share/url?" + encode(values), nil
+	case Email:
+		setIfNotEmpty(values, "subject", target.Text)
+		values.Set("body", joinText(target.Text, shared))
+		return "mailto:?" + encode(values), nil
+	default:
+		return "", fmt.Errorf("share: unsupported platform %q", platform)
+	}
+}
+
+// All returns the share URLs for every platform in Platforms.
+func All(target Target) (map[Platform]string, error) {
+	links := make(map[Platform]string, len(Platforms))
+	for _, platform := range Platforms {
+		link, err := URL(platform, target)
+		if err != nil {
+			return nil, err
+		}
+		links[platform] = link
+	}
+	return links, nil
+}
+
+// trackedURL returns the target URL with UTM parameters for platform.
+func trackedURL(platform Platform, target Target) (string, error) {
+	if target.Tracking == nil {
+		return target.URL, nil
+	}
+	parsed, err := url.Parse(target.URL)
+	if err != nil {
+		return "", fmt.Errorf("share %s: %w", platform, err)
+	}
+
+	medium := target.Tracking.Medium
+	if medium == "" {
+		medium = defaultMedium
+		if platform == Email {
+			medium = "email"
+		}
+	}
+
+	query := parsed.Query()
+	query.Set("utm_source", string(platform))
+	query.Set("utm_medium", medium)
+	setIfNotEmpty(query, "utm_campaign", target.Tracking.Campaign)
+	setIfNotEmpty(query, "utm_content", target.Tracking.Content)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+func joinText(text, link string) string {
+	if text == "" {
+		return link
+	}
+	return text + " " + link
+}
+
+func setIfNotEmpty(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+// encode encodes values with %20 for spaces; mailto and several share
+// endpoints show "+" literally.
+func encode(values url.Values) string {
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
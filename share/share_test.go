@@ -0,0 +1,68 @@
+package share
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestShareURLs(t *testing.T) {
+	target := Target{
+		URL:      "https://example.com/posts/42?lang=en",
+		Text:     "Read this & share",
+		Hashtags: []string{"go", "urls"},
+		Via:      "@example",
+	}
+
+	want := map[Platform]string{
+		X:        "https://x.com/intent/tweet?hashtags=go%2Curls&text=Read%20this%20%26%20share&url=https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den&via=example",
+		Facebook: "https://www.facebook.com/sharer/sharer.php?u=https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den",
+		LinkedIn: "https://www.linkedin.com/sharing/share-offsite/?url=https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den",
+		WhatsApp: "https://wa.me/?text=Read%20this%20%26%20share%20https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den",
+		Telegram: "https://t.me/share/url?text=Read%20this%20%26%20share&url=https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den",
+		Email:    "mailto:?body=Read%20this%20%26%20share%20https%3A%2F%2Fexample.com%2Fposts%2F42%3Flang%3Den&subject=Read%20this%20%26%20share",
+	}
+
+	links, err := All(target)
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	for platform, expected := range want {
+		if links[platform] != expected {
+			t.Fatalf("%s: expected %s, got %s", platform, expected, links[platform])
+		}
+	}
+
+	if _, err := URL("myspace", target); err == nil {
+		t.Fatal("expected error for unsupported platform")
+	}
+	if _, err := URL(X, Target{Text: "no url"}); err == nil {
+		t.Fatal("expected error without target url")
+	}
+}
+
+func TestShareTracking(t *testing.T) {
+	target := Target{URL: "https://example.com/posts/42", Tracking: &Tracking{Campaign: "launch"}}
+
+	for platform, wantMedium := range map[Platform]string{LinkedIn: "social", Email: "email"} {
+		link, err := URL(platform, target)
+		if err != nil {
+			t.Fatalf("URL(%s) failed: %v", platform, err)
+		}
+		parsed, err := url.Parse(link)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		shared := parsed.Query().Get("url")
+		if platform == Email {
+			shared = parsed.Query().Get("body")
+		}
+		sharedURL, err := url.Parse(shared)
+		if err != nil {
+			t.Fatalf("Parse shared url failed: %v", err)
+		}
+		query := sharedURL.Query()
+		if query.Get("utm_source") != string(platform) || query.Get("utm_medium") != wantMedium || query.Get("utm_campaign") != "launch" {
+			t.Fatalf("%s: unexpected tracking params %v", platform, query)
+		}
+	}
+}
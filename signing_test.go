@@ -0,0 +1,62 @@
+package urlkit_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSignedURLs(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "files", BaseURL: "https://files.example.com", Routes: map[string]string{"download": "/download/:id", "hook": "/hooks/stripe"}},
+		},
+	})
+	key := []byte("secret")
+	files := manager.Group("files")
+
+	signed, err := files.Builder("download").WithParam("id", 42).WithQuery("expires", 1767225600).WithSignature(key).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://files.example.com/download/42?expires=1767225600&sig=") {
+		t.Fatalf("unexpected signed URL %q", signed)
+	}
+	if err := urlkit.VerifySignedURL(signed, key); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+
+	parsed, _ := url.Parse(signed)
+	if err := urlkit.VerifySignedURL(parsed.RequestURI(), key); err != nil {
+		t.Fatalf("expected request URI to verify, got %v", err)
+	}
+	if err := urlkit.VerifySignedURL(signed, []byte("other")); !errors.Is(err, urlkit.ErrSignatureInvalid) {
+		t.Fatalf("expected ErrSignatureInvalid for another key, got %v", err)
+	}
+	tampered := strings.Replace(signed, "/42?", "/43?", 1)
+	if err := urlkit.VerifySignedURL(tampered, key); !errors.Is(err, urlkit.ErrSignatureInvalid) {
+		t.Fatalf("expected ErrSignatureInvalid for a tampered path, got %v", err)
+	}
+	if err := urlkit.VerifySignedURL(signed+"&extra=1", key); !errors.Is(err, urlkit.ErrSignatureInvalid) {
+		t.Fatalf("expected ErrSignatureInvalid for an appended param, got %v", err)
+	}
+	if err := urlkit.VerifySignedURL("https://files.example.com/download/42", key); !errors.Is(err, urlkit.ErrSignatureMissing) {
+		t.Fatalf("expected ErrSignatureMissing, got %v", err)
+	}
+
+	hook, err := files.Builder("hook").WithSignature(key).BuildCtx(context.Background())
+	if err != nil {
+		t.Fatalf("BuildCtx failed: %v", err)
+	}
+	if err := urlkit.VerifySignedURL(hook, key); err != nil {
+		t.Fatalf("expected signed URL without query to verify, got %v", err)
+	}
+
+	if _, err := files.Builder("hook").WithQuery("sig", "x").WithSignature(key).Build(); err == nil {
+		t.Fatal("expected error when the query already has a sig param")
+	}
+}
@@ -0,0 +1,445 @@
+package urlkit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sitemapNamespace      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapXHTMLNamespace = "http://www.w3.org/1999/xhtml"
+	// DefaultSitemapPath is where per-host sitemaps are expected to be served,
+	// used to build sitemap index entries.
+	DefaultSitemapPath = "/sitemap.xml"
+	// DefaultSitemapPagePath is the fmt pattern, given the 1-based page number,
+	// of the pages written by SitemapPages.
+	DefaultSitemapPagePath = "/sitemap-%d.xml"
+	// MaxSitemapURLs is the number of URLs a single sitemap may list under the
+	// sitemaps.org protocol.
+	MaxSitemapURLs = 50000
+)
+
+// ErrSitemapTooLarge is returned by Sitemap and SitemapsByHost when a document
+// would list more than SitemapOptions.MaxURLs URLs. Use SitemapPages to split
+// it behind a sitemap index.
+var ErrSitemapTooLarge = errors.New("sitemap too large")
+
+// SitemapChangeFreq is the sitemap changefreq hint of a route.
+type SitemapChangeFreq string
+
+const (
+	SitemapAlways  SitemapChangeFreq = "always"
+	SitemapHourly  SitemapChangeFreq = "hourly"
+	SitemapDaily   SitemapChangeFreq = "daily"
+	SitemapWeekly  SitemapChangeFreq = "weekly"
+	SitemapMonthly SitemapChangeFreq = "monthly"
+	SitemapYearly  SitemapChangeFreq = "yearly"
+	SitemapNever   SitemapChangeFreq = "never"
+)
+
+// SitemapPolicy annotates the sitemap entries of a route.
+type SitemapPolicy struct {
+	// ChangeFreq hints how often the page changes. Empty omits the element.
+	ChangeFreq SitemapChangeFreq `json:"changefreq,omitempty" yaml:"changefreq,omitempty"`
+	// Priority ranks the page against the site's other pages, from 0.0 to
+	// 1.0. Zero omits the element, which crawlers read as 0.5.
+	Priority float64 `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Exclude leaves the route out of the sitemap.
+	Exclude bool `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+func (p SitemapPolicy) validate() error {
+	switch p.ChangeFreq {
+	case "", SitemapAlways, SitemapHourly, SitemapDaily, SitemapWeekly, SitemapMonthly, SitemapYearly, SitemapNever:
+	default:
+		return fmt.Errorf("sitemap policy: unsupported changefreq %q", p.ChangeFreq)
+	}
+	if p.Priority < 0 || p.Priority > 1 {
+		return fmt.Errorf("sitemap policy: priority must be between 0 and 1, got %v", p.Priority)
+	}
+	return nil
+}
+
+// SitemapOptions configures sitemap generation.
+type SitemapOptions struct {
+	// Path is where each host serves its sitemap, used for the sitemap index
+	// entries. Defaults to DefaultSitemapPath.
+	Path string
+
+	// Params enumerates the params of every URL to list for a parameterized
+	// route, keyed by group FQN and route name (e.g. one Params per blog post
+	// slug). Parameterized routes are skipped when Params is nil or returns
+	// no entries.
+	Params func(group, route string) []Params
+
+	// LocaleGroups lists groups whose children are locale variants of the same
+	// site (e.g. "frontend" with "frontend.en" and "frontend.es"). URLs built
+	// in those children link to the same route in every sibling as
+	// <xhtml:link rel="alternate" hreflang="..."/>, using the child name as
+	// the language code.
+	LocaleGroups []string
+
+	// DefaultLocale, when set, adds the sibling with that name as the
+	// x-default alternate of localized URLs.
+	DefaultLocale string
+
+	// MaxURLs caps the URLs per sitemap document. Defaults to (and may not
+	// exceed) MaxSitemapURLs.
+	MaxURLs int
+
+	// PagePath is the fmt pattern, given the 1-based page number, where the
+	// pages of SitemapPages are served. Defaults to DefaultSitemapPagePath.
+	PagePath string
+}
+
+func (o SitemapOptions) maxURLs() int {
+	if o.MaxURLs <= 0 || o.MaxURLs > MaxSitemapURLs {
+		return MaxSitemapURLs
+	}
+	return o.MaxURLs
+}
+
+// SitemapPages is the output of SitemapPages.
+type SitemapPages struct {
+	// Pages holds sitemap documents of at most SitemapOptions.MaxURLs URLs,
+	// in order. Page i is served at fmt.Sprintf(SitemapOptions.PagePath, i+1).
+	Pages [][]byte
+	// Index is a sitemap index listing every page, or nil when all URLs fit in
+	// a single page (serve Pages[0] as the sitemap instead).
+	Index []byte
+}
+
+// SitemapSet is the output of SitemapsByHost: one sitemap per host plus an
+// index referencing all of them.
+type SitemapSet struct {
+	// Sitemaps holds a sitemap document per host (host[:port]).
+	Sitemaps map[string][]byte
+	// Index is a sitemap index listing every host's sitemap URL.
+	Index []byte
+}
+
+type sitemapURLSet struct {
+	XMLName    xml.Name     `xml:"urlset"`
+	Xmlns      string       `xml:"xmlns,attr"`
+	XmlnsXHTML string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs       []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string             `xml:"loc"`
+	ChangeFreq SitemapChangeFreq  `xml:"changefreq,omitempty"`
+	Priority   string             `xml:"priority,omitempty"`
+	Alternates []sitemapAlternate `xml:"xhtml:link"`
+}
+
+type sitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type sitemapIndexDocument struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexRef `xml:"sitemap"`
+}
+
+type sitemapIndexRef struct {
+	Loc string `xml:"loc"`
+}
+
+// GroupsByHost returns the root group names keyed by the host (and port, when
+// present) of their base URL. Groups sharing a host are listed together, sorted
+// by name. Wildcard and missing base URLs are skipped.
+func (m *RouteManager) GroupsByHost() map[string][]string {
+	byHost := map[string][]string{}
+	if m == nil {
+		return byHost
+	}
+
+	m.mu.RLock()
+	roots := maps.Clone(m.groups)
+	m.mu.RUnlock()
+
+	for _, name := range slices.Sorted(maps.Keys(roots)) {
+		root := roots[name]
+		root.mu.RLock()
+		baseURL := root.baseURL
+		root.mu.RUnlock()
+
+		parsed, err := url.Parse(baseURL)
+		if err != nil || parsed.Host == "" || strings.Contains(parsed.Host, "*") {
+			continue
+		}
+		host := strings.ToLower(parsed.Host)
+		byHost[host] = append(byHost[host], name)
+	}
+	return byHost
+}
+
+// Sitemap returns a sitemap.xml document with the URL of every route that can
+// be built without params, plus one URL per Params that opts.Params enumerates
+// for parameterized routes. Routes whose metadata declares a method other than
+// GET, and hidden or excluded routes, are left out; RouteMetadata.Sitemap sets
+// changefreq and priority. The registry Fingerprint is embedded as an XML
+// comment. Registries with more than opts.MaxURLs URLs return
+// ErrSitemapTooLarge; use SitemapPages for them.
+//
+// Example:
+//
+//	doc, err := manager.Sitemap(urlkit.SitemapOptions{
+//		LocaleGroups: []string{"frontend"},
+//		Params: func(group, route string) []urlkit.Params {
+//			if route != "post" {
+//				return nil
+//			}
+//			return postParams() // one urlkit.Params{"slug": ...} per post
+//		},
+//	})
+func (m *RouteManager) Sitemap(opts SitemapOptions) ([]byte, error) {
+	urls, err := m.sitemapURLs(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) > opts.maxURLs() {
+		return nil, fmt.Errorf("%w: %d urls exceed the limit of %d", ErrSitemapTooLarge, len(urls), opts.maxURLs())
+	}
+	return encodeSitemapXML(newSitemapURLSet(urls), m.Fingerprint())
+}
+
+// SitemapPages builds the sitemap like Sitemap, split into pages of at most
+// opts.MaxURLs URLs behind a sitemap index. Index entries point at
+// opts.PagePath on the scheme and host of each page's first URL, so it suits
+// registries served from a single host; see SitemapsByHost otherwise.
+//
+// Example:
+//
+//	pages, _ := manager.SitemapPages(urlkit.SitemapOptions{})
+//	if pages.Index == nil {
+//		serve("/sitemap.xml", pages.Pages[0])
+//	} else {
+//		serve("/sitemap.xml", pages.Index)
+//		for i, page := range pages.Pages {
+//			serve(fmt.Sprintf(urlkit.DefaultSitemapPagePath, i+1), page)
+//		}
+//	}
+func (m *RouteManager) SitemapPages(opts SitemapOptions) (SitemapPages, error) {
+	pagePath := opts.PagePath
+	if pagePath == "" {
+		pagePath = DefaultSitemapPagePath
+	}
+
+	urls, err := m.sitemapURLs(opts)
+	if err != nil {
+		return SitemapPages{}, err
+	}
+
+	fingerprint := m.Fingerprint()
+	var pages SitemapPages
+	index := sitemapIndexDocument{Xmlns: sitemapNamespace}
+	for page := range slices.Chunk(urls, opts.maxURLs()) {
+		doc, err := encodeSitemapXML(newSitemapURLSet(page), fingerprint)
+		if err != nil {
+			return SitemapPages{}, err
+		}
+		pages.Pages = append(pages.Pages, doc)
+
+		loc, err := url.Parse(page[0].Loc)
+		if err != nil {
+			return SitemapPages{}, fmt.Errorf("sitemap page %d: %w", len(pages.Pages), err)
+		}
+		pageURL := url.URL{Scheme: loc.Scheme, Host: loc.Host, Path: fmt.Sprintf(pagePath, len(pages.Pages))}
+		index.Sitemaps = append(index.Sitemaps, sitemapIndexRef{Loc: pageURL.String()})
+	}
+
+	if len(pages.Pages) == 0 {
+		doc, err := encodeSitemapXML(newSitemapURLSet(nil), fingerprint)
+		if err != nil {
+			return SitemapPages{}, err
+		}
+		pages.Pages = [][]byte{doc}
+	}
+	if len(pages.Pages) > 1 {
+		if pages.Index, err = encodeSitemapXML(index, fingerprint); err != nil {
+			return SitemapPages{}, err
+		}
+	}
+	return pages, nil
+}
+
+// SitemapsByHost splits the sitemap by the host of each URL, as search engines
+// require one sitemap per host, and builds a sitemap index pointing at each
+// host's sitemap under opts.Path.
+//
+// Example:
+//
+//	set, _ := manager.SitemapsByHost(urlkit.SitemapOptions{})
+//	mux.HandleFunc("/sitemap.xml", func(w http.ResponseWriter, r *http.Request) {
+//		w.Write(set.Sitemaps[r.Host])
+//	})
+func (m *RouteManager) SitemapsByHost(opts SitemapOptions) (SitemapSet, error) {
+	path := opts.Path
+	if path == "" {
+		path = DefaultSitemapPath
+	}
+
+	urls, err := m.sitemapURLs(opts)
+	if err != nil {
+		return SitemapSet{}, err
+	}
+
+	byHost := map[string][]sitemapURL{}
+	schemes := map[string]string{}
+	for _, entry := range urls {
+		parsed, err := url.Parse(entry.Loc)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		host := strings.ToLower(parsed.Host)
+		byHost[host] = append(byHost[host], entry)
+		if _, ok := schemes[host]; !ok {
+			schemes[host] = parsed.Scheme
+		}
+	}
+
+	fingerprint := m.Fingerprint()
+	set := SitemapSet{Sitemaps: make(map[string][]byte, len(byHost))}
+	index := sitemapIndexDocument{Xmlns: sitemapNamespace}
+	for _, host := range slices.Sorted(maps.Keys(byHost)) {
+		if len(byHost[host]) > opts.maxURLs() {
+			return SitemapSet{}, fmt.Errorf("%w: %d urls for host %s exceed the limit of %d", ErrSitemapTooLarge, len(byHost[host]), host, opts.maxURLs())
+		}
+		doc, err := encodeSitemapXML(newSitemapURLSet(byHost[host]), fingerprint)
+		if err != nil {
+			return SitemapSet{}, err
+		}
+		set.Sitemaps[host] = doc
+		index.Sitemaps = append(index.Sitemaps, sitemapIndexRef{Loc: schemes[host] + "://" + host + path})
+	}
+
+	if set.Index, err = encodeSitemapXML(index, fingerprint); err != nil {
+		return SitemapSet{}, err
+	}
+	return set, nil
+}
+
+// sitemapURLs builds the sitemap entries of all sitemap routes, sorted and
+// de-duplicated by location.
+func (m *RouteManager) sitemapURLs(opts SitemapOptions) ([]sitemapURL, error) {
+	locales := make(map[string]bool, len(opts.LocaleGroups))
+	for _, name := range opts.LocaleGroups {
+		locales[name] = true
+	}
+
+	now := time.Now()
+	var urls []sitemapURL
+	for _, entry := range m.Manifest() {
+		group, err := m.GetGroup(entry.GroupFQN)
+		if err != nil {
+			return nil, err
+		}
+		var policy SitemapPolicy
+		if meta, ok := group.RouteMetadata(entry.RouteKey); ok {
+			if meta.Hidden || (meta.Method != "" && !strings.EqualFold(meta.Method, http.MethodGet)) {
+				continue
+			}
+			if meta.Sitemap != nil {
+				policy = *meta.Sitemap
+			}
+			if meta.Availability != nil && !meta.Availability.AvailableAt(now) {
+				continue
+			}
+		}
+		if policy.Exclude {
+			continue
+		}
+
+		paramSets := []Params{nil}
+		if names, _ := routeParamNames(entry.RouteTemplate); len(names) > 0 {
+			if opts.Params == nil {
+				continue
+			}
+			if paramSets = opts.Params(entry.GroupFQN, entry.RouteKey); len(paramSets) == 0 {
+				continue
+			}
+		}
+
+		var siblings map[string]*Group
+		group.mu.RLock()
+		parent := group.parent
+		group.mu.RUnlock()
+		if parent != nil && locales[parent.FQN()] {
+			parent.mu.RLock()
+			siblings = maps.Clone(parent.children)
+			parent.mu.RUnlock()
+		}
+
+		for _, params := range paramSets {
+			loc, err := group.Render(entry.RouteKey, params)
+			if err != nil {
+				return nil, fmt.Errorf("sitemap %s.%s: %w", entry.GroupFQN, entry.RouteKey, err)
+			}
+			item := sitemapURL{Loc: loc, ChangeFreq: policy.ChangeFreq}
+			if policy.Priority > 0 {
+				item.Priority = strconv.FormatFloat(policy.Priority, 'f', 1, 64)
+			}
+			item.Alternates = sitemapAlternates(siblings, entry.RouteKey, params, opts.DefaultLocale)
+			urls = append(urls, item)
+		}
+	}
+
+	slices.SortFunc(urls, func(a, b sitemapURL) int { return strings.Compare(a.Loc, b.Loc) })
+	return slices.CompactFunc(urls, func(a, b sitemapURL) bool { return a.Loc == b.Loc }), nil
+}
+
+// sitemapAlternates links route in every locale sibling that can build it.
+func sitemapAlternates(siblings map[string]*Group, route string, params Params, defaultLocale string) []sitemapAlternate {
+	var alternates []sitemapAlternate
+	for _, locale := range slices.Sorted(maps.Keys(siblings)) {
+		href, err := siblings[locale].Render(route, params)
+		if err != nil {
+			continue
+		}
+		alternates = append(alternates, sitemapAlternate{Rel: "alternate", Hreflang: locale, Href: href})
+		if locale == defaultLocale {
+			alternates = append(alternates, sitemapAlternate{Rel: "alternate", Hreflang: "x-default", Href: href})
+		}
+	}
+	if len(alternates) < 2 {
+		return nil
+	}
+	return alternates
+}
+
+func newSitemapURLSet(urls []sitemapURL) sitemapURLSet {
+	set := sitemapURLSet{Xmlns: sitemapNamespace, URLs: urls}
+	for _, entry := range urls {
+		if len(entry.Alternates) > 0 {
+			set.XmlnsXHTML = sitemapXHTMLNamespace
+			break
+		}
+	}
+	return set
+}
+
+func encodeSitemapXML(doc any, fingerprint string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, "<!-- fingerprint: %s -->\n", fingerprint)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encode sitemap: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,166 @@
+package urlkit_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSitemapsByHost(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "blog", BaseURL: "https://blog.example.com", Routes: map[string]string{"home": "/", "post": "/posts/:slug"}},
+			{Name: "shop", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "cart": "/cart"}},
+			{Name: "help", BaseURL: "https://example.com", Path: "/help", Routes: map[string]string{"faq": "/help/faq"}},
+		},
+	})
+
+	byHost := manager.GroupsByHost()
+	want := map[string][]string{"blog.example.com": {"blog"}, "example.com": {"help", "shop"}}
+	if !reflect.DeepEqual(byHost, want) {
+		t.Fatalf("expected %v, got %v", want, byHost)
+	}
+
+	set, err := manager.SitemapsByHost(urlkit.SitemapOptions{})
+	if err != nil {
+		t.Fatalf("SitemapsByHost failed: %v", err)
+	}
+	if len(set.Sitemaps) != 2 {
+		t.Fatalf("expected two sitemaps, got %d", len(set.Sitemaps))
+	}
+
+	shop := string(set.Sitemaps["example.com"])
+	for _, loc := range []string{"<loc>https://example.com/</loc>", "<loc>https://example.com/cart</loc>", "<loc>https://example.com/help/faq</loc>"} {
+		if !strings.Contains(shop, loc) {
+			t.Fatalf("example.com sitemap missing %s:\n%s", loc, shop)
+		}
+	}
+	if strings.Contains(shop, "blog.example.com") {
+		t.Fatalf("example.com sitemap leaked another host:\n%s", shop)
+	}
+	if blog := string(set.Sitemaps["blog.example.com"]); strings.Contains(blog, "/posts/") {
+		t.Fatalf("expected parameterized routes to be skipped:\n%s", blog)
+	}
+
+	index := string(set.Index)
+	for _, want := range []string{
+		`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+		"<loc>https://blog.example.com/sitemap.xml</loc>",
+		"<loc>https://example.com/sitemap.xml</loc>",
+		"<!-- fingerprint: " + manager.Fingerprint() + " -->",
+	} {
+		if !strings.Contains(index, want) {
+			t.Fatalf("sitemap index missing %q:\n%s", want, index)
+		}
+	}
+}
+
+func TestSitemapParamsPoliciesAndAlternates(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Groups: []urlkit.GroupConfig{
+					{
+						Name: "en", Path: "/en",
+						Routes: map[string]string{"home": "/", "post": "/posts/:slug", "about": "/about"},
+						Metadata: map[string]urlkit.RouteMetadata{
+							"home":  {Sitemap: &urlkit.SitemapPolicy{ChangeFreq: urlkit.SitemapDaily, Priority: 1}},
+							"about": {Sitemap: &urlkit.SitemapPolicy{Exclude: true}},
+						},
+					},
+					{Name: "es", Path: "/es", Routes: map[string]string{"home": "/", "post": "/entradas/:slug"}},
+				},
+			},
+		},
+	})
+
+	doc, err := manager.Sitemap(urlkit.SitemapOptions{
+		LocaleGroups:  []string{"frontend"},
+		DefaultLocale: "en",
+		Params: func(group, route string) []urlkit.Params {
+			if route != "post" {
+				return nil
+			}
+			return []urlkit.Params{{"slug": "hello"}, {"slug": "world"}}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Sitemap failed: %v", err)
+	}
+
+	sitemap := string(doc)
+	for _, want := range []string{
+		`xmlns:xhtml="http://www.w3.org/1999/xhtml"`,
+		"<loc>https://example.com/en/posts/world</loc>",
+		"<loc>https://example.com/es/entradas/hello</loc>",
+		"<changefreq>daily</changefreq>",
+		"<priority>1.0</priority>",
+		`<xhtml:link rel="alternate" hreflang="es" href="https://example.com/es/entradas/hello"></xhtml:link>`,
+		`<xhtml:link rel="alternate" hreflang="x-default" href="https://example.com/en/"></xhtml:link>`,
+	} {
+		if !strings.Contains(sitemap, want) {
+			t.Fatalf("sitemap missing %q:\n%s", want, sitemap)
+		}
+	}
+	if strings.Contains(sitemap, "/about") {
+		t.Fatalf("expected excluded route to be left out:\n%s", sitemap)
+	}
+	if got := strings.Count(sitemap, "<url>"); got != 6 {
+		t.Fatalf("expected 6 urls, got %d:\n%s", got, sitemap)
+	}
+
+	group, _ := manager.GetGroup("frontend.en")
+	err = group.SetRouteMetadata("home", urlkit.RouteMetadata{Sitemap: &urlkit.SitemapPolicy{Priority: 2}})
+	if err == nil || !strings.Contains(err.Error(), "priority") {
+		t.Fatalf("expected priority validation error, got %v", err)
+	}
+}
+
+func TestSitemapPages(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "blog", BaseURL: "https://example.com", Routes: map[string]string{"home": "/", "post": "/posts/:id"}},
+		},
+	})
+	opts := urlkit.SitemapOptions{
+		MaxURLs: 2,
+		Params: func(group, route string) []urlkit.Params {
+			return []urlkit.Params{{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}}
+		},
+	}
+
+	if _, err := manager.Sitemap(opts); !errors.Is(err, urlkit.ErrSitemapTooLarge) {
+		t.Fatalf("expected ErrSitemapTooLarge, got %v", err)
+	}
+
+	pages, err := manager.SitemapPages(opts)
+	if err != nil {
+		t.Fatalf("SitemapPages failed: %v", err)
+	}
+	if len(pages.Pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(pages.Pages))
+	}
+	if got := strings.Count(string(pages.Pages[2]), "<url>"); got != 1 {
+		t.Fatalf("expected last page to hold 1 url, got %d", got)
+	}
+	index := string(pages.Index)
+	for _, want := range []string{"<loc>https://example.com/sitemap-1.xml</loc>", "<loc>https://example.com/sitemap-3.xml</loc>"} {
+		if !strings.Contains(index, want) {
+			t.Fatalf("sitemap index missing %q:\n%s", want, index)
+		}
+	}
+
+	opts.MaxURLs = 0
+	pages, err = manager.SitemapPages(opts)
+	if err != nil {
+		t.Fatalf("SitemapPages failed: %v", err)
+	}
+	if len(pages.Pages) != 1 || pages.Index != nil {
+		t.Fatalf("expected a single page without index, got %d pages", len(pages.Pages))
+	}
+}
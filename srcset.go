@@ -0,0 +1,97 @@
+package urlkit
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Width parameters used by SrcSet. Routes declaring a ":width" param receive the
+// width there; other routes and assets receive it as the "w" query parameter.
+const (
+	ImageWidthParam      = "width"
+	ImageWidthQueryParam = "w"
+)
+
+// defaultImageSizes is the sizes value used when none is given.
+const defaultImageSizes = "100vw"
+
+// SrcSet holds the attribute values for a responsive <img>.
+type SrcSet struct {
+	// Src is the URL of the widest candidate, for the src fallback.
+	Src string `json:"src"`
+	// SrcSet lists one "<url> <width>w" candidate per width, narrowest first.
+	SrcSet string `json:"srcset"`
+	// Sizes is the sizes attribute value.
+	Sizes string `json:"sizes"`
+}
+
+// SrcSet builds srcset and sizes attribute values for image, which names either
+// a route (rendered with params) or an asset in the group's manifest, at each of
+// the given widths. Sizes entries are joined with ", " and default to "100vw".
+//
+// Example:
+//
+//	set, _ := cdn.SrcSet("product_image", []int{320, 640, 1280}, urlkit.Params{"id": 7}, "(max-width: 640px) 100vw", "640px")
+//	// <img src="{set.Src}" srcset="{set.SrcSet}" sizes="{set.Sizes}">
+func (u *Group) SrcSet(image string, widths []int, params Params, sizes ...string) (SrcSet, error) {
+	if len(widths) == 0 {
+		return SrcSet{}, fmt.Errorf("srcset %q: at least one width is required", image)
+	}
+	widths = slices.Clone(widths)
+	slices.Sort(widths)
+	widths = slices.Compact(widths)
+	if widths[0] <= 0 {
+		return SrcSet{}, fmt.Errorf("srcset %q: widths must be positive, got %d", image, widths[0])
+	}
+
+	build, err := u.imageURLBuilder(image, params)
+	if err != nil {
+		return SrcSet{}, err
+	}
+
+	candidates := make([]string, 0, len(widths))
+	var src string
+	for _, width := range widths {
+		candidate, err := build(width)
+		if err != nil {
+			return SrcSet{}, err
+		}
+		candidates = append(candidates, candidate+" "+strconv.Itoa(width)+"w")
+		src = candidate
+	}
+
+	if len(sizes) == 0 {
+		sizes = []string{defaultImageSizes}
+	}
+	return SrcSet{Src: src, SrcSet: strings.Join(candidates, ", "), Sizes: strings.Join(sizes, ", ")}, nil
+}
+
+// imageURLBuilder returns a function rendering image at a given width.
+func (u *Group) imageURLBuilder(image string, params Params) (func(width int) (string, error), error) {
+	if template, err := u.Route(image); err == nil {
+		names, _ := routeParamNames(template)
+		inPath := slices.Contains(names, ImageWidthParam)
+		return func(width int) (string, error) {
+			value := strconv.Itoa(width)
+			if inPath {
+				withWidth := cloneParamsMap(params)
+				if withWidth == nil {
+					withWidth = Params{}
+				}
+				withWidth[ImageWidthParam] = value
+				return u.Render(image, withWidth)
+			}
+			return u.Render(image, params, Query{ImageWidthQueryParam: value})
+		}, nil
+	}
+
+	assetURL, err := u.Asset(image)
+	if err != nil {
+		return nil, fmt.Errorf("srcset %q: not a route or asset in group %s", image, groupDisplayName(u))
+	}
+	return func(width int) (string, error) {
+		return JoinURL(assetURL, "", Query{ImageWidthQueryParam: strconv.Itoa(width)}), nil
+	}, nil
+}
@@ -0,0 +1,68 @@
+package urlkit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestSrcSet(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	cdn, _, err := manager.RegisterGroup("cdn", "https://img.example.com", map[string]string{
+		"product": "/products/:id/:width.jpg",
+		"avatar":  "/avatars/:user",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	manifest, err := urlkit.LoadAssetManifest(strings.NewReader(`{"hero.jpg": "assets/hero.1a2b.jpg"}`))
+	if err != nil {
+		t.Fatalf("LoadAssetManifest failed: %v", err)
+	}
+	if err := cdn.SetAssetManifest(manifest); err != nil {
+		t.Fatalf("SetAssetManifest failed: %v", err)
+	}
+
+	set, err := cdn.SrcSet("product", []int{640, 320, 640}, urlkit.Params{"id": 7}, "(max-width: 640px) 100vw", "640px")
+	if err != nil {
+		t.Fatalf("SrcSet failed: %v", err)
+	}
+	want := urlkit.SrcSet{
+		Src:    "https://img.example.com/products/7/640.jpg",
+		SrcSet: "https://img.example.com/products/7/320.jpg 320w, https://img.example.com/products/7/640.jpg 640w",
+		Sizes:  "(max-width: 640px) 100vw, 640px",
+	}
+	if set != want {
+		t.Fatalf("expected %+v, got %+v", want, set)
+	}
+
+	set, err = cdn.SrcSet("avatar", []int{64}, urlkit.Params{"user": "ana"})
+	if err != nil || set.SrcSet != "https://img.example.com/avatars/ana?w=64 64w" || set.Sizes != "100vw" {
+		t.Fatalf("unexpected query width srcset: %+v (%v)", set, err)
+	}
+
+	set, err = cdn.SrcSet("hero.jpg", []int{800}, nil)
+	if err != nil || set.Src != "https://img.example.com/assets/hero.1a2b.jpg?w=800" {
+		t.Fatalf("unexpected asset srcset: %+v (%v)", set, err)
+	}
+
+	if _, err := cdn.SrcSet("missing", []int{100}, nil); err == nil {
+		t.Fatal("expected error for unknown image")
+	}
+	if _, err := cdn.SrcSet("avatar", nil, nil); err == nil {
+		t.Fatal("expected error without widths")
+	}
+
+	helpers := urlkit.TemplateHelpers(manager, nil)
+	srcset := helpers["srcset"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	value, perr := srcset(pongo2.AsValue("cdn"), pongo2.AsValue("product"), pongo2.AsValue("320, 640"), pongo2.AsValue(map[string]any{"id": 7}), pongo2.AsValue("50vw"))
+	if perr != nil {
+		t.Fatalf("srcset helper failed: %v", perr)
+	}
+	result, ok := value.Interface().(map[string]any)
+	if !ok || result["srcset"] != want.SrcSet || result["sizes"] != "50vw" || result["src"] != want.Src {
+		t.Fatalf("unexpected srcset helper result: %v", value.Interface())
+	}
+}
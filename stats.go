@@ -0,0 +1,85 @@
+package urlkit
+
+import (
+	"maps"
+	"slices"
+)
+
+// Stats summarizes the size and shape of a route registry.
+type Stats struct {
+	RootGroups int `json:"root_groups"`
+	Groups     int `json:"groups"`
+	Routes     int `json:"routes"`
+	Params     int `json:"params"`
+	// AvgParamsPerRoute is Params divided by Routes, or zero without routes.
+	AvgParamsPerRoute float64 `json:"avg_params_per_route"`
+	// MaxParams is the largest number of path params used by a single route.
+	MaxParams int `json:"max_params"`
+	// MaxDepth is the deepest nesting level; root groups are at depth 1.
+	MaxDepth int `json:"max_depth"`
+	// TemplateGroups counts groups that define their own URL template.
+	TemplateGroups int `json:"template_groups"`
+	// TemplatedRoutes counts routes rendered through a URL template, either
+	// their group's or an inherited one.
+	TemplatedRoutes int `json:"templated_routes"`
+	Mounts          int `json:"mounts"`
+}
+
+// Stats walks the registry and returns counts of groups, routes and params,
+// the deepest nesting level, and how widely URL templates are used. Mounted
+// managers are counted in Mounts but not traversed.
+func (m *RouteManager) Stats() Stats {
+	var stats Stats
+	if m == nil {
+		return stats
+	}
+
+	m.mu.RLock()
+	roots := make([]*Group, 0, len(m.groups))
+	for _, name := range slices.Sorted(maps.Keys(m.groups)) {
+		roots = append(roots, m.groups[name])
+	}
+	stats.Mounts = len(m.mounts)
+	m.mu.RUnlock()
+
+	stats.RootGroups = len(roots)
+	for _, root := range roots {
+		collectStats(root, 1, &stats)
+	}
+
+	if stats.Routes > 0 {
+		stats.AvgParamsPerRoute = float64(stats.Params) / float64(stats.Routes)
+	}
+	return stats
+}
+
+func collectStats(group *Group, depth int, stats *Stats) {
+	group.mu.RLock()
+	routes := slices.Collect(maps.Values(group.routes))
+	children := slices.Collect(maps.Values(group.children))
+	ownsTemplate := group.urlTemplate != ""
+	group.mu.RUnlock()
+
+	stats.Groups++
+	stats.MaxDepth = max(stats.MaxDepth, depth)
+	if ownsTemplate {
+		stats.TemplateGroups++
+	}
+	if len(routes) > 0 && group.FindTemplateOwner() != nil {
+		stats.TemplatedRoutes += len(routes)
+	}
+
+	for _, template := range routes {
+		stats.Routes++
+		names, err := routeParamNames(template)
+		if err != nil {
+			continue
+		}
+		stats.Params += len(names)
+		stats.MaxParams = max(stats.MaxParams, len(names))
+	}
+
+	for _, child := range children {
+		collectStats(child, depth+1, stats)
+	}
+}
@@ -0,0 +1,49 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestManagerStats(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	frontend, _, err := manager.RegisterGroup("frontend", "https://example.com", map[string]string{
+		"home": "/",
+		"post": "/posts/:category/:slug",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	if err := frontend.SetURLTemplate("{protocol}://{host}{route_path}"); err != nil {
+		t.Fatalf("SetURLTemplate failed: %v", err)
+	}
+	blog := mustRegisterGroup(t, frontend, "blog", "/blog", map[string]string{"show": "/:id"})
+	mustRegisterGroup(t, blog, "archive", "/archive", map[string]string{"year": "/:year"})
+
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"users": "/users",
+	}); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	stats := manager.Stats()
+	want := urlkit.Stats{
+		RootGroups:        2,
+		Groups:            4,
+		Routes:            5,
+		Params:            4,
+		AvgParamsPerRoute: 0.8,
+		MaxParams:         2,
+		MaxDepth:          3,
+		TemplateGroups:    1,
+		TemplatedRoutes:   4,
+	}
+	if stats != want {
+		t.Fatalf("expected %+v, got %+v", want, stats)
+	}
+
+	if empty := urlkit.NewRouteManager().Stats(); empty != (urlkit.Stats{}) {
+		t.Fatalf("expected zero stats, got %+v", empty)
+	}
+}
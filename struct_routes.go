@@ -0,0 +1,160 @@
+package urlkit
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// structRoute is a route definition parsed from a `route` struct tag.
+type structRoute struct {
+	name    string
+	pattern string
+	method  string
+}
+
+// RegisterStruct registers routes declared through `route` struct tags on the
+// fields of handlers, keeping URL definitions next to the handlers that serve
+// them. handlers must be a struct or a pointer to a struct.
+//
+// Tag syntax is "[METHOD ]pattern[,name=route_name]". The route name defaults to
+// the field name with a lowercase first letter. A method, when present, is
+// stored as route metadata.
+//
+// Example:
+//
+//	type UserHandlers struct {
+//	    Show   http.HandlerFunc `route:"GET /users/:id"`
+//	    Create http.HandlerFunc `route:"POST /users,name=create_user"`
+//	}
+//
+//	result, err := group.RegisterStruct(UserHandlers{})
+//	// routes: show -> /users/:id, create_user -> /users
+func (u *Group) RegisterStruct(handlers any) (RouteMutationResult, error) {
+	definitions, err := parseStructRoutes(handlers)
+	if err != nil {
+		return RouteMutationResult{}, err
+	}
+
+	routes := make(map[string]string, len(definitions))
+	for _, def := range definitions {
+		routes[def.name] = def.pattern
+	}
+
+	result, err := u.AddRoutes(routes)
+	if err != nil {
+		return result, err
+	}
+
+	for _, def := range definitions {
+		if def.method == "" || slices.Contains(result.Skipped, def.name) {
+			continue
+		}
+		if err := u.SetRouteMetadata(def.name, RouteMetadata{Method: def.method}); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// RegisterStruct registers the routes declared on handlers in the group at the
+// given dot-separated path. See Group.RegisterStruct for the tag syntax.
+func (m *RouteManager) RegisterStruct(groupPath string, handlers any) (*Group, RouteMutationResult, error) {
+	group, err := m.GetGroup(groupPath)
+	if err != nil {
+		return nil, RouteMutationResult{}, err
+	}
+
+	result, err := group.RegisterStruct(handlers)
+	return group, result, err
+}
+
+func parseStructRoutes(handlers any) ([]structRoute, error) {
+	value := reflect.ValueOf(handlers)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("register struct: nil %T", handlers)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("register struct: unsupported type %T", handlers)
+	}
+
+	valueType := value.Type()
+	definitions := make([]structRoute, 0, valueType.NumField())
+	seen := make(map[string]string, valueType.NumField())
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag, ok := field.Tag.Lookup("route")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		def, err := parseRouteTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("register struct: field %s: %w", field.Name, err)
+		}
+		if def.name == "" {
+			def.name = lowerFirst(field.Name)
+		}
+		if previous, exists := seen[def.name]; exists {
+			return nil, fmt.Errorf("register struct: fields %s and %s both declare route %q", previous, field.Name, def.name)
+		}
+		seen[def.name] = field.Name
+		definitions = append(definitions, def)
+	}
+
+	return definitions, nil
+}
+
+func parseRouteTag(tag string) (structRoute, error) {
+	var def structRoute
+
+	spec, options, _ := strings.Cut(tag, ",")
+	for _, option := range strings.Split(options, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		key, value, found := strings.Cut(option, "=")
+		if !found || strings.TrimSpace(key) != "name" {
+			return def, fmt.Errorf("unknown route tag option %q", option)
+		}
+		def.name = strings.TrimSpace(value)
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 1:
+		def.pattern = fields[0]
+	case 2:
+		def.method = strings.ToUpper(fields[0])
+		def.pattern = fields[1]
+		if !isHTTPMethod(def.method) {
+			return def, fmt.Errorf("unknown HTTP method %q", fields[0])
+		}
+	default:
+		return def, fmt.Errorf("route tag %q must be \"[METHOD ]pattern\"", tag)
+	}
+
+	if !strings.HasPrefix(def.pattern, "/") {
+		return def, fmt.Errorf("route pattern %q must start with '/'", def.pattern)
+	}
+
+	return def, nil
+}
+
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
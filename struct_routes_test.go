@@ -0,0 +1,72 @@
+package urlkit_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+type userHandlers struct {
+	Show     http.HandlerFunc `route:"GET /users/:id"`
+	Create   http.HandlerFunc `route:"post /users,name=create_user"`
+	Settings string           `route:"/users/:id/settings"`
+	Ignored  http.HandlerFunc `route:"-"`
+	Plain    http.HandlerFunc
+}
+
+func TestRegisterStructRoutes(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	if _, _, err := manager.RegisterGroup("api", "https://api.example.com", nil); err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+
+	group, result, err := manager.RegisterStruct("api", &userHandlers{})
+	if err != nil {
+		t.Fatalf("RegisterStruct failed: %v", err)
+	}
+	if strings.Join(result.Added, ",") != "create_user,settings,show" {
+		t.Fatalf("unexpected added routes: %v", result.Added)
+	}
+
+	url, err := group.Builder("show").WithParam("id", 7).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if url != "https://api.example.com/users/7" {
+		t.Fatalf("unexpected URL: %s", url)
+	}
+
+	meta, ok := group.RouteMetadata("create_user")
+	if !ok || meta.Method != http.MethodPost {
+		t.Fatalf("expected POST metadata, got %+v (ok=%v)", meta, ok)
+	}
+	if _, ok := group.RouteMetadata("settings"); ok {
+		t.Fatal("expected no metadata for route without method")
+	}
+}
+
+func TestRegisterStructRoutesRejectsInvalidTags(t *testing.T) {
+	group := urlkit.NewURIHelper("https://api.example.com", nil)
+
+	cases := []any{
+		struct {
+			A string `route:"FETCH /users"`
+		}{},
+		struct {
+			A string `route:"users"`
+		}{},
+		struct {
+			A string `route:"GET /a,name=dup"`
+			B string `route:"GET /b,name=dup"`
+		}{},
+		42,
+	}
+
+	for _, input := range cases {
+		if _, err := group.RegisterStruct(input); err == nil {
+			t.Fatalf("expected error for %T", input)
+		}
+	}
+}
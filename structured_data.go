@@ -0,0 +1,160 @@
+package urlkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const schemaOrgContext = "https://schema.org"
+
+// Breadcrumb is one entry of a breadcrumb trail.
+type Breadcrumb struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// BreadcrumbStep names the route behind one breadcrumb.
+type BreadcrumbStep struct {
+	Name   string
+	Group  string // dot-separated group path
+	Route  string
+	Params Params
+}
+
+// Breadcrumbs resolves each step to its URL, in order, to build a breadcrumb
+// trail for navigation markup or BreadcrumbListJSONLD.
+//
+// Example:
+//
+//	crumbs, err := manager.Breadcrumbs(
+//		urlkit.BreadcrumbStep{Name: "Home", Group: "frontend", Route: "home"},
+//		urlkit.BreadcrumbStep{Name: "Blog", Group: "frontend", Route: "blog"},
+//		urlkit.BreadcrumbStep{Name: post.Title, Group: "frontend", Route: "post", Params: urlkit.Params{"slug": post.Slug}},
+//	)
+func (m *RouteManager) Breadcrumbs(steps ...BreadcrumbStep) ([]Breadcrumb, error) {
+	crumbs := make([]Breadcrumb, 0, len(steps))
+	for i, step := range steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("breadcrumb %d (%s.%s): name is required", i+1, step.Group, step.Route)
+		}
+		built, err := m.Resolve(step.Group, step.Route, step.Params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("breadcrumb %d (%s.%s): %w", i+1, step.Group, step.Route, err)
+		}
+		crumbs = append(crumbs, Breadcrumb{Name: step.Name, URL: built})
+	}
+	return crumbs, nil
+}
+
+type jsonLDListItem struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	Item     string `json:"item"`
+}
+
+type jsonLDBreadcrumbList struct {
+	Context         string           `json:"@context"`
+	Type            string           `json:"@type"`
+	ItemListElement []jsonLDListItem `json:"itemListElement"`
+}
+
+// BreadcrumbListJSONLD returns a schema.org BreadcrumbList for crumbs as a
+// JSON-LD string. HTML-sensitive characters are escaped, so the result can be
+// embedded in a <script type="application/ld+json"> element as is.
+func BreadcrumbListJSONLD(crumbs []Breadcrumb) (string, error) {
+	list := jsonLDBreadcrumbList{
+		Context:         schemaOrgContext,
+		Type:            "BreadcrumbList",
+		ItemListElement: make([]jsonLDListItem, 0, len(crumbs)),
+	}
+	for i, crumb := range crumbs {
+		list.ItemListElement = append(list.ItemListElement, jsonLDListItem{
+			Type:     "ListItem",
+			Position: i + 1,
+			Name:     crumb.Name,
+			Item:     crumb.URL,
+		})
+	}
+	return marshalJSONLD(list)
+}
+
+// WebSiteOptions describes the schema.org WebSite generated by WebSiteJSONLD.
+type WebSiteOptions struct {
+	Name      string
+	Group     string // dot-separated group path of HomeRoute and SearchRoute
+	HomeRoute string
+
+	// SearchRoute, when set, adds a SearchAction whose URL template passes
+	// the search term in the SearchParam query parameter (default "q").
+	SearchRoute string
+	SearchParam string
+}
+
+type jsonLDSearchAction struct {
+	Type       string `json:"@type"`
+	Target     string `json:"target"`
+	QueryInput string `json:"query-input"`
+}
+
+type jsonLDWebSite struct {
+	Context         string              `json:"@context"`
+	Type            string              `json:"@type"`
+	Name            string              `json:"name,omitempty"`
+	URL             string              `json:"url"`
+	PotentialAction *jsonLDSearchAction `json:"potentialAction,omitempty"`
+}
+
+// WebSiteJSONLD returns a schema.org WebSite, with an optional sitelinks
+// search box SearchAction, as a JSON-LD string. The search URL template is the
+// rendered search route with a {search_term_string} placeholder query value.
+//
+// Example:
+//
+//	jsonLD, err := manager.WebSiteJSONLD(urlkit.WebSiteOptions{
+//		Name: "Example", Group: "frontend", HomeRoute: "home", SearchRoute: "search",
+//	})
+//	// ..."target":"https://example.com/search?q={search_term_string}"...
+func (m *RouteManager) WebSiteJSONLD(opts WebSiteOptions) (string, error) {
+	home, err := m.Resolve(opts.Group, opts.HomeRoute, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("website home route: %w", err)
+	}
+	site := jsonLDWebSite{
+		Context: schemaOrgContext,
+		Type:    "WebSite",
+		Name:    opts.Name,
+		URL:     home,
+	}
+
+	if opts.SearchRoute != "" {
+		search, err := m.Resolve(opts.Group, opts.SearchRoute, nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("website search route: %w", err)
+		}
+		param := opts.SearchParam
+		if param == "" {
+			param = "q"
+		}
+		separator := "?"
+		if strings.Contains(search, "?") {
+			separator = "&"
+		}
+		site.PotentialAction = &jsonLDSearchAction{
+			Type:       "SearchAction",
+			Target:     search + separator + url.QueryEscape(param) + "={search_term_string}",
+			QueryInput: "required name=search_term_string",
+		}
+	}
+	return marshalJSONLD(site)
+}
+
+func marshalJSONLD(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("encode json-ld: %w", err)
+	}
+	return string(encoded), nil
+}
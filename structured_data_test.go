@@ -0,0 +1,52 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestStructuredData(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	manager.RegisterGroup("frontend", "https://example.com", map[string]string{
+		"home":   "/",
+		"blog":   "/blog",
+		"post":   "/blog/:slug",
+		"search": "/search",
+	})
+
+	crumbs, err := manager.Breadcrumbs(
+		urlkit.BreadcrumbStep{Name: "Home", Group: "frontend", Route: "home"},
+		urlkit.BreadcrumbStep{Name: "Blog", Group: "frontend", Route: "blog"},
+		urlkit.BreadcrumbStep{Name: "Q&A <2024>", Group: "frontend", Route: "post", Params: urlkit.Params{"slug": "qa"}},
+	)
+	if err != nil {
+		t.Fatalf("Breadcrumbs failed: %v", err)
+	}
+	jsonLD, err := urlkit.BreadcrumbListJSONLD(crumbs)
+	if err != nil {
+		t.Fatalf("BreadcrumbListJSONLD failed: %v", err)
+	}
+	want := `{"@context":"https://schema.org","@type":"BreadcrumbList","itemListElement":[` +
+		`{"@type":"ListItem","position":1,"name":"Home","item":"https://example.com/"},` +
+		`{"@type":"ListItem","position":2,"name":"Blog","item":"https://example.com/blog"},` +
+		`{"@type":"ListItem","position":3,"name":"Q\u0026A \u003c2024\u003e","item":"https://example.com/blog/qa"}]}`
+	if jsonLD != want {
+		t.Fatalf("unexpected breadcrumb list:\n%s\nwant:\n%s", jsonLD, want)
+	}
+
+	if _, err := manager.Breadcrumbs(urlkit.BreadcrumbStep{Name: "Gone", Group: "frontend", Route: "gone"}); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+
+	site, err := manager.WebSiteJSONLD(urlkit.WebSiteOptions{Name: "Example", Group: "frontend", HomeRoute: "home", SearchRoute: "search"})
+	if err != nil {
+		t.Fatalf("WebSiteJSONLD failed: %v", err)
+	}
+	want = `{"@context":"https://schema.org","@type":"WebSite","name":"Example","url":"https://example.com/",` +
+		`"potentialAction":{"@type":"SearchAction","target":"https://example.com/search?q={search_term_string}","query-input":"required name=search_term_string"}}`
+	if site != want {
+		t.Fatalf("unexpected website:\n%s\nwant:\n%s", site, want)
+	}
+}
@@ -0,0 +1,126 @@
+package urlkit
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrSubdomainRequired is returned when building a route of a group whose
+	// base URL has a wildcard subdomain (e.g. "https://*.preview.example.com")
+	// without supplying one with Builder.WithSubdomain.
+	ErrSubdomainRequired = errors.New("wildcard base URL requires a subdomain")
+	// ErrInvalidSubdomain is returned when the subdomain passed to
+	// Builder.WithSubdomain is not a DNS label matching the group's subdomain
+	// pattern, or the group's base URL has no wildcard to fill.
+	ErrInvalidSubdomain = errors.New("invalid subdomain")
+)
+
+// dnsLabelPattern matches a single lowercase DNS label (RFC 1123).
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// SetSubdomainPattern restricts the subdomains accepted by
+// Builder.WithSubdomain for this group and its descendants to those fully
+// matching pattern (e.g. `pr-[0-9]+`), so preview links cannot point at
+// arbitrary hosts. Subdomains must always be a valid DNS label. An empty
+// pattern removes the restriction; child groups override their parents.
+//
+// Example:
+//
+//	preview.SetSubdomainPattern(`pr-[0-9]+`)
+//	preview.Builder("home").WithSubdomain("pr-123").Build() // https://pr-123.preview.example.com/
+func (u *Group) SetSubdomainPattern(pattern string) error {
+	var compiled *regexp.Regexp
+	if pattern != "" {
+		var err error
+		compiled, err = regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid subdomain pattern %q: %w", pattern, err)
+		}
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set subdomain pattern", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventRoutesChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	u.subdomainPattern = compiled
+	u.mu.Unlock()
+	return nil
+}
+
+// effectiveSubdomainPattern returns the subdomain pattern of the nearest group
+// in the parent chain that sets one.
+func (u *Group) effectiveSubdomainPattern() *regexp.Regexp {
+	for current := u; current != nil; {
+		current.mu.RLock()
+		pattern, parent := current.subdomainPattern, current.parent
+		current.mu.RUnlock()
+		if pattern != nil {
+			return pattern
+		}
+		current = parent
+	}
+	return nil
+}
+
+// WithSubdomain fills the wildcard subdomain of the group's base URL (e.g.
+// "https://*.preview.example.com") with subdomain, for links to preview
+// deployments. The subdomain is lowercased and must be a DNS label matching
+// the group's subdomain pattern (see Group.SetSubdomainPattern); otherwise
+// Build returns ErrInvalidSubdomain.
+//
+// Example:
+//
+//	link, err := preview.Builder("checkout").WithSubdomain("pr-123").Build()
+//	// https://pr-123.preview.example.com/checkout
+func (b *Builder) WithSubdomain(subdomain string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if subdomain == "" {
+		b.err = fmt.Errorf("with subdomain: %w: subdomain is empty", ErrInvalidSubdomain)
+		return b
+	}
+	b.subdomain = strings.ToLower(subdomain)
+	return b
+}
+
+// fillSubdomain replaces the leading "*" label of the built URL's host with
+// subdomain after validating it.
+func (u *Group) fillSubdomain(routeName, subdomain, built string) (string, error) {
+	if subdomain == "" && !strings.Contains(built, "*") {
+		return built, nil
+	}
+
+	target, err := url.Parse(built)
+	if err != nil {
+		return "", fmt.Errorf("route %q in group %s: %w", routeName, groupDisplayName(u), err)
+	}
+	rest, wildcard := strings.CutPrefix(target.Host, "*.")
+	if !wildcard {
+		if subdomain != "" {
+			return "", fmt.Errorf("%w: route %q in group %s: base URL has no wildcard subdomain", ErrInvalidSubdomain, routeName, groupDisplayName(u))
+		}
+		return built, nil
+	}
+	if subdomain == "" {
+		return "", fmt.Errorf("%w: route %q in group %s", ErrSubdomainRequired, routeName, groupDisplayName(u))
+	}
+
+	if !dnsLabelPattern.MatchString(subdomain) {
+		return "", fmt.Errorf("%w: %q is not a DNS label", ErrInvalidSubdomain, subdomain)
+	}
+	if pattern := u.effectiveSubdomainPattern(); pattern != nil && !pattern.MatchString(subdomain) {
+		return "", fmt.Errorf("%w: %q does not match pattern %s", ErrInvalidSubdomain, subdomain, pattern)
+	}
+
+	return strings.Replace(built, "//"+target.Host, "//"+subdomain+"."+rest, 1), nil
+}
@@ -0,0 +1,68 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goliatone/go-urlkit"
+)
+
+func TestWildcardSubdomain(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:             "preview",
+				BaseURL:          "https://*.preview.example.com",
+				Routes:           map[string]string{"home": "/", "order": "/orders/:id"},
+				SubdomainPattern: `pr-[0-9]+`,
+				Groups: []urlkit.GroupConfig{
+					{Name: "docs", Path: "/docs", Routes: map[string]string{"page": "/:slug"}},
+				},
+			},
+			{
+				Name:    "frontend",
+				BaseURL: "https://example.com",
+				Routes:  map[string]string{"home": "/"},
+			},
+		},
+	})
+	preview := manager.Group("preview")
+
+	built, err := preview.Builder("order").WithParam("id", 42).WithQuery("tab", "items").WithSubdomain("PR-123").Build()
+	if err != nil || built != "https://pr-123.preview.example.com/orders/42?tab=items" {
+		t.Fatalf("Build = %q, %v", built, err)
+	}
+
+	// Child groups inherit the wildcard base URL and the pattern
+	built, err = preview.Group("docs").Builder("page").WithParam("slug", "intro").WithSubdomain("pr-7").Build()
+	if err != nil || built != "https://pr-7.preview.example.com/docs/intro" {
+		t.Fatalf("child Build = %q, %v", built, err)
+	}
+
+	if _, err := preview.Builder("home").Build(); !errors.Is(err, urlkit.ErrSubdomainRequired) {
+		t.Errorf("expected ErrSubdomainRequired, got %v", err)
+	}
+	if _, err := manager.Resolve("preview", "home", nil, nil); !errors.Is(err, urlkit.ErrSubdomainRequired) {
+		t.Errorf("expected ErrSubdomainRequired from Resolve, got %v", err)
+	}
+
+	for _, subdomain := range []string{"staging", "pr-1.evil.com", "pr_1", ""} {
+		if _, err := preview.Builder("home").WithSubdomain(subdomain).Build(); !errors.Is(err, urlkit.ErrInvalidSubdomain) {
+			t.Errorf("WithSubdomain(%q): expected ErrInvalidSubdomain, got %v", subdomain, err)
+		}
+	}
+	if _, err := manager.Group("frontend").Builder("home").WithSubdomain("pr-1").Build(); !errors.Is(err, urlkit.ErrInvalidSubdomain) {
+		t.Errorf("expected ErrInvalidSubdomain for a group without wildcard, got %v", err)
+	}
+
+	// Without a pattern any DNS label is accepted
+	if err := preview.SetSubdomainPattern(""); err != nil {
+		t.Fatalf("SetSubdomainPattern failed: %v", err)
+	}
+	if built, err := preview.Builder("home").WithSubdomain("staging").Build(); err != nil || built != "https://staging.preview.example.com/" {
+		t.Errorf("Build without pattern = %q, %v", built, err)
+	}
+	if err := preview.SetSubdomainPattern("pr-("); err == nil {
+		t.Error("expected invalid pattern error")
+	}
+}
@@ -0,0 +1,300 @@
+package urlkit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// HelperArgKind is the type a template helper argument accepts.
+type HelperArgKind string
+
+const (
+	// HelperArgString accepts a string.
+	HelperArgString HelperArgKind = "string"
+	// HelperArgMap accepts a map with string keys.
+	HelperArgMap HelperArgKind = "map"
+	// HelperArgList accepts a single string or a list.
+	HelperArgList HelperArgKind = "string or list"
+	// HelperArgAny accepts any value, including nil.
+	HelperArgAny HelperArgKind = "any"
+)
+
+// HelperArg describes one positional argument of a template helper.
+type HelperArg struct {
+	Name     string
+	Kind     HelperArgKind
+	Optional bool
+}
+
+// HelperSignature describes the positional arguments of a template helper and
+// an example call shown in validation errors. Optional arguments come last and
+// may be nil.
+type HelperSignature struct {
+	Helper string
+	Args   []HelperArg
+	Usage  string
+}
+
+// HelperArgError reports a template helper call with missing or mistyped
+// arguments. Index is the 1-based position of the offending argument, or 0
+// when too few arguments were passed.
+type HelperArgError struct {
+	Helper   string
+	Index    int
+	Arg      string
+	Expected HelperArgKind
+	Received string
+	Usage    string
+	message  string
+}
+
+func (e *HelperArgError) Error() string {
+	return fmt.Sprintf("%s: %s; usage: %s", e.Helper, e.message, e.Usage)
+}
+
+// helperSignatures lists the signatures of the built-in template helpers.
+var helperSignatures = map[string]HelperSignature{}
+
+func init() {
+	urlArgs := []HelperArg{
+		{Name: "group", Kind: HelperArgString},
+		{Name: "route", Kind: HelperArgString},
+		{Name: "params", Kind: HelperArgMap, Optional: true},
+		{Name: "query", Kind: HelperArgMap, Optional: true},
+	}
+	for _, signature := range []HelperSignature{
+		{Helper: "url", Args: urlArgs, Usage: "{{ url('frontend', 'user_profile', {'id': 42}, {'tab': 'posts'}) }}"},
+		{Helper: "url_abs", Args: urlArgs, Usage: "{{ url_abs('frontend', 'user_profile', {'id': 42}) }}"},
+		{Helper: "route_path", Args: urlArgs, Usage: "{{ route_path('frontend', 'user_profile', {'id': 42}) }}"},
+		{Helper: "url_i18n", Args: urlArgs, Usage: "{{ url_i18n('frontend', 'user_profile', {'id': 42}) }}"},
+		{Helper: "url_all_locales", Args: urlArgs, Usage: "{{ url_all_locales('frontend', 'about') }}"},
+		{
+			Helper: "url_region",
+			Args: []HelperArg{
+				{Name: "group", Kind: HelperArgString},
+				{Name: "region", Kind: HelperArgString},
+				{Name: "route", Kind: HelperArgString},
+				{Name: "params", Kind: HelperArgMap, Optional: true},
+				{Name: "query", Kind: HelperArgMap, Optional: true},
+			},
+			Usage: "{{ url_region('cdn', request.country, 'assets', {'file': 'app.js'}) }}",
+		},
+		{
+			Helper: "url_locale",
+			Args: []HelperArg{
+				{Name: "group", Kind: HelperArgString},
+				{Name: "route", Kind: HelperArgString},
+				{Name: "locale", Kind: HelperArgString},
+				{Name: "params", Kind: HelperArgMap, Optional: true},
+				{Name: "query", Kind: HelperArgMap, Optional: true},
+			},
+			Usage: "{{ url_locale('frontend', 'about', 'es') }}",
+		},
+		{
+			Helper: "route_template",
+			Args:   urlArgs[:2],
+			Usage:  "{{ route_template('frontend', 'user_profile') }}",
+		},
+		{
+			Helper: "route_vars",
+			Args:   urlArgs[:1],
+			Usage:  "{{ route_vars('frontend') }}",
+		},
+		{
+			Helper: "navigation",
+			Args: []HelperArg{
+				{Name: "group", Kind: HelperArgString},
+				{Name: "routes", Kind: HelperArgList},
+				{Name: "params", Kind: HelperArgMap, Optional: true},
+			},
+			Usage: "{{ navigation('frontend', ['home', 'about']) }}",
+		},
+		{
+			Helper: "current_route_if",
+			Args: []HelperArg{
+				{Name: "target_route", Kind: HelperArgString},
+				{Name: "current_route", Kind: HelperArgString},
+				{Name: "value_if_true", Kind: HelperArgAny},
+				{Name: "value_if_false", Kind: HelperArgAny, Optional: true},
+			},
+			Usage: "{{ current_route_if('frontend.home', current_route_name, 'active') }}",
+		},
+		{
+			Helper: "asset",
+			Args:   []HelperArg{{Name: "group", Kind: HelperArgString}, {Name: "asset", Kind: HelperArgString}},
+			Usage:  "{{ asset('cdn', 'app.css') }}",
+		},
+		{
+			Helper: "asset_sri",
+			Args:   []HelperArg{{Name: "group", Kind: HelperArgString}, {Name: "asset", Kind: HelperArgString}},
+			Usage:  "{{ asset_sri('cdn', 'app.js').integrity }}",
+		},
+		{
+			Helper: "srcset",
+			Args: []HelperArg{
+				{Name: "group", Kind: HelperArgString},
+				{Name: "image", Kind: HelperArgString},
+				{Name: "widths", Kind: HelperArgAny},
+				{Name: "params", Kind: HelperArgMap, Optional: true},
+				{Name: "sizes", Kind: HelperArgList, Optional: true},
+			},
+			Usage: "{{ srcset('cdn', 'product_image', '320,640', {'id': product.id}, '50vw') }}",
+		},
+	} {
+		helperSignatures[signature.Helper] = signature
+	}
+}
+
+// LookupHelperSignature returns the signature of a built-in template helper,
+// so other template engine adapters can validate calls the same way.
+func LookupHelperSignature(helper string) (HelperSignature, bool) {
+	signature, ok := helperSignatures[helper]
+	return signature, ok
+}
+
+// ValidateHelperArgs checks args against the signature of the built-in helper.
+// Helpers without a registered signature accept any arguments.
+//
+// Example:
+//
+//	err := urlkit.ValidateHelperArgs("url", "frontend", 42)
+//	// url: argument 2: route must be a string, got int; usage: {{ url('frontend', 'user_profile', ...) }}
+func ValidateHelperArgs(helper string, args ...any) error {
+	signature, ok := helperSignatures[helper]
+	if !ok {
+		return nil
+	}
+	return signature.Validate(args...)
+}
+
+// Validate checks the number and types of args, returning a *HelperArgError
+// naming the first offending argument.
+func (s HelperSignature) Validate(args ...any) error {
+	required := 0
+	for _, arg := range s.Args {
+		if !arg.Optional {
+			required++
+		}
+	}
+	if len(args) < required {
+		names := make([]string, 0, required)
+		for _, arg := range s.Args[:required] {
+			names = append(names, arg.Name)
+		}
+		noun := "arguments"
+		if required == 1 {
+			noun = "argument"
+		}
+		return s.argError(0, HelperArg{}, nil, fmt.Sprintf("at least %d %s required: %s, got %d", required, noun, joinArgNames(names), len(args)))
+	}
+
+	for i, arg := range s.Args {
+		if i >= len(args) {
+			break
+		}
+		value := args[i]
+		if value == nil && (arg.Optional || arg.Kind == HelperArgAny) {
+			continue
+		}
+		if !arg.Kind.accepts(value) {
+			message := fmt.Sprintf("argument %d: %s must be a %s, got %s", i+1, arg.Name, arg.Kind, describeArgType(value))
+			return s.argError(i+1, arg, value, message)
+		}
+	}
+	return nil
+}
+
+func (s HelperSignature) argError(index int, arg HelperArg, value any, message string) *HelperArgError {
+	err := &HelperArgError{Helper: s.Helper, Index: index, Usage: s.Usage, message: message}
+	if index > 0 {
+		err.Arg = arg.Name
+		err.Expected = arg.Kind
+		err.Received = describeArgType(value)
+	}
+	return err
+}
+
+func (k HelperArgKind) accepts(value any) bool {
+	switch k {
+	case HelperArgAny:
+		return true
+	case HelperArgString:
+		_, ok := value.(string)
+		return ok
+	case HelperArgMap:
+		rv := reflect.ValueOf(value)
+		return rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String
+	case HelperArgList:
+		if _, ok := value.(string); ok {
+			return true
+		}
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	}
+	return false
+}
+
+// describeArgType names the type of a template value in template terms.
+func describeArgType(value any) string {
+	if value == nil {
+		return "nil"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Map:
+		return "map"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func joinArgNames(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// validatePongoArgs validates pongo2 helper arguments against the helper's
+// signature.
+func validatePongoArgs(helper string, args []*pongo2.Value) error {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = fromPongoValue(arg)
+	}
+	return ValidateHelperArgs(helper, values...)
+}
+
+// formatArgsError formats an argument validation failure, with the offending
+// argument and the usage example in the error context.
+func formatArgsError(helper string, err error, argsCount int, config *TemplateHelperConfig) *pongo2.Value {
+	context := map[string]any{"args_count": argsCount}
+	argErr, ok := err.(*HelperArgError)
+	if !ok {
+		return formatError(helper, "parse_error", err.Error(), context, config)
+	}
+
+	errorType := "invalid_args"
+	context["usage"] = argErr.Usage
+	if argErr.Index == 0 {
+		errorType = "insufficient_args"
+	} else {
+		context["arg_index"] = argErr.Index
+		context["arg"] = argErr.Arg
+		context["expected"] = string(argErr.Expected)
+		context["received"] = argErr.Received
+	}
+	return formatError(helper, errorType, argErr.message+"; usage: "+argErr.Usage, context, config)
+}
@@ -0,0 +1,61 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestValidateHelperArgs(t *testing.T) {
+	if err := urlkit.ValidateHelperArgs("url", "frontend", "home", map[string]string{"id": "1"}, nil); err != nil {
+		t.Fatalf("expected valid args, got %v", err)
+	}
+
+	err := urlkit.ValidateHelperArgs("url", "frontend", "home", "id=1")
+	var argErr *urlkit.HelperArgError
+	if !errors.As(err, &argErr) {
+		t.Fatalf("expected HelperArgError, got %v", err)
+	}
+	if argErr.Index != 3 || argErr.Arg != "params" || argErr.Expected != urlkit.HelperArgMap || argErr.Received != "string" {
+		t.Fatalf("unexpected error details %+v", argErr)
+	}
+	if want := "url: argument 3: params must be a map, got string; usage: {{ url('frontend', 'user_profile', {'id': 42}, {'tab': 'posts'}) }}"; err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+
+	err = urlkit.ValidateHelperArgs("url_locale", "frontend", "about")
+	if !errors.As(err, &argErr) || argErr.Index != 0 || !strings.Contains(err.Error(), "at least 3 arguments required: group, route and locale, got 2") {
+		t.Fatalf("unexpected count error %v", err)
+	}
+
+	signature, ok := urlkit.LookupHelperSignature("navigation")
+	if !ok || len(signature.Args) != 3 || signature.Args[1].Kind != urlkit.HelperArgList {
+		t.Fatalf("unexpected navigation signature %+v", signature)
+	}
+	if err := urlkit.ValidateHelperArgs("unknown", 1, 2); err != nil {
+		t.Fatalf("expected unknown helpers to accept any args, got %v", err)
+	}
+}
+
+func TestTemplateHelperArgErrors(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	manager.RegisterGroup("frontend", "https://example.com", map[string]string{"home": "/"})
+	helpers := urlkit.TemplateHelpers(manager, urlkit.DefaultTemplateHelperConfig())
+
+	routeTemplate := helpers["route_template"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	result, _ := routeTemplate(pongo2.AsValue("frontend"), pongo2.AsValue(7))
+	if want := "#error:route_template:invalid_args:argument 2: route must be a string, got int; usage: {{ route_template('frontend', 'user_profile') }}"; result.String() != want {
+		t.Fatalf("expected %q, got %q", want, result.String())
+	}
+
+	structured := urlkit.TemplateHelpers(manager, &urlkit.TemplateHelperConfig{EnableStructuredErrors: true})
+	url := structured["url"].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	result, _ = url(pongo2.AsValue("frontend"))
+	templateErr, ok := result.Interface().(urlkit.TemplateError)
+	if !ok || templateErr.Type != "insufficient_args" || templateErr.Context["usage"] == nil {
+		t.Fatalf("unexpected structured error %+v", result.Interface())
+	}
+}
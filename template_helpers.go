@@ -2,8 +2,8 @@ package urlkit
 
 import (
 	"fmt"
-	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -15,6 +15,10 @@ type TemplateHelperConfig struct {
 	// Error reporting configuration
 	EnableStructuredErrors bool // When true, returns JSON error objects instead of simple strings
 	EnableErrorLogging     bool // When true, logs errors for production debugging
+
+	// MissCollector, when set, aggregates every group and route miss reported
+	// by the helpers. See LinkMissCollector.
+	MissCollector *LinkMissCollector
 }
 
 // LocaleConfig defines configuration for localization helpers
@@ -527,6 +531,11 @@ func TemplateHelpers(manager *RouteManager, config *TemplateHelperConfig) map[st
 	navigationFn := safeTemplateHelper("navigation", config, navigationHelper(manager, config))
 	helpers["navigation"] = navigationFn
 
+	helpers["asset"] = safeTemplateHelper("asset", config, assetHelper(manager, config, false))
+	helpers["asset_sri"] = safeTemplateHelper("asset_sri", config, assetHelper(manager, config, true))
+	helpers["srcset"] = safeTemplateHelper("srcset", config, srcSetHelper(manager, config))
+	helpers["url_region"] = safeTemplateHelper("url_region", config, urlRegionHelper(manager, config))
+
 	// Contextual Helper Functions (work with middleware-injected context)
 	currentRouteIfFn := safeTemplateHelper("current_route_if", config, currentRouteIfHelper(config))
 	helpers["current_route_if"] = currentRouteIfFn
@@ -598,6 +607,8 @@ type TemplateError struct {
 
 // formatError creates appropriate error response based on configuration
 func formatError(helper, errorType, message string, context map[string]any, config *TemplateHelperConfig) *pongo2.Value {
+	recordLinkMiss(helper, errorType, context, config)
+
 	if config.EnableStructuredErrors {
 		errorObj := TemplateError{
 			Helper:  helper,
@@ -622,28 +633,36 @@ func formatError(helper, errorType, message string, context map[string]any, conf
 }
 
 // GroupCache provides simple caching for frequently accessed groups
-// This reduces the overhead of repeated group lookups in template helpers
+// This reduces the overhead of repeated group lookups in template helpers.
+// Cached groups are dropped whenever the manager publishes a registry event,
+// so a Reload never leaves helpers rendering from replaced groups.
 type GroupCache struct {
-	cache   map[string]*Group
-	manager *RouteManager
-	mutex   sync.RWMutex
+	cache      map[string]*Group
+	manager    *RouteManager
+	generation uint64
+	mutex      sync.RWMutex
 }
 
 // NewGroupCache creates a new group cache
 func NewGroupCache(manager *RouteManager) *GroupCache {
 	return &GroupCache{
-		cache:   make(map[string]*Group),
-		manager: manager,
+		cache:      make(map[string]*Group),
+		manager:    manager,
+		generation: manager.registryGeneration(),
 	}
 }
 
 // Get retrieves a group from cache or fetches it from the manager
 func (gc *GroupCache) Get(groupName string) *Group {
+	generation := gc.manager.registryGeneration()
+
 	// Try read lock first for cache hit
 	gc.mutex.RLock()
-	if group, exists := gc.cache[groupName]; exists {
-		gc.mutex.RUnlock()
-		return group
+	if gc.generation == generation {
+		if group, exists := gc.cache[groupName]; exists {
+			gc.mutex.RUnlock()
+			return group
+		}
 	}
 	gc.mutex.RUnlock()
 
@@ -651,13 +670,19 @@ func (gc *GroupCache) Get(groupName string) *Group {
 	gc.mutex.Lock()
 	defer gc.mutex.Unlock()
 
+	// Drop entries cached before the registry changed
+	if gc.generation != generation {
+		gc.cache = make(map[string]*Group)
+		gc.generation = generation
+	}
+
 	// Double-check cache after acquiring write lock (another goroutine might have populated it)
 	if group, exists := gc.cache[groupName]; exists {
 		return group
 	}
 
 	// Fetch from manager and cache result (skip caching nil to allow future registrations)
-	group := safeGroupAccess(gc.manager, groupName)
+	group := lookupGroup(gc.manager, groupName)
 	if group != nil {
 		gc.cache[groupName] = group
 	}
@@ -671,15 +696,61 @@ func (gc *GroupCache) Clear() {
 	gc.cache = make(map[string]*Group)
 }
 
-// safeGroupAccess safely accesses a group without panicking
-func safeGroupAccess(manager *RouteManager, groupName string) *Group {
+// registryGeneration returns the number of registry events published so far.
+func (m *RouteManager) registryGeneration() uint64 {
+	if m == nil || m.runtime == nil {
+		return 0
+	}
+	return m.runtime.generation.Load()
+}
+
+// lookupGroup returns the group at groupName, or nil when the manager is nil or
+// the group is not registered. Callers report the miss through formatError.
+func lookupGroup(manager *RouteManager, groupName string) *Group {
+	if manager == nil {
+		return nil
+	}
+	group, err := manager.GetGroup(groupName)
+	if err != nil {
+		return nil
+	}
+	return group
+}
+
+// helperParamsPool recycles the params maps helpers normalize arguments into.
+// Rendering never retains the map (build interceptors receive a clone), so it
+// can be reused as soon as the URL is built.
+var helperParamsPool = sync.Pool{
+	New: func() any { return make(Params, 8) },
+}
+
+// renderHelperURL builds a route URL from template arguments. It renders like
+// Builder.Build without allocating a builder and its maps per call.
+func renderHelperURL(group *Group, routeName string, params map[string]any, query map[string]string) (string, error) {
+	normalized := helperParamsPool.Get().(Params)
 	defer func() {
-		if r := recover(); r != nil {
-			// Group access panicked, group doesn't exist
-		}
+		clear(normalized)
+		helperParamsPool.Put(normalized)
 	}()
 
-	return manager.Group(groupName)
+	for key, value := range params {
+		normalized[key] = normalizeParamValue(value)
+	}
+
+	if len(query) == 0 {
+		return group.Render(routeName, normalized)
+	}
+	return group.Render(routeName, normalized, Query(query))
+}
+
+// renderHelperPath is renderHelperURL returning only the path and query, like
+// Builder.BuildPath.
+func renderHelperPath(group *Group, routeName string, params map[string]any, query map[string]string) (string, error) {
+	built, err := renderHelperURL(group, routeName, params, query)
+	if err != nil {
+		return "", err
+	}
+	return pathAndQuery(built)
 }
 
 // safeTemplateHelper wraps template helper functions with comprehensive panic recovery
@@ -720,58 +791,34 @@ type urlHelperArgs struct {
 // parseArgs parses variadic pongo2.Value arguments into structured data
 // Optimized version that avoids unnecessary allocations for common cases
 func parseArgs(args ...*pongo2.Value) (*urlHelperArgs, error) {
-	if len(args) < 2 {
-		return nil, fmt.Errorf("at least 2 arguments required: group and route")
-	}
-
-	// Pre-allocate result with likely sizes to reduce allocations
-	result := &urlHelperArgs{}
-
-	// First argument: group name (direct string access for common case)
-	if args[0] == nil {
-		return nil, fmt.Errorf("group cannot be nil")
-	}
-	groupVal := fromPongoValue(args[0])
-	if group, ok := groupVal.(string); ok {
-		result.Group = group
-	} else {
-		return nil, fmt.Errorf("group must be a string")
-	}
+	return parseHelperArgs("url", args...)
+}
 
-	// Second argument: route name (direct string access for common case)
-	if args[1] == nil {
-		return nil, fmt.Errorf("route cannot be nil")
+// parseHelperArgs parses group, route, params and query arguments, validating
+// them against the signature of helper so errors name the right helper.
+func parseHelperArgs(helper string, args ...*pongo2.Value) (*urlHelperArgs, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = fromPongoValue(arg)
 	}
-	routeVal := fromPongoValue(args[1])
-	if route, ok := routeVal.(string); ok {
-		result.Route = route
-	} else {
-		return nil, fmt.Errorf("route must be a string")
+	if err := helperSignatures[helper].Validate(values...); err != nil {
+		return nil, err
 	}
 
-	// Only allocate maps if we actually have arguments for them
-	var hasParams, hasQuery bool
-	if len(args) > 2 && args[2] != nil {
-		hasParams = true
-	}
-	if len(args) > 3 && args[3] != nil {
-		hasQuery = true
+	result := &urlHelperArgs{
+		Group: values[0].(string),
+		Route: values[1].(string),
 	}
 
-	// Allocate params map only if needed
-	if hasParams {
-		paramsVal := fromPongoValue(args[2])
-		if params, ok := paramsVal.(map[string]any); ok {
+	// Optional params and query were validated as maps (or nil) above
+	if len(values) > 2 {
+		if params, ok := values[2].(map[string]any); ok {
 			result.Params = params // Use existing map to avoid copy
-		} else if paramsVal != nil {
-			return nil, fmt.Errorf("params must be a map")
 		}
 	}
 
-	// Allocate query map only if needed
-	if hasQuery {
-		queryVal := fromPongoValue(args[3])
-		if queryMap, ok := queryVal.(map[string]any); ok {
+	if len(values) > 3 {
+		if queryMap, ok := values[3].(map[string]any); ok {
 			// Pre-allocate with known size
 			result.Query = make(map[string]string, len(queryMap))
 			// Convert to map[string]string efficiently
@@ -782,8 +829,6 @@ func parseArgs(args ...*pongo2.Value) (*urlHelperArgs, error) {
 					result.Query[k] = fmt.Sprintf("%v", v)
 				}
 			}
-		} else if queryVal != nil {
-			return nil, fmt.Errorf("query must be a map")
 		}
 	}
 
@@ -800,8 +845,8 @@ func parseArgs(args ...*pongo2.Value) (*urlHelperArgs, error) {
 
 func navigationHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 2 {
-			return formatError("navigation", "insufficient_args", "requires group and routes", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("navigation", args); err != nil {
+			return formatArgsError("navigation", err, len(args), config), nil
 		}
 
 		groupVal := fromPongoValue(args[0])
@@ -825,7 +870,7 @@ func navigationHelper(manager *RouteManager, config *TemplateHelperConfig) func(
 			}
 		}
 
-		group := safeGroupAccess(manager, groupName)
+		group := lookupGroup(manager, groupName)
 		if group == nil {
 			context := map[string]any{
 				"group_name": groupName,
@@ -844,7 +889,7 @@ func navigationHelper(manager *RouteManager, config *TemplateHelperConfig) func(
 				"group_name": groupName,
 				"routes":     routeNames,
 			}
-			return formatError("navigation", "build_error", err.Error(), context, config), nil
+			return formatBuildError("navigation", err, context, config), nil
 		}
 
 		return pongo2.AsValue(nodes), nil
@@ -1013,9 +1058,9 @@ func fromPongoValueReflection(iface any) any {
 // urlHelperWithCache returns a template function that generates complete URLs using group cache
 func urlHelperWithCache(groupCache *GroupCache, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		parsedArgs, err := parseArgs(args...)
+		parsedArgs, err := parseHelperArgs("url", args...)
 		if err != nil {
-			return formatError("url", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+			return formatArgsError("url", err, len(args), config), nil
 		}
 
 		// Get the group from cache
@@ -1027,32 +1072,8 @@ func urlHelperWithCache(groupCache *GroupCache, config *TemplateHelperConfig) fu
 			return formatError("url", "group_not_found", fmt.Sprintf("group '%s' not found", parsedArgs.Group), context, config), nil
 		}
 
-		// Build the URL using the fluent API
-		builder := group.Builder(parsedArgs.Route)
-		if builder == nil {
-			context := map[string]any{
-				"route_name": parsedArgs.Route,
-				"group_name": parsedArgs.Group,
-			}
-			return formatError("url", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", parsedArgs.Route, parsedArgs.Group), context, config), nil
-		}
-
-		// Add parameters if provided
-		if len(parsedArgs.Params) > 0 {
-			for key, value := range parsedArgs.Params {
-				builder = builder.WithParam(key, value)
-			}
-		}
-
-		// Add query parameters if provided
-		if len(parsedArgs.Query) > 0 {
-			for key, value := range parsedArgs.Query {
-				builder = builder.WithQuery(key, value)
-			}
-		}
-
-		// Build the final URL
-		url, err := builder.Build()
+		// Build the URL
+		url, err := renderHelperURL(group, parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
@@ -1060,7 +1081,7 @@ func urlHelperWithCache(groupCache *GroupCache, config *TemplateHelperConfig) fu
 				"params":     parsedArgs.Params,
 				"query":      parsedArgs.Query,
 			}
-			return formatError("url", "build_error", err.Error(), context, config), nil
+			return formatBuildError("url", err, context, config), nil
 		}
 
 		return pongo2.AsValue(url), nil
@@ -1070,13 +1091,13 @@ func urlHelperWithCache(groupCache *GroupCache, config *TemplateHelperConfig) fu
 // urlHelper returns a template function that generates complete URLs (legacy version for compatibility)
 func urlHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		parsedArgs, err := parseArgs(args...)
+		parsedArgs, err := parseHelperArgs("url", args...)
 		if err != nil {
-			return formatError("url", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+			return formatArgsError("url", err, len(args), config), nil
 		}
 
 		// Get the group safely
-		group := safeGroupAccess(manager, parsedArgs.Group)
+		group := lookupGroup(manager, parsedArgs.Group)
 		if group == nil {
 			context := map[string]any{
 				"group_name": parsedArgs.Group,
@@ -1084,32 +1105,8 @@ func urlHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pon
 			return formatError("url", "group_not_found", fmt.Sprintf("group '%s' not found", parsedArgs.Group), context, config), nil
 		}
 
-		// Build the URL using the fluent API
-		builder := group.Builder(parsedArgs.Route)
-		if builder == nil {
-			context := map[string]any{
-				"route_name": parsedArgs.Route,
-				"group_name": parsedArgs.Group,
-			}
-			return formatError("url", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", parsedArgs.Route, parsedArgs.Group), context, config), nil
-		}
-
-		// Add parameters if provided
-		if len(parsedArgs.Params) > 0 {
-			for key, value := range parsedArgs.Params {
-				builder = builder.WithParam(key, value)
-			}
-		}
-
-		// Add query parameters if provided
-		if len(parsedArgs.Query) > 0 {
-			for key, value := range parsedArgs.Query {
-				builder = builder.WithQuery(key, value)
-			}
-		}
-
-		// Build the final URL
-		url, err := builder.Build()
+		// Build the URL
+		url, err := renderHelperURL(group, parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
@@ -1117,7 +1114,7 @@ func urlHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pon
 				"params":     parsedArgs.Params,
 				"query":      parsedArgs.Query,
 			}
-			return formatError("url", "build_error", err.Error(), context, config), nil
+			return formatBuildError("url", err, context, config), nil
 		}
 
 		return pongo2.AsValue(url), nil
@@ -1126,14 +1123,15 @@ func urlHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pon
 
 // routePathHelper returns a template function that generates URL paths (without base URL)
 func routePathHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	groups := NewGroupCache(manager)
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		parsedArgs, err := parseArgs(args...)
+		parsedArgs, err := parseHelperArgs("route_path", args...)
 		if err != nil {
-			return formatError("route_path", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+			return formatArgsError("route_path", err, len(args), config), nil
 		}
 
 		// Get the group safely
-		group := safeGroupAccess(manager, parsedArgs.Group)
+		group := groups.Get(parsedArgs.Group)
 		if group == nil {
 			context := map[string]any{
 				"group_name": parsedArgs.Group,
@@ -1141,59 +1139,177 @@ func routePathHelper(manager *RouteManager, config *TemplateHelperConfig) func(.
 			return formatError("route_path", "group_not_found", fmt.Sprintf("group '%s' not found", parsedArgs.Group), context, config), nil
 		}
 
-		// Build the URL using the fluent API
-		builder := group.Builder(parsedArgs.Route)
-		if builder == nil {
+		// Build the path and query, without scheme and host
+		routePath, err := renderHelperPath(group, parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
+		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
 				"group_name": parsedArgs.Group,
+				"params":     parsedArgs.Params,
+				"query":      parsedArgs.Query,
 			}
-			return formatError("route_path", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", parsedArgs.Route, parsedArgs.Group), context, config), nil
+			return formatBuildError("route_path", err, context, config), nil
 		}
 
-		// Add parameters if provided
-		if len(parsedArgs.Params) > 0 {
-			for key, value := range parsedArgs.Params {
-				builder = builder.WithParam(key, value)
-			}
+		return pongo2.AsValue(routePath), nil
+	}
+}
+
+// assetHelper returns a template function that resolves an asset from a group's
+// asset manifest. With withSRI it returns a map with "url" and "integrity" keys
+// instead of the bare URL:
+//
+//	{{ asset('cdn', 'app.css') }}
+//	{% with a = asset_sri('cdn', 'app.js') %}<script src="{{ a.url }}" integrity="{{ a.integrity }}"></script>{% endwith %}
+func assetHelper(manager *RouteManager, config *TemplateHelperConfig, withSRI bool) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	helperName := "asset"
+	if withSRI {
+		helperName = "asset_sri"
+	}
+
+	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		if err := validatePongoArgs(helperName, args); err != nil {
+			return formatArgsError(helperName, err, len(args), config), nil
 		}
 
-		// Add query parameters if provided
-		if len(parsedArgs.Query) > 0 {
-			for key, value := range parsedArgs.Query {
-				builder = builder.WithQuery(key, value)
-			}
+		groupName := fromPongoValue(args[0]).(string)
+		assetName := fromPongoValue(args[1]).(string)
+
+		group := lookupGroup(manager, groupName)
+		if group == nil {
+			context := map[string]any{"group_name": groupName}
+			return formatError(helperName, "group_not_found", fmt.Sprintf("group '%s' not found", groupName), context, config), nil
 		}
 
-		// Build the full URL, then extract path + query
-		fullURL, err := builder.Build()
+		assetURL, integrity, err := group.AssetWithSRI(assetName)
 		if err != nil {
-			context := map[string]any{
-				"route_name": parsedArgs.Route,
-				"group_name": parsedArgs.Group,
-				"params":     parsedArgs.Params,
-				"query":      parsedArgs.Query,
+			context := map[string]any{"group_name": groupName, "asset": assetName}
+			return formatError(helperName, "asset_not_found", err.Error(), context, config), nil
+		}
+
+		if withSRI {
+			return pongo2.AsValue(map[string]any{"url": assetURL, "integrity": integrity}), nil
+		}
+		return pongo2.AsValue(assetURL), nil
+	}
+}
+
+// srcSetHelper returns a template function that builds responsive image
+// attributes with Group.SrcSet. Widths may be a list or a comma separated
+// string, and sizes a string or a list. It returns a map with "src", "srcset"
+// and "sizes" keys:
+//
+//	{% with img = srcset('cdn', 'product_image', '320,640,1280', {'id': product.id}, '50vw') %}
+//	<img src="{{ img.src }}" srcset="{{ img.srcset }}" sizes="{{ img.sizes }}">
+//	{% endwith %}
+func srcSetHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		if err := validatePongoArgs("srcset", args); err != nil {
+			return formatArgsError("srcset", err, len(args), config), nil
+		}
+
+		groupName := fromPongoValue(args[0]).(string)
+		image := fromPongoValue(args[1]).(string)
+		widths, err := extractWidths(fromPongoValue(args[2]))
+		if err != nil {
+			return formatError("srcset", "parse_error", err.Error(), map[string]any{"widths": args[2].Interface()}, config), nil
+		}
+
+		params := Params{}
+		if len(args) > 3 {
+			if err := mergeParamsInput(params, fromPongoValue(args[3])); err != nil {
+				return formatError("srcset", "parse_error", err.Error(), nil, config), nil
 			}
-			return formatError("route_path", "build_error", err.Error(), context, config), nil
+		}
+		var sizes []string
+		if len(args) > 4 {
+			if sizes, err = extractRouteNames(fromPongoValue(args[4])); err != nil {
+				return formatError("srcset", "parse_error", "sizes must be a string or a list of strings", nil, config), nil
+			}
+		}
+
+		group := lookupGroup(manager, groupName)
+		if group == nil {
+			context := map[string]any{"group_name": groupName}
+			return formatError("srcset", "group_not_found", fmt.Sprintf("group '%s' not found", groupName), context, config), nil
+		}
+
+		set, err := group.SrcSet(image, widths, params, sizes...)
+		if err != nil {
+			context := map[string]any{"group_name": groupName, "image": image}
+			return formatBuildError("srcset", err, context, config), nil
+		}
+		return pongo2.AsValue(map[string]any{"src": set.Src, "srcset": set.SrcSet, "sizes": set.Sizes}), nil
+	}
+}
+
+// urlRegionHelper returns a template function that builds a route on the
+// regional child group selected by Group.ForRegion.
+//
+// Template usage:
+//
+//	{{ url_region('cdn', request.country, 'assets', {'version': 'v1', 'file': 'app.js'}) }}
+func urlRegionHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		if err := validatePongoArgs("url_region", args); err != nil {
+			return formatArgsError("url_region", err, len(args), config), nil
+		}
+		region := fromPongoValue(args[1]).(string)
+		parsedArgs, err := parseArgs(append([]*pongo2.Value{args[0]}, args[2:]...)...)
+		if err != nil {
+			return formatError("url_region", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+		}
+
+		group := lookupGroup(manager, parsedArgs.Group)
+		if group == nil {
+			context := map[string]any{"group_name": parsedArgs.Group}
+			return formatError("url_region", "group_not_found", fmt.Sprintf("group '%s' not found", parsedArgs.Group), context, config), nil
 		}
 
-		parsed, err := url.Parse(fullURL)
+		url, err := group.ForRegion(region).Render(parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
 				"group_name": parsedArgs.Group,
-				"url":        fullURL,
+				"region":     region,
 			}
-			return formatError("route_path", "parse_error", err.Error(), context, config), nil
+			return formatBuildError("url_region", err, context, config), nil
 		}
+		return pongo2.AsValue(url), nil
+	}
+}
 
-		routePath := parsed.Path
-		if parsed.RawQuery != "" {
-			routePath += "?" + parsed.RawQuery
+func extractWidths(value any) ([]int, error) {
+	var items []any
+	switch v := value.(type) {
+	case string:
+		for _, part := range strings.Split(v, ",") {
+			items = append(items, strings.TrimSpace(part))
+		}
+	case []any:
+		items = v
+	case int:
+		items = []any{v}
+	default:
+		return nil, fmt.Errorf("widths must be a list of integers or a comma separated string")
+	}
+
+	widths := make([]int, 0, len(items))
+	for _, item := range items {
+		switch w := item.(type) {
+		case int:
+			widths = append(widths, w)
+		case float64:
+			widths = append(widths, int(w))
+		default:
+			n, err := strconv.Atoi(fmt.Sprint(w))
+			if err != nil {
+				return nil, fmt.Errorf("invalid width %q", fmt.Sprint(w))
+			}
+			widths = append(widths, n)
 		}
-
-		return pongo2.AsValue(routePath), nil
 	}
+	return widths, nil
 }
 
 // hasRouteHelper returns a template function that checks if a route exists
@@ -1214,7 +1330,7 @@ func hasRouteHelper(manager *RouteManager, _ *TemplateHelperConfig) func(...*pon
 		}
 
 		// Check if group exists safely
-		group := safeGroupAccess(manager, groupName)
+		group := lookupGroup(manager, groupName)
 		if group == nil {
 			return pongo2.AsValue(false), nil
 		}
@@ -1231,26 +1347,15 @@ func hasRouteHelper(manager *RouteManager, _ *TemplateHelperConfig) func(...*pon
 // routeTemplateHelper returns a template function that returns the raw route template string
 func routeTemplateHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 2 {
-			return formatError("route_template", "insufficient_args", "at least 2 arguments required: group and route", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("route_template", args); err != nil {
+			return formatArgsError("route_template", err, len(args), config), nil
 		}
 
-		groupVal := fromPongoValue(args[0])
-		routeVal := fromPongoValue(args[1])
-
-		groupName, ok1 := groupVal.(string)
-		routeName, ok2 := routeVal.(string)
-
-		if !ok1 || !ok2 {
-			context := map[string]any{
-				"group_type": fmt.Sprintf("%T", groupVal),
-				"route_type": fmt.Sprintf("%T", routeVal),
-			}
-			return formatError("route_template", "invalid_args", "group and route must be strings", context, config), nil
-		}
+		groupName := fromPongoValue(args[0]).(string)
+		routeName := fromPongoValue(args[1]).(string)
 
 		// Get the group safely
-		group := safeGroupAccess(manager, groupName)
+		group := lookupGroup(manager, groupName)
 		if group == nil {
 			context := map[string]any{
 				"group_name": groupName,
@@ -1275,22 +1380,14 @@ func routeTemplateHelper(manager *RouteManager, config *TemplateHelperConfig) fu
 // routeVarsHelper returns a template function that returns template variables for debugging
 func routeVarsHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 1 {
-			return formatError("route_vars", "insufficient_args", "at least 1 argument required: group", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("route_vars", args); err != nil {
+			return formatArgsError("route_vars", err, len(args), config), nil
 		}
 
-		groupVal := fromPongoValue(args[0])
-		groupName, ok := groupVal.(string)
-
-		if !ok {
-			context := map[string]any{
-				"group_type": fmt.Sprintf("%T", groupVal),
-			}
-			return formatError("route_vars", "invalid_args", "group must be a string", context, config), nil
-		}
+		groupName := fromPongoValue(args[0]).(string)
 
 		// Get the group safely
-		group := safeGroupAccess(manager, groupName)
+		group := lookupGroup(manager, groupName)
 		if group == nil {
 			context := map[string]any{
 				"group_name": groupName,
@@ -1326,7 +1423,7 @@ func routeExistsHelper(manager *RouteManager, _ *TemplateHelperConfig) func(...*
 		}
 
 		// Check if group exists safely
-		group := safeGroupAccess(manager, groupName)
+		group := lookupGroup(manager, groupName)
 		exists := group != nil
 
 		return pongo2.AsValue(exists), nil
@@ -1335,14 +1432,15 @@ func routeExistsHelper(manager *RouteManager, _ *TemplateHelperConfig) func(...*
 
 // urlAbsHelper returns a template function that forces absolute URL generation with base URL
 func urlAbsHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	groups := NewGroupCache(manager)
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		parsedArgs, err := parseArgs(args...)
+		parsedArgs, err := parseHelperArgs("url_abs", args...)
 		if err != nil {
-			return formatError("url_abs", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+			return formatArgsError("url_abs", err, len(args), config), nil
 		}
 
 		// Get the group safely
-		group := safeGroupAccess(manager, parsedArgs.Group)
+		group := groups.Get(parsedArgs.Group)
 		if group == nil {
 			context := map[string]any{
 				"group_name": parsedArgs.Group,
@@ -1350,34 +1448,10 @@ func urlAbsHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*
 			return formatError("url_abs", "group_not_found", fmt.Sprintf("group '%s' not found", parsedArgs.Group), context, config), nil
 		}
 
-		// Build the URL using the fluent API
-		builder := group.Builder(parsedArgs.Route)
-		if builder == nil {
-			context := map[string]any{
-				"route_name": parsedArgs.Route,
-				"group_name": parsedArgs.Group,
-			}
-			return formatError("url_abs", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", parsedArgs.Route, parsedArgs.Group), context, config), nil
-		}
-
-		// Add parameters if provided
-		if len(parsedArgs.Params) > 0 {
-			for key, value := range parsedArgs.Params {
-				builder = builder.WithParam(key, value)
-			}
-		}
-
-		// Add query parameters if provided
-		if len(parsedArgs.Query) > 0 {
-			for key, value := range parsedArgs.Query {
-				builder = builder.WithQuery(key, value)
-			}
-		}
-
 		// Build the final URL - this already includes the base URL by default
 		// The url_abs helper is essentially the same as url helper for now
 		// but it's here for explicit absolute URL generation semantics
-		url, err := builder.Build()
+		url, err := renderHelperURL(group, parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
@@ -1385,7 +1459,7 @@ func urlAbsHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*
 				"params":     parsedArgs.Params,
 				"query":      parsedArgs.Query,
 			}
-			return formatError("url_abs", "build_error", err.Error(), context, config), nil
+			return formatBuildError("url_abs", err, context, config), nil
 		}
 
 		return pongo2.AsValue(url), nil
@@ -1397,25 +1471,14 @@ func urlAbsHelper(manager *RouteManager, config *TemplateHelperConfig) func(...*
 // This helper works with middleware-injected context data passed as template variables
 func currentRouteIfHelper(config *TemplateHelperConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 3 {
-			return formatError("current_route_if", "insufficient_args", "requires targetRoute, currentRoute, valueIfTrue", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("current_route_if", args); err != nil {
+			return formatArgsError("current_route_if", err, len(args), config), nil
 		}
 
-		targetRouteVal := fromPongoValue(args[0])
-		currentRouteVal := fromPongoValue(args[1])
+		targetRoute := fromPongoValue(args[0]).(string)
+		currentRoute := fromPongoValue(args[1]).(string)
 		valueIfTrueVal := fromPongoValue(args[2])
 
-		targetRoute, ok1 := targetRouteVal.(string)
-		currentRoute, ok2 := currentRouteVal.(string)
-
-		if !ok1 || !ok2 {
-			context := map[string]any{
-				"target_route_type":  fmt.Sprintf("%T", targetRouteVal),
-				"current_route_type": fmt.Sprintf("%T", currentRouteVal),
-			}
-			return formatError("current_route_if", "invalid_args", "targetRoute and currentRoute must be strings", context, config), nil
-		}
-
 		var valueIfFalse any = ""
 		if len(args) > 3 {
 			valueIfFalse = fromPongoValue(args[3])
@@ -1432,10 +1495,11 @@ func currentRouteIfHelper(config *TemplateHelperConfig) func(...*pongo2.Value) (
 // urlI18nHelper returns a template function that generates URLs with automatic locale detection from context
 // Template usage: {{ url_i18n('frontend', 'user_profile', {'id': user.id}) }}
 func urlI18nHelper(manager *RouteManager, config *TemplateHelperConfig, localeConfig *LocaleConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	groups := NewGroupCache(manager)
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		parsedArgs, err := parseArgs(args...)
+		parsedArgs, err := parseHelperArgs("url_i18n", args...)
 		if err != nil {
-			return formatError("url_i18n", "parse_error", err.Error(), map[string]any{"args_count": len(args)}, config), nil
+			return formatArgsError("url_i18n", err, len(args), config), nil
 		}
 
 		// Get template context for locale detection (if available)
@@ -1472,11 +1536,11 @@ func urlI18nHelper(manager *RouteManager, config *TemplateHelperConfig, localeCo
 		}
 
 		// Get the group safely
-		group := safeGroupAccess(manager, localizedGroupName)
+		group := groups.Get(localizedGroupName)
 		if group == nil {
 			// If hierarchical locale group doesn't exist, try the original group
 			if localeConfig.EnableHierarchicalLocales {
-				group = safeGroupAccess(manager, parsedArgs.Group)
+				group = groups.Get(parsedArgs.Group)
 			}
 			if group == nil {
 				context := map[string]any{
@@ -1488,33 +1552,8 @@ func urlI18nHelper(manager *RouteManager, config *TemplateHelperConfig, localeCo
 			}
 		}
 
-		// Build URL using standard logic
-		builder := group.Builder(parsedArgs.Route)
-		if builder == nil {
-			context := map[string]any{
-				"route_name": parsedArgs.Route,
-				"group_name": localizedGroupName,
-				"locale":     detectedLocale,
-			}
-			return formatError("url_i18n", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", parsedArgs.Route, localizedGroupName), context, config), nil
-		}
-
-		// Add parameters
-		if len(parsedArgs.Params) > 0 {
-			for key, value := range parsedArgs.Params {
-				builder = builder.WithParam(key, value)
-			}
-		}
-
-		// Add query parameters
-		if len(parsedArgs.Query) > 0 {
-			for key, value := range parsedArgs.Query {
-				builder = builder.WithQuery(key, value)
-			}
-		}
-
-		// Build the final URL
-		url, err := builder.Build()
+		// Build the URL
+		url, err := renderHelperURL(group, parsedArgs.Route, parsedArgs.Params, parsedArgs.Query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": parsedArgs.Route,
@@ -1523,7 +1562,7 @@ func urlI18nHelper(manager *RouteManager, config *TemplateHelperConfig, localeCo
 				"params":     parsedArgs.Params,
 				"query":      parsedArgs.Query,
 			}
-			return formatError("url_i18n", "build_error", err.Error(), context, config), nil
+			return formatBuildError("url_i18n", err, context, config), nil
 		}
 
 		return pongo2.AsValue(url), nil
@@ -1533,28 +1572,16 @@ func urlI18nHelper(manager *RouteManager, config *TemplateHelperConfig, localeCo
 // urlLocaleHelper returns a template function that generates URLs for a specific locale
 // Template usage: {{ url_locale('frontend', 'about', 'es', {'id': 1}) }}
 func urlLocaleHelper(manager *RouteManager, config *TemplateHelperConfig, localeConfig *LocaleConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	groups := NewGroupCache(manager)
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 3 {
-			return formatError("url_locale", "insufficient_args", "requires group, route, and locale", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("url_locale", args); err != nil {
+			return formatArgsError("url_locale", err, len(args), config), nil
 		}
 
 		// Parse basic arguments
-		groupVal := fromPongoValue(args[0])
-		routeVal := fromPongoValue(args[1])
-		localeVal := fromPongoValue(args[2])
-
-		groupName, ok1 := groupVal.(string)
-		routeName, ok2 := routeVal.(string)
-		locale, ok3 := localeVal.(string)
-
-		if !ok1 || !ok2 || !ok3 {
-			context := map[string]any{
-				"group_type":  fmt.Sprintf("%T", groupVal),
-				"route_type":  fmt.Sprintf("%T", routeVal),
-				"locale_type": fmt.Sprintf("%T", localeVal),
-			}
-			return formatError("url_locale", "invalid_args", "group, route, and locale must be strings", context, config), nil
-		}
+		groupName := fromPongoValue(args[0]).(string)
+		routeName := fromPongoValue(args[1]).(string)
+		locale := fromPongoValue(args[2]).(string)
 
 		// Parse optional params and query arguments (args[3] and args[4])
 		params := make(map[string]any)
@@ -1611,11 +1638,11 @@ func urlLocaleHelper(manager *RouteManager, config *TemplateHelperConfig, locale
 		}
 
 		// Get the group safely
-		group := safeGroupAccess(manager, localizedGroupName)
+		group := groups.Get(localizedGroupName)
 		if group == nil {
 			// If hierarchical locale group doesn't exist, try the original group
 			if localeConfig.EnableHierarchicalLocales {
-				group = safeGroupAccess(manager, groupName)
+				group = groups.Get(groupName)
 			}
 			if group == nil {
 				context := map[string]any{
@@ -1627,33 +1654,8 @@ func urlLocaleHelper(manager *RouteManager, config *TemplateHelperConfig, locale
 			}
 		}
 
-		// Build URL using standard logic
-		builder := group.Builder(routeName)
-		if builder == nil {
-			context := map[string]any{
-				"route_name": routeName,
-				"group_name": localizedGroupName,
-				"locale":     locale,
-			}
-			return formatError("url_locale", "route_not_found", fmt.Sprintf("route '%s' not found in group '%s'", routeName, localizedGroupName), context, config), nil
-		}
-
-		// Add parameters
-		if len(params) > 0 {
-			for key, value := range params {
-				builder = builder.WithParam(key, value)
-			}
-		}
-
-		// Add query parameters
-		if len(query) > 0 {
-			for key, value := range query {
-				builder = builder.WithQuery(key, value)
-			}
-		}
-
-		// Build the final URL
-		url, err := builder.Build()
+		// Build the URL
+		url, err := renderHelperURL(group, routeName, params, query)
 		if err != nil {
 			context := map[string]any{
 				"route_name": routeName,
@@ -1662,7 +1664,7 @@ func urlLocaleHelper(manager *RouteManager, config *TemplateHelperConfig, locale
 				"params":     params,
 				"query":      query,
 			}
-			return formatError("url_locale", "build_error", err.Error(), context, config), nil
+			return formatBuildError("url_locale", err, context, config), nil
 		}
 
 		return pongo2.AsValue(url), nil
@@ -1673,25 +1675,15 @@ func urlLocaleHelper(manager *RouteManager, config *TemplateHelperConfig, locale
 // Template usage: {{ url_all_locales('frontend', 'about', {'id': 1}) }}
 // Returns array of LocaleInfo objects with locale and url fields
 func urlAllLocalesHelper(manager *RouteManager, config *TemplateHelperConfig, localeConfig *LocaleConfig) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+	groups := NewGroupCache(manager)
 	return func(args ...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
-		if len(args) < 2 {
-			return formatError("url_all_locales", "insufficient_args", "requires group and route", map[string]any{"args_count": len(args)}, config), nil
+		if err := validatePongoArgs("url_all_locales", args); err != nil {
+			return formatArgsError("url_all_locales", err, len(args), config), nil
 		}
 
 		// Parse basic arguments
-		groupVal := fromPongoValue(args[0])
-		routeVal := fromPongoValue(args[1])
-
-		groupName, ok1 := groupVal.(string)
-		routeName, ok2 := routeVal.(string)
-
-		if !ok1 || !ok2 {
-			context := map[string]any{
-				"group_type": fmt.Sprintf("%T", groupVal),
-				"route_type": fmt.Sprintf("%T", routeVal),
-			}
-			return formatError("url_all_locales", "invalid_args", "group and route must be strings", context, config), nil
-		}
+		groupName := fromPongoValue(args[0]).(string)
+		routeName := fromPongoValue(args[1]).(string)
 
 		// Parse optional params and query arguments
 		params := make(map[string]any)
@@ -1729,34 +1721,18 @@ func urlAllLocalesHelper(manager *RouteManager, config *TemplateHelperConfig, lo
 			}
 
 			// Get the group safely
-			group := safeGroupAccess(manager, localizedGroupName)
+			group := groups.Get(localizedGroupName)
 			if group == nil && localeConfig.EnableHierarchicalLocales {
 				// If hierarchical locale group doesn't exist, try the original group
-				group = safeGroupAccess(manager, groupName)
+				group = groups.Get(groupName)
 			}
 
 			if group == nil {
 				continue // Skip this locale if group doesn't exist
 			}
 
-			// Build URL
-			builder := group.Builder(routeName)
-			if builder == nil {
-				continue // Skip this locale if route doesn't exist
-			}
-
-			// Add parameters
-			for key, value := range params {
-				builder = builder.WithParam(key, value)
-			}
-
-			// Add query parameters
-			for key, value := range query {
-				builder = builder.WithQuery(key, value)
-			}
-
-			// Build URL
-			url, err := builder.Build()
+			// Build the URL
+			url, err := renderHelperURL(group, routeName, params, query)
 			if err != nil {
 				continue // Skip this locale if URL building fails
 			}
@@ -510,3 +510,86 @@ func BenchmarkComplexScenarios(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkNavigationHelpers renders a realistic page chrome per iteration: a
+// header of url links, localized footer links and a language switcher. The
+// numbers are published in README.md under "Template Helper Performance".
+func BenchmarkNavigationHelpers(b *testing.B) {
+	manager := setupBenchmarkManager()
+	for _, locale := range []string{"es", "fr"} {
+		manager.Group("frontend").RegisterGroup(locale, "/"+locale, map[string]string{
+			"home":         "/",
+			"about":        "/about",
+			"contact":      "/contact",
+			"user_profile": "/users/:id/profile",
+			"product":      "/products/:slug",
+		})
+	}
+	localeConfig := &LocaleConfig{
+		DefaultLocale:             "en",
+		SupportedLocales:          []string{"en", "es", "fr"},
+		LocaleGroups:              map[string][]string{"frontend": {"en", "es", "fr"}},
+		EnableLocaleFallback:      true,
+		EnableHierarchicalLocales: true,
+		EnableLocaleValidation:    true,
+		DetectionStrategies:       []LocaleDetectionStrategy{LocaleFromContext},
+	}
+	helpers := TemplateHelpersWithLocale(manager, DefaultTemplateHelperConfig(), localeConfig)
+	call := func(name string) func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error) {
+		return helpers[name].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+	}
+
+	frontend := pongo2.AsValue("frontend")
+	params := pongo2.AsValue(map[string]any{"id": 123, "slug": "blue-shirt"})
+	query := pongo2.AsValue(map[string]any{"ref": "nav"})
+	context := pongo2.AsValue(map[string]any{"locale": "es"})
+	routes := []*pongo2.Value{
+		pongo2.AsValue("home"), pongo2.AsValue("about"), pongo2.AsValue("contact"),
+		pongo2.AsValue("user_profile"), pongo2.AsValue("product"),
+	}
+
+	render := map[string]func() *pongo2.Error{
+		"url": func() *pongo2.Error {
+			for _, route := range routes {
+				if _, err := call("url")(frontend, route, params, query); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"url_i18n": func() *pongo2.Error {
+			for _, route := range routes {
+				if _, err := call("url_i18n")(frontend, route, params, query, context); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"url_all_locales": func() *pongo2.Error {
+			_, err := call("url_all_locales")(frontend, routes[0])
+			return err
+		},
+	}
+
+	for _, name := range []string{"url", "url_i18n", "url_all_locales"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := render[name](); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	b.Run("page", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, name := range []string{"url", "url_i18n", "url_all_locales"} {
+				if err := render[name](); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -2385,3 +2385,33 @@ func TestMockMiddlewareContextData(t *testing.T) {
 		})
 	}
 }
+
+// TestTemplateHelperAllocationBudget guards the per-call allocation budget of
+// the URL helpers measured by BenchmarkNavigationHelpers.
+func TestTemplateHelperAllocationBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budget skipped in short mode")
+	}
+
+	manager := setupBenchmarkManager()
+	helpers := TemplateHelpersWithLocale(manager, DefaultTemplateHelperConfig(), nil)
+	args := []*pongo2.Value{
+		pongo2.AsValue("frontend"),
+		pongo2.AsValue("user_profile"),
+		pongo2.AsValue(map[string]any{"id": 123}),
+		pongo2.AsValue(map[string]any{"ref": "nav"}),
+	}
+
+	budgets := map[string]float64{"url": 40, "url_i18n": 40, "url_all_locales": 44}
+	for name, budget := range budgets {
+		helper := helpers[name].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := helper(args...); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs > budget {
+			t.Errorf("%s: %.0f allocs per call, budget is %.0f", name, allocs, budget)
+		}
+	}
+}
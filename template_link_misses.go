@@ -0,0 +1,122 @@
+package urlkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// LinkMiss records a group or route a template helper could not resolve.
+type LinkMiss struct {
+	Helper string `json:"helper"`
+	Type   string `json:"type"` // "group_not_found" or "route_not_found"
+	Group  string `json:"group"`
+	Route  string `json:"route,omitempty"`
+	Count  int    `json:"count"`
+}
+
+// LinkMissCollector aggregates the group and route misses of template helpers
+// so a render pass can report them in one log entry instead of one per link.
+// Set it on TemplateHelperConfig.MissCollector; every helper built from that
+// config shares it, so use a collector (and helper set) per render for
+// per-request reports. Repeated misses are counted, not duplicated.
+//
+// Example:
+//
+//	misses := urlkit.NewLinkMissCollector()
+//	helpers := urlkit.TemplateHelpers(manager, &urlkit.TemplateHelperConfig{MissCollector: misses})
+//	// render templates with helpers...
+//	if err := misses.Flush(); err != nil {
+//		logger.Warn(err.Error())
+//	}
+type LinkMissCollector struct {
+	mu     sync.Mutex
+	misses []LinkMiss
+}
+
+// NewLinkMissCollector returns an empty collector.
+func NewLinkMissCollector() *LinkMissCollector {
+	return &LinkMissCollector{}
+}
+
+// Misses returns the misses recorded so far, in first-seen order.
+func (c *LinkMissCollector) Misses() []LinkMiss {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]LinkMiss(nil), c.misses...)
+}
+
+// Flush clears the collector and returns the recorded misses as a single
+// *LinkMissError, or nil when there were none.
+func (c *LinkMissCollector) Flush() error {
+	c.mu.Lock()
+	misses := c.misses
+	c.misses = nil
+	c.mu.Unlock()
+
+	if len(misses) == 0 {
+		return nil
+	}
+	return &LinkMissError{Misses: misses}
+}
+
+func (c *LinkMissCollector) record(miss LinkMiss) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.misses {
+		existing := &c.misses[i]
+		if existing.Helper == miss.Helper && existing.Type == miss.Type && existing.Group == miss.Group && existing.Route == miss.Route {
+			existing.Count++
+			return
+		}
+	}
+	miss.Count = 1
+	c.misses = append(c.misses, miss)
+}
+
+// LinkMissError is the consolidated report returned by LinkMissCollector.Flush.
+type LinkMissError struct {
+	Misses []LinkMiss
+}
+
+func (e *LinkMissError) Error() string {
+	entries := make([]string, 0, len(e.Misses))
+	for _, miss := range e.Misses {
+		target := miss.Group
+		if miss.Route != "" {
+			target += "." + miss.Route
+		}
+		entry := fmt.Sprintf("%s %s (%s)", miss.Helper, target, miss.Type)
+		if miss.Count > 1 {
+			entry += fmt.Sprintf(" x%d", miss.Count)
+		}
+		entries = append(entries, entry)
+	}
+	return fmt.Sprintf("template link misses: %d unresolved: %s", len(e.Misses), strings.Join(entries, "; "))
+}
+
+// recordLinkMiss reports a group_not_found or route_not_found helper error to
+// the configured collector, reading the group and route from the error context.
+func recordLinkMiss(helper, errorType string, context map[string]any, config *TemplateHelperConfig) {
+	if config.MissCollector == nil || (errorType != "group_not_found" && errorType != "route_not_found") {
+		return
+	}
+	groupName, _ := context["group_name"].(string)
+	miss := LinkMiss{Helper: helper, Type: errorType, Group: groupName}
+	if errorType == "route_not_found" {
+		miss.Route, _ = context["route_name"].(string)
+	}
+	config.MissCollector.record(miss)
+}
+
+// formatBuildError formats a helper build error, recording it as a route miss
+// when the route was not registered in the group.
+func formatBuildError(helper string, err error, context map[string]any, config *TemplateHelperConfig) *pongo2.Value {
+	if errors.Is(err, ErrRouteNotFound) {
+		recordLinkMiss(helper, "route_not_found", context, config)
+	}
+	return formatError(helper, "build_error", err.Error(), context, config)
+}
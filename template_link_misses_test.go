@@ -0,0 +1,65 @@
+package urlkit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestLinkMissCollector(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	manager.RegisterGroup("frontend", "https://example.com", map[string]string{"home": "/"})
+
+	misses := urlkit.NewLinkMissCollector()
+	helpers := urlkit.TemplateHelpers(manager, &urlkit.TemplateHelperConfig{MissCollector: misses})
+
+	call := func(name string, args ...any) string {
+		values := make([]*pongo2.Value, len(args))
+		for i, arg := range args {
+			values[i] = pongo2.AsValue(arg)
+		}
+		result, _ := helpers[name].(func(...*pongo2.Value) (*pongo2.Value, *pongo2.Error))(values...)
+		return result.String()
+	}
+
+	if got := call("url", "frontend", "home"); got != "https://example.com/" {
+		t.Fatalf("unexpected url %q", got)
+	}
+	if got := call("url", "frontend", "missing"); !strings.HasPrefix(got, "#error:url:build_error:") {
+		t.Fatalf("unexpected url %q", got)
+	}
+	call("url", "blog", "index")
+	call("route_template", "blog", "index")
+	call("url", "blog", "index")
+	call("route_exists", "blog")
+
+	want := []urlkit.LinkMiss{
+		{Helper: "url", Type: "route_not_found", Group: "frontend", Route: "missing", Count: 1},
+		{Helper: "url", Type: "group_not_found", Group: "blog", Count: 2},
+		{Helper: "route_template", Type: "group_not_found", Group: "blog", Count: 1},
+	}
+	got := misses.Misses()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d misses, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("miss %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	err := misses.Flush()
+	var missErr *urlkit.LinkMissError
+	if !errors.As(err, &missErr) || len(missErr.Misses) != 3 {
+		t.Fatalf("expected a consolidated LinkMissError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "url blog (group_not_found) x2") {
+		t.Fatalf("unexpected report %q", err.Error())
+	}
+	if err := misses.Flush(); err != nil {
+		t.Fatalf("expected Flush to reset the collector, got %v", err)
+	}
+}
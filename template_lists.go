@@ -0,0 +1,117 @@
+package urlkit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// TemplateJoinPath joins list values as path segments ("docs/v2/intro").
+	TemplateJoinPath = "/"
+	// TemplateJoinDomain joins list values as domain labels ("eu.cdn").
+	TemplateJoinDomain = "."
+)
+
+// TemplateList is a multi-value template variable. Each value is escaped on
+// its own with the manager's TemplateEscapeMode and the results are joined
+// with Join, so hierarchical values such as path segments or domain labels
+// render without callers pre-joining strings or escaping the separators.
+type TemplateList struct {
+	Values []string `json:"values" yaml:"values"`
+	// Join is the separator placed between values. Defaults to
+	// TemplateJoinPath.
+	Join string `json:"join,omitempty" yaml:"join,omitempty"`
+}
+
+func (l TemplateList) join(mode TemplateEscapeMode) string {
+	escaped := make([]string, len(l.Values))
+	for i, value := range l.Values {
+		escaped[i] = EscapeTemplateValue(value, mode)
+	}
+	return strings.Join(escaped, l.Join)
+}
+
+// SetTemplateList sets a multi-value template variable. It follows the same
+// inheritance as SetTemplateVar, and either call replaces a variable set by
+// the other. Values must be non-empty.
+//
+// Example:
+//
+//	docs.SetURLTemplate("https://{labels}.example.com/{segments}{route_path}")
+//	docs.SetTemplateList("labels", urlkit.TemplateList{Values: []string{"eu", "docs"}, Join: urlkit.TemplateJoinDomain})
+//	docs.SetTemplateList("segments", urlkit.TemplateList{Values: []string{"guides", "v2"}})
+//	// https://eu.docs.example.com/guides/v2/intro
+func (u *Group) SetTemplateList(key string, list TemplateList) error {
+	if list.Join == "" {
+		list.Join = TemplateJoinPath
+	}
+	for i, value := range list.Values {
+		if value == "" {
+			return fmt.Errorf("template list %q: value %d is empty", key, i)
+		}
+	}
+	list.Values = slices.Clone(list.Values)
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set template list", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
+	defer releaseMutation()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.templateVars[key] = list.join(TemplateEscapeRaw)
+	if u.templateLists == nil {
+		u.templateLists = make(map[string]TemplateList)
+	}
+	u.templateLists[key] = list
+	return nil
+}
+
+// TemplateList returns the multi-value variable set on this group only.
+func (u *Group) TemplateList(key string) (TemplateList, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	list, ok := u.templateLists[key]
+	list.Values = slices.Clone(list.Values)
+	return list, ok
+}
+
+// collectTemplateLists returns the list variables in effect for this group,
+// applying the precedence of CollectTemplateVars: a plain variable (or profile
+// variable) set closer to the group hides a list of the same name.
+func (u *Group) collectTemplateLists() map[string]TemplateList {
+	var chain []*Group
+	for current := u; current != nil; {
+		current.mu.RLock()
+		parent := current.parent
+		current.mu.RUnlock()
+
+		chain = append(chain, current)
+		current = parent
+	}
+
+	profile := u.runtime.activeProfile()
+
+	lists := map[string]TemplateList{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		chain[i].mu.RLock()
+		for key := range chain[i].templateVars {
+			if list, ok := chain[i].templateLists[key]; ok {
+				lists[key] = list
+			} else {
+				delete(lists, key)
+			}
+		}
+		if profile != "" {
+			for key := range chain[i].profiles[profile] {
+				delete(lists, key)
+			}
+		}
+		chain[i].mu.RUnlock()
+	}
+	return lists
+}
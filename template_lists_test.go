@@ -0,0 +1,55 @@
+package urlkit_test
+
+import (
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestTemplateLists(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:        "docs",
+				BaseURL:     "https://example.com",
+				URLTemplate: "https://{labels}.example.com/{segments}{route_path}",
+				TemplateLists: map[string]urlkit.TemplateList{
+					"labels":   {Values: []string{"eu", "docs"}, Join: urlkit.TemplateJoinDomain},
+					"segments": {Values: []string{"guides", "a b/c"}},
+				},
+				Routes: map[string]string{"page": "/:slug"},
+				Groups: []urlkit.GroupConfig{
+					{Name: "flat", TemplateVars: map[string]string{"segments": "all"}, Routes: map[string]string{"page": "/:slug"}},
+				},
+			},
+		},
+	}, urlkit.WithTemplateEscaping(urlkit.TemplateEscapePath))
+
+	docs := manager.Group("docs")
+	url, err := docs.Render("page", urlkit.Params{"slug": "intro"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if want := "https://eu.docs.example.com/guides/a%20b%2Fc/intro/"; url != want {
+		t.Fatalf("expected %q, got %q", want, url)
+	}
+
+	url, err = manager.Group("docs.flat").Render("page", urlkit.Params{"slug": "intro"})
+	if err != nil || url != "https://eu.docs.example.com/all/intro/" {
+		t.Fatalf("expected child var to override the list, got %q, %v", url, err)
+	}
+
+	if err := docs.SetTemplateVar("labels", "www"); err != nil {
+		t.Fatalf("SetTemplateVar failed: %v", err)
+	}
+	if _, ok := docs.TemplateList("labels"); ok {
+		t.Fatal("expected SetTemplateVar to replace the list")
+	}
+	if url, _ := docs.Render("page", urlkit.Params{"slug": "intro"}); url != "https://www.example.com/guides/a%20b%2Fc/intro/" {
+		t.Fatalf("unexpected URL after replacing list: %q", url)
+	}
+
+	if err := docs.SetTemplateList("segments", urlkit.TemplateList{Values: []string{"guides", ""}}); err == nil {
+		t.Fatal("expected error for an empty value")
+	}
+}
@@ -0,0 +1,150 @@
+package urlkit
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RouteKey is implemented by the per-group route key types written by
+// WriteRouteKeys. Each type knows its group, so a key from one group cannot be
+// passed where another group's key is expected.
+type RouteKey interface {
+	~string
+	GroupPath() string
+}
+
+// TypedGroup is a Group facade that only accepts route keys of type K.
+type TypedGroup[K RouteKey] struct {
+	group *Group
+}
+
+// Typed returns the facade for the group that K belongs to.
+//
+// Example (with keys generated by WriteRouteKeys):
+//
+//	frontend, err := urlkit.Typed[routes.FrontendRoute](manager)
+//	url, err := frontend.Render(routes.FrontendUser, urlkit.Params{"id": 7})
+//	frontend.Render(routes.APIStatus, nil) // compile error
+func Typed[K RouteKey](m *RouteManager) (*TypedGroup[K], error) {
+	var key K
+	group, err := m.GetGroup(key.GroupPath())
+	if err != nil {
+		return nil, err
+	}
+	return &TypedGroup[K]{group: group}, nil
+}
+
+// Group returns the underlying group.
+func (g *TypedGroup[K]) Group() *Group {
+	return g.group
+}
+
+// Render builds the URL for route.
+func (g *TypedGroup[K]) Render(route K, params Params, queries ...Query) (string, error) {
+	return g.group.Render(string(route), params, queries...)
+}
+
+// Builder returns a URL builder for route.
+func (g *TypedGroup[K]) Builder(route K) *Builder {
+	return g.group.Builder(string(route))
+}
+
+// RenderRoute builds the URL for a typed route key, resolving its group from
+// the key type.
+func RenderRoute[K RouteKey](m *RouteManager, route K, params Params, queries ...Query) (string, error) {
+	group, err := m.GetGroup(route.GroupPath())
+	if err != nil {
+		return "", err
+	}
+	return group.Render(string(route), params, queries...)
+}
+
+// WriteRouteKeys writes a gofmt'd Go file declaring a route key type per
+// group (e.g. "admin.api" becomes AdminAPIRoute) with a constant per route
+// (AdminAPIPreview), for use with Typed and RenderRoute. Run it from a
+// go:generate program after loading the config, and commit the output.
+// Names that map to the same identifier (e.g. routes "admin.api_preview" and
+// "admin_api.preview", or a route called "route" next to its group's type)
+// return an error instead of writing code that does not compile.
+func WriteRouteKeys(w io.Writer, pkg string, m *RouteManager) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by urlkit. DO NOT EDIT.\n\npackage %s\n", pkg)
+
+	declared := map[string]string{}
+	declare := func(identifier, source string) error {
+		if previous, ok := declared[identifier]; ok {
+			return fmt.Errorf("route keys: %s and %s both generate identifier %s", previous, source, identifier)
+		}
+		declared[identifier] = source
+		return nil
+	}
+
+	entries := m.Manifest()
+	for start := 0; start < len(entries); {
+		fqn := entries[start].GroupFQN
+		end := start
+		for end < len(entries) && entries[end].GroupFQN == fqn {
+			end++
+		}
+
+		prefix := goIdentifier(fqn)
+		typeName := prefix + "Route"
+		if err := declare(typeName, fmt.Sprintf("group %q", fqn)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "\n// %s is a route key of group %q.\ntype %s string\n\n", typeName, fqn, typeName)
+		fmt.Fprintf(&buf, "// GroupPath implements urlkit.RouteKey.\nfunc (%s) GroupPath() string { return %s }\n\nconst (\n", typeName, strconv.Quote(fqn))
+		for _, entry := range entries[start:end] {
+			identifier := prefix + goIdentifier(entry.RouteKey)
+			if err := declare(identifier, fmt.Sprintf("route %q", fqn+"."+entry.RouteKey)); err != nil {
+				return err
+			}
+			fmt.Fprintf(&buf, "%s %s = %s\n", identifier, typeName, strconv.Quote(entry.RouteKey))
+		}
+		buf.WriteString(")\n")
+		start = end
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format route keys: %w", err)
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+// commonInitialisms are upper-cased in generated identifiers, following Go
+// naming conventions.
+var commonInitialisms = map[string]bool{
+	"API": true, "CDN": true, "CSS": true, "HTML": true, "HTTP": true, "ID": true,
+	"JS": true, "JSON": true, "SSO": true, "UI": true, "URL": true, "UUID": true,
+}
+
+// goIdentifier converts a name such as "user_profile" or "admin.api" to an
+// exported Go identifier ("UserProfile", "AdminAPI").
+func goIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var out strings.Builder
+	for _, part := range parts {
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			out.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		out.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+
+	identifier := out.String()
+	if identifier == "" || !unicode.IsLetter([]rune(identifier)[0]) {
+		identifier = "R" + identifier
+	}
+	return identifier
+}
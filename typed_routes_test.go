@@ -0,0 +1,95 @@
+package urlkit_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// Keys as written by WriteRouteKeys.
+type frontendRoute string
+
+func (frontendRoute) GroupPath() string { return "frontend" }
+
+const frontendUser frontendRoute = "user"
+
+type adminAPIRoute string
+
+func (adminAPIRoute) GroupPath() string { return "admin.api" }
+
+const adminAPIStatus adminAPIRoute = "status"
+
+func typedRoutesManager(t *testing.T) *urlkit.RouteManager {
+	return mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{Name: "frontend", BaseURL: "https://example.com", Routes: map[string]string{"user": "/users/:id", "user_profile": "/users/:id/profile"}},
+			{Name: "admin", BaseURL: "https://admin.example.com", Groups: []urlkit.GroupConfig{
+				{Name: "api", Path: "/api", Routes: map[string]string{"status": "/status"}},
+			}},
+		},
+	})
+}
+
+func TestTypedRouteKeys(t *testing.T) {
+	manager := typedRoutesManager(t)
+
+	frontend, err := urlkit.Typed[frontendRoute](manager)
+	if err != nil {
+		t.Fatalf("Typed failed: %v", err)
+	}
+	if got, _ := frontend.Render(frontendUser, urlkit.Params{"id": 7}); got != "https://example.com/users/7" {
+		t.Fatalf("unexpected url %s", got)
+	}
+	if got, _ := urlkit.RenderRoute(manager, adminAPIStatus, nil, urlkit.Query{"v": "1"}); got != "https://admin.example.com/api/status?v=1" {
+		t.Fatalf("unexpected url %s", got)
+	}
+}
+
+func TestWriteRouteKeys(t *testing.T) {
+	var buf bytes.Buffer
+	if err := urlkit.WriteRouteKeys(&buf, "routes", typedRoutesManager(t)); err != nil {
+		t.Fatalf("WriteRouteKeys failed: %v", err)
+	}
+	source := buf.String()
+	for _, want := range []string{
+		"// Code generated by urlkit. DO NOT EDIT.",
+		"package routes",
+		"type AdminAPIRoute string",
+		`func (AdminAPIRoute) GroupPath() string { return "admin.api" }`,
+		`AdminAPIStatus AdminAPIRoute = "status"`,
+		`FrontendUserProfile FrontendRoute = "user_profile"`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("expected generated source to contain %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestWriteRouteKeysCollisions(t *testing.T) {
+	for name, groups := range map[string][]urlkit.GroupConfig{
+		"routes across groups": {
+			{Name: "admin", BaseURL: "https://example.com", Groups: []urlkit.GroupConfig{
+				{Name: "api", Routes: map[string]string{"preview": "/preview"}},
+			}, Routes: map[string]string{"api_preview": "/api-preview"}},
+		},
+		"group types": {
+			{Name: "admin", BaseURL: "https://example.com", Groups: []urlkit.GroupConfig{
+				{Name: "api", Routes: map[string]string{"home": "/"}},
+			}},
+			{Name: "admin_api", BaseURL: "https://api.example.com", Routes: map[string]string{"home": "/"}},
+		},
+		"route named route": {
+			{Name: "admin", BaseURL: "https://example.com", Routes: map[string]string{"route": "/route"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			manager := mustManagerFromConfig(t, urlkit.Config{Groups: groups})
+			if err := urlkit.WriteRouteKeys(io.Discard, "routes", manager); err == nil || !strings.Contains(err.Error(), "both generate identifier") {
+				t.Fatalf("expected a collision error, got %v", err)
+			}
+		})
+	}
+}
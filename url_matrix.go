@@ -0,0 +1,195 @@
+package urlkit
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultMatrixLimit caps the URLs URLMatrix generates per route when
+// MatrixOptions.Limit is zero.
+const DefaultMatrixLimit = 100
+
+// MatrixSampling selects which combinations URLMatrix keeps when a route has
+// more than MatrixOptions.Limit of them.
+type MatrixSampling string
+
+const (
+	// MatrixSampleFirst keeps the first combinations in enumeration order.
+	MatrixSampleFirst MatrixSampling = "first"
+	// MatrixSampleEven keeps combinations spread evenly across the product.
+	MatrixSampleEven MatrixSampling = "even"
+	// MatrixSampleRandom keeps a random subset chosen with MatrixOptions.Seed,
+	// so runs are reproducible.
+	MatrixSampleRandom MatrixSampling = "random"
+)
+
+// MatrixOptions configures RouteManager.URLMatrix.
+type MatrixOptions struct {
+	// Routes selects routes by fully qualified name ("group.route"). Empty
+	// selects every GET route that is not hidden.
+	Routes []string
+	// Values provides the candidate values of each param, keyed by param name.
+	// Params without values use a single example value derived from the
+	// route's declared param type (see Group.ExampleParams).
+	Values map[string][]any
+	// Limit caps the URLs generated per route. Defaults to DefaultMatrixLimit.
+	Limit int
+	// Sampling picks the combinations kept when a route exceeds Limit.
+	// Defaults to MatrixSampleFirst.
+	Sampling MatrixSampling
+	// Seed seeds MatrixSampleRandom.
+	Seed uint64
+}
+
+// MatrixURL is one entry of a URL matrix.
+type MatrixURL struct {
+	Route  string `json:"route"`
+	Params Params `json:"params,omitempty"`
+	URL    string `json:"url"`
+	// Total is the size of the route's full Cartesian product, which is
+	// larger than the number of its entries when the route was sampled.
+	Total uint64 `json:"total"`
+}
+
+// URLMatrix enumerates the Cartesian product of param values for the selected
+// routes, for screenshot and visual diff pipelines. Combinations follow the
+// order of the route's params and of their values; routes whose product
+// exceeds opts.Limit are sampled with opts.Sampling.
+//
+// Example:
+//
+//	urls, err := manager.URLMatrix(urlkit.MatrixOptions{
+//		Routes:   []string{"frontend.product"},
+//		Values:   map[string][]any{"locale": {"en", "es"}, "slug": {"shoe", "hat"}},
+//		Limit:    50,
+//		Sampling: urlkit.MatrixSampleEven,
+//	})
+func (m *RouteManager) URLMatrix(opts MatrixOptions) ([]MatrixURL, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultMatrixLimit
+	}
+	switch opts.Sampling {
+	case "", MatrixSampleFirst, MatrixSampleEven, MatrixSampleRandom:
+	default:
+		return nil, fmt.Errorf("url matrix: unsupported sampling %q", opts.Sampling)
+	}
+
+	routes := opts.Routes
+	if len(routes) == 0 {
+		routes = m.matrixRoutes()
+	}
+
+	var rng *rand.Rand
+	if opts.Sampling == MatrixSampleRandom {
+		rng = rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	}
+
+	var matrix []MatrixURL
+	for _, fqn := range routes {
+		groupPath, route, err := splitRouteFQN(fqn)
+		if err != nil {
+			return nil, err
+		}
+		group, err := m.GetGroup(groupPath)
+		if err != nil {
+			return nil, err
+		}
+		template, err := group.Route(route)
+		if err != nil {
+			return nil, err
+		}
+
+		names, _ := routeParamNames(template)
+		examples := group.exampleParams(template, route, nil)
+		axes := make([][]any, len(names))
+		total := uint64(1)
+		for i, name := range names {
+			axes[i] = opts.Values[name]
+			if len(axes[i]) == 0 {
+				axes[i] = []any{examples[name]}
+			}
+			total = saturatingMul(total, uint64(len(axes[i])))
+		}
+
+		for _, index := range sampleIndexes(total, limit, opts.Sampling, rng) {
+			params := make(Params, len(names))
+			for i := len(names) - 1; i >= 0; i-- {
+				size := uint64(len(axes[i]))
+				params[names[i]] = axes[i][index%size]
+				index /= size
+			}
+
+			built, err := group.Render(route, params)
+			if err != nil {
+				return nil, fmt.Errorf("url matrix %s: %w", fqn, err)
+			}
+			matrix = append(matrix, MatrixURL{Route: fqn, Params: params, URL: built, Total: total})
+		}
+	}
+	return matrix, nil
+}
+
+// matrixRoutes lists the fully qualified names of every visible GET route.
+func (m *RouteManager) matrixRoutes() []string {
+	var routes []string
+	for _, entry := range m.Manifest() {
+		group, err := m.GetGroup(entry.GroupFQN)
+		if err != nil {
+			continue
+		}
+		if meta, ok := group.RouteMetadata(entry.RouteKey); ok {
+			if meta.Hidden || (meta.Method != "" && !strings.EqualFold(meta.Method, http.MethodGet)) {
+				continue
+			}
+		}
+		routes = append(routes, entry.GroupFQN+"."+entry.RouteKey)
+	}
+	return routes
+}
+
+// sampleIndexes returns at most limit combination indexes out of total, in
+// ascending order.
+func sampleIndexes(total uint64, limit int, sampling MatrixSampling, rng *rand.Rand) []uint64 {
+	count := uint64(limit)
+	if total <= count {
+		count = total
+		sampling = MatrixSampleFirst
+	}
+
+	indexes := make([]uint64, 0, count)
+	switch sampling {
+	case MatrixSampleEven:
+		step := float64(total) / float64(count)
+		for i := range count {
+			indexes = append(indexes, uint64(float64(i)*step))
+		}
+	case MatrixSampleRandom:
+		seen := make(map[uint64]bool, count)
+		for uint64(len(indexes)) < count {
+			index := rng.Uint64N(total)
+			if !seen[index] {
+				seen[index] = true
+				indexes = append(indexes, index)
+			}
+		}
+		slices.Sort(indexes)
+	default:
+		for i := range count {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// saturatingMul multiplies a and b, clamping at math.MaxUint64.
+func saturatingMul(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
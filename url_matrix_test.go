@@ -0,0 +1,116 @@
+package urlkit_test
+
+import (
+	"reflect"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func newMatrixManager(t *testing.T) *urlkit.RouteManager {
+	t.Helper()
+	return mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "shop",
+				BaseURL: "https://example.com",
+				Routes: map[string]string{
+					"product": "/:locale/products/:slug/:page",
+					"home":    "/",
+					"cart":    "/cart",
+				},
+				Metadata: map[string]urlkit.RouteMetadata{
+					"product": {Params: map[string]urlkit.ParamType{"page": urlkit.ParamTypeInt}},
+					"cart":    {Method: "POST"},
+				},
+			},
+		},
+	})
+}
+
+func matrixURLs(entries []urlkit.MatrixURL) []string {
+	urls := make([]string, len(entries))
+	for i, entry := range entries {
+		urls[i] = entry.URL
+	}
+	return urls
+}
+
+func TestURLMatrix(t *testing.T) {
+	manager := newMatrixManager(t)
+
+	entries, err := manager.URLMatrix(urlkit.MatrixOptions{
+		Routes: []string{"shop.product"},
+		Values: map[string][]any{"locale": {"en", "es"}, "slug": {"hat", "shoe"}},
+	})
+	if err != nil {
+		t.Fatalf("URLMatrix failed: %v", err)
+	}
+	want := []string{
+		"https://example.com/en/products/hat/1",
+		"https://example.com/en/products/shoe/1",
+		"https://example.com/es/products/hat/1",
+		"https://example.com/es/products/shoe/1",
+	}
+	if got := matrixURLs(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if entries[0].Total != 4 || entries[0].Params["page"] != "1" {
+		t.Fatalf("unexpected entry %+v", entries[0])
+	}
+
+	// Every visible GET route is selected by default
+	entries, err = manager.URLMatrix(urlkit.MatrixOptions{Values: map[string][]any{"locale": {"en"}}})
+	if err != nil {
+		t.Fatalf("URLMatrix failed: %v", err)
+	}
+	want = []string{"https://example.com/", "https://example.com/en/products/example/1"}
+	if got := matrixURLs(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestURLMatrixSampling(t *testing.T) {
+	manager := newMatrixManager(t)
+	opts := urlkit.MatrixOptions{
+		Routes: []string{"shop.product"},
+		Values: map[string][]any{"locale": {"en", "es", "fr", "de"}, "slug": {"a", "b", "c", "d"}, "page": {1, 2, 3, 4}},
+		Limit:  4,
+	}
+
+	entries, err := manager.URLMatrix(opts)
+	if err != nil {
+		t.Fatalf("URLMatrix failed: %v", err)
+	}
+	if len(entries) != 4 || entries[0].Total != 64 || entries[3].URL != "https://example.com/en/products/a/4" {
+		t.Fatalf("unexpected first sample %v", matrixURLs(entries))
+	}
+
+	opts.Sampling = urlkit.MatrixSampleEven
+	entries, _ = manager.URLMatrix(opts)
+	want := []string{
+		"https://example.com/en/products/a/1",
+		"https://example.com/es/products/a/1",
+		"https://example.com/fr/products/a/1",
+		"https://example.com/de/products/a/1",
+	}
+	if got := matrixURLs(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	opts.Sampling = urlkit.MatrixSampleRandom
+	opts.Seed = 42
+	first, _ := manager.URLMatrix(opts)
+	second, _ := manager.URLMatrix(opts)
+	if len(first) != 4 || !reflect.DeepEqual(matrixURLs(first), matrixURLs(second)) {
+		t.Fatalf("expected reproducible random sample, got %v and %v", matrixURLs(first), matrixURLs(second))
+	}
+
+	opts.Sampling = "stratified"
+	if _, err := manager.URLMatrix(opts); err == nil {
+		t.Fatal("expected error for unsupported sampling")
+	}
+	if _, err := manager.URLMatrix(urlkit.MatrixOptions{Routes: []string{"shop.missing"}}); err == nil {
+		t.Fatal("expected error for unknown route")
+	}
+}
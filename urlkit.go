@@ -1,6 +1,7 @@
 package urlkit
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"maps"
@@ -9,6 +10,8 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	ptre "github.com/soongo/path-to-regexp"
 )
@@ -120,6 +123,26 @@ type runtimeState struct {
 	mu             sync.RWMutex
 	conflictPolicy RouteConflictPolicy
 	frozen         bool
+	profile        string
+	escapeMode     TemplateEscapeMode
+	lookup         func(path string) (*Group, error)
+	bus            eventBus
+	globalPrefix   string
+	interceptor    func(BuildRequest, string)
+	duplicateGroup DuplicateGroupPolicy
+	observer       Observer
+
+	// generation counts registry mutations and published events so caches
+	// of registry lookups (e.g. GroupCache) can tell when to drop stale
+	// entries.
+	generation atomic.Uint64
+
+	caseInsensitiveParams bool
+
+	// ctxMu is separate from mu because registrations run while beginMutation
+	// holds mu for reading.
+	ctxMu         sync.RWMutex
+	contextParams map[string]ContextParamExtractor
 }
 
 func newRuntimeState() *runtimeState {
@@ -186,6 +209,7 @@ func (r *runtimeState) beginMutation(operation, groupFQN string) (func(), error)
 	}
 
 	return func() {
+		r.generation.Add(1)
 		r.mu.RUnlock()
 	}, nil
 }
@@ -212,6 +236,7 @@ type NavigationNode struct {
 	Path      string `json:"path"`       // Raw route template (e.g., "/about" or "/users/:id")
 	URL       string `json:"url"`        // Resolved URL including host/base path
 	Params    Params `json:"params,omitempty"`
+	Weight    int    `json:"weight,omitempty"` // Ordering weight from route metadata
 }
 
 type ValidationError struct {
@@ -254,13 +279,26 @@ func (e TemplateSubstitutionError) Error() string {
 }
 
 type RouteManager struct {
-	mu      sync.RWMutex
-	groups  map[string]*Group
-	runtime *runtimeState
+	mu        sync.RWMutex
+	groups    map[string]*Group
+	hosts     map[string]string        // extra host patterns mapped to root group names
+	mounts    map[string]*RouteManager // external managers exposed under a namespace
+	redirects []compiledRedirect
+	lazy      map[string]*lazyGroup // root groups materialized on first access
+	sources   map[string][]byte     // encoded configs of root groups, compared by Reload
+	runtime   *runtimeState
+
+	// matchers caches the compiled route matchers of Match and Coverage for
+	// one registry generation.
+	matchers atomic.Pointer[matcherCache]
 }
 
 type Config struct {
-	Groups []GroupConfig `json:"groups" yaml:"groups"`
+	// Version is the schema version of the config document. Older versions
+	// are upgraded on load by the migrations registered with
+	// RegisterConfigMigration.
+	Version int           `json:"version,omitempty" yaml:"version,omitempty"`
+	Groups  []GroupConfig `json:"groups" yaml:"groups"`
 }
 
 // GroupConfig defines the configuration structure for a group when loading from JSON/YAML.
@@ -289,6 +327,76 @@ type GroupConfig struct {
 	//   - base_url: Automatically set to the group's base URL
 	//   - route_path: Automatically set to the compiled route path with parameters
 	TemplateVars map[string]string `json:"template_vars,omitempty" yaml:"template_vars,omitempty"`
+
+	// TemplateLists declares multi-value template variables joined with a
+	// separator at render time, e.g. {"segments": {"values": ["docs", "v2"],
+	// "join": "/"}}. See Group.SetTemplateList.
+	TemplateLists map[string]TemplateList `json:"template_lists,omitempty" yaml:"template_lists,omitempty"`
+
+	// Profiles contains named template variable sets (e.g. "prod", "staging")
+	// that overlay TemplateVars while the profile is active on the manager.
+	// See RouteManager.UseProfile.
+	Profiles map[string]map[string]string `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+
+	// Metadata attaches optional per-route information (HTTP method, etc.)
+	// keyed by route name. Every key must reference a route in this group.
+	Metadata map[string]RouteMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// SchemePolicy declares how http URLs built by this group and its
+	// descendants are handled ("require_https" or "upgrade_https").
+	SchemePolicy SchemePolicy `json:"scheme_policy,omitempty" yaml:"scheme_policy,omitempty"`
+
+	// VanityRoutes declares an alternate vanity pattern per route name, rendered
+	// by Builder.Vanity. Every key must reference a route in this group.
+	VanityRoutes map[string]string `json:"vanity_routes,omitempty" yaml:"vanity_routes,omitempty"`
+
+	// Aliases maps alternate route names to routes of this group, e.g.
+	// {"profile": "user_profile"} after renaming a route. See
+	// Group.SetRouteAlias.
+	Aliases map[string]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// DateLayout and DateTimezone control how time.Time params render for this
+	// group and its descendants (e.g. "2006-01-02" and "Europe/Madrid").
+	DateLayout   string `json:"date_layout,omitempty" yaml:"date_layout,omitempty"`
+	DateTimezone string `json:"date_timezone,omitempty" yaml:"date_timezone,omitempty"`
+
+	// QueryNames maps canonical query parameter names to localized ones for
+	// this group and its descendants (e.g. {"page": "seite"}).
+	QueryNames map[string]string `json:"query_names,omitempty" yaml:"query_names,omitempty"`
+
+	// LinkPolicy constrains the host and first path segment of URLs built by
+	// this group and its descendants.
+	LinkPolicy *LinkPolicy `json:"link_policy,omitempty" yaml:"link_policy,omitempty"`
+
+	// AppLinks configures the native app used for deep links and install links.
+	AppLinks *AppLinks `json:"app_links,omitempty" yaml:"app_links,omitempty"`
+
+	// RegionRouting maps client hints to regional child groups for ForRegion.
+	RegionRouting *RegionRouting `json:"region_routing,omitempty" yaml:"region_routing,omitempty"`
+
+	// Locale describes the locale served by this group, overriding the
+	// built-in names listed by RouteManager.Locales.
+	Locale *GroupLocale `json:"locale,omitempty" yaml:"locale,omitempty"`
+
+	// LocaleSort orders the locale children of this group in
+	// RouteManager.Locales ("code", "name" or "native_name").
+	LocaleSort LocaleSort `json:"locale_sort,omitempty" yaml:"locale_sort,omitempty"`
+
+	// CompactRoutes designates routes whose builder query is packed into a
+	// single compact parameter, for beacon and pixel URLs.
+	CompactRoutes map[string]CompactRoute `json:"compact_routes,omitempty" yaml:"compact_routes,omitempty"`
+
+	// SubdomainPattern restricts the subdomains filled into a wildcard base
+	// URL (e.g. "https://*.preview.example.com") by Builder.WithSubdomain.
+	SubdomainPattern string `json:"subdomain_pattern,omitempty" yaml:"subdomain_pattern,omitempty"`
+
+	// Type selects the group kind. A root group of type "redirects" declares
+	// Redirects instead of routes and builds no URLs.
+	Type GroupType `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Redirects maps source path patterns to target routes for a group of type
+	// "redirects". See RouteManager.ResolveRedirect.
+	Redirects []RedirectRule `json:"redirects,omitempty" yaml:"redirects,omitempty"`
 }
 
 func (g GroupConfig) effectiveRoutes() map[string]string {
@@ -334,10 +442,29 @@ func NewRouteManagerFromConfig(config Configurator, opts ...Option) (*RouteManag
 		return manager, nil
 	}
 
+	config, err := migrateConfigurator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var redirects []RedirectRule
 	for _, groupConfig := range config.GetGroups() {
+		if groupConfig.Type == GroupTypeRedirects {
+			rules, err := redirectsFromConfig(groupConfig)
+			if err != nil {
+				return nil, err
+			}
+			redirects = append(redirects, rules...)
+			continue
+		}
 		if _, err := manager.loadGroupFromConfig(groupConfig, nil); err != nil {
 			return nil, err
 		}
+		manager.recordSource(groupConfig)
+	}
+
+	if err := manager.AddRedirects(redirects...); err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
 	}
 
 	return manager, nil
@@ -349,6 +476,8 @@ func NewRouteManager(opts ...Option) *RouteManager {
 		runtime: newRuntimeState(),
 	}
 
+	manager.runtime.lookup = manager.GetGroup
+
 	for _, opt := range opts {
 		if opt != nil {
 			opt(manager)
@@ -362,6 +491,9 @@ func (m *RouteManager) loadGroupFromConfig(cfg GroupConfig, parent *Group) (*Gro
 	if cfg.Name == "" {
 		return nil, fmt.Errorf("configuration error: group name is required")
 	}
+	if err := checkGroupType(cfg); err != nil {
+		return nil, err
+	}
 
 	routes := cloneRoutes(cfg.effectiveRoutes())
 
@@ -376,16 +508,8 @@ func (m *RouteManager) loadGroupFromConfig(cfg GroupConfig, parent *Group) (*Gro
 			group.mu.Unlock()
 		}
 
-		if cfg.URLTemplate != "" {
-			if err := group.SetURLTemplate(cfg.URLTemplate); err != nil {
-				return nil, fmt.Errorf("configuration error: %w", err)
-			}
-		}
-
-		for key, value := range cfg.TemplateVars {
-			if err := group.SetTemplateVar(key, value); err != nil {
-				return nil, fmt.Errorf("configuration error: %w", err)
-			}
+		if err := applyGroupConfig(group, cfg); err != nil {
+			return nil, fmt.Errorf("configuration error: %w", err)
 		}
 
 		for _, child := range cfg.Groups {
@@ -409,16 +533,8 @@ func (m *RouteManager) loadGroupFromConfig(cfg GroupConfig, parent *Group) (*Gro
 		return nil, fmt.Errorf("configuration error: %w", err)
 	}
 
-	if cfg.URLTemplate != "" {
-		if err := childGroup.SetURLTemplate(cfg.URLTemplate); err != nil {
-			return nil, fmt.Errorf("configuration error: %w", err)
-		}
-	}
-
-	for key, value := range cfg.TemplateVars {
-		if err := childGroup.SetTemplateVar(key, value); err != nil {
-			return nil, fmt.Errorf("configuration error: %w", err)
-		}
+	if err := applyGroupConfig(childGroup, cfg); err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
 	}
 
 	for _, child := range cfg.Groups {
@@ -433,7 +549,116 @@ func (m *RouteManager) loadGroupFromConfig(cfg GroupConfig, parent *Group) (*Gro
 	return childGroup, nil
 }
 
+// applyGroupConfig applies the template and variable settings shared by root and
+// nested group configurations.
+func applyGroupConfig(group *Group, cfg GroupConfig) error {
+	if cfg.URLTemplate != "" {
+		if err := group.SetURLTemplate(cfg.URLTemplate); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range cfg.TemplateVars {
+		if err := group.SetTemplateVar(key, value); err != nil {
+			return err
+		}
+	}
+
+	for key, list := range cfg.TemplateLists {
+		if err := group.SetTemplateList(key, list); err != nil {
+			return err
+		}
+	}
+
+	for profile, vars := range cfg.Profiles {
+		if err := group.SetProfileVars(profile, vars); err != nil {
+			return err
+		}
+	}
+
+	for route, meta := range cfg.Metadata {
+		if err := group.SetRouteMetadata(route, meta); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SchemePolicy != SchemePolicyNone {
+		if err := group.SetSchemePolicy(cfg.SchemePolicy); err != nil {
+			return err
+		}
+	}
+
+	for route, pattern := range cfg.VanityRoutes {
+		if err := group.SetVanityRoute(route, pattern); err != nil {
+			return err
+		}
+	}
+
+	for alias, route := range cfg.Aliases {
+		if err := group.SetRouteAlias(alias, route); err != nil {
+			return err
+		}
+	}
+
+	if err := applyDateConfig(group, cfg.DateLayout, cfg.DateTimezone); err != nil {
+		return err
+	}
+
+	if len(cfg.QueryNames) > 0 {
+		if err := group.SetQueryNames(cfg.QueryNames); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LinkPolicy != nil {
+		if err := group.SetLinkPolicy(*cfg.LinkPolicy); err != nil {
+			return err
+		}
+	}
+
+	if cfg.AppLinks != nil {
+		if err := group.SetAppLinks(*cfg.AppLinks); err != nil {
+			return err
+		}
+	}
+
+	if cfg.RegionRouting != nil {
+		if err := group.SetRegionRouting(*cfg.RegionRouting); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Locale != nil {
+		if err := group.SetLocale(*cfg.Locale); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LocaleSort != "" {
+		if err := group.SetLocaleSort(cfg.LocaleSort); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SubdomainPattern != "" {
+		if err := group.SetSubdomainPattern(cfg.SubdomainPattern); err != nil {
+			return err
+		}
+	}
+
+	for _, route := range slices.Sorted(maps.Keys(cfg.CompactRoutes)) {
+		if err := group.SetCompactRoute(route, cfg.CompactRoutes[route]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func compileRouteTemplate(tpl string) (func(any) (string, error), error) {
+	if err := checkRouteTemplateTokens(tpl); err != nil {
+		return nil, err
+	}
 	return ptre.Compile(tpl, &ptre.Options{
 		Encode: func(uri string, token any) string {
 			return url.PathEscape(uri)
@@ -441,6 +666,53 @@ func compileRouteTemplate(tpl string) (func(any) (string, error), error) {
 	})
 }
 
+// checkRouteTemplateTokens rejects patterns with unnamed groups. A literal "(" or
+// "{" parses as a group that can never be filled from Params, so it must be
+// escaped with a backslash (e.g. "/v\\(1\\)" or "/\\{id\\}").
+func checkRouteTemplateTokens(tpl string) error {
+	tokens, err := ptre.Parse(tpl, nil)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		t, ok := token.(ptre.Token)
+		if !ok {
+			continue
+		}
+		if name, ok := t.Name.(string); !ok || name == "" {
+			return fmt.Errorf("route template %q contains an unnamed group; escape literal '(' and '{' with a backslash", tpl)
+		}
+	}
+	return nil
+}
+
+// compileRouteMatcher returns a function that matches a request path against
+// tpl and extracts its decoded params.
+func compileRouteMatcher(tpl string) (func(string) (Params, bool), error) {
+	match, err := ptre.Match(tpl, &ptre.Options{
+		Decode: func(str string, token any) (string, error) {
+			return url.PathUnescape(str)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path string) (Params, bool) {
+		result, err := match(path)
+		if err != nil || result == nil {
+			return nil, false
+		}
+		params := make(Params, len(result.Params))
+		for key, value := range result.Params {
+			if name, ok := key.(string); ok {
+				params[name] = value
+			}
+		}
+		return params, true
+	}, nil
+}
+
 func compileRouteTemplates(routes map[string]string) (map[string]func(any) (string, error), error) {
 	compiled := make(map[string]func(any) (string, error), len(routes))
 	for route, tpl := range routes {
@@ -492,15 +764,34 @@ func newManagedGroup(baseURL, name, path string, routes map[string]string, paren
 	}, nil
 }
 
+// RegisterGroup registers a root group. When a root group with the same name
+// already exists, the routes are merged into it under the default
+// DuplicateGroupPolicyMerge policy, or a DuplicateGroupError is returned under
+// DuplicateGroupPolicyError (see WithDuplicateGroupPolicy and MergeGroup).
 func (m *RouteManager) RegisterGroup(name, baseURL string, routes map[string]string) (*Group, RouteMutationResult, error) {
+	mode := registerOrMerge
+	if m.runtime.duplicateGroupPolicy() == DuplicateGroupPolicyError {
+		mode = registerOnly
+	}
+	return m.registerRootGroup("register group", name, baseURL, routes, mode)
+}
+
+func (m *RouteManager) registerRootGroup(op, name, baseURL string, routes map[string]string, mode registerMode) (*Group, RouteMutationResult, error) {
 	if strings.Contains(name, ".") {
-		return nil, RouteMutationResult{}, fmt.Errorf("register group: root group name %q cannot contain '.'", name)
+		return nil, RouteMutationResult{}, fmt.Errorf("%s: root group name %q cannot contain '.'", op, name)
 	}
 	if name == "" {
-		return nil, RouteMutationResult{}, fmt.Errorf("register group: group name is required")
+		return nil, RouteMutationResult{}, fmt.Errorf("%s: group name is required", op)
+	}
+	baseURL, err := NormalizeURLHost(baseURL)
+	if err != nil {
+		return nil, RouteMutationResult{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	releaseMutation, err := m.runtime.beginMutation("register group", name)
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+
+	releaseMutation, err := m.runtime.beginMutation(op, name)
 	if err != nil {
 		return nil, RouteMutationResult{}, err
 	}
@@ -509,7 +800,19 @@ func (m *RouteManager) RegisterGroup(name, baseURL string, routes map[string]str
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if group, exists := m.groups[name]; exists {
+	if _, mounted := m.mounts[name]; mounted {
+		return nil, RouteMutationResult{}, fmt.Errorf("%s: %q is used as a mount prefix", op, name)
+	}
+
+	group, exists := m.groups[name]
+	switch {
+	case exists && mode == registerOnly:
+		return nil, RouteMutationResult{}, DuplicateGroupError{GroupName: name}
+	case !exists && mode == mergeOnly:
+		return nil, RouteMutationResult{}, fmt.Errorf("%s: %w: %s", op, ErrGroupNotFound, name)
+	}
+
+	if exists {
 		group.mu.RLock()
 		existingBaseURL := group.baseURL
 		group.mu.RUnlock()
@@ -522,14 +825,16 @@ func (m *RouteManager) RegisterGroup(name, baseURL string, routes map[string]str
 		}
 
 		result, err := group.addRoutesLocked(routes)
+		events = routesChangedEvents(name, result)
 		return group, result, err
 	}
 
-	group, err := newManagedGroup(baseURL, name, "", routes, nil, m.runtime)
+	group, err = newManagedGroup(baseURL, name, "", routes, nil, m.runtime)
 	if err != nil {
 		return nil, RouteMutationResult{}, err
 	}
 	m.groups[name] = group
+	events = append(events, Event{Type: EventGroupRegistered, Group: name, Routes: slices.Sorted(maps.Keys(routes))})
 
 	result := RouteMutationResult{Added: slices.Sorted(maps.Keys(routes))}
 	result.normalize()
@@ -713,20 +1018,21 @@ func (m *RouteManager) GetGroup(path string) (*Group, error) {
 	}
 
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	if group, ok := m.groups[path]; ok {
-		return group, nil
-	}
-
-	var group *Group
-	if strings.Contains(path, ".") {
+	group, ok := m.groups[path]
+	if !ok && strings.Contains(path, ".") {
 		group = m.findGroupByPath(path)
-	} else {
-		group = m.groups[path]
 	}
+	m.mu.RUnlock()
 
 	if group == nil {
+		if mounted, ok := m.mountedGroup(path); ok {
+			return mounted, nil
+		}
+		if materialized, err := m.materializeLazyGroup(path); err != nil {
+			return nil, err
+		} else if materialized {
+			return m.GetGroup(path)
+		}
 		return nil, fmt.Errorf("%w: %s", ErrGroupNotFound, path)
 	}
 
@@ -803,6 +1109,9 @@ func (m *RouteManager) EnsureGroup(path string) (*Group, error) {
 		return group, nil
 	}
 
+	var events []Event
+	defer func() { m.runtime.publish(events...) }()
+
 	releaseMutation, err := m.runtime.beginMutation("ensure group", path)
 	if err != nil {
 		return nil, err
@@ -837,10 +1146,13 @@ func (m *RouteManager) EnsureGroup(path string) (*Group, error) {
 			continue
 		}
 
-		next, _, err := current.registerChildLocked(name, segmentPath, map[string]string{})
+		next, _, created, err := current.registerChildLocked(name, segmentPath, map[string]string{})
 		if err != nil {
 			return nil, fmt.Errorf("ensure group: %w", err)
 		}
+		if created {
+			events = append(events, Event{Type: EventGroupRegistered, Group: next.FQN()})
+		}
 		current = next
 		current.mu.RLock()
 		currentPath := current.path
@@ -892,17 +1204,34 @@ func (m *RouteManager) AddRoutes(path string, routes map[string]string) (*Group,
 // - {base_url}: Automatically available, contains the root group's base URL
 // - {route_path}: Automatically available, contains the compiled route with parameters
 type Group struct {
-	mu             sync.RWMutex
-	baseURL        string
-	routes         map[string]string
-	compiledRoutes map[string]func(any) (string, error)
-	name           string            // The name of this group relative to its parent
-	path           string            // The path prefix for this group (e.g., "/en", "/v1")
-	parent         *Group            // Pointer to parent group (nil for root groups)
-	children       map[string]*Group // Map of child groups
-	urlTemplate    string            // URL template string (e.g., "{base_url}/{locale}{route_path}")
-	templateVars   map[string]string // Key-value pairs provided by this group
-	runtime        *runtimeState
+	mu               sync.RWMutex
+	baseURL          string
+	routes           map[string]string
+	compiledRoutes   map[string]func(any) (string, error)
+	name             string                  // The name of this group relative to its parent
+	path             string                  // The path prefix for this group (e.g., "/en", "/v1")
+	parent           *Group                  // Pointer to parent group (nil for root groups)
+	children         map[string]*Group       // Map of child groups
+	urlTemplate      string                  // URL template string (e.g., "{base_url}/{locale}{route_path}")
+	templateVars     map[string]string       // Key-value pairs provided by this group
+	templateLists    map[string]TemplateList // Multi-value vars, also present joined in templateVars
+	profiles         map[string]map[string]string
+	metadata         map[string]RouteMetadata
+	schemePolicy     SchemePolicy
+	vanityRoutes     map[string]vanityRoute
+	aliases          map[string]routeAlias
+	dateLayout       string
+	dateLocation     *time.Location
+	assets           AssetManifest
+	queryNames       map[string]string
+	linkPolicy       *compiledLinkPolicy
+	appLinks         *AppLinks
+	regionRouting    *RegionRouting
+	locale           *GroupLocale
+	localeSort       LocaleSort
+	compactRoutes    map[string]compactQuery
+	subdomainPattern *regexp.Regexp
+	runtime          *runtimeState
 }
 
 func NewURIHelper(baseURL string, routes map[string]string) *Group {
@@ -926,7 +1255,8 @@ func NewURIHelper(baseURL string, routes map[string]string) *Group {
 	}
 }
 
-// Validate checks whether the group contains all expected routes.
+// Validate checks whether the group contains all expected routes. Route
+// aliases count as the routes they refer to.
 // It returns a GroupValidationError if any routes are missing.
 func (u *Group) Validate(routes []string) error {
 	u.mu.RLock()
@@ -934,7 +1264,7 @@ func (u *Group) Validate(routes []string) error {
 
 	var missing []string
 	for _, name := range routes {
-		if _, ok := u.routes[name]; !ok {
+		if _, ok := u.routes[u.canonicalRouteNameLocked(name)]; !ok {
 			missing = append(missing, name)
 		}
 	}
@@ -947,18 +1277,66 @@ func (u *Group) Validate(routes []string) error {
 }
 
 func (u *Group) Render(routeName string, params Params, queries ...Query) (string, error) {
+	return u.render(routeName, RouteVariantCanonical, "", params, nil, queries...)
+}
+
+// RenderPath renders the route like Render and returns only the path and
+// query of the URL. See Builder.BuildPath.
+func (u *Group) RenderPath(routeName string, params Params, queries ...Query) (string, error) {
+	built, err := u.Render(routeName, params, queries...)
+	if err != nil {
+		return "", err
+	}
+	return pathAndQuery(built)
+}
+
+// render builds the URL for routeName using the requested pattern variant. The
+// visiting slice tracks the fully qualified routes currently being resolved
+// through {ref:...} placeholders. Top-level builds are reported to the build
+// interceptor, if one is set.
+func (u *Group) render(routeName string, variant RouteVariant, subdomain string, params Params, visiting []string, queries ...Query) (string, error) {
+	routeName = u.canonicalRouteName(routeName)
+	built, err := u.renderURL(routeName, variant, subdomain, params, visiting, queries...)
+	if err == nil && len(visiting) == 0 {
+		u.intercept(routeName, variant, params, queries, built)
+	}
+	return built, err
+}
+
+func (u *Group) renderURL(routeName string, variant RouteVariant, subdomain string, params Params, visiting []string, queries ...Query) (string, error) {
 	u.mu.RLock()
 	compiled, ok := u.compiledRoutes[routeName]
+	template := u.routes[routeName]
+	if vanity, hasVanity := u.vanityRoutes[routeName]; hasVanity && variant == RouteVariantVanity {
+		compiled = vanity.compiled
+		template = vanity.template
+	}
+	constraints := u.metadata[routeName].Params
+	availability := u.metadata[routeName].Availability
 	u.mu.RUnlock()
 	if !ok {
 		return "", fmt.Errorf("%w: route %q in group %s", ErrRouteNotFound, routeName, groupDisplayName(u))
 	}
 
+	params = u.reconcileParamCase(routeName, template, params)
+	params = u.formatDateParams(params)
+	queries = u.localizeQueries(queries)
+	if err := u.checkParamConstraints(routeName, constraints, params); err != nil {
+		return "", err
+	}
+	if err := u.checkAvailability(routeName, availability); err != nil {
+		return "", err
+	}
+
 	// Check if template rendering mode is available
 	templateOwner := u.FindTemplateOwner()
 	if templateOwner != nil {
 		// Use template rendering mode
-		return u.renderTemplatedURL(routeName, compiled, params, queries...)
+		built, err := u.renderTemplatedURL(routeName, compiled, params, visiting, queries...)
+		if err != nil {
+			return "", err
+		}
+		return u.enforcePolicies(routeName, subdomain, built)
 	}
 
 	// Fall back to existing path concatenation mode
@@ -967,19 +1345,36 @@ func (u *Group) Render(routeName string, params Params, queries ...Query) (strin
 		return "", fmt.Errorf("failed to build route: %s", err)
 	}
 
-	fullPath := joinURLPath(u.getFullPath(), routePath)
+	fullPath := u.runtime.applyGlobalPrefix(joinURLPath(u.getFullPath(), routePath))
 
 	rootGroup := u.getRootGroup()
 	rootGroup.mu.RLock()
 	baseURL := rootGroup.baseURL
 	rootGroup.mu.RUnlock()
 
-	return JoinURL(baseURL, fullPath, queries...), nil
+	return u.enforcePolicies(routeName, subdomain, JoinURL(baseURL, fullPath, queries...))
+}
+
+// enforcePolicies fills the wildcard subdomain and applies the effective
+// scheme and link policies to a built URL.
+func (u *Group) enforcePolicies(routeName, subdomain, built string) (string, error) {
+	built, err := u.fillSubdomain(routeName, subdomain, built)
+	if err != nil {
+		return "", err
+	}
+	built, err = u.enforceScheme(routeName, built)
+	if err != nil {
+		return "", err
+	}
+	if err := u.enforceLinkPolicy(routeName, built); err != nil {
+		return "", err
+	}
+	return built, nil
 }
 
 func (u *Group) Route(routeName string) (string, error) {
 	u.mu.RLock()
-	route, ok := u.routes[routeName]
+	route, ok := u.routes[u.canonicalRouteNameLocked(routeName)]
 	u.mu.RUnlock()
 	if !ok {
 		return "", fmt.Errorf("%w: route %q in group %s", ErrRouteNotFound, routeName, groupDisplayName(u))
@@ -995,6 +1390,17 @@ func (u *Group) MustRoute(routeName string) string {
 	return r
 }
 
+// RoutePath returns the group path plus the raw route template (e.g.
+// "/api/v1/users/:id"), for router registration. It excludes the base URL
+// and the global prefix.
+func (u *Group) RoutePath(routeName string) (string, error) {
+	routeTemplate, err := u.Route(routeName)
+	if err != nil {
+		return "", err
+	}
+	return joinURLPath(u.getFullPath(), routeTemplate), nil
+}
+
 func (u *Group) Builder(routeName string) *Builder {
 	return &Builder{
 		helper:    u,
@@ -1053,6 +1459,8 @@ func (u *Group) getRootGroup() *Group {
 
 // Navigation builds a slice of NavigationNode entries for the provided routes.
 // The params callback can supply per-route parameter maps which are applied before building URLs.
+// Routes marked Hidden in their metadata are skipped, and nodes are ordered by
+// metadata Weight, keeping the requested order for equal weights.
 func (u *Group) Navigation(routes []string, params func(route string) Params) ([]NavigationNode, error) {
 	if len(routes) == 0 {
 		return []NavigationNode{}, nil
@@ -1066,6 +1474,11 @@ func (u *Group) Navigation(routes []string, params func(route string) Params) ([
 			continue
 		}
 
+		meta, _ := u.RouteMetadata(routeName)
+		if meta.Hidden {
+			continue
+		}
+
 		builder := u.Builder(routeName)
 
 		var providedParams Params
@@ -1101,9 +1514,14 @@ func (u *Group) Navigation(routes []string, params func(route string) Params) ([
 			Path:      routePattern,
 			URL:       urlValue,
 			Params:    cloneParamsMap(providedParams),
+			Weight:    meta.Weight,
 		})
 	}
 
+	slices.SortStableFunc(nodes, func(a, b NavigationNode) int {
+		return cmp.Compare(a.Weight, b.Weight)
+	})
+
 	return nodes, nil
 }
 
@@ -1168,13 +1586,24 @@ func (u *Group) RegisterGroup(name, path string, routes map[string]string) (*Gro
 		groupFQN = name
 	}
 
+	var events []Event
+	defer func() { u.runtime.publish(events...) }()
+
 	releaseMutation, err := u.runtime.beginMutation("register group", groupFQN)
 	if err != nil {
 		return nil, RouteMutationResult{}, err
 	}
 	defer releaseMutation()
 
-	return u.registerChildLocked(name, path, routes)
+	group, result, created, err := u.registerChildLocked(name, path, routes)
+	switch {
+	case err != nil:
+	case created:
+		events = append(events, Event{Type: EventGroupRegistered, Group: groupFQN, Routes: append([]string(nil), result.Added...)})
+	default:
+		events = routesChangedEvents(groupFQN, result)
+	}
+	return group, result, err
 }
 
 func (u *Group) addRoutesLocked(routes map[string]string) (RouteMutationResult, error) {
@@ -1259,9 +1688,11 @@ func (u *Group) addRoutesLocked(routes map[string]string) (RouteMutationResult,
 	return result, nil
 }
 
-func (u *Group) registerChildLocked(name, path string, routes map[string]string) (*Group, RouteMutationResult, error) {
+// registerChildLocked registers or extends the child group called name. The
+// returned bool reports whether a new child group was created.
+func (u *Group) registerChildLocked(name, path string, routes map[string]string) (*Group, RouteMutationResult, bool, error) {
 	if name == "" {
-		return nil, RouteMutationResult{}, fmt.Errorf("register group: group name is required")
+		return nil, RouteMutationResult{}, false, fmt.Errorf("register group: group name is required")
 	}
 
 	u.mu.Lock()
@@ -1276,20 +1707,20 @@ func (u *Group) registerChildLocked(name, path string, routes map[string]string)
 		}
 		u.mu.Unlock()
 		result, err := existingGroup.addRoutesLocked(routes)
-		return existingGroup, result, err
+		return existingGroup, result, false, err
 	}
 
 	childGroup, err := newManagedGroup("", name, path, routes, u, u.runtime)
 	if err != nil {
 		u.mu.Unlock()
-		return nil, RouteMutationResult{}, err
+		return nil, RouteMutationResult{}, false, err
 	}
 	u.children[name] = childGroup
 	u.mu.Unlock()
 
 	result := RouteMutationResult{Added: slices.Sorted(maps.Keys(routes))}
 	result.normalize()
-	return childGroup, result, nil
+	return childGroup, result, true, nil
 }
 
 // Template Management Methods
@@ -1302,6 +1733,8 @@ func (u *Group) registerChildLocked(name, path string, routes map[string]string)
 //   - Use {variable_name} to insert template variables
 //   - {base_url} is automatically available (the root group's base URL)
 //   - {route_path} is automatically available (the compiled route with parameters)
+//   - {ref:group.route} embeds the URL built for another registered route; inside
+//     the query portion of the template the URL is query-escaped
 //
 // Example templates:
 //   - "{base_url}/api/{version}{route_path}"
@@ -1310,10 +1743,12 @@ func (u *Group) registerChildLocked(name, path string, routes map[string]string)
 //
 // To disable template rendering and revert to path concatenation, pass an empty string.
 func (u *Group) SetURLTemplate(template string) error {
-	releaseMutation, err := u.runtime.beginMutation("set url template", u.FQN())
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set url template", groupFQN)
 	if err != nil {
 		return err
 	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
 	defer releaseMutation()
 
 	u.mu.Lock()
@@ -1337,15 +1772,18 @@ func (u *Group) SetURLTemplate(template string) error {
 //   - SetTemplateVar("env", "staging") for environment-specific URLs
 //   - SetTemplateVar("region", "eu-west") for regional deployments
 func (u *Group) SetTemplateVar(key, value string) error {
-	releaseMutation, err := u.runtime.beginMutation("set template var", u.FQN())
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set template var", groupFQN)
 	if err != nil {
 		return err
 	}
+	defer u.runtime.publish(Event{Type: EventTemplateChanged, Group: groupFQN})
 	defer releaseMutation()
 
 	u.mu.Lock()
 	defer u.mu.Unlock()
 	u.templateVars[key] = value
+	delete(u.templateLists, key)
 	return nil
 }
 
@@ -1390,13 +1828,19 @@ func (u *Group) GetTemplateVar(key string) (string, bool) {
 //	    "status":   "/status",
 //	})
 func (u *Group) AddRoutes(routes map[string]string) (RouteMutationResult, error) {
-	releaseMutation, err := u.runtime.beginMutation("add routes", u.FQN())
+	var events []Event
+	defer func() { u.runtime.publish(events...) }()
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("add routes", groupFQN)
 	if err != nil {
 		return RouteMutationResult{}, err
 	}
 	defer releaseMutation()
 
-	return u.addRoutesLocked(routes)
+	result, err := u.addRoutesLocked(routes)
+	events = routesChangedEvents(groupFQN, result)
+	return result, err
 }
 
 // Template discovery methods
@@ -1437,7 +1881,7 @@ func (u *Group) FindTemplateOwner() *Group {
 //
 // Variable Precedence Rules (highest to lowest priority):
 //  1. Built in dynamic variables (route_path, base_url)
-//  2. Current group's templateVars
+//  2. Current group's templateVars (overlaid by the active profile's vars)
 //  3. Parent groups' templateVars (closer ancestors override distant ones)
 //
 // Returns:
@@ -1459,10 +1903,15 @@ func (u *Group) CollectTemplateVars() map[string]string {
 		current = parent
 	}
 
+	profile := u.runtime.activeProfile()
+
 	vars := make(map[string]string)
 	for i := len(chain) - 1; i >= 0; i-- {
 		chain[i].mu.RLock()
 		maps.Copy(vars, chain[i].templateVars)
+		if profile != "" {
+			maps.Copy(vars, chain[i].profiles[profile])
+		}
 		chain[i].mu.RUnlock()
 	}
 
@@ -1498,7 +1947,7 @@ func (u *Group) CollectTemplateVars() map[string]string {
 //	With template "{protocol}://{host}/{lang}{route_path}" and variables
 //	{"protocol": "https", "host": "example.com", "lang": "en"},
 //	a route "/about" becomes "https://example.com/en/about".
-func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string, error), params Params, queries ...Query) (string, error) {
+func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string, error), params Params, visiting []string, queries ...Query) (string, error) {
 	// Find the template owner (should exist since this method is called when template is found)
 	templateOwner := u.FindTemplateOwner()
 	if templateOwner == nil {
@@ -1519,7 +1968,7 @@ func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string,
 		routePathSuffix = "/"
 	}
 
-	routePath = applyRoutePathSuffix(routePath, routePathSuffix)
+	routePath = applyRoutePathSuffix(u.runtime.applyGlobalPrefix(routePath), routePathSuffix)
 
 	// Add dynamic variables
 	templateVars["route_path"] = routePath
@@ -1531,10 +1980,19 @@ func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string,
 	templateVars["base_url"] = root.baseURL
 	root.mu.RUnlock()
 
+	if err := expandTemplateVars(groupDisplayName(u), templateVars); err != nil {
+		return "", err
+	}
+
 	templateOwner.mu.RLock()
 	templateString := templateOwner.urlTemplate
 	templateOwner.mu.RUnlock()
 
+	templateString, err = u.resolveTemplateRefs(templateString, routeName, params, visiting)
+	if err != nil {
+		return "", err
+	}
+
 	if missing := detectMissingTemplateVars(templateString, templateVars); len(missing) > 0 {
 		return "", TemplateSubstitutionError{
 			Group:         groupDisplayName(u),
@@ -1545,8 +2003,17 @@ func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string,
 		}
 	}
 
+	escapeMode := u.runtime.templateEscapeMode()
+	escapeTemplateVars(templateVars, escapeMode)
+	for key, list := range u.collectTemplateLists() {
+		templateVars[key] = list.join(escapeMode)
+	}
+
 	// Substitute template variables in the template string
-	finalURL := SubstituteTemplate(templateString, templateVars)
+	finalURL, err := NormalizeURLHost(SubstituteTemplate(templateString, templateVars))
+	if err != nil {
+		return "", err
+	}
 
 	// Append query parameters using existing logic
 	if len(queries) > 0 {
@@ -1563,6 +2030,8 @@ func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string,
 //   - Placeholders use curly brace notation: {variable_name}
 //   - Variable names are case-sensitive and can contain letters, numbers, and underscores
 //   - Nested braces are not supported: {{variable}} is treated as literal text
+//   - Literal braces are written escaped as \{ and \}; they are never treated as
+//     placeholders and are emitted without the backslash
 //   - Missing variables: If a placeholder's variable is not found in the vars map,
 //     the placeholder is left unchanged in the output string
 //
@@ -1587,15 +2056,25 @@ func (u *Group) renderTemplatedURL(routeName string, compiled func(any) (string,
 //	})
 //	Returns: "https://api.example.com/v1"
 func SubstituteTemplate(template string, vars map[string]string) string {
-	result := template
+	masked := maskEscapedBraces(template)
 
-	// Replace all {variable} placeholders
-	for key, value := range vars {
-		placeholder := "{" + key + "}"
-		result = strings.ReplaceAll(result, placeholder, value)
+	var (
+		builder strings.Builder
+		last    int
+	)
+	// Single pass over the template so substituted values are never rescanned.
+	for _, match := range placeholderPattern.FindAllStringSubmatchIndex(masked, -1) {
+		value, ok := vars[masked[match[2]:match[3]]]
+		if !ok {
+			continue
+		}
+		builder.WriteString(unescapeBraces(template[last:match[0]]))
+		builder.WriteString(value)
+		last = match[1]
 	}
+	builder.WriteString(unescapeBraces(template[last:]))
 
-	return result
+	return builder.String()
 }
 
 func applyRoutePathSuffix(routePath, suffix string) string {
@@ -1617,10 +2096,33 @@ func applyRoutePathSuffix(routePath, suffix string) string {
 	return routePath + suffix
 }
 
-var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+// placeholderPattern matches {key} for any key without braces, so keys such
+// as {api-host} or {tenant.id} work. Escaped braces are masked to NULs before
+// matching and are excluded too.
+var placeholderPattern = regexp.MustCompile(`\{([^{}\\\x00]+)\}`)
+
+var (
+	braceMasker    = strings.NewReplacer(`\{`, "\x00\x00", `\}`, "\x00\x00")
+	braceUnescaper = strings.NewReplacer(`\{`, "{", `\}`, "}")
+)
+
+// maskEscapedBraces blanks out escaped braces (\{ and \}) so placeholder
+// patterns skip them. The result has the same length as s, keeping match
+// indices valid for the original string.
+func maskEscapedBraces(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return braceMasker.Replace(s)
+}
+
+// unescapeBraces turns escaped braces into literal ones.
+func unescapeBraces(s string) string {
+	return braceUnescaper.Replace(s)
+}
 
 func detectMissingTemplateVars(template string, vars map[string]string) []string {
-	matches := placeholderPattern.FindAllStringSubmatch(template, -1)
+	matches := placeholderPattern.FindAllStringSubmatch(maskEscapedBraces(template), -1)
 	if len(matches) == 0 {
 		return nil
 	}
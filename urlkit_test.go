@@ -166,6 +166,53 @@ func TestBuilderBuild(t *testing.T) {
 	}
 }
 
+func TestBuilderBuildPath(t *testing.T) {
+	rm := mustManagerFromConfig(t, urlkit.Config{Groups: []urlkit.GroupConfig{
+		{
+			Name:    "api",
+			BaseURL: "https://example.com/base",
+			Groups: []urlkit.GroupConfig{
+				{Name: "v1", Path: "/v1", Routes: map[string]string{"user": "/users/:id", "search": "/search/:term?"}},
+			},
+		},
+	}})
+	v1 := rm.Group("api.v1")
+
+	path, err := v1.Builder("user").WithParam("id", "42").WithQuery("tab", "posts").BuildPath()
+	if err != nil {
+		t.Fatalf("BuildPath returned error: %v", err)
+	}
+	if expected := "/base/v1/users/42?tab=posts"; path != expected {
+		t.Errorf("Expected %q, got %q", expected, path)
+	}
+
+	path, err = v1.RenderPath("search", nil)
+	if err != nil {
+		t.Fatalf("RenderPath returned error: %v", err)
+	}
+	if expected := "/base/v1/search"; path != expected {
+		t.Errorf("Expected %q, got %q", expected, path)
+	}
+}
+
+func TestBuilderWithMultiQuery(t *testing.T) {
+	group := urlkit.NewURIHelper("https://example.com", map[string]string{"search": "/search"})
+
+	urlStr, err := group.Builder("search").
+		WithQuery("q", "go").
+		WithMultiQuery("tag", "a").
+		WithMultiQuery("tag", []string{"b", "3"}...).
+		WithMultiQuery("q", "urls").
+		Build()
+	if err != nil {
+		t.Fatalf("Builder Build returned error: %v", err)
+	}
+	expected := "https://example.com/search?q=go&q=urls&tag=a&tag=b&tag=3"
+	if urlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, urlStr)
+	}
+}
+
 func TestBuilderParamHelpers(t *testing.T) {
 	rm := urlkit.NewRouteManager()
 	rm.RegisterGroup("api", "https://api.example.com", map[string]string{
@@ -228,6 +275,45 @@ func TestBuilderWithStructError(t *testing.T) {
 	}
 }
 
+func TestBuilderWithQueryURL(t *testing.T) {
+	rm := urlkit.NewRouteManager()
+	rm.RegisterGroup("auth", "https://auth.example.com", map[string]string{
+		"authorize": "/oauth/authorize",
+	})
+	rm.RegisterGroup("app", "https://app.example.com", map[string]string{
+		"callback": "/auth/:provider/callback",
+	})
+
+	callback := rm.Group("app").Builder("callback").
+		WithParam("provider", "google").
+		WithQuery("next", "/dashboard?tab=1")
+
+	result, err := rm.Group("auth").Builder("authorize").
+		WithQueryURL("redirect_uri", callback).
+		Build()
+	if err != nil {
+		t.Fatalf("Builder.Build returned error: %v", err)
+	}
+
+	expected := "https://auth.example.com/oauth/authorize?redirect_uri=https%3A%2F%2Fapp.example.com%2Fauth%2Fgoogle%2Fcallback%3Fnext%3D%252Fdashboard%253Ftab%253D1"
+	if result != expected {
+		t.Fatalf("expected %s, got %s", expected, result)
+	}
+
+	parsed, err := url.Parse(result)
+	if err != nil {
+		t.Fatalf("Failed to parse generated URL: %v", err)
+	}
+	if got := parsed.Query().Get("redirect_uri"); got != "https://app.example.com/auth/google/callback?next=%2Fdashboard%3Ftab%3D1" {
+		t.Errorf("unexpected decoded redirect_uri: %s", got)
+	}
+
+	broken := rm.Group("app").Builder("missing")
+	if _, err := rm.Group("auth").Builder("authorize").WithQueryURL("redirect_uri", broken).Build(); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound from nested builder, got %v", err)
+	}
+}
+
 func TestGroupRenderNoParams(t *testing.T) {
 	routes := map[string]string{
 		"home": "/",
@@ -0,0 +1,167 @@
+package urlkithttp
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	ptre "github.com/soongo/path-to-regexp"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// RegisterOnServeMux registers handlers on mux under the Go 1.22 ServeMux
+// patterns of the group's routes, keyed by route name (or alias). Patterns
+// are prefixed with the route's metadata Method, if any ("GET
+// /users/{id}"). Handlers see the matched route through CurrentRoute, with
+// params read from the request's path values. Routes of the group without a
+// handler are not registered.
+//
+// Every pattern is converted and checked before anything is registered, so a
+// failing call leaves mux untouched unless mux itself rejects a conflicting
+// pattern.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	err := urlkithttp.RegisterOnServeMux(mux, manager.Group("api"), map[string]http.Handler{
+//		"users.show": http.HandlerFunc(showUser), // GET /api/users/{id}
+//		"users.list": http.HandlerFunc(listUsers),
+//	})
+func RegisterOnServeMux(mux *http.ServeMux, group *urlkit.Group, handlers map[string]http.Handler) (err error) {
+	if mux == nil || group == nil {
+		return fmt.Errorf("urlkithttp: mux and group are required")
+	}
+
+	type registration struct {
+		pattern string
+		handler http.Handler
+	}
+	registrations := make([]registration, 0, len(handlers))
+	groupFQN := group.FQN()
+	aliases := group.RouteAliases()
+	for _, name := range slices.Sorted(maps.Keys(handlers)) {
+		handler := handlers[name]
+		if handler == nil {
+			return fmt.Errorf("urlkithttp: route %q: handler is nil", name)
+		}
+		path, err := group.RoutePath(name)
+		if err != nil {
+			return fmt.Errorf("urlkithttp: %w", err)
+		}
+		pattern, params, err := serveMuxPattern(path)
+		if err != nil {
+			return fmt.Errorf("urlkithttp: route %q: %w", name, err)
+		}
+		if meta, ok := group.RouteMetadata(name); ok && meta.Method != "" {
+			pattern = meta.Method + " " + pattern
+		}
+		route := name
+		if target, ok := aliases[name]; ok {
+			route = target
+		}
+		registrations = append(registrations, registration{
+			pattern: pattern,
+			handler: withPathValues(groupFQN, route, params, handler),
+		})
+	}
+
+	// ServeMux panics on conflicting patterns
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("urlkithttp: register on ServeMux: %v", recovered)
+		}
+	}()
+	for _, entry := range registrations {
+		mux.Handle(entry.pattern, entry.handler)
+	}
+	return nil
+}
+
+// ServeMuxPattern converts a urlkit route path to a Go 1.22 ServeMux pattern:
+// ":id" becomes "{id}", a trailing ":path*" or ":path+" becomes
+// "{path...}", and paths ending in "/" get "{$}" so they match exactly, as
+// urlkit routes do. Param patterns such as ":id(\\d+)" are dropped, since
+// ServeMux wildcards have no constraints; validate them in the handler.
+// Optional params and params that do not span a whole segment (e.g.
+// "/files/:name.pdf") have no ServeMux equivalent and are rejected.
+func ServeMuxPattern(path string) (string, error) {
+	pattern, _, err := serveMuxPattern(path)
+	return pattern, err
+}
+
+func serveMuxPattern(path string) (string, []string, error) {
+	tokens, err := ptre.Parse(path, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var out strings.Builder
+	var params []string
+	for i, token := range tokens {
+		switch t := token.(type) {
+		case string:
+			if strings.ContainsAny(t, "{}") {
+				return "", nil, fmt.Errorf("literal braces in %q are not supported by ServeMux", path)
+			}
+			if i > 0 && !strings.HasPrefix(t, "/") {
+				if _, afterParam := tokens[i-1].(ptre.Token); afterParam {
+					return "", nil, fmt.Errorf("param must span a whole path segment in %q", path)
+				}
+			}
+			out.WriteString(t)
+		case ptre.Token:
+			name, ok := t.Name.(string)
+			if !ok || name == "" {
+				return "", nil, fmt.Errorf("unnamed param in %q", path)
+			}
+			if t.Suffix != "" {
+				return "", nil, fmt.Errorf("param %q must span a whole path segment in %q", name, path)
+			}
+			out.WriteString(t.Prefix)
+			if !strings.HasSuffix(out.String(), "/") {
+				return "", nil, fmt.Errorf("param %q must span a whole path segment in %q", name, path)
+			}
+			switch t.Modifier {
+			case "":
+				out.WriteString("{" + name + "}")
+			case "*", "+":
+				if i != len(tokens)-1 {
+					return "", nil, fmt.Errorf("repeated param %q must be the last segment in %q", name, path)
+				}
+				out.WriteString("{" + name + "...}")
+			default:
+				return "", nil, fmt.Errorf("param %q with modifier %q is not supported by ServeMux", name, t.Modifier)
+			}
+			params = append(params, name)
+		}
+	}
+
+	pattern := out.String()
+	if pattern == "" {
+		pattern = "/"
+	}
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+	return pattern, params, nil
+}
+
+// withPathValues stores the route matched by ServeMux in the request context,
+// like Middleware does.
+func withPathValues(group, route string, params []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := &RouteInfo{
+			Group:  group,
+			Route:  route,
+			Params: make(urlkit.Params, len(params)),
+			Query:  r.URL.Query(),
+		}
+		for _, name := range params {
+			info.Params[name] = r.PathValue(name)
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), info)))
+	})
+}
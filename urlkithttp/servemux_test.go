@@ -0,0 +1,109 @@
+package urlkithttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+	"github.com/goliatone/go-urlkit/urlkithttp"
+)
+
+func TestServeMuxPattern(t *testing.T) {
+	cases := map[string]string{
+		"/":                      "/{$}",
+		"/users":                 "/users",
+		"/users/":                "/users/{$}",
+		"/users/:id":             "/users/{id}",
+		"/users/:id(\\d+)/posts": "/users/{id}/posts",
+		"/files/:path*":          "/files/{path...}",
+		"/docs/:slug+":           "/docs/{slug...}",
+	}
+	for path, want := range cases {
+		got, err := urlkithttp.ServeMuxPattern(path)
+		if err != nil || got != want {
+			t.Errorf("ServeMuxPattern(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+
+	for _, path := range []string{"/search/:query?", "/files/:name.pdf", "/v-:version/users", "/files/:path*/raw"} {
+		if got, err := urlkithttp.ServeMuxPattern(path); err == nil {
+			t.Errorf("ServeMuxPattern(%q) = %q, expected error", path, got)
+		}
+	}
+}
+
+func TestRegisterOnServeMux(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	api, _, err := manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"user_show":   "/users/:id",
+		"user_create": "/users",
+		"index":       "/",
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	v1, _, err := api.RegisterGroup("v1", "/v1", map[string]string{"status": "/status"})
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	api.SetRouteMetadata("user_show", urlkit.RouteMetadata{Method: "get"})
+	api.SetRouteMetadata("user_create", urlkit.RouteMetadata{Method: "POST"})
+	api.SetRouteAlias("profile", "user_show")
+
+	var seen *urlkithttp.RouteInfo
+	handler := func(body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = urlkithttp.CurrentRoute(r)
+			w.Write([]byte(body))
+		})
+	}
+
+	mux := http.NewServeMux()
+	if err := urlkithttp.RegisterOnServeMux(mux, api, map[string]http.Handler{
+		"profile":     handler("show"),
+		"user_create": handler("create"),
+		"index":       handler("index"),
+	}); err != nil {
+		t.Fatalf("RegisterOnServeMux failed: %v", err)
+	}
+	if err := urlkithttp.RegisterOnServeMux(mux, v1, map[string]http.Handler{"status": handler("status")}); err != nil {
+		t.Fatalf("RegisterOnServeMux(v1) failed: %v", err)
+	}
+
+	serve := func(method, target string) (int, string) {
+		seen = nil
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	if code, body := serve(http.MethodGet, "/users/42?tab=posts"); code != http.StatusOK || body != "show" {
+		t.Fatalf("GET /users/42 = %d %q", code, body)
+	}
+	if seen == nil || seen.Name() != "api.user_show" || seen.Params["id"] != "42" || seen.Query.Get("tab") != "posts" {
+		t.Fatalf("unexpected route info %+v", seen)
+	}
+	if code, body := serve(http.MethodPost, "/users"); code != http.StatusOK || body != "create" {
+		t.Fatalf("POST /users = %d %q", code, body)
+	}
+	if code, _ := serve(http.MethodGet, "/users"); code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET /users = %d, want 405", code)
+	}
+	if code, body := serve(http.MethodGet, "/v1/status"); code != http.StatusOK || body != "status" {
+		t.Fatalf("GET /v1/status = %d %q", code, body)
+	}
+	if code, _ := serve(http.MethodGet, "/missing"); code != http.StatusNotFound {
+		t.Fatalf("GET /missing = %d, want 404: the index route must match exactly", code)
+	}
+
+	err = urlkithttp.RegisterOnServeMux(http.NewServeMux(), api, map[string]http.Handler{"missing": handler("")})
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected unknown route error, got %v", err)
+	}
+	err = urlkithttp.RegisterOnServeMux(mux, api, map[string]http.Handler{"index": handler("")})
+	if err == nil {
+		t.Fatal("expected conflicting pattern error")
+	}
+}
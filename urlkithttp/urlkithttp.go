@@ -0,0 +1,149 @@
+// Package urlkithttp provides net/http middleware that recognizes the urlkit
+// route serving each request and exposes it to handlers and templates, as the
+// current_route_name, current_params and current_query variables expected by
+// helpers such as current_route_if.
+//
+//	handler := urlkithttp.Middleware(manager)(mux)
+//
+//	func show(w http.ResponseWriter, r *http.Request) {
+//		route, ok := urlkithttp.CurrentRoute(r)
+//		tpl.ExecuteWriter(urlkithttp.Pongo2Context(r, pongo2.Context{"user": user}), w)
+//	}
+//
+// Stdlib-only services can also register their handlers on a Go 1.22
+// http.ServeMux straight from the registry with RegisterOnServeMux.
+package urlkithttp
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+
+	"github.com/flosch/pongo2/v6"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// Template variable names set by TemplateContext.
+const (
+	CurrentRouteNameKey = "current_route_name"
+	CurrentParamsKey    = "current_params"
+	CurrentQueryKey     = "current_query"
+)
+
+// RouteInfo describes the route that matched a request.
+type RouteInfo struct {
+	// Group is the fully qualified group name (e.g. "frontend.en").
+	Group string
+	// Route is the route name within Group.
+	Route string
+	// Params holds the decoded path params.
+	Params urlkit.Params
+	// Query holds the query values of the request.
+	Query url.Values
+}
+
+// Name returns the fully qualified route name ("group.route").
+func (i *RouteInfo) Name() string {
+	return i.Group + "." + i.Route
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying info.
+func NewContext(ctx context.Context, info *RouteInfo) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the route stored in ctx by Middleware or NewContext.
+func FromContext(ctx context.Context) (*RouteInfo, bool) {
+	info, ok := ctx.Value(contextKey{}).(*RouteInfo)
+	return info, ok && info != nil
+}
+
+// CurrentRoute returns the route Middleware matched for r.
+func CurrentRoute(r *http.Request) (*RouteInfo, bool) {
+	return FromContext(r.Context())
+}
+
+// Middleware matches every request against the manager's routes with
+// RouteManager.Match and stores the result in the request context. The request
+// host narrows the match to the root group serving it; hosts no group serves
+// (e.g. localhost in development) fall back to matching the path alone.
+// Requests that match no route are passed through unchanged.
+func Middleware(manager *urlkit.RouteManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if info, ok := Match(manager, r); ok {
+				r = r.WithContext(NewContext(r.Context(), info))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Match resolves the route serving r without touching its context.
+func Match(manager *urlkit.RouteManager, r *http.Request) (*RouteInfo, bool) {
+	if manager == nil || r == nil || r.URL == nil {
+		return nil, false
+	}
+
+	requestURI := r.URL.RequestURI()
+	var match *urlkit.MatchResult
+	var err error
+	if r.Host != "" {
+		match, err = manager.Match("//" + r.Host + requestURI)
+	}
+	if r.Host == "" || errors.Is(err, urlkit.ErrGroupNotFound) {
+		match, err = manager.Match(requestURI)
+	}
+	if err != nil {
+		return nil, false
+	}
+
+	return &RouteInfo{
+		Group:  match.Group,
+		Route:  match.Route,
+		Params: match.Params,
+		Query:  match.Query,
+	}, true
+}
+
+// TemplateContext returns the current route as template variables:
+// current_route_name ("group.route"), current_params and current_query, where
+// single query values are strings and repeated ones lists. Unmatched requests
+// get an empty name and empty maps, so templates can use the variables
+// unconditionally.
+func TemplateContext(r *http.Request) map[string]any {
+	name := ""
+	params := map[string]any{}
+	query := map[string]any{}
+
+	if info, ok := CurrentRoute(r); ok {
+		name = info.Name()
+		maps.Copy(params, info.Params)
+		for key, values := range info.Query {
+			if len(values) == 1 {
+				query[key] = values[0]
+			} else {
+				query[key] = append([]string(nil), values...)
+			}
+		}
+	}
+
+	return map[string]any{
+		CurrentRouteNameKey: name,
+		CurrentParamsKey:    params,
+		CurrentQueryKey:     query,
+	}
+}
+
+// Pongo2Context returns a copy of data with the TemplateContext variables of r
+// added, ready to pass to a pongo2 template. Keys already in data win.
+func Pongo2Context(r *http.Request, data pongo2.Context) pongo2.Context {
+	ctx := pongo2.Context(TemplateContext(r))
+	maps.Copy(ctx, data)
+	return ctx
+}
@@ -0,0 +1,58 @@
+package urlkithttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+
+	urlkit "github.com/goliatone/go-urlkit"
+	"github.com/goliatone/go-urlkit/urlkithttp"
+)
+
+func TestMiddleware(t *testing.T) {
+	manager := urlkit.NewRouteManager()
+	manager.RegisterGroup("frontend", "https://example.com", map[string]string{
+		"home": "/",
+		"user": "/users/:id",
+	})
+	manager.RegisterGroup("api", "https://api.example.com", map[string]string{
+		"user": "/users/:id",
+	})
+
+	var got map[string]any
+	var info *urlkithttp.RouteInfo
+	handler := urlkithttp.Middleware(manager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, _ = urlkithttp.CurrentRoute(r)
+		got = urlkithttp.Pongo2Context(r, pongo2.Context{"title": "Profile"})
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "https://api.example.com/users/42?tag=a&tag=b&page=2", nil))
+	if info == nil || info.Name() != "api.user" {
+		t.Fatalf("expected api.user, got %+v", info)
+	}
+	want := map[string]any{
+		"title":              "Profile",
+		"current_route_name": "api.user",
+		"current_params":     map[string]any{"id": "42"},
+		"current_query":      map[string]any{"page": "2", "tag": []string{"a", "b"}},
+	}
+	if !reflect.DeepEqual(map[string]any(got), want) {
+		t.Fatalf("unexpected template context %#v", got)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost:8080/users/7", nil))
+	if info == nil || info.Params["id"] != "7" {
+		t.Fatalf("expected an unknown host to fall back to path matching, got %+v", info)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "https://example.com/missing", nil))
+	if info != nil {
+		t.Fatalf("expected no route for an unmatched path, got %+v", info)
+	}
+	if got[urlkithttp.CurrentRouteNameKey] != "" {
+		t.Fatalf("expected empty route name, got %#v", got)
+	}
+}
@@ -0,0 +1,150 @@
+// Package urlkitmux moves named routes between a gorilla/mux Router and urlkit,
+// for codebases migrating from mux URL building to urlkit templates.
+//
+//	// mux -> urlkit: r.HandleFunc("/users/{id:[0-9]+}", h).Name("user")
+//	urlkitmux.Import(router, manager.Group("frontend"))
+//	manager.Group("frontend").Render("user", urlkit.Params{"id": 7})
+//
+//	// urlkit -> mux: router.Get("user").URL("id", "7") keeps working
+//	urlkitmux.Export(router, manager, "frontend")
+package urlkitmux
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// urlkitParam matches a path-to-regexp parameter with an optional pattern and
+// modifier, e.g. ":id", ":id(\\d+)" or ":query?".
+var urlkitParam = regexp.MustCompile(`:(\w+)(?:\(([^)]*)\))?([?*+])?`)
+
+// Import adds every named route of router that has a path template to group,
+// converting mux variables ({id}, {id:[0-9]+}) to urlkit params (:id,
+// :id([0-9]+)). Unnamed routes are skipped, as are host, method and query
+// matchers, which urlkit routes do not model.
+func Import(router *mux.Router, group *urlkit.Group) (urlkit.RouteMutationResult, error) {
+	if router == nil || group == nil {
+		return urlkit.RouteMutationResult{}, fmt.Errorf("urlkitmux: router and group are required")
+	}
+
+	routes := map[string]string{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		name := route.GetName()
+		if name == "" {
+			return nil
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		path, err := FromMuxTemplate(template)
+		if err != nil {
+			return fmt.Errorf("route %q: %w", name, err)
+		}
+		routes[name] = path
+		return nil
+	})
+	if err != nil {
+		return urlkit.RouteMutationResult{}, fmt.Errorf("urlkitmux: import: %w", err)
+	}
+	return group.AddRoutes(routes)
+}
+
+// Export registers every route of the group at groupPath on router as a named
+// mux route, using the route key as the name and the full group path as the
+// template. Handlers are attached by the caller:
+//
+//	router.Get("user").HandlerFunc(showUser)
+func Export(router *mux.Router, manager *urlkit.RouteManager, groupPath string) error {
+	if router == nil || manager == nil {
+		return fmt.Errorf("urlkitmux: router and manager are required")
+	}
+	group, err := manager.GetGroup(groupPath)
+	if err != nil {
+		return fmt.Errorf("urlkitmux: export: %w", err)
+	}
+	groupFQN := group.FQN()
+
+	for _, entry := range manager.Manifest() {
+		if entry.GroupFQN != groupFQN {
+			continue
+		}
+		template, err := ToMuxTemplate(entry.FullPathTemplate)
+		if err != nil {
+			return fmt.Errorf("urlkitmux: export route %q: %w", entry.RouteKey, err)
+		}
+		if existing := router.Get(entry.RouteKey); existing != nil {
+			return fmt.Errorf("urlkitmux: export route %q: name already registered", entry.RouteKey)
+		}
+		route := router.Path(template).Name(entry.RouteKey)
+		if err := route.GetError(); err != nil {
+			return fmt.Errorf("urlkitmux: export route %q: %w", entry.RouteKey, err)
+		}
+	}
+	return nil
+}
+
+// FromMuxTemplate converts a mux path template to urlkit route syntax.
+func FromMuxTemplate(template string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(template); i++ {
+		if template[i] == '}' {
+			return "", fmt.Errorf("unbalanced braces in %q", template)
+		}
+		if template[i] != '{' {
+			out.WriteByte(template[i])
+			continue
+		}
+
+		// Variable patterns may contain braces themselves, e.g. {code:[a-z]{2}}.
+		end, depth := i+1, 1
+		for ; end < len(template) && depth > 0; end++ {
+			switch template[end] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+		if depth != 0 {
+			return "", fmt.Errorf("unbalanced braces in %q", template)
+		}
+
+		name, pattern, _ := strings.Cut(template[i+1:end-1], ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return "", fmt.Errorf("missing variable name in %q", template)
+		}
+		out.WriteString(":" + name)
+		if pattern != "" {
+			out.WriteString("(" + pattern + ")")
+		}
+		i = end - 1
+	}
+	return out.String(), nil
+}
+
+// ToMuxTemplate converts a urlkit route template to mux syntax. Optional and
+// repeated params have no mux equivalent and are rejected.
+func ToMuxTemplate(template string) (string, error) {
+	var convErr error
+	converted := urlkitParam.ReplaceAllStringFunc(template, func(param string) string {
+		match := urlkitParam.FindStringSubmatch(param)
+		if match[3] != "" && convErr == nil {
+			convErr = fmt.Errorf("param %q with modifier %q is not supported by mux", match[1], match[3])
+		}
+		if match[2] != "" {
+			return "{" + match[1] + ":" + match[2] + "}"
+		}
+		return "{" + match[1] + "}"
+	})
+	if convErr != nil {
+		return "", convErr
+	}
+	return converted, nil
+}
@@ -0,0 +1,76 @@
+package urlkitmux
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestTemplateConversion(t *testing.T) {
+	cases := []struct{ mux, urlkit string }{
+		{"/users/{id}", "/users/:id"},
+		{"/users/{id:[0-9]+}/posts/{slug}", "/users/:id([0-9]+)/posts/:slug"},
+		{"/lang/{code:[a-z]{2}}", "/lang/:code([a-z]{2})"},
+	}
+	for _, tc := range cases {
+		got, err := FromMuxTemplate(tc.mux)
+		if err != nil || got != tc.urlkit {
+			t.Fatalf("FromMuxTemplate(%q) = %q, %v; want %q", tc.mux, got, err, tc.urlkit)
+		}
+		back, err := ToMuxTemplate(tc.urlkit)
+		if err != nil || back != tc.mux {
+			t.Fatalf("ToMuxTemplate(%q) = %q, %v; want %q", tc.urlkit, back, err, tc.mux)
+		}
+	}
+
+	if _, err := FromMuxTemplate("/users/{id"); err == nil {
+		t.Fatal("expected error for unbalanced braces")
+	}
+	if _, err := ToMuxTemplate("/search/:query?"); err == nil {
+		t.Fatal("expected error for optional param")
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	noop := func(http.ResponseWriter, *http.Request) {}
+
+	source := mux.NewRouter()
+	source.HandleFunc("/users/{id:[0-9]+}", noop).Name("user")
+	api := source.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/status", noop).Name("status")
+	source.HandleFunc("/unnamed", noop)
+
+	manager := urlkit.NewRouteManager()
+	group, _, err := manager.RegisterGroup("frontend", "https://example.com", nil)
+	if err != nil {
+		t.Fatalf("RegisterGroup failed: %v", err)
+	}
+	result, err := Import(source, group)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(result.Added) != 2 {
+		t.Fatalf("expected 2 imported routes, got %v", result.Added)
+	}
+	if got, _ := group.Render("user", urlkit.Params{"id": 7}); got != "https://example.com/users/7" {
+		t.Fatalf("unexpected user url %s", got)
+	}
+	if got, _ := group.Render("status", nil); got != "https://example.com/api/status" {
+		t.Fatalf("unexpected status url %s", got)
+	}
+
+	target := mux.NewRouter()
+	if err := Export(target, manager, "frontend"); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	built, err := target.Get("user").URL("id", "7")
+	if err != nil || built.String() != "/users/7" {
+		t.Fatalf("unexpected mux url %v, %v", built, err)
+	}
+	if err := Export(target, manager, "frontend"); err == nil {
+		t.Fatal("expected error for duplicate route names")
+	}
+}
@@ -0,0 +1,128 @@
+// Package urlkittest generates and runs table-driven route tests from a urlkit
+// configuration.
+//
+// Generated cases pin every route to the URL it builds with example params, so
+// teams can commit them and let config changes show up as failing assertions:
+//
+//	cases, err := urlkittest.GenerateCases(cfg)
+//	err = urlkittest.WriteGo(file, "routes_test", "routeCases", cases)
+//
+//	func TestRoutes(t *testing.T) {
+//		urlkittest.Run(t, manager, routeCases)
+//	}
+package urlkittest
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+// Case is a single generated route assertion.
+type Case struct {
+	Name     string        `json:"name"`
+	Group    string        `json:"group"`
+	Route    string        `json:"route"`
+	Params   urlkit.Params `json:"params,omitempty"`
+	Expected string        `json:"expected"`
+}
+
+// GenerateCases builds a manager from config and returns one case per route,
+// ordered like RouteManager.Manifest, with params filled by Group.ExampleParams.
+func GenerateCases(config urlkit.Configurator) ([]Case, error) {
+	manager, err := urlkit.NewRouteManagerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return GenerateManagerCases(manager)
+}
+
+// GenerateManagerCases returns one case per route registered on manager.
+func GenerateManagerCases(manager *urlkit.RouteManager) ([]Case, error) {
+	manifest := manager.Manifest()
+	cases := make([]Case, 0, len(manifest))
+	for _, entry := range manifest {
+		group, err := manager.GetGroup(entry.GroupFQN)
+		if err != nil {
+			return nil, err
+		}
+		params, err := group.ExampleParams(entry.RouteKey)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := group.Render(entry.RouteKey, params)
+		if err != nil {
+			return nil, fmt.Errorf("generate case %s.%s: %w", entry.GroupFQN, entry.RouteKey, err)
+		}
+		if len(params) == 0 {
+			params = nil
+		}
+		cases = append(cases, Case{
+			Name:     entry.GroupFQN + "." + entry.RouteKey,
+			Group:    entry.GroupFQN,
+			Route:    entry.RouteKey,
+			Params:   params,
+			Expected: expected,
+		})
+	}
+	return cases, nil
+}
+
+// Run asserts every case against manager in its own subtest.
+func Run(t *testing.T, manager *urlkit.RouteManager, cases []Case) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			group, err := manager.GetGroup(tc.Group)
+			if err != nil {
+				t.Fatalf("GetGroup(%q) failed: %v", tc.Group, err)
+			}
+			got, err := group.Render(tc.Route, tc.Params)
+			if err != nil {
+				t.Fatalf("Render(%q) failed: %v", tc.Route, err)
+			}
+			if got != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, got)
+			}
+		})
+	}
+}
+
+// WriteGo writes cases as a gofmt-ed Go file in package pkg declaring them as
+// the variable varName, ready to be committed next to the tests using Run.
+func WriteGo(w io.Writer, pkg, varName string, cases []Case) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by urlkittest. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	buf.WriteString("import (\n")
+	if slices.ContainsFunc(cases, func(tc Case) bool { return len(tc.Params) > 0 }) {
+		buf.WriteString("\turlkit \"github.com/goliatone/go-urlkit\"\n")
+	}
+	buf.WriteString("\t\"github.com/goliatone/go-urlkit/urlkittest\"\n)\n\n")
+	fmt.Fprintf(&buf, "var %s = []urlkittest.Case{\n", varName)
+	for _, tc := range cases {
+		fmt.Fprintf(&buf, "{\nName: %s,\nGroup: %s,\nRoute: %s,\n", strconv.Quote(tc.Name), strconv.Quote(tc.Group), strconv.Quote(tc.Route))
+		if len(tc.Params) > 0 {
+			buf.WriteString("Params: urlkit.Params{")
+			for _, key := range slices.Sorted(maps.Keys(tc.Params)) {
+				fmt.Fprintf(&buf, "%s: %s, ", strconv.Quote(key), strconv.Quote(fmt.Sprint(tc.Params[key])))
+			}
+			buf.WriteString("},\n")
+		}
+		fmt.Fprintf(&buf, "Expected: %s,\n},\n", strconv.Quote(tc.Expected))
+	}
+	buf.WriteString("}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format generated cases: %w", err)
+	}
+	_, err = w.Write(source)
+	return err
+}
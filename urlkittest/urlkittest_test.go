@@ -0,0 +1,61 @@
+package urlkittest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+	"github.com/goliatone/go-urlkit/urlkittest"
+)
+
+func TestGenerateCases(t *testing.T) {
+	cfg := urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "api",
+				BaseURL: "https://api.example.com",
+				Routes: map[string]string{
+					"users": "/users",
+					"user":  "/users/:id",
+				},
+			},
+		},
+	}
+
+	cases, err := urlkittest.GenerateCases(cfg)
+	if err != nil {
+		t.Fatalf("GenerateCases failed: %v", err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("expected 2 cases, got %+v", cases)
+	}
+	if cases[0].Name != "api.user" || cases[0].Expected != "https://api.example.com/users/example" {
+		t.Fatalf("unexpected first case: %+v", cases[0])
+	}
+	if cases[1].Params != nil || cases[1].Expected != "https://api.example.com/users" {
+		t.Fatalf("unexpected second case: %+v", cases[1])
+	}
+
+	manager, err := urlkit.NewRouteManagerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewRouteManagerFromConfig failed: %v", err)
+	}
+	urlkittest.Run(t, manager, cases)
+
+	var out bytes.Buffer
+	if err := urlkittest.WriteGo(&out, "routes_test", "routeCases", cases); err != nil {
+		t.Fatalf("WriteGo failed: %v", err)
+	}
+	source := out.String()
+	for _, want := range []string{
+		"package routes_test",
+		"var routeCases = []urlkittest.Case{",
+		`Params:   urlkit.Params{"id": "example"},`,
+		`Expected: "https://api.example.com/users",`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Fatalf("generated source missing %q:\n%s", want, source)
+		}
+	}
+}
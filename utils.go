@@ -7,10 +7,16 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 	"unicode"
+
+	ptre "github.com/soongo/path-to-regexp"
 )
 
 func JoinURL(base, path string, queries ...Query) string {
+	if normalized, err := NormalizeURLHost(base); err == nil {
+		base = normalized
+	}
 	u, err := url.Parse(base)
 	if err != nil {
 		u = &url.URL{Path: base}
@@ -97,11 +103,25 @@ func coerceParams(source Params) Params {
 
 	normalized := make(Params, len(source))
 	for key, value := range source {
-		normalized[key] = fmt.Sprint(value)
+		normalized[key] = normalizeParamValue(value)
 	}
 	return normalized
 }
 
+// normalizeParamValue stringifies a param value. Dates are kept as time.Time so
+// they can be formatted with the rendering group's date layout and timezone.
+func normalizeParamValue(value any) any {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed
+	case *time.Time:
+		if typed != nil {
+			return *typed
+		}
+	}
+	return fmt.Sprint(value)
+}
+
 func combineQueries(single Query, multi map[string][]string) []Query {
 	var queries []Query
 
@@ -175,7 +195,7 @@ func mergeParamsInput(target Params, input any) error {
 	// 	return nil
 	case map[string]any:
 		for key, value := range v {
-			target[key] = fmt.Sprint(value)
+			target[key] = normalizeParamValue(value)
 		}
 		return nil
 	case map[string]string:
@@ -183,6 +203,9 @@ func mergeParamsInput(target Params, input any) error {
 			target[key] = value
 		}
 		return nil
+	case *OrderedParams:
+		maps.Copy(target, v.Params())
+		return nil
 	default:
 		val := reflect.ValueOf(input)
 		if !val.IsValid() {
@@ -218,7 +241,7 @@ func mergeStructParams(target Params, value reflect.Value) error {
 		}
 
 		fieldValue := value.Field(i).Interface()
-		target[key] = fmt.Sprint(fieldValue)
+		target[key] = normalizeParamValue(fieldValue)
 	}
 	return nil
 }
@@ -439,3 +462,40 @@ func minInt(a, b int) int {
 	}
 	return b
 }
+
+// routeParamNames returns the named params declared by a route template, in order.
+func routeParamNames(template string) ([]string, error) {
+	tokens, err := ptre.Parse(template, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, token := range tokens {
+		if t, ok := token.(ptre.Token); ok {
+			if name, ok := t.Name.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// pathAndQuery returns the escaped path, query and fragment of a built URL.
+func pathAndQuery(built string) (string, error) {
+	parsed, err := url.Parse(built)
+	if err != nil {
+		return "", err
+	}
+	out := parsed.EscapedPath()
+	if out == "" {
+		out = "/"
+	}
+	if parsed.RawQuery != "" {
+		out += "?" + parsed.RawQuery
+	}
+	if parsed.Fragment != "" {
+		out += "#" + parsed.EscapedFragment()
+	}
+	return out, nil
+}
@@ -0,0 +1,133 @@
+package urlkit
+
+import "fmt"
+
+// RouteVariant selects which pattern of a route is rendered or was matched.
+type RouteVariant string
+
+const (
+	// RouteVariantCanonical is the route's registered pattern (e.g. "/p/:id").
+	RouteVariantCanonical RouteVariant = "canonical"
+	// RouteVariantVanity is the optional SEO friendly pattern set with
+	// SetVanityRoute (e.g. "/:category/:slug-:id").
+	RouteVariantVanity RouteVariant = "vanity"
+)
+
+type vanityRoute struct {
+	template string
+	compiled func(any) (string, error)
+}
+
+// SetVanityRoute attaches a vanity pattern to an existing route, so the same
+// route can render both its canonical URL and an SEO friendly one. Pass an
+// empty pattern to remove the vanity variant.
+// Returns ErrRouteNotFound when the route is not registered.
+//
+// Example:
+//
+//	group.SetVanityRoute("product", "/:category/:slug-:id")
+//	group.Builder("product").Vanity().WithParamsMap(params).Build()
+func (u *Group) SetVanityRoute(routeName, pattern string) error {
+	var compiled func(any) (string, error)
+	if pattern != "" {
+		var err error
+		if compiled, err = compileRouteTemplate(pattern); err != nil {
+			return fmt.Errorf("compile vanity route %q: %w", routeName, err)
+		}
+	}
+
+	groupFQN := u.FQN()
+	releaseMutation, err := u.runtime.beginMutation("set vanity route", groupFQN)
+	if err != nil {
+		return err
+	}
+	defer releaseMutation()
+
+	u.mu.Lock()
+	if _, ok := u.routes[routeName]; !ok {
+		u.mu.Unlock()
+		return fmt.Errorf("%w: route %q in group %s", ErrRouteNotFound, routeName, groupFQN)
+	}
+	if pattern == "" {
+		delete(u.vanityRoutes, routeName)
+	} else {
+		if u.vanityRoutes == nil {
+			u.vanityRoutes = make(map[string]vanityRoute)
+		}
+		u.vanityRoutes[routeName] = vanityRoute{template: pattern, compiled: compiled}
+	}
+	u.mu.Unlock()
+
+	u.runtime.publish(Event{Type: EventRoutesAdded, Group: groupFQN, Routes: []string{routeName}})
+	return nil
+}
+
+// VanityRoute returns the vanity pattern of the route, if one is set.
+func (u *Group) VanityRoute(routeName string) (string, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	vanity, ok := u.vanityRoutes[routeName]
+	return vanity.template, ok
+}
+
+// MatchRoute matches a request path against the full canonical and vanity
+// patterns of the route (group path included) and returns the extracted
+// params and which variant matched. The canonical pattern is tried first.
+func (u *Group) MatchRoute(routeName, path string) (Params, RouteVariant, bool) {
+	u.mu.RLock()
+	routeName = u.canonicalRouteNameLocked(routeName)
+	canonical, ok := u.routes[routeName]
+	vanity, hasVanity := u.vanityRoutes[routeName]
+	u.mu.RUnlock()
+	if !ok {
+		return nil, "", false
+	}
+
+	variants := []RouteVariant{RouteVariantCanonical}
+	templates := []string{canonical}
+	if hasVanity {
+		variants = append(variants, RouteVariantVanity)
+		templates = append(templates, vanity.template)
+	}
+
+	path, ok = u.runtime.stripGlobalPrefix(path)
+	if !ok {
+		return nil, "", false
+	}
+
+	groupPath := u.getFullPath()
+	for i, template := range templates {
+		match, err := compileRouteMatcher(joinURLPath(groupPath, template))
+		if err != nil {
+			continue
+		}
+		if params, ok := match(path); ok {
+			return params, variants[i], true
+		}
+	}
+	return nil, "", false
+}
+
+// VanityRoutes returns a copy of the vanity patterns keyed by route name.
+func (u *Group) VanityRoutes() map[string]string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	out := make(map[string]string, len(u.vanityRoutes))
+	for name, vanity := range u.vanityRoutes {
+		out[name] = vanity.template
+	}
+	return out
+}
+
+// Canonical renders the route's registered pattern. This is the default.
+func (b *Builder) Canonical() *Builder {
+	b.variant = RouteVariantCanonical
+	return b
+}
+
+// Vanity renders the route's vanity pattern. Routes without a vanity pattern
+// fall back to their canonical pattern.
+func (b *Builder) Vanity() *Builder {
+	b.variant = RouteVariantVanity
+	return b
+}
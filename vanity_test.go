@@ -0,0 +1,79 @@
+package urlkit_test
+
+import (
+	"errors"
+	"testing"
+
+	urlkit "github.com/goliatone/go-urlkit"
+)
+
+func TestVanityRoutes(t *testing.T) {
+	manager := mustManagerFromConfig(t, urlkit.Config{
+		Groups: []urlkit.GroupConfig{
+			{
+				Name:    "shop",
+				BaseURL: "https://shop.example.com",
+				Routes:  map[string]string{"product": "/p/:id", "about": "/about"},
+				VanityRoutes: map[string]string{
+					"product": `/:category/:slug-:id(\d+)`,
+				},
+			},
+		},
+	})
+	group, err := manager.GetGroup("shop")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+
+	params := map[string]any{"id": 42, "category": "shoes", "slug": "red-runner"}
+	canonical, err := group.Builder("product").WithParamsMap(params).Build()
+	if err != nil {
+		t.Fatalf("canonical Build failed: %v", err)
+	}
+	if want := "https://shop.example.com/p/42"; canonical != want {
+		t.Fatalf("expected %q, got %q", want, canonical)
+	}
+
+	vanity, err := group.Builder("product").Vanity().WithParamsMap(params).Build()
+	if err != nil {
+		t.Fatalf("vanity Build failed: %v", err)
+	}
+	if want := "https://shop.example.com/shoes/red-runner-42"; vanity != want {
+		t.Fatalf("expected %q, got %q", want, vanity)
+	}
+
+	switched, err := group.Builder("product").Vanity().Canonical().WithParamsMap(params).Build()
+	if err != nil || switched != canonical {
+		t.Fatalf("expected Canonical to switch back, got %q (%v)", switched, err)
+	}
+
+	fallback, err := group.Builder("about").Vanity().Build()
+	if err != nil || fallback != "https://shop.example.com/about" {
+		t.Fatalf("expected vanity to fall back to canonical, got %q (%v)", fallback, err)
+	}
+
+	matched, variant, ok := group.MatchRoute("product", "/p/42")
+	if !ok || variant != urlkit.RouteVariantCanonical || matched["id"] != "42" {
+		t.Fatalf("unexpected canonical match: %v %q %v", matched, variant, ok)
+	}
+	matched, variant, ok = group.MatchRoute("product", "/shoes/red-runner-42")
+	if !ok || variant != urlkit.RouteVariantVanity {
+		t.Fatalf("unexpected vanity match: %v %q %v", matched, variant, ok)
+	}
+	if matched["category"] != "shoes" || matched["slug"] != "red-runner" || matched["id"] != "42" {
+		t.Fatalf("unexpected vanity params: %v", matched)
+	}
+	if _, _, ok := group.MatchRoute("product", "/about"); ok {
+		t.Fatal("expected /about not to match product")
+	}
+
+	if err := group.SetVanityRoute("missing", "/x"); !errors.Is(err, urlkit.ErrRouteNotFound) {
+		t.Fatalf("expected ErrRouteNotFound, got %v", err)
+	}
+	if err := group.SetVanityRoute("product", ""); err != nil {
+		t.Fatalf("SetVanityRoute remove failed: %v", err)
+	}
+	if _, ok := group.VanityRoute("product"); ok {
+		t.Fatal("expected vanity route to be removed")
+	}
+}